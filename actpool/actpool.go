@@ -7,9 +7,12 @@
 package actpool
 
 import (
-	"fmt"
+	"container/heap"
+	"math/big"
+	"sort"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -47,30 +50,66 @@ var (
 	ErrVotee = errors.New("votee is not a candidate")
 	// ErrHash indicates the error of action's hash
 	ErrHash = errors.New("invalid hash")
+	// ErrActionExists indicates that the action is already in the pool
+	ErrActionExists = errors.New("action already exists in pool")
+	// ErrActionAlreadyMined indicates that the action has already been committed to the chain. It is only detected if
+	// the chain indexes the actions, i.e., the explorer is enabled, otherwise ErrNonce is returned instead.
+	ErrActionAlreadyMined = errors.New("action already mined")
+	// ErrFeeTooLow indicates that the gas price or the fee of the action is below the floor of the pool
+	ErrFeeTooLow = errors.New("fee too low")
 )
 
+// IsDuplicate returns whether err is caused by adding an action which is already in the pool or on the chain. Such
+// errors are benign, as the same action is usually received from multiple peers.
+func IsDuplicate(err error) bool {
+	cause := errors.Cause(err)
+	return cause == ErrActionExists || cause == ErrActionAlreadyMined
+}
+
 // ActPool is the interface of actpool
 type ActPool interface {
 	// Reset resets actpool state
 	Reset()
 	// PickActs returns all currently accepted transfers and votes in actpool
 	PickActs() ([]*action.Transfer, []*action.Vote, []*action.Execution)
+	// PickActions returns the accepted actions with the highest gas prices whose total gas limit fits in maxGas
+	PickActions(maxGas uint64) ([]*action.Transfer, []*action.Vote, []*action.Execution)
 	// AddTsf adds an transfer into the pool after passing validation
 	AddTsf(tsf *action.Transfer) error
 	// AddVote adds a vote into the pool after passing validation
 	AddVote(vote *action.Vote) error
 	// AddExecution adds an execution into the pool after passing validation
 	AddExecution(execution *action.Execution) error
+	// AddTsfs adds a batch of transfers into the pool, and returns the errors aligned with the given transfers
+	AddTsfs(tsfs []*action.Transfer) []error
+	// AddVotes adds a batch of votes into the pool, and returns the errors aligned with the given votes
+	AddVotes(votes []*action.Vote) []error
+	// AddExecutions adds a batch of executions into the pool, and returns the errors aligned with the given executions
+	AddExecutions(executions []*action.Execution) []error
+	// AddAction adds an action into the pool after passing validation, and returns whether another action has been
+	// evicted from the full pool to make room for it
+	AddAction(act *iproto.ActionPb) (bool, error)
 	// GetPendingNonce returns pending nonce in pool given an account address
 	GetPendingNonce(addr string) (uint64, error)
 	// GetUnconfirmedActs returns unconfirmed actions in pool given an account address
 	GetUnconfirmedActs(addr string) []*iproto.ActionPb
 	// GetActionByHash returns the pending action in pool given action's hash
 	GetActionByHash(hash hash.Hash32B) (*iproto.ActionPb, error)
+	// PendingActionMap returns a copy of the actions in pool grouped by sender
+	PendingActionMap() map[string][]action.Action
+	// AllActions returns a copy of all the actions in pool
+	AllActions() []action.Action
 	// GetSize returns the act pool size
 	GetSize() uint64
 	// GetCapacity returns the act pool capacity
 	GetCapacity() uint64
+	// GetEvictionCount returns the number of actions evicted from the pool before being committed
+	GetEvictionCount() uint64
+	// Recover re-adds the pending actions persisted in the write-ahead log, dropping the invalid or stale ones
+	Recover() error
+	// SetMinGasPrice sets the minimum gas price of the actions accepted into the pool, which applies to the incoming
+	// actions only
+	SetMinGasPrice(price *big.Int)
 }
 
 // actPool implements ActPool interface
@@ -80,6 +119,16 @@ type actPool struct {
 	bc          blockchain.Blockchain
 	accountActs map[string]ActQueue
 	allActions  map[hash.Hash32B]*iproto.ActionPb
+	// actSeqs records the order in which the actions are added into the pool
+	actSeqs map[hash.Hash32B]uint64
+	nextSeq uint64
+	// evictionCount is the number of actions removed from the pool because they become invalid or they are evicted to
+	// make room for other actions
+	evictionCount uint64
+	// wal persists the pending actions if it's not nil
+	wal *wal
+	// minGasPrice is the minimum gas price of the incoming actions, which starts from cfg.MinGasPrice
+	minGasPrice *big.Int
 }
 
 // NewActPool constructs a new actpool
@@ -92,6 +141,11 @@ func NewActPool(bc blockchain.Blockchain, cfg config.ActPool) (ActPool, error) {
 		bc:          bc,
 		accountActs: make(map[string]ActQueue),
 		allActions:  make(map[hash.Hash32B]*iproto.ActionPb),
+		actSeqs:     make(map[hash.Hash32B]uint64),
+		minGasPrice: new(big.Int).SetUint64(cfg.MinGasPrice),
+	}
+	if cfg.WALPath != "" {
+		ap.wal = newWAL(cfg.WALPath)
 	}
 	return ap, nil
 }
@@ -130,6 +184,7 @@ func (ap *actPool) Reset() {
 		queue.SetPendingNonce(pendingNonce)
 		ap.updateAccount(from)
 	}
+	ap.compactWAL()
 }
 
 // PickActs returns all currently accepted transfers and votes for all accounts
@@ -171,101 +226,146 @@ func (ap *actPool) PickActs() ([]*action.Transfer, []*action.Vote, []*action.Exe
 	return transfers, votes, executions
 }
 
-// AddTsf inserts a new transfer into account queue if it passes validation
-func (ap *actPool) AddTsf(tsf *action.Transfer) error {
+// PickActions returns the currently accepted actions of all accounts, preferring the ones with higher gas prices,
+// until the total gas limit of the picked actions reaches maxGas. The actions of an account are still picked in nonce
+// order, so once an action doesn't fit in the remaining gas, the subsequent actions of the same account are skipped.
+func (ap *actPool) PickActions(maxGas uint64) ([]*action.Transfer, []*action.Vote, []*action.Execution) {
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
 
-	hash := tsf.Hash()
-	// Reject transfer if it already exists in pool
-	if ap.allActions[hash] != nil {
-		logger.Error().
-			Hex("hash", hash[:]).
-			Msg("Rejecting existed transfer")
-		return fmt.Errorf("existed transfer: %x", hash)
-	}
-	// Reject transfer if it fails validation
-	if err := ap.validateTsf(tsf); err != nil {
-		logger.Error().
-			Hex("hash", hash[:]).
-			Err(err).
-			Msg("Rejecting invalid transfer")
-		return err
+	pq := make(gasPriceQueue, 0, len(ap.accountActs))
+	for _, queue := range ap.accountActs {
+		if acts := queue.PendingActs(); len(acts) > 0 {
+			pq = append(pq, acts)
+		}
 	}
-	// Reject transfer if pool space is full
-	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
-		logger.Warn().
-			Hex("hash", hash[:]).
-			Msg("Rejecting transfer due to insufficient space")
-		return errors.Wrapf(ErrActPool, "insufficient space for transfer")
+	heap.Init(&pq)
+
+	numActs := uint64(0)
+	gasLeft := maxGas
+	transfers := make([]*action.Transfer, 0)
+	votes := make([]*action.Vote, 0)
+	executions := make([]*action.Execution, 0)
+	for pq.Len() > 0 {
+		acts, ok := heap.Pop(&pq).([]*iproto.ActionPb)
+		if !ok {
+			continue
+		}
+		act := acts[0]
+		if act.GetGasLimit() > gasLeft {
+			continue
+		}
+		switch {
+		case act.GetTransfer() != nil:
+			tsf := action.Transfer{}
+			tsf.ConvertFromActionPb(act)
+			transfers = append(transfers, &tsf)
+		case act.GetVote() != nil:
+			vote := action.Vote{}
+			vote.ConvertFromActionPb(act)
+			votes = append(votes, &vote)
+		case act.GetExecution() != nil:
+			execution := action.Execution{}
+			execution.ConvertFromActionPb(act)
+			executions = append(executions, &execution)
+		default:
+			continue
+		}
+		gasLeft -= act.GetGasLimit()
+		numActs++
+		if ap.cfg.MaxNumActsToPick > 0 && numActs >= ap.cfg.MaxNumActsToPick {
+			logger.Debug().
+				Uint64("limit", ap.cfg.MaxNumActsToPick).
+				Msg("reach the max number of actions to pick")
+			break
+		}
+		if len(acts) > 1 {
+			heap.Push(&pq, acts[1:])
+		}
 	}
-	// Wrap tsf as an action
-	action := tsf.ConvertToActionPb()
-	return ap.enqueueAction(tsf.Sender(), action, hash, tsf.Nonce())
+	return transfers, votes, executions
+}
+
+// AddTsf inserts a new transfer into account queue if it passes validation
+func (ap *actPool) AddTsf(tsf *action.Transfer) error {
+	height := ap.nextHeight()
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	_, err := ap.addTsf(tsf, height)
+	return err
 }
 
 // AddVote inserts a new vote into account queue if it passes validation
 func (ap *actPool) AddVote(vote *action.Vote) error {
+	height := ap.nextHeight()
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
 
-	hash := vote.Hash()
-	// Reject vote if it already exists in pool
-	if ap.allActions[hash] != nil {
-		logger.Error().
-			Hex("hash", hash[:]).
-			Msg("Rejecting existed vote")
-		return fmt.Errorf("existed vote: %x", hash)
-	}
-	// Reject vote if it fails validation
-	if err := ap.validateVote(vote); err != nil {
-		logger.Error().
-			Hex("hash", hash[:]).
-			Err(err).
-			Msg("Rejecting invalid vote")
-		return err
-	}
-	// Reject vote if pool space is full
-	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
-		logger.Warn().
-			Hex("hash", hash[:]).
-			Msg("Rejecting vote due to insufficient space")
-		return errors.Wrapf(ErrActPool, "insufficient space for vote")
-	}
-
-	return ap.enqueueAction(vote.Voter(), vote.ConvertToActionPb(), hash, vote.Nonce())
+	_, err := ap.addVote(vote, height)
+	return err
 }
 
 // AddExecution inserts a new execution into account queue if it passes validation
 func (ap *actPool) AddExecution(exec *action.Execution) error {
+	height := ap.nextHeight()
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
-	hash := exec.Hash()
-	// Reject execution if it already exists in pool
-	if ap.allActions[hash] != nil {
-		logger.Error().
-			Hex("hash", hash[:]).
-			Msg("Rejecting existed execution")
-		return fmt.Errorf("existed execution: %x", hash)
+
+	_, err := ap.addExecution(exec, height)
+	return err
+}
+
+// AddTsfs inserts a batch of transfers while acquiring the pool lock only once. The i-th returned error is the result
+// of adding the i-th transfer.
+func (ap *actPool) AddTsfs(tsfs []*action.Transfer) []error {
+	height := ap.nextHeight()
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	errs := make([]error, len(tsfs))
+	for i, tsf := range tsfs {
+		_, errs[i] = ap.addTsf(tsf, height)
 	}
-	// Reject transfer if it fails validation
-	if err := ap.validateExecution(exec); err != nil {
-		logger.Error().
-			Hex("hash", hash[:]).
-			Err(err).
-			Msg("Rejecting invalid execution")
-		return err
+	return errs
+}
+
+// AddVotes inserts a batch of votes while acquiring the pool lock only once. The i-th returned error is the result of
+// adding the i-th vote.
+func (ap *actPool) AddVotes(votes []*action.Vote) []error {
+	height := ap.nextHeight()
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	errs := make([]error, len(votes))
+	for i, vote := range votes {
+		_, errs[i] = ap.addVote(vote, height)
 	}
-	// Reject execution if pool space is full
-	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
-		logger.Warn().
-			Hex("hash", hash[:]).
-			Msg("Rejecting execution due to insufficient space")
-		return errors.Wrapf(ErrActPool, "insufficient space for execution")
+	return errs
+}
+
+// AddExecutions inserts a batch of executions while acquiring the pool lock only once. The i-th returned error is the
+// result of adding the i-th execution.
+func (ap *actPool) AddExecutions(execs []*action.Execution) []error {
+	height := ap.nextHeight()
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	errs := make([]error, len(execs))
+	for i, exec := range execs {
+		_, errs[i] = ap.addExecution(exec, height)
 	}
-	// Wrap execution as an action
-	action := exec.ConvertToActionPb()
-	return ap.enqueueAction(exec.Executor(), action, hash, exec.Nonce())
+	return errs
+}
+
+// AddAction inserts a new action into account queue if it passes validation. It returns whether another action has
+// been evicted from the full pool to make room for it.
+func (ap *actPool) AddAction(act *iproto.ActionPb) (bool, error) {
+	height := ap.nextHeight()
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	return ap.addAction(act, height)
 }
 
 // GetPendingNonce returns pending nonce in pool or confirmed nonce given an account address
@@ -304,6 +404,43 @@ func (ap *actPool) GetActionByHash(hash hash.Hash32B) (*iproto.ActionPb, error)
 	return action, nil
 }
 
+// PendingActionMap returns a copy of the actions in pool grouped by sender, each group of which is in nonce order.
+// Changing the returned actions doesn't affect the pool.
+func (ap *actPool) PendingActionMap() map[string][]action.Action {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	acts := make(map[string][]action.Action, len(ap.accountActs))
+	for sender, queue := range ap.accountActs {
+		for _, pbAct := range queue.AllActs() {
+			if act := copyAction(pbAct); act != nil {
+				acts[sender] = append(acts[sender], act)
+			}
+		}
+	}
+	return acts
+}
+
+// AllActions returns a copy of all the actions in pool in the order they are added. Changing the returned actions
+// doesn't affect the pool.
+func (ap *actPool) AllActions() []action.Action {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	hashes := make([]hash.Hash32B, 0, len(ap.allActions))
+	for h := range ap.allActions {
+		hashes = append(hashes, h)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return ap.actSeqs[hashes[i]] < ap.actSeqs[hashes[j]] })
+	acts := make([]action.Action, 0, len(hashes))
+	for _, h := range hashes {
+		if act := copyAction(ap.allActions[h]); act != nil {
+			acts = append(acts, act)
+		}
+	}
+	return acts
+}
+
 // GetSize returns the act pool size
 func (ap *actPool) GetSize() uint64 {
 	ap.mutex.RLock()
@@ -317,164 +454,388 @@ func (ap *actPool) GetCapacity() uint64 {
 	return ap.cfg.MaxNumActsPerPool
 }
 
+// GetEvictionCount returns the number of actions evicted from the pool before being committed
+func (ap *actPool) GetEvictionCount() uint64 {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	return ap.evictionCount
+}
+
+// SetMinGasPrice sets the minimum gas price of the actions accepted into the pool, so that it could be raised during
+// congestion. The actions already in the pool are kept
+func (ap *actPool) SetMinGasPrice(price *big.Int) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	ap.minGasPrice = new(big.Int).Set(price)
+	logger.Info().Str("min-gas-price", price.String()).Msg("Set the minimum gas price of actpool")
+}
+
+// Recover reads the pending actions persisted in the write-ahead log and adds them into the pool again. Each action is
+// re-validated against the current chain state, so the ones already committed or no longer valid are dropped.
+func (ap *actPool) Recover() error {
+	if ap.wal == nil {
+		return nil
+	}
+	acts, err := ap.wal.readAll()
+	if err != nil {
+		return err
+	}
+
+	height := ap.nextHeight()
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	numRecovered := 0
+	for _, act := range acts {
+		if _, err := ap.addAction(act, height); err != nil {
+			logger.Debug().Err(err).Msg("Dropped action from actpool WAL")
+			continue
+		}
+		numRecovered++
+	}
+	logger.Info().
+		Int("recovered", numRecovered).
+		Int("dropped", len(acts)-numRecovered).
+		Msg("Recovered actions from actpool WAL")
+	ap.compactWAL()
+	return nil
+}
+
 //======================================
 // private functions
 //======================================
+// nextHeight returns the height of the next block, which the incoming actions are validated against. It must be called
+// before taking the lock, since the chain takes its own lock to return the tip height, and the chain may call back into
+// the pool while holding it
+func (ap *actPool) nextHeight() uint64 {
+	return ap.bc.TipHeight() + 1
+}
+
+// addAction inserts a new action of any type into account queue if it passes validation against the block of the given
+// height. The caller must hold the lock.
+func (ap *actPool) addAction(act *iproto.ActionPb, height uint64) (bool, error) {
+	switch {
+	case act.GetTransfer() != nil:
+		tsf := &action.Transfer{}
+		tsf.ConvertFromActionPb(act)
+		return ap.addTsf(tsf, height)
+	case act.GetVote() != nil:
+		vote := &action.Vote{}
+		vote.ConvertFromActionPb(act)
+		return ap.addVote(vote, height)
+	case act.GetExecution() != nil:
+		exec := &action.Execution{}
+		exec.ConvertFromActionPb(act)
+		return ap.addExecution(exec, height)
+	}
+	return false, errors.Wrap(ErrActPool, "unknown action type")
+}
+
+// addTsf inserts a new transfer into account queue if it passes validation. The caller must hold the lock.
+func (ap *actPool) addTsf(tsf *action.Transfer, height uint64) (bool, error) {
+	hash := tsf.Hash()
+	// Reject transfer if it already exists in pool
+	if ap.allActions[hash] != nil {
+		logger.Debug().
+			Hex("hash", hash[:]).
+			Msg("Rejecting existed transfer")
+		return false, errors.Wrapf(ErrActionExists, "transfer %x", hash)
+	}
+	// Reject transfer if it fails validation
+	if err := ap.validateTsf(tsf, height); err != nil {
+		if IsDuplicate(err) {
+			logger.Debug().
+				Hex("hash", hash[:]).
+				Msg("Rejecting mined transfer")
+			return false, err
+		}
+		logger.Warn().
+			Hex("hash", hash[:]).
+			Err(err).
+			Msg("Rejecting invalid transfer")
+		return false, err
+	}
+	// Reject transfer if pool space is full and no action could be evicted
+	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool && !ap.evictionEnabled() {
+		logger.Warn().
+			Hex("hash", hash[:]).
+			Msg("Rejecting transfer due to insufficient space")
+		return false, errors.Wrapf(ErrActPool, "insufficient space for transfer")
+	}
+	// Wrap tsf as an action
+	action := tsf.ConvertToActionPb()
+	return ap.enqueueAction(tsf.Sender(), action, hash, tsf.Nonce())
+}
+
+// addVote inserts a new vote into account queue if it passes validation. The caller must hold the lock.
+func (ap *actPool) addVote(vote *action.Vote, height uint64) (bool, error) {
+	hash := vote.Hash()
+	// Reject vote if it already exists in pool
+	if ap.allActions[hash] != nil {
+		logger.Debug().
+			Hex("hash", hash[:]).
+			Msg("Rejecting existed vote")
+		return false, errors.Wrapf(ErrActionExists, "vote %x", hash)
+	}
+	// Reject vote if it fails validation
+	if err := ap.validateVote(vote, height); err != nil {
+		if IsDuplicate(err) {
+			logger.Debug().
+				Hex("hash", hash[:]).
+				Msg("Rejecting mined vote")
+			return false, err
+		}
+		logger.Warn().
+			Hex("hash", hash[:]).
+			Err(err).
+			Msg("Rejecting invalid vote")
+		return false, err
+	}
+	// Reject vote if pool space is full and no action could be evicted
+	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool && !ap.evictionEnabled() {
+		logger.Warn().
+			Hex("hash", hash[:]).
+			Msg("Rejecting vote due to insufficient space")
+		return false, errors.Wrapf(ErrActPool, "insufficient space for vote")
+	}
+
+	return ap.enqueueAction(vote.Voter(), vote.ConvertToActionPb(), hash, vote.Nonce())
+}
+
+// addExecution inserts a new execution into account queue if it passes validation. The caller must hold the lock.
+func (ap *actPool) addExecution(exec *action.Execution, height uint64) (bool, error) {
+	hash := exec.Hash()
+	// Reject execution if it already exists in pool
+	if ap.allActions[hash] != nil {
+		logger.Debug().
+			Hex("hash", hash[:]).
+			Msg("Rejecting existed execution")
+		return false, errors.Wrapf(ErrActionExists, "execution %x", hash)
+	}
+	// Reject transfer if it fails validation
+	if err := ap.validateExecution(exec, height); err != nil {
+		if IsDuplicate(err) {
+			logger.Debug().
+				Hex("hash", hash[:]).
+				Msg("Rejecting mined execution")
+			return false, err
+		}
+		logger.Warn().
+			Hex("hash", hash[:]).
+			Err(err).
+			Msg("Rejecting invalid execution")
+		return false, err
+	}
+	// Reject execution if pool space is full and no action could be evicted
+	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool && !ap.evictionEnabled() {
+		logger.Warn().
+			Hex("hash", hash[:]).
+			Msg("Rejecting execution due to insufficient space")
+		return false, errors.Wrapf(ErrActPool, "insufficient space for execution")
+	}
+	// Wrap execution as an action
+	action := exec.ConvertToActionPb()
+	return ap.enqueueAction(exec.Executor(), action, hash, exec.Nonce())
+}
+
 // validateTsf checks whether a tranfer is valid
-func (ap *actPool) validateTsf(tsf *action.Transfer) error {
+func (ap *actPool) validateTsf(tsf *action.Transfer, height uint64) error {
 	// Reject coinbase transfer
 	if tsf.IsCoinbase() {
-		logger.Error().Msg("Error when validating whether transfer is coinbase")
+		logger.Debug().Msg("Error when validating whether transfer is coinbase")
 		return errors.Wrapf(ErrTransfer, "coinbase transfer")
 	}
 	// Reject oversized transfer
 	if tsf.TotalSize() > TransferSizeLimit {
-		logger.Error().Msg("Error when validating transfer's data size")
+		logger.Debug().Msg("Error when validating transfer's data size")
 		return errors.Wrapf(ErrActPool, "oversized data")
 	}
 	// Reject over-gassed transfer
 	if tsf.GasLimit() > action.GasLimit {
-		logger.Error().Msg("Error when validating transfer's gas limit")
+		logger.Debug().Msg("Error when validating transfer's gas limit")
 		return errors.Wrapf(ErrGasHigherThanLimit, "gas is higher than gas limit")
 	}
 	// Reject transfer of insufficient gas limit
 	intrinsicGas, err := tsf.IntrinsicGas()
 	if intrinsicGas > tsf.GasLimit() || err != nil {
-		logger.Error().Msg("Error when validating transfer's gas limit")
+		logger.Debug().Msg("Error when validating transfer's gas limit")
 		return errors.Wrapf(ErrInsufficientGas, "insufficient gas for transfer")
 	}
+	// Reject transfer of too low gas price or fee
+	if err := ap.validateFee(tsf); err != nil {
+		logger.Debug().Err(err).Msg("Error when validating transfer's fee")
+		return err
+	}
+	transferFee := big.NewInt(0)
+	if tsf.GasPrice() != nil {
+		transferFee.Mul(tsf.GasPrice(), new(big.Int).SetUint64(intrinsicGas))
+	}
+	if transferFee.Cmp(new(big.Int).SetUint64(ap.cfg.MinTransferFee)) < 0 {
+		logger.Debug().Msg("Error when validating transfer's fee")
+		return errors.Wrapf(ErrFeeTooLow, "transfer fee %s is lower than %d", transferFee, ap.cfg.MinTransferFee)
+	}
 	// Reject transfer of negative amount
 	if tsf.Amount().Sign() < 0 {
-		logger.Error().Msg("Error when validating transfer's amount")
+		logger.Debug().Msg("Error when validating transfer's amount")
 		return errors.Wrapf(ErrBalance, "negative value")
 	}
 
 	// check if sender's address is valid
 	if _, err := iotxaddress.GetPubkeyHash(tsf.Sender()); err != nil {
-		logger.Error().Msg("Error when validating transfer sender's address")
+		logger.Debug().Msg("Error when validating transfer sender's address")
 		return errors.Wrapf(err, "error when validating sender's address %s", tsf.Sender())
 	}
 	// check if recipient's address is valid
 	if _, err := iotxaddress.GetPubkeyHash(tsf.Recipient()); err != nil {
-		logger.Error().Msg("Error when validating transfer recipient's address")
+		logger.Debug().Msg("Error when validating transfer recipient's address")
 		return errors.Wrapf(err, "error when validating recipient's address %s", tsf.Recipient())
 	}
 
 	// Verify transfer using sender's public key
-	if err := action.Verify(tsf); err != nil {
-		logger.Error().Err(err).Msg("Error when validating transfer's signature")
+	if err := ap.bc.VerifyActionSignatureAtHeight(tsf, height); err != nil {
+		logger.Debug().Err(err).Msg("Error when validating transfer's signature")
 		return errors.Wrapf(err, "failed to verify Transfer signature")
 	}
 	// Reject transfer if nonce is too low
 	confirmedNonce, err := ap.bc.Nonce(tsf.Sender())
 	if err != nil {
-		logger.Error().Err(err).Msg("Error when validating transfer's nonce")
+		logger.Debug().Err(err).Msg("Error when validating transfer's nonce")
 		return errors.Wrapf(err, "invalid nonce value")
 	}
 	pendingNonce := confirmedNonce + 1
 	if pendingNonce > tsf.Nonce() {
-		logger.Error().Msg("Error when validating transfer's nonce")
+		if _, err := ap.bc.GetBlockHashByTransferHash(tsf.Hash()); err == nil {
+			return errors.Wrapf(ErrActionAlreadyMined, "transfer %x", tsf.Hash())
+		}
+		logger.Debug().Msg("Error when validating transfer's nonce")
 		return errors.Wrapf(ErrNonce, "nonce too low")
 	}
 	return nil
 }
 
-func (ap *actPool) validateExecution(exec *action.Execution) error {
+// validateFee rejects the action whose gas price is lower than the minimum gas price. The caller must hold the lock.
+func (ap *actPool) validateFee(act action.Action) error {
+	gasPrice := act.GasPrice()
+	if gasPrice == nil {
+		gasPrice = big.NewInt(0)
+	}
+	if gasPrice.Cmp(ap.minGasPrice) < 0 {
+		return errors.Wrapf(ErrFeeTooLow, "gas price %s is lower than %s", gasPrice, ap.minGasPrice)
+	}
+	return nil
+}
+
+func (ap *actPool) validateExecution(exec *action.Execution, height uint64) error {
 	// Reject oversized exeuction
 	if exec.TotalSize() > ExecutionSizeLimit {
-		logger.Error().Msg("Error when validating execution's data size")
+		logger.Debug().Msg("Error when validating execution's data size")
 		return errors.Wrapf(ErrActPool, "oversized data")
 	}
 	// Reject over-gassed execution
 	if exec.GasLimit() > action.GasLimit {
-		logger.Error().Msg("Error when validating execution's gas limit")
+		logger.Debug().Msg("Error when validating execution's gas limit")
 		return errors.Wrapf(ErrGasHigherThanLimit, "gas is higher than gas limit")
 	}
 	// Reject execution of insufficient gas limit
 	intrinsicGas, err := exec.IntrinsicGas()
 	if intrinsicGas > exec.GasLimit() || err != nil {
-		logger.Error().Msg("Error when validating execution's gas limit")
+		logger.Debug().Msg("Error when validating execution's gas limit")
 		return errors.Wrapf(ErrInsufficientGas, "insufficient gas for execution")
 	}
+	// Reject execution of too low gas price
+	if err := ap.validateFee(exec); err != nil {
+		logger.Debug().Err(err).Msg("Error when validating execution's fee")
+		return err
+	}
 	// Reject execution of negative amount
 	if exec.Amount().Sign() < 0 {
-		logger.Error().Msg("Error when validating execution's amount")
+		logger.Debug().Msg("Error when validating execution's amount")
 		return errors.Wrapf(ErrBalance, "negative value")
 	}
 
 	// check if executor's address is valid
 	if _, err := iotxaddress.GetPubkeyHash(exec.Executor()); err != nil {
-		logger.Error().Msg("Error when validating executor's address")
+		logger.Debug().Msg("Error when validating executor's address")
 		return errors.Wrapf(err, "error when validating executor's address %s", exec.Executor())
 	}
 	// check if contract's address is valid
 	if exec.Contract() != action.EmptyAddress {
 		if _, err := iotxaddress.GetPubkeyHash(exec.Contract()); err != nil {
-			logger.Error().Msg("Error when validating contract's address")
+			logger.Debug().Msg("Error when validating contract's address")
 			return errors.Wrapf(err, "error when validating contract's address %s", exec.Contract())
 		}
 	}
 
 	// Verify transfer using executor's public key
-	if err := action.Verify(exec); err != nil {
-		logger.Error().Err(err).Msg("Error when validating execution's signature")
+	if err := ap.bc.VerifyActionSignatureAtHeight(exec, height); err != nil {
+		logger.Debug().Err(err).Msg("Error when validating execution's signature")
 		return errors.Wrapf(err, "failed to verify Execution signature")
 	}
 	// Reject transfer if nonce is too low
 	confirmedNonce, err := ap.bc.Nonce(exec.Executor())
 	if err != nil {
-		logger.Error().Err(err).Msg("Error when validating execution's nonce")
+		logger.Debug().Err(err).Msg("Error when validating execution's nonce")
 		return errors.Wrapf(err, "invalid nonce value")
 	}
 	pendingNonce := confirmedNonce + 1
 	if pendingNonce > exec.Nonce() {
-		logger.Error().Msg("Error when validating execution's nonce")
+		if _, err := ap.bc.GetBlockHashByExecutionHash(exec.Hash()); err == nil {
+			return errors.Wrapf(ErrActionAlreadyMined, "execution %x", exec.Hash())
+		}
+		logger.Debug().Msg("Error when validating execution's nonce")
 		return errors.Wrapf(ErrNonce, "nonce too low")
 	}
 	return nil
 }
 
 // validateVote checks whether a vote is valid
-func (ap *actPool) validateVote(vote *action.Vote) error {
+func (ap *actPool) validateVote(vote *action.Vote, height uint64) error {
 	// Reject oversized vote
 	if vote.TotalSize() > VoteSizeLimit {
-		logger.Error().Msg("Error when validating vote's data size")
+		logger.Debug().Msg("Error when validating vote's data size")
 		return errors.Wrapf(ErrActPool, "oversized data")
 	}
 	// Reject over-gassed transfer
 	if vote.GasLimit() > action.GasLimit {
-		logger.Error().Msg("Error when validating vote's gas limit")
+		logger.Debug().Msg("Error when validating vote's gas limit")
 		return errors.Wrapf(ErrGasHigherThanLimit, "gas is higher than gas limit")
 	}
 	// Reject transfer of insufficient gas limit
 	intrinsicGas, err := vote.IntrinsicGas()
 	if intrinsicGas > vote.GasLimit() || err != nil {
-		logger.Error().Msg("Error when validating vote's gas limit")
+		logger.Debug().Msg("Error when validating vote's gas limit")
 		return errors.Wrapf(ErrInsufficientGas, "insufficient gas for vote")
 	}
+	// Reject vote of too low gas price
+	if err := ap.validateFee(vote); err != nil {
+		logger.Debug().Err(err).Msg("Error when validating vote's fee")
+		return err
+	}
 	// check if voter's address is valid
 	if _, err := iotxaddress.GetPubkeyHash(vote.Voter()); err != nil {
-		logger.Error().Err(err).Msg("Error when validating voter's address")
+		logger.Debug().Err(err).Msg("Error when validating voter's address")
 		return errors.Wrapf(err, "error when validating voter's address %s", vote.Voter())
 	}
 	// check if votee's address is valid
 	if vote.Votee() != action.EmptyAddress {
 		if _, err := iotxaddress.GetPubkeyHash(vote.Votee()); err != nil {
-			logger.Error().Err(err).Msg("Error when validating votee's address")
+			logger.Debug().Err(err).Msg("Error when validating votee's address")
 			return errors.Wrapf(err, "error when validating votee's address %s", vote.Votee())
 		}
 	}
 
 	// Verify vote using voter's public key
-	if err := action.Verify(vote); err != nil {
-		logger.Error().Err(err).Msg("Error when validating vote's signature")
+	if err := ap.bc.VerifyActionSignatureAtHeight(vote, height); err != nil {
+		logger.Debug().Err(err).Msg("Error when validating vote's signature")
 		return errors.Wrapf(err, "failed to verify vote signature")
 	}
 
 	// Reject vote if nonce is too low
 	confirmedNonce, err := ap.bc.Nonce(vote.Voter())
 	if err != nil {
-		logger.Error().Err(err).Msg("Error when validating vote's nonce")
+		logger.Debug().Err(err).Msg("Error when validating vote's nonce")
 		return errors.Wrapf(err, "invalid nonce value")
 	}
 
@@ -482,7 +843,7 @@ func (ap *actPool) validateVote(vote *action.Vote) error {
 		// Reject vote if votee is not a candidate
 		voteeState, err := ap.bc.StateByAddr(vote.Votee())
 		if err != nil {
-			logger.Error().
+			logger.Debug().
 				Err(err).
 				Str("voter", vote.Voter()).
 				Str("votee", vote.Votee()).
@@ -490,7 +851,7 @@ func (ap *actPool) validateVote(vote *action.Vote) error {
 			return errors.Wrapf(err, "cannot find votee's state: %s", vote.Votee())
 		}
 		if vote.Voter() != vote.Votee() && !voteeState.IsCandidate {
-			logger.Error().
+			logger.Debug().
 				Err(ErrVotee).
 				Str("voter", vote.Voter()).
 				Str("votee", vote.Votee()).
@@ -501,13 +862,16 @@ func (ap *actPool) validateVote(vote *action.Vote) error {
 
 	pendingNonce := confirmedNonce + 1
 	if pendingNonce > vote.Nonce() {
-		logger.Error().Msg("Error when validating vote's nonce")
+		if _, err := ap.bc.GetBlockHashByVoteHash(vote.Hash()); err == nil {
+			return errors.Wrapf(ErrActionAlreadyMined, "vote %x", vote.Hash())
+		}
+		logger.Debug().Msg("Error when validating vote's nonce")
 		return errors.Wrapf(ErrNonce, "nonce too low")
 	}
 	return nil
 }
 
-func (ap *actPool) enqueueAction(sender string, act *iproto.ActionPb, hash hash.Hash32B, actNonce uint64) error {
+func (ap *actPool) enqueueAction(sender string, act *iproto.ActionPb, hash hash.Hash32B, actNonce uint64) (bool, error) {
 	queue := ap.accountActs[sender]
 	if queue == nil {
 		queue = NewActQueue()
@@ -515,7 +879,7 @@ func (ap *actPool) enqueueAction(sender string, act *iproto.ActionPb, hash hash.
 		confirmedNonce, err := ap.bc.Nonce(sender)
 		if err != nil {
 			logger.Error().Err(err).Msg("Error when adding action")
-			return err
+			return false, err
 		}
 		// Initialize pending nonce for new account
 		pendingNonce := confirmedNonce + 1
@@ -525,16 +889,16 @@ func (ap *actPool) enqueueAction(sender string, act *iproto.ActionPb, hash hash.
 		balance, err := ap.bc.Balance(sender)
 		if err != nil {
 			logger.Error().Err(err).Msg("Error when adding action")
-			return err
+			return false, err
 		}
 		queue.SetPendingBalance(balance)
 	}
 	if queue.Overlaps(act) {
 		// Nonce already exists
-		logger.Error().
+		logger.Warn().
 			Hex("hash", hash[:]).
 			Msg("Rejecting action because replacement action is not supported")
-		return errors.Wrapf(ErrNonce, "duplicate nonce")
+		return false, errors.Wrapf(ErrNonce, "duplicate nonce")
 	}
 
 	if actNonce-queue.StartNonce() >= ap.cfg.MaxNumActsPerAcct {
@@ -543,7 +907,7 @@ func (ap *actPool) enqueueAction(sender string, act *iproto.ActionPb, hash hash.
 			Hex("hash", hash[:]).
 			Uint64("startNonce", queue.StartNonce()).Uint64("actNonce", actNonce).
 			Msg("Rejecting action because nonce is too large")
-		return errors.Wrapf(ErrNonce, "nonce too large")
+		return false, errors.Wrapf(ErrNonce, "nonce too large")
 	}
 
 	switch {
@@ -553,14 +917,14 @@ func (ap *actPool) enqueueAction(sender string, act *iproto.ActionPb, hash hash.
 		cost, err := tsf.Cost()
 		if err != nil {
 			logger.Error().Err(err).Msg("Error when adding action")
-			return errors.Wrap(err, "failed to get cost of transfer")
+			return false, errors.Wrap(err, "failed to get cost of transfer")
 		}
 		if queue.PendingBalance().Cmp(cost) < 0 {
 			// Pending balance is insufficient
 			logger.Warn().
 				Hex("hash", hash[:]).
 				Msg("Rejecting transfer due to insufficient balance")
-			return errors.Wrapf(ErrBalance, "insufficient balance for transfer")
+			return false, errors.Wrapf(ErrBalance, "insufficient balance for transfer")
 		}
 	case act.GetVote() != nil:
 		vote := action.Vote{}
@@ -568,13 +932,13 @@ func (ap *actPool) enqueueAction(sender string, act *iproto.ActionPb, hash hash.
 		cost, err := vote.Cost()
 		if err != nil {
 			logger.Error().Err(err).Msg("Error when adding action")
-			return errors.Wrap(err, "failed to get cost of vote")
+			return false, errors.Wrap(err, "failed to get cost of vote")
 		}
 		if queue.PendingBalance().Cmp(cost) < 0 {
 			logger.Warn().
 				Hex("hash", hash[:]).
 				Msg("Rejecting vote due to insufficient balance")
-			return errors.Wrapf(ErrBalance, "insufficient balance for vote")
+			return false, errors.Wrapf(ErrBalance, "insufficient balance for vote")
 		}
 	case act.GetExecution() != nil:
 		exec := action.Execution{}
@@ -583,8 +947,19 @@ func (ap *actPool) enqueueAction(sender string, act *iproto.ActionPb, hash hash.
 			logger.Warn().
 				Hex("hash", hash[:]).
 				Msg("Rejecting execution due to insufficient balance")
-			return errors.Wrapf(ErrBalance, "insufficient balance for execution")
+			return false, errors.Wrapf(ErrBalance, "insufficient balance for execution")
+		}
+	}
+
+	evicted := false
+	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
+		if !ap.evictAction(sender, new(big.Int).SetBytes(act.GetGasPrice())) {
+			logger.Warn().
+				Hex("hash", hash[:]).
+				Msg("Rejecting action due to insufficient space")
+			return false, errors.Wrapf(ErrActPool, "insufficient space for action")
 		}
+		evicted = true
 	}
 
 	err := queue.Put(act)
@@ -593,15 +968,91 @@ func (ap *actPool) enqueueAction(sender string, act *iproto.ActionPb, hash hash.
 			Hex("hash", hash[:]).
 			Err(err).
 			Msg("cannot put act into ActQueue")
-		return errors.Wrap(err, "cannot put act into ActQueue")
+		return false, errors.Wrap(err, "cannot put act into ActQueue")
 	}
 	ap.allActions[hash] = act
+	ap.actSeqs[hash] = ap.nextSeq
+	ap.nextSeq++
+	if ap.wal != nil {
+		if err := ap.wal.append(act); err != nil {
+			logger.Warn().Err(err).Msg("Error when persisting action into actpool WAL")
+		}
+	}
 	// If the pending nonce equals this nonce, update queue
 	nonce := queue.PendingNonce()
 	if actNonce == nonce {
 		ap.updateAccount(sender)
 	}
-	return nil
+	return evicted, nil
+}
+
+// evictionEnabled returns whether actions could be evicted to make room for the incoming ones when the pool is full
+func (ap *actPool) evictionEnabled() bool {
+	return ap.cfg.EvictionPolicy == config.OldestEvictionPolicy || ap.cfg.EvictionPolicy == config.LowestFeeEvictionPolicy
+}
+
+// evictAction evicts an action of an account other than the sender according to the eviction policy, to make room for
+// the sender's incoming action with the given gas price. Only the action with the largest nonce of an account queue is
+// evictable, so that no nonce gap is left behind. It returns false if no action could be evicted.
+func (ap *actPool) evictAction(sender string, gasPrice *big.Int) bool {
+	if !ap.evictionEnabled() {
+		return false
+	}
+	var (
+		victimAddr string
+		victim     *iproto.ActionPb
+	)
+	for addr, queue := range ap.accountActs {
+		if addr == sender {
+			continue
+		}
+		act := queue.LastAct()
+		if act == nil {
+			continue
+		}
+		if victim == nil || ap.evictsBefore(act, victim) {
+			victimAddr, victim = addr, act
+		}
+	}
+	if victim == nil {
+		return false
+	}
+	if ap.cfg.EvictionPolicy == config.LowestFeeEvictionPolicy &&
+		new(big.Int).SetBytes(victim.GetGasPrice()).Cmp(gasPrice) >= 0 {
+		return false
+	}
+	queue := ap.accountActs[victimAddr]
+	ap.removeInvalidActs([]*iproto.ActionPb{queue.RemoveLast()})
+	ap.evictionCount++
+	if queue.Empty() {
+		delete(ap.accountActs, victimAddr)
+	}
+	return true
+}
+
+// evictsBefore returns whether act should be evicted before other according to the eviction policy. Actions with the
+// same gas price are evicted from the oldest one.
+func (ap *actPool) evictsBefore(act *iproto.ActionPb, other *iproto.ActionPb) bool {
+	if ap.cfg.EvictionPolicy == config.LowestFeeEvictionPolicy {
+		if cmp := new(big.Int).SetBytes(act.GetGasPrice()).Cmp(new(big.Int).SetBytes(other.GetGasPrice())); cmp != 0 {
+			return cmp < 0
+		}
+	}
+	return ap.actSeqs[actionHash(act)] < ap.actSeqs[actionHash(other)]
+}
+
+// compactWAL rewrites the write-ahead log with the actions remaining in pool
+func (ap *actPool) compactWAL() {
+	if ap.wal == nil {
+		return
+	}
+	acts := make([]*iproto.ActionPb, 0, len(ap.allActions))
+	for _, queue := range ap.accountActs {
+		acts = append(acts, queue.AllActs()...)
+	}
+	if err := ap.wal.rewrite(acts); err != nil {
+		logger.Warn().Err(err).Msg("Error when compacting actpool WAL")
+	}
 }
 
 // removeConfirmedActs removes processed (committed to block) actions from pool
@@ -626,26 +1077,33 @@ func (ap *actPool) removeConfirmedActs() {
 
 func (ap *actPool) removeInvalidActs(acts []*iproto.ActionPb) {
 	for _, act := range acts {
-		var hash hash.Hash32B
-		switch {
-		case act.GetTransfer() != nil:
-			tsf := &action.Transfer{}
-			tsf.ConvertFromActionPb(act)
-			hash = tsf.Hash()
-		case act.GetVote() != nil:
-			vote := &action.Vote{}
-			vote.ConvertFromActionPb(act)
-			hash = vote.Hash()
-		case act.GetExecution() != nil:
-			execution := &action.Execution{}
-			execution.ConvertFromActionPb(act)
-			hash = execution.Hash()
-		}
+		hash := actionHash(act)
 		logger.Debug().
 			Hex("hash", hash[:]).
 			Msg("Removed invalidated action")
 		delete(ap.allActions, hash)
+		delete(ap.actSeqs, hash)
+	}
+}
+
+// actionHash returns the hash of the given action
+func actionHash(act *iproto.ActionPb) hash.Hash32B {
+	var hash hash.Hash32B
+	switch {
+	case act.GetTransfer() != nil:
+		tsf := &action.Transfer{}
+		tsf.ConvertFromActionPb(act)
+		hash = tsf.Hash()
+	case act.GetVote() != nil:
+		vote := &action.Vote{}
+		vote.ConvertFromActionPb(act)
+		hash = vote.Hash()
+	case act.GetExecution() != nil:
+		execution := &action.Execution{}
+		execution.ConvertFromActionPb(act)
+		hash = execution.Hash()
 	}
+	return hash
 }
 
 // updateAccount updates queue's status and remove invalidated actions from pool if necessary
@@ -654,6 +1112,7 @@ func (ap *actPool) updateAccount(sender string) {
 	acts := queue.UpdateQueue(queue.PendingNonce())
 	if len(acts) > 0 {
 		ap.removeInvalidActs(acts)
+		ap.evictionCount += uint64(len(acts))
 	}
 
 	// Delete the queue entry if it becomes empty
@@ -661,3 +1120,28 @@ func (ap *actPool) updateAccount(sender string) {
 		delete(ap.accountActs, sender)
 	}
 }
+
+// copyAction converts a deep copy of the action in pool, so that the returned action doesn't share any memory with it.
+// It returns nil if the action is of an unknown type.
+func copyAction(pbAct *iproto.ActionPb) action.Action {
+	pbAct, ok := proto.Clone(pbAct).(*iproto.ActionPb)
+	if !ok {
+		return nil
+	}
+	switch {
+	case pbAct.GetTransfer() != nil:
+		tsf := &action.Transfer{}
+		tsf.ConvertFromActionPb(pbAct)
+		return tsf
+	case pbAct.GetVote() != nil:
+		vote := &action.Vote{}
+		vote.ConvertFromActionPb(pbAct)
+		return vote
+	case pbAct.GetExecution() != nil:
+		execution := &action.Execution{}
+		execution.ConvertFromActionPb(pbAct)
+		return execution
+	default:
+		return nil
+	}
+}
@@ -8,8 +8,10 @@ package actpool
 
 import (
 	"context"
-	"fmt"
+	"encoding/binary"
+	"math"
 	"math/big"
+	"os"
 	"strings"
 	"testing"
 
@@ -20,6 +22,8 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/action"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -68,29 +72,29 @@ func TestActPool_validateTsf(t *testing.T) {
 	require.True(ok)
 	// Case I: Coinbase transfer
 	coinbaseTsf := action.NewCoinBaseTransfer(big.NewInt(1), "1")
-	err = ap.validateTsf(coinbaseTsf)
+	err = ap.validateTsf(coinbaseTsf, ap.nextHeight())
 	require.Equal(ErrTransfer, errors.Cause(err))
 	// Case II: Oversized data
 	tmpPayload := [32769]byte{}
 	payload := tmpPayload[:]
 	tsf, err := action.NewTransfer(uint64(1), big.NewInt(1), "1", "2", payload, uint64(0), big.NewInt(0))
 	require.NoError(err)
-	err = ap.validateTsf(tsf)
+	err = ap.validateTsf(tsf, ap.nextHeight())
 	require.Equal(ErrActPool, errors.Cause(err))
 	// Case III: Over-gassed transfer
 	tsf, err = action.NewTransfer(uint64(1), big.NewInt(1), "1", "2", nil, action.GasLimit+1, big.NewInt(0))
 	require.NoError(err)
-	err = ap.validateTsf(tsf)
+	err = ap.validateTsf(tsf, ap.nextHeight())
 	require.Equal(ErrGasHigherThanLimit, errors.Cause(err))
 	// Case IV: Insufficient gas
 	tsf, err = action.NewTransfer(uint64(1), big.NewInt(1), "1", "2", nil, uint64(0), big.NewInt(0))
 	require.NoError(err)
-	err = ap.validateTsf(tsf)
+	err = ap.validateTsf(tsf, ap.nextHeight())
 	require.Equal(ErrInsufficientGas, errors.Cause(err))
 	// Case V: Negative amount
 	tsf, err = action.NewTransfer(uint64(1), big.NewInt(-100), "1", "2", nil, uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	err = ap.validateTsf(tsf)
+	err = ap.validateTsf(tsf, ap.nextHeight())
 	require.Equal(ErrBalance, errors.Cause(err))
 	// Case VI: Invalid address
 	tsf, err = action.NewTransfer(
@@ -103,13 +107,13 @@ func TestActPool_validateTsf(t *testing.T) {
 		big.NewInt(0),
 	)
 	require.NoError(err)
-	err = ap.validateTsf(tsf)
+	err = ap.validateTsf(tsf, ap.nextHeight())
 	require.Error(err)
 	require.True(strings.Contains(err.Error(), "error when validating recipient's address"))
 	// Case VII: Signature verification fails
 	unsignedTsf, err := action.NewTransfer(uint64(1), big.NewInt(1), addr1.RawAddress, addr1.RawAddress, []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	err = ap.validateTsf(unsignedTsf)
+	err = ap.validateTsf(unsignedTsf, ap.nextHeight())
 	require.Equal(action.ErrAction, errors.Cause(err))
 	// Case VIII: Nonce is too low
 	prevTsf, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(50),
@@ -124,7 +128,7 @@ func TestActPool_validateTsf(t *testing.T) {
 	nTsf, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(60),
 		[]byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	err = ap.validateTsf(nTsf)
+	err = ap.validateTsf(nTsf, ap.nextHeight())
 	require.Equal(ErrNonce, errors.Cause(err))
 }
 
@@ -147,18 +151,18 @@ func TestActPool_validateVote(t *testing.T) {
 	// Case I: Over-gassed vote
 	vote, err := action.NewVote(1, "123", "456", action.GasLimit+1, big.NewInt(0))
 	require.NoError(err)
-	err = ap.validateVote(vote)
+	err = ap.validateVote(vote, ap.nextHeight())
 	require.Equal(ErrGasHigherThanLimit, errors.Cause(err))
 	// Case II: Insufficient gas
 	vote, err = action.NewVote(1, "123", "456", uint64(0), big.NewInt(0))
 	require.NoError(err)
-	err = ap.validateVote(vote)
+	err = ap.validateVote(vote, ap.nextHeight())
 	require.Equal(ErrInsufficientGas, errors.Cause(err))
 	// Case III: Invalid address
 	vote, err = action.NewVote(1, addr1.RawAddress, "123", uint64(100000), big.NewInt(0))
 	require.NoError(err)
 	vote.SetVoterPublicKey(addr1.PublicKey)
-	err = ap.validateVote(vote)
+	err = ap.validateVote(vote, ap.nextHeight())
 	require.Error(err)
 	require.True(strings.Contains(err.Error(), "error when validating votee's address"))
 	// Case IV: Signature verification fails
@@ -166,7 +170,7 @@ func TestActPool_validateVote(t *testing.T) {
 	require.NoError(err)
 	unsignedVote.SetVoterPublicKey(addr1.PublicKey)
 	require.NoError(err)
-	err = ap.validateVote(unsignedVote)
+	err = ap.validateVote(unsignedVote, ap.nextHeight())
 	require.Equal(action.ErrAction, errors.Cause(err))
 	// Case V: Nonce is too low
 	prevTsf, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(50),
@@ -180,12 +184,12 @@ func TestActPool_validateVote(t *testing.T) {
 	ap.Reset()
 	nVote, err := testutil.SignedVote(addr1, addr1, uint64(1), uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	err = ap.validateVote(nVote)
+	err = ap.validateVote(nVote, ap.nextHeight())
 	require.Equal(ErrNonce, errors.Cause(err))
 	// Case VI: Votee is not a candidate
 	vote2, err := testutil.SignedVote(addr1, addr2, uint64(2), uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	err = ap.validateVote(vote2)
+	err = ap.validateVote(vote2, ap.nextHeight())
 	require.Equal(ErrVotee, errors.Cause(err))
 }
 
@@ -272,9 +276,10 @@ func TestActPool_AddActs(t *testing.T) {
 	// Error Case Handling
 	// Case I: Action already exists in pool
 	err = ap.AddTsf(tsf1)
-	require.Equal(fmt.Errorf("existed transfer: %x", tsf1.Hash()), err)
+	require.Equal(ErrActionExists, errors.Cause(err))
+	require.True(IsDuplicate(err))
 	err = ap.AddVote(vote4)
-	require.Equal(fmt.Errorf("existed vote: %x", vote4.Hash()), err)
+	require.Equal(ErrActionExists, errors.Cause(err))
 	// Case II: Pool space is full
 	mockBC := mock_blockchain.NewMockBlockchain(ctrl)
 	Ap2, err := NewActPool(mockBC, apConfig)
@@ -288,6 +293,8 @@ func TestActPool_AddActs(t *testing.T) {
 		nAction := nTsf.ConvertToActionPb()
 		ap2.allActions[nTsf.Hash()] = nAction
 	}
+	mockBC.EXPECT().TipHeight().Return(uint64(0)).Times(2)
+	mockBC.EXPECT().VerifyActionSignatureAtHeight(gomock.Any(), uint64(1)).Times(2).Return(nil)
 	mockBC.EXPECT().Nonce(gomock.Any()).Times(2).Return(uint64(0), nil)
 	mockBC.EXPECT().StateByAddr(gomock.Any()).Times(1).Return(nil, nil)
 	err = ap2.AddTsf(tsf1)
@@ -338,6 +345,138 @@ func TestActPool_AddActs(t *testing.T) {
 	require.Equal(ErrInsufficientGas, errors.Cause(err))
 }
 
+func TestActPool_AlreadyMined(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+
+	tsf, err := testutil.SignedTransfer(addr1, addr2, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	vote, err := testutil.SignedVote(addr1, addr1, uint64(1), uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	bc.EXPECT().TipHeight().Return(uint64(0)).AnyTimes()
+	bc.EXPECT().VerifyActionSignatureAtHeight(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	bc.EXPECT().Nonce(addr1.RawAddress).Return(uint64(1), nil).AnyTimes()
+	bc.EXPECT().StateByAddr(addr1.RawAddress).Return(nil, nil).AnyTimes()
+
+	bc.EXPECT().GetBlockHashByTransferHash(tsf.Hash()).Return(hash.ZeroHash32B, nil).Times(1)
+	err = Ap.AddTsf(tsf)
+	require.Equal(ErrActionAlreadyMined, errors.Cause(err))
+	require.True(IsDuplicate(err))
+	bc.EXPECT().GetBlockHashByVoteHash(vote.Hash()).Return(hash.ZeroHash32B, nil).Times(1)
+	err = Ap.AddVote(vote)
+	require.Equal(ErrActionAlreadyMined, errors.Cause(err))
+
+	// The action is not mined, but its nonce is taken by another action
+	bc.EXPECT().GetBlockHashByTransferHash(tsf.Hash()).Return(hash.ZeroHash32B, errors.New("not found")).Times(1)
+	err = Ap.AddTsf(tsf)
+	require.Equal(ErrNonce, errors.Cause(err))
+	require.False(IsDuplicate(err))
+}
+
+func TestActPool_FutureActs(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(&config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, uint64(100))
+	require.NoError(err)
+	_, err = bc.GetFactory().RunActions(0, nil, nil, nil)
+	require.NoError(err)
+	require.Nil(bc.GetFactory().Commit())
+	// Create actpool
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr2, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, addr2, uint64(2), big.NewInt(20),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, addr2, uint64(3), big.NewInt(30),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+
+	// Actions arriving ahead of the pending nonce are queued instead of being rejected
+	require.NoError(ap.AddTsf(tsf3))
+	require.NoError(ap.AddTsf(tsf2))
+	require.Equal(uint64(2), ap.GetSize())
+	pNonce, _ := ap.getPendingNonce(addr1.RawAddress)
+	require.Equal(uint64(1), pNonce)
+	pBalance, _ := ap.getPendingBalance(addr1.RawAddress)
+	require.Equal(uint64(100), pBalance.Uint64())
+	pickedTsfs, _, _ := ap.PickActs()
+	require.Empty(pickedTsfs)
+
+	// Filling the gap promotes all the queued actions
+	require.NoError(ap.AddTsf(tsf1))
+	pNonce, _ = ap.getPendingNonce(addr1.RawAddress)
+	require.Equal(uint64(4), pNonce)
+	pBalance, _ = ap.getPendingBalance(addr1.RawAddress)
+	require.Equal(uint64(40), pBalance.Uint64())
+	pickedTsfs, _, _ = ap.PickActs()
+	require.Equal([]*action.Transfer{tsf1, tsf2, tsf3}, pickedTsfs)
+}
+
+func TestActPool_AddActsInBatch(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(&config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, uint64(100))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2.RawAddress, uint64(10))
+	require.NoError(err)
+	_, err = bc.GetFactory().RunActions(0, nil, nil, nil)
+	require.NoError(err)
+	require.Nil(bc.GetFactory().Commit())
+	// Create actpool
+	apConfig := getActPoolCfg()
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, addr1, uint64(2), big.NewInt(20),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	// Insufficient balance
+	tsf3, err := testutil.SignedTransfer(addr2, addr2, uint64(1), big.NewInt(20),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	errs := ap.AddTsfs([]*action.Transfer{tsf1, tsf2, tsf3, tsf1})
+	require.Equal(4, len(errs))
+	require.NoError(errs[0])
+	require.NoError(errs[1])
+	require.Equal(ErrBalance, errors.Cause(errs[2]))
+	// Existed transfer
+	require.Error(errs[3])
+
+	vote1, err := testutil.SignedVote(addr1, addr1, uint64(3), uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	vote2, err := testutil.SignedVote(addr1, addr1, uint64(3), uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	errs = ap.AddVotes([]*action.Vote{vote1, vote2})
+	require.Equal(2, len(errs))
+	require.NoError(errs[0])
+	require.Error(errs[1])
+
+	require.Equal(0, len(ap.AddExecutions(nil)))
+	require.Equal(uint64(3), ap.GetSize())
+	pNonce, err := ap.getPendingNonce(addr1.RawAddress)
+	require.NoError(err)
+	require.Equal(uint64(4), pNonce)
+}
+
 func TestActPool_PickActs(t *testing.T) {
 	createActPool := func(cfg config.ActPool) (*actPool, []*action.Transfer, []*action.Vote, []*action.Execution) {
 		require := require.New(t)
@@ -1020,6 +1159,116 @@ func TestActPool_GetActionByHash(t *testing.T) {
 	require.Equal(act2, act)
 }
 
+func TestActPool_PendingActionMapAndAllActions(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(&config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, uint64(100))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2.RawAddress, uint64(100))
+	require.NoError(err)
+	// Create actpool
+	apConfig := getActPoolCfg()
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+
+	require.Empty(ap.PendingActionMap())
+	require.Empty(ap.AllActions())
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr2, uint64(2), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	vote2, err := testutil.SignedVote(addr2, addr2, uint64(1), uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, addr2, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.AddTsf(tsf1))
+	require.NoError(ap.AddVote(vote2))
+	require.NoError(ap.AddTsf(tsf3))
+
+	actMap := ap.PendingActionMap()
+	require.Len(actMap, 2)
+	require.Len(actMap[addr1.RawAddress], 2)
+	// The actions of a sender are in nonce order
+	require.Equal(tsf3.Hash(), actMap[addr1.RawAddress][0].Hash())
+	require.Equal(tsf1.Hash(), actMap[addr1.RawAddress][1].Hash())
+	require.Len(actMap[addr2.RawAddress], 1)
+	require.Equal(vote2.Hash(), actMap[addr2.RawAddress][0].Hash())
+
+	// All the actions are in the order they are added
+	acts := ap.AllActions()
+	require.Len(acts, 3)
+	require.Equal(tsf1.Hash(), acts[0].Hash())
+	require.Equal(vote2.Hash(), acts[1].Hash())
+	require.Equal(tsf3.Hash(), acts[2].Hash())
+
+	// Changing the returned actions doesn't affect the pool
+	signature := make([]byte, len(tsf1.Signature()))
+	copy(signature, tsf1.Signature())
+	acts[0].Signature()[0] ^= 0xff
+	act, err := ap.GetActionByHash(tsf1.Hash())
+	require.NoError(err)
+	require.Equal(signature, act.Signature)
+}
+
+func TestActPool_PickActions(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(&config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, uint64(10000000))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2.RawAddress, uint64(10000000))
+	require.NoError(err)
+	_, err = bc.GetFactory().RunActions(0, nil, nil, nil)
+	require.NoError(err)
+	require.Nil(bc.GetFactory().Commit())
+	// Create actpool
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, addr1, uint64(2), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(3))
+	require.NoError(err)
+	exec3, err := testutil.SignedExecution(addr2, action.EmptyAddress, uint64(1), big.NewInt(10),
+		uint64(100000), big.NewInt(5), []byte{1})
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr2, addr1, uint64(2), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	require.NoError(ap.AddTsf(tsf1))
+	require.NoError(ap.AddTsf(tsf2))
+	require.NoError(ap.AddExecution(exec3))
+	require.NoError(ap.AddTsf(tsf4))
+
+	// Actions are picked by gas price, while the nonce order of each account is kept
+	pickedTsfs, pickedVotes, pickedExecutions := ap.PickActions(action.GasLimit)
+	require.Equal([]*action.Transfer{tsf4, tsf1, tsf2}, pickedTsfs)
+	require.Equal([]*action.Vote{}, pickedVotes)
+	require.Equal(1, len(pickedExecutions))
+	require.Equal(exec3.Hash(), pickedExecutions[0].Hash())
+
+	// Only the actions of addr2 fit in the gas budget
+	pickedTsfs, pickedVotes, pickedExecutions = ap.PickActions(uint64(250000))
+	require.Equal([]*action.Transfer{tsf4}, pickedTsfs)
+	require.Equal([]*action.Vote{}, pickedVotes)
+	require.Equal(1, len(pickedExecutions))
+	require.Equal(exec3.Hash(), pickedExecutions[0].Hash())
+
+	// No action fits in the gas budget
+	pickedTsfs, pickedVotes, pickedExecutions = ap.PickActions(uint64(10))
+	require.Equal([]*action.Transfer{}, pickedTsfs)
+	require.Equal([]*action.Vote{}, pickedVotes)
+	require.Equal([]*action.Execution{}, pickedExecutions)
+}
+
 func TestActPool_GetCapacity(t *testing.T) {
 	require := require.New(t)
 	bc := blockchain.NewBlockchain(&config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
@@ -1072,6 +1321,292 @@ func TestActPool_GetSize(t *testing.T) {
 	require.Equal(uint64(0), ap.GetSize())
 }
 
+func TestActPool_GetEvictionCount(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(&config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, uint64(100))
+	require.NoError(err)
+	_, err = bc.GetFactory().RunActions(0, nil, nil, nil)
+	require.NoError(err)
+	require.Nil(bc.GetFactory().Commit())
+	// Create actpool
+	apConfig := getActPoolCfg()
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	require.Zero(ap.GetEvictionCount())
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, addr2, uint64(2), big.NewInt(20),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.AddTsf(tsf1))
+	require.NoError(ap.AddTsf(tsf2))
+	require.Equal(uint64(2), ap.GetSize())
+
+	// Another transfer with nonce 1 is committed and drains the balance, so that tsf2 becomes unpayable
+	tsf3, err := testutil.SignedTransfer(addr1, addr2, uint64(1), big.NewInt(90),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	_, err = bc.GetFactory().RunActions(0, []*action.Transfer{tsf3}, nil, nil)
+	require.NoError(err)
+	require.Nil(bc.GetFactory().Commit())
+	ap.Reset()
+	require.Zero(ap.GetSize())
+	// The confirmed nonce removes tsf1, which is not an eviction
+	require.Equal(uint64(1), ap.GetEvictionCount())
+}
+
+func TestActPool_Eviction(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(&config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, uint64(1000000))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2.RawAddress, uint64(1000000))
+	require.NoError(err)
+	_, err = bc.CreateState(addr3.RawAddress, uint64(1000000))
+	require.NoError(err)
+	_, err = bc.GetFactory().RunActions(0, nil, nil, nil)
+	require.NoError(err)
+	require.Nil(bc.GetFactory().Commit())
+
+	newTsf := func(sender *iotxaddress.Address, nonce uint64, gasPrice int64) *action.Transfer {
+		tsf, err := testutil.SignedTransfer(sender, addr4, nonce, big.NewInt(10),
+			[]byte{}, uint64(100000), big.NewInt(gasPrice))
+		require.NoError(err)
+		return tsf
+	}
+
+	t.Run("no eviction", func(t *testing.T) {
+		apConfig := getActPoolCfg()
+		apConfig.MaxNumActsPerPool = 1
+		ap, err := NewActPool(bc, apConfig)
+		require.NoError(err)
+		require.NoError(ap.AddTsf(newTsf(addr1, 1, 1)))
+		evicted, err := ap.AddAction(newTsf(addr2, 1, 2).ConvertToActionPb())
+		require.Equal(ErrActPool, errors.Cause(err))
+		require.False(evicted)
+		require.Zero(ap.GetEvictionCount())
+	})
+
+	t.Run("oldest", func(t *testing.T) {
+		apConfig := getActPoolCfg()
+		apConfig.MaxNumActsPerPool = 2
+		apConfig.EvictionPolicy = config.OldestEvictionPolicy
+		ap, err := NewActPool(bc, apConfig)
+		require.NoError(err)
+		tsf1 := newTsf(addr1, 1, 1)
+		tsf2 := newTsf(addr2, 1, 2)
+		require.NoError(ap.AddTsf(tsf1))
+		require.NoError(ap.AddTsf(tsf2))
+
+		evicted, err := ap.AddAction(newTsf(addr3, 1, 1).ConvertToActionPb())
+		require.NoError(err)
+		require.True(evicted)
+		require.Equal(uint64(2), ap.GetSize())
+		_, err = ap.GetActionByHash(tsf1.Hash())
+		require.Error(err)
+		// The evicted action's account is removed from pool, so that its pending nonce falls back to confirmed nonce
+		nonce, err := ap.GetPendingNonce(addr1.RawAddress)
+		require.NoError(err)
+		require.Equal(uint64(1), nonce)
+
+		evicted, err = ap.AddAction(newTsf(addr3, 2, 1).ConvertToActionPb())
+		require.NoError(err)
+		require.True(evicted)
+		_, err = ap.GetActionByHash(tsf2.Hash())
+		require.Error(err)
+		require.Equal(uint64(2), ap.GetEvictionCount())
+
+		// The sender's own actions are never evicted
+		evicted, err = ap.AddAction(newTsf(addr3, 3, 1).ConvertToActionPb())
+		require.Equal(ErrActPool, errors.Cause(err))
+		require.False(evicted)
+		require.Equal(uint64(2), ap.GetSize())
+	})
+
+	t.Run("lowest fee", func(t *testing.T) {
+		apConfig := getActPoolCfg()
+		apConfig.MaxNumActsPerPool = 2
+		apConfig.EvictionPolicy = config.LowestFeeEvictionPolicy
+		ap, err := NewActPool(bc, apConfig)
+		require.NoError(err)
+		tsf1 := newTsf(addr1, 1, 3)
+		tsf2 := newTsf(addr2, 1, 1)
+		require.NoError(ap.AddTsf(tsf1))
+		require.NoError(ap.AddTsf(tsf2))
+
+		// The incoming action doesn't pay a higher gas price than the cheapest action in pool
+		evicted, err := ap.AddAction(newTsf(addr3, 1, 1).ConvertToActionPb())
+		require.Equal(ErrActPool, errors.Cause(err))
+		require.False(evicted)
+
+		evicted, err = ap.AddAction(newTsf(addr3, 1, 2).ConvertToActionPb())
+		require.NoError(err)
+		require.True(evicted)
+		_, err = ap.GetActionByHash(tsf2.Hash())
+		require.Error(err)
+		_, err = ap.GetActionByHash(tsf1.Hash())
+		require.NoError(err)
+		require.Equal(uint64(1), ap.GetEvictionCount())
+	})
+}
+
+func TestActPool_FeeFloor(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(&config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, uint64(100000000))
+	require.NoError(err)
+	_, err = bc.GetFactory().RunActions(0, nil, nil, nil)
+	require.NoError(err)
+	require.Nil(bc.GetFactory().Commit())
+
+	newTsf := func(nonce uint64, gasPrice int64) *action.Transfer {
+		tsf, err := testutil.SignedTransfer(addr1, addr2, nonce, big.NewInt(10), []byte{}, uint64(100000),
+			big.NewInt(gasPrice))
+		require.NoError(err)
+		return tsf
+	}
+
+	t.Run("min gas price", func(t *testing.T) {
+		apConfig := getActPoolCfg()
+		apConfig.MinGasPrice = 2
+		ap, err := NewActPool(bc, apConfig)
+		require.NoError(err)
+		// below the floor
+		_, err = ap.AddAction(newTsf(1, 1).ConvertToActionPb())
+		require.Equal(ErrFeeTooLow, errors.Cause(err))
+		exec, err := testutil.SignedExecution(addr1, action.EmptyAddress, 1, big.NewInt(0), uint64(100000),
+			big.NewInt(1), []byte{})
+		require.NoError(err)
+		require.Equal(ErrFeeTooLow, errors.Cause(ap.AddExecution(exec)))
+		vote, err := testutil.SignedVote(addr1, addr1, 1, uint64(100000), big.NewInt(1))
+		require.NoError(err)
+		require.Equal(ErrFeeTooLow, errors.Cause(ap.AddVote(vote)))
+		require.Zero(ap.GetSize())
+		// at and above the floor
+		_, err = ap.AddAction(newTsf(1, 2).ConvertToActionPb())
+		require.NoError(err)
+		_, err = ap.AddAction(newTsf(2, 3).ConvertToActionPb())
+		require.NoError(err)
+
+		// raise the floor at runtime, which doesn't affect the actions in pool
+		ap.SetMinGasPrice(big.NewInt(4))
+		_, err = ap.AddAction(newTsf(3, 3).ConvertToActionPb())
+		require.Equal(ErrFeeTooLow, errors.Cause(err))
+		_, err = ap.AddAction(newTsf(3, 4).ConvertToActionPb())
+		require.NoError(err)
+		require.Equal(uint64(3), ap.GetSize())
+	})
+
+	t.Run("min transfer fee", func(t *testing.T) {
+		apConfig := getActPoolCfg()
+		apConfig.MinTransferFee = 2 * action.TransferBaseIntrinsicGas
+		ap, err := NewActPool(bc, apConfig)
+		require.NoError(err)
+		// zero-fee and below the floor
+		require.Equal(ErrFeeTooLow, errors.Cause(ap.AddTsf(newTsf(1, 0))))
+		require.Equal(ErrFeeTooLow, errors.Cause(ap.AddTsf(newTsf(1, 1))))
+		// at and above the floor
+		require.NoError(ap.AddTsf(newTsf(1, 2)))
+		require.NoError(ap.AddTsf(newTsf(2, 3)))
+		require.Equal(uint64(2), ap.GetSize())
+	})
+}
+
+func TestActPool_Recover(t *testing.T) {
+	require := require.New(t)
+	testWALPath := "actpool.wal.test"
+	testutil.CleanupPath(t, testWALPath)
+	defer testutil.CleanupPath(t, testWALPath)
+
+	bc := blockchain.NewBlockchain(&config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, uint64(100))
+	require.NoError(err)
+	_, err = bc.GetFactory().RunActions(0, nil, nil, nil)
+	require.NoError(err)
+	require.Nil(bc.GetFactory().Commit())
+	// Create actpool
+	apConfig := getActPoolCfg()
+	apConfig.WALPath = testWALPath
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, addr1, uint64(2), big.NewInt(20),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	vote3, err := testutil.SignedVote(addr1, addr1, uint64(3), uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.AddTsf(tsf1))
+	require.NoError(ap.AddTsf(tsf2))
+	require.NoError(ap.AddVote(vote3))
+	acts, err := ap.wal.readAll()
+	require.NoError(err)
+	require.Equal(3, len(acts))
+
+	// tsf1 is committed before the node restarts
+	_, err = bc.GetFactory().RunActions(0, []*action.Transfer{tsf1}, nil, nil)
+	require.NoError(err)
+	require.Nil(bc.GetFactory().Commit())
+
+	Ap, err = NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok = Ap.(*actPool)
+	require.True(ok)
+	require.Zero(ap.GetSize())
+	require.NoError(ap.Recover())
+	require.Equal(uint64(2), ap.GetSize())
+	pendingNonce, err := ap.getPendingNonce(addr1.RawAddress)
+	require.NoError(err)
+	require.Equal(uint64(4), pendingNonce)
+	// The stale transfer is dropped from the log as well
+	acts, err = ap.wal.readAll()
+	require.NoError(err)
+	require.Equal(2, len(acts))
+
+	// Recovering without a log is a no-op
+	Ap, err = NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	require.NoError(Ap.Recover())
+	require.Zero(Ap.GetSize())
+}
+
+func TestActPool_WALCorruptedSize(t *testing.T) {
+	require := require.New(t)
+	testWALPath := "actpool.wal.test"
+	testutil.CleanupPath(t, testWALPath)
+	defer testutil.CleanupPath(t, testWALPath)
+
+	w := newWAL(testWALPath)
+	tsf, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(w.append(tsf.ConvertToActionPb()))
+	// A record claiming to be larger than any action ends the log
+	f, err := os.OpenFile(testWALPath, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(err)
+	require.NoError(binary.Write(f, binary.BigEndian, uint32(math.MaxUint32)))
+	_, err = f.Write([]byte{1, 2, 3})
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	acts, err := w.readAll()
+	require.NoError(err)
+	require.Equal(1, len(acts))
+}
+
 // Helper function to return the correct pending nonce just in case of empty queue
 func (ap *actPool) getPendingNonce(addr string) (uint64, error) {
 	if queue, ok := ap.accountActs[addr]; ok {
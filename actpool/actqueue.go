@@ -39,6 +39,34 @@ func (h *noncePriorityQueue) Pop() interface{} {
 	return x
 }
 
+// gasPriceQueue is a max heap of the pending actions of accounts, ordered by the gas price of each account's first
+// action
+type gasPriceQueue [][]*iproto.ActionPb
+
+func (h gasPriceQueue) Len() int { return len(h) }
+func (h gasPriceQueue) Less(i, j int) bool {
+	pi := new(big.Int).SetBytes(h[i][0].GetGasPrice())
+	pj := new(big.Int).SetBytes(h[j][0].GetGasPrice())
+	return pi.Cmp(pj) > 0
+}
+func (h gasPriceQueue) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *gasPriceQueue) Push(x interface{}) {
+	in, ok := x.([]*iproto.ActionPb)
+	if !ok {
+		return
+	}
+	*h = append(*h, in)
+}
+
+func (h *gasPriceQueue) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
 // ActQueue is the interface of actQueue
 type ActQueue interface {
 	Overlaps(*iproto.ActionPb) bool
@@ -55,6 +83,8 @@ type ActQueue interface {
 	Empty() bool
 	PendingActs() []*iproto.ActionPb
 	AllActs() []*iproto.ActionPb
+	LastAct() *iproto.ActionPb
+	RemoveLast() *iproto.ActionPb
 }
 
 // actQueue is a queue of actions from an account
@@ -215,6 +245,37 @@ func (q *actQueue) AllActs() []*iproto.ActionPb {
 	return acts
 }
 
+// LastAct returns the action with the largest nonce in queue
+func (q *actQueue) LastAct() *iproto.ActionPb {
+	if q.Len() == 0 {
+		return nil
+	}
+	last := q.index[0]
+	for _, nonce := range q.index {
+		if nonce > last {
+			last = nonce
+		}
+	}
+	return q.items[last]
+}
+
+// RemoveLast removes the action with the largest nonce from queue. If the action is pending, the pending nonce and
+// balance are rolled back to the state before it
+func (q *actQueue) RemoveLast() *iproto.ActionPb {
+	if q.Len() == 0 {
+		return nil
+	}
+	sort.Sort(q.index)
+	last := q.index.Len() - 1
+	nonce := q.index[last]
+	act := q.removeActs(last)[0]
+	if nonce < q.pendingNonce {
+		q.pendingNonce = nonce
+		q.pendingBalance.Add(q.pendingBalance, actionCost(act))
+	}
+	return act
+}
+
 // removeActs removes all the actions starting at idx from queue
 func (q *actQueue) removeActs(idx int) []*iproto.ActionPb {
 	removedFromQueue := make([]*iproto.ActionPb, 0)
@@ -229,36 +290,33 @@ func (q *actQueue) removeActs(idx int) []*iproto.ActionPb {
 
 // enoughBalance helps check whether queue's pending balance is sufficient for the given action
 func (q *actQueue) enoughBalance(act *iproto.ActionPb, updateBalance bool) bool {
+	cost := actionCost(act)
+	if q.pendingBalance.Cmp(cost) < 0 {
+		return false
+	}
+	if updateBalance {
+		q.pendingBalance.Sub(q.pendingBalance, cost)
+	}
+	return true
+}
+
+// actionCost returns the maximum amount of balance the given action may cost
+func actionCost(act *iproto.ActionPb) *big.Int {
 	switch {
 	case act.GetTransfer() != nil:
 		tsf := action.Transfer{}
 		tsf.ConvertFromActionPb(act)
 		cost, _ := tsf.Cost()
-		if q.pendingBalance.Cmp(cost) < 0 {
-			return false
-		}
-		if updateBalance {
-			q.pendingBalance.Sub(q.pendingBalance, cost)
-		}
+		return cost
 	case act.GetVote() != nil:
 		vote := action.Vote{}
 		vote.ConvertFromActionPb(act)
 		cost, _ := vote.Cost()
-		if q.pendingBalance.Cmp(cost) < 0 {
-			return false
-		}
-		if updateBalance {
-			q.pendingBalance.Sub(q.pendingBalance, cost)
-		}
+		return cost
 	case act.GetExecution() != nil:
 		exec := action.Execution{}
 		exec.ConvertFromActionPb(act)
-		if q.pendingBalance.Cmp(exec.CostLimit()) < 0 {
-			return false
-		}
-		if updateBalance {
-			q.pendingBalance.Sub(q.pendingBalance, exec.CostLimit())
-		}
+		return exec.CostLimit()
 	}
-	return true
+	return big.NewInt(0)
 }
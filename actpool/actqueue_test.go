@@ -228,3 +228,38 @@ func TestActQueue_removeActs(t *testing.T) {
 	require.Equal(1, len(q.items))
 	require.Equal([]*pb.ActionPb{action5, action6}, removed)
 }
+
+func TestActQueue_RemoveLast(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue().(*actQueue)
+	require.Nil(q.LastAct())
+	require.Nil(q.RemoveLast())
+	tsf1, err := action.NewTransfer(uint64(1), big.NewInt(10), "1", "2", nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	action1 := tsf1.ConvertToActionPb()
+	tsf2, err := action.NewTransfer(uint64(2), big.NewInt(20), "1", "2", nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	action2 := tsf2.ConvertToActionPb()
+	tsf4, err := action.NewTransfer(uint64(4), big.NewInt(40), "1", "2", nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	action4 := tsf4.ConvertToActionPb()
+	require.NoError(q.Put(action4))
+	require.NoError(q.Put(action1))
+	require.NoError(q.Put(action2))
+	q.pendingBalance = big.NewInt(100)
+	q.UpdateQueue(uint64(1))
+	require.Equal(uint64(3), q.pendingNonce)
+	require.Equal(big.NewInt(70), q.pendingBalance)
+
+	// Removing a non-pending action doesn't change the pending nonce and balance
+	require.Equal(action4, q.LastAct())
+	require.Equal(action4, q.RemoveLast())
+	require.Equal(uint64(3), q.pendingNonce)
+	require.Equal(big.NewInt(70), q.pendingBalance)
+
+	// Removing a pending action rolls back the pending nonce and balance
+	require.Equal(action2, q.RemoveLast())
+	require.Equal(uint64(2), q.pendingNonce)
+	require.Equal(big.NewInt(90), q.pendingBalance)
+	require.Equal([]*pb.ActionPb{action1}, q.AllActs())
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+// maxWALRecordSize is the maximum size of a record in the log. It is the default maximum size of a network message,
+// which no valid action could exceed, so that a corrupted length doesn't make the recovery allocate gigabytes
+const maxWALRecordSize = 10 * 1024 * 1024
+
+// wal is the write-ahead log persisting the actions accepted by the actpool, so that the pending actions can be
+// recovered after the node restarts. Each record is a 4-byte big-endian length followed by the serialized action.
+type wal struct {
+	path string
+}
+
+func newWAL(path string) *wal {
+	return &wal{path: path}
+}
+
+// append appends an action to the end of the log
+func (w *wal) append(act *iproto.ActionPb) error {
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open actpool WAL %s", w.path)
+	}
+	defer f.Close()
+
+	return writeRecord(f, act)
+}
+
+// rewrite replaces the content of the log with the given actions
+func (w *wal) rewrite(acts []*iproto.ActionPb) error {
+	tmpPath := w.path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open actpool WAL %s", tmpPath)
+	}
+	writer := bufio.NewWriter(f)
+	for _, act := range acts {
+		if err := writeRecord(writer, act); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to flush actpool WAL")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to close actpool WAL")
+	}
+	return os.Rename(tmpPath, w.path)
+}
+
+// readAll reads all the actions in the log. A missing log is treated as an empty one, and a truncated record at the
+// end of the log, which could be left by a crash, is ignored. So is the rest of the log from a record whose length
+// exceeds maxWALRecordSize, which must be corrupted.
+func (w *wal) readAll() ([]*iproto.ActionPb, error) {
+	f, err := os.Open(w.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open actpool WAL %s", w.path)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	var acts []*iproto.ActionPb
+	for {
+		var size uint32
+		if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+			if err != io.EOF {
+				logger.Warn().Err(err).Msg("Ignoring truncated record in actpool WAL")
+			}
+			return acts, nil
+		}
+		if size > maxWALRecordSize {
+			logger.Warn().Uint32("size", size).Msg("Ignoring corrupted records in actpool WAL")
+			return acts, nil
+		}
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			logger.Warn().Err(err).Msg("Ignoring truncated record in actpool WAL")
+			return acts, nil
+		}
+		act := &iproto.ActionPb{}
+		if err := proto.Unmarshal(buf, act); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal action in actpool WAL")
+		}
+		acts = append(acts, act)
+	}
+}
+
+func writeRecord(writer io.Writer, act *iproto.ActionPb) error {
+	buf, err := proto.Marshal(act)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal action")
+	}
+	if err := binary.Write(writer, binary.BigEndian, uint32(len(buf))); err != nil {
+		return errors.Wrap(err, "failed to write actpool WAL")
+	}
+	if _, err := writer.Write(buf); err != nil {
+		return errors.Wrap(err, "failed to write actpool WAL")
+	}
+	return nil
+}
@@ -7,10 +7,11 @@
 package address
 
 import (
-	"errors"
 	"os"
 	"strings"
 
+	"github.com/pkg/errors"
+
 	"github.com/iotexproject/iotex-core/pkg/hash"
 )
 
@@ -71,6 +72,32 @@ func IotxAddressToAddress(iotxRawAddr string) (Address, error) {
 	return V1.IotxAddressToAddress(iotxRawAddr)
 }
 
+// ValidateAddress checks if the string is a valid address of the current network. The address is the Bech32 encoding,
+// prefixed by "io" on mainnet and "it" on testnet, of 25 bytes composed of the following parts in order:
+// 1. uint8: version of address
+// 2. uint32: chain ID
+// 3. 20 bytes: hash derived from the public key
+// The whole string is either in lowercase or in uppercase, as Bech32 requires.
+func ValidateAddress(addr string) error {
+	if _, err := V1.IotxAddressToAddress(addr); err != nil {
+		if errors.Cause(err) == ErrInvalidAddr {
+			return err
+		}
+		return errors.Wrapf(ErrInvalidAddr, "address %s: %v", addr, err)
+	}
+	return nil
+}
+
+// NormalizeAddress trims the surrounding spaces of the address, validates it and returns it in lowercase, which is
+// how the addresses are stored on the blockchain
+func NormalizeAddress(addr string) (string, error) {
+	addr = strings.ToLower(strings.TrimSpace(addr))
+	if err := ValidateAddress(addr); err != nil {
+		return "", err
+	}
+	return addr, nil
+}
+
 // prefix returns the current prefix
 func prefix() string {
 	prefix := MainnetPrefix
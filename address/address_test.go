@@ -0,0 +1,57 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+)
+
+func TestValidateAddress(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	var chainID [4]byte
+	enc.MachineEndian.PutUint32(chainID[:], 1)
+	iotxAddr, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, chainID[:])
+	require.NoError(err)
+	rawAddr := iotxAddr.RawAddress
+	// a different last character breaks the checksum
+	wrongChecksum := rawAddr[:len(rawAddr)-1] + "q"
+	if strings.HasSuffix(rawAddr, "q") {
+		wrongChecksum = rawAddr[:len(rawAddr)-1] + "p"
+	}
+
+	require.NoError(ValidateAddress(rawAddr))
+	require.NoError(ValidateAddress(strings.ToUpper(rawAddr)))
+
+	for _, addr := range []string{
+		"",
+		"io1",
+		prefix() + "1qyqsyqcy",
+		rawAddr[:len(rawAddr)-1],
+		wrongChecksum,
+		strings.ToUpper(rawAddr[:3]) + rawAddr[3:],
+		" " + rawAddr,
+	} {
+		err := ValidateAddress(addr)
+		require.Error(err, addr)
+		require.Equal(ErrInvalidAddr, errors.Cause(err), addr)
+	}
+
+	normalized, err := NormalizeAddress(" " + strings.ToUpper(rawAddr) + "\n")
+	require.NoError(err)
+	require.Equal(rawAddr, normalized)
+	_, err = NormalizeAddress("io1")
+	require.Equal(ErrInvalidAddr, errors.Cause(err))
+}
@@ -11,9 +11,11 @@ import (
 	"math/big"
 
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 )
@@ -41,6 +43,14 @@ type Action interface {
 	IntrinsicGas() (uint64, error)
 }
 
+// ChainBoundAction is the interface of the actions whose signatures could be bound to a chain, so that they cannot be
+// replayed on other chains
+type ChainBoundAction interface {
+	Action
+	// ChainBoundHash returns the hash of the action together with the chain ID, which is signed instead of Hash
+	ChainBoundHash(chainID uint32) hash.Hash32B
+}
+
 type action struct {
 	version   uint32
 	nonce     uint64
@@ -84,6 +94,20 @@ func (act *action) SetSignature(signature []byte) { act.signature = signature }
 
 // Sign signs the action using sender's private key
 func Sign(act Action, sk keypair.PrivateKey) error {
+	return sign(act, sk, act.Hash)
+}
+
+// SignWithChainID signs the action bound to the chain using sender's private key. Actions not implementing
+// ChainBoundAction are signed as by Sign.
+func SignWithChainID(act Action, sk keypair.PrivateKey, chainID uint32) error {
+	bound, ok := act.(ChainBoundAction)
+	if !ok {
+		return Sign(act, sk)
+	}
+	return sign(act, sk, func() hash.Hash32B { return bound.ChainBoundHash(chainID) })
+}
+
+func sign(act Action, sk keypair.PrivateKey, hashFunc func() hash.Hash32B) error {
 	// TODO: remove this conversion once we deprecate old address format
 	srcAddr, err := address.IotxAddressToAddress(act.SrcAddr())
 	if err != nil {
@@ -105,7 +129,7 @@ func Sign(act Action, sk keypair.PrivateKey) error {
 		)
 	}
 	act.SetSrcPubkey(pk)
-	hash := act.Hash()
+	hash := hashFunc()
 	if act.SetSignature(crypto.EC283.Sign(sk, hash[:])); act.Signature() == nil {
 		return errors.Wrapf(ErrAction, "failed to sign action hash = %x", hash)
 	}
@@ -125,3 +149,34 @@ func Verify(act Action) error {
 		act.Signature(),
 	)
 }
+
+// VerifyWithChainID verifies the action bound to the chain using sender's public key. If allowUnbound is true, the
+// signature not bound to any chain is accepted as well, which is the migration path for the actions signed by Sign.
+// Actions not implementing ChainBoundAction are verified as by Verify.
+func VerifyWithChainID(act Action, chainID uint32, allowUnbound bool) error {
+	bound, ok := act.(ChainBoundAction)
+	if !ok {
+		return Verify(act)
+	}
+	hash := bound.ChainBoundHash(chainID)
+	if success := crypto.EC283.Verify(act.SrcPubkey(), hash[:], act.Signature()); success {
+		return nil
+	}
+	if allowUnbound {
+		return Verify(act)
+	}
+	return errors.Wrapf(
+		ErrAction,
+		"failed to verify action hash = %x and signature = %x bound to chain %d",
+		act.Hash(),
+		act.Signature(),
+		chainID,
+	)
+}
+
+// chainBoundHash returns the hash of the action's byte stream appended by the chain ID
+func chainBoundHash(stream []byte, chainID uint32) hash.Hash32B {
+	id := make([]byte, 4)
+	enc.MachineEndian.PutUint32(id, chainID)
+	return blake2b.Sum256(append(stream, id...))
+}
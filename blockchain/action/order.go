@@ -0,0 +1,51 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"sort"
+)
+
+// CanonicalOrder returns the actions sorted by sender and then by nonce. The actions of the same sender and nonce keep
+// their order of arrival, i.e., their order in acts. acts is not modified.
+func CanonicalOrder(acts []Action) []Action {
+	sorted := make([]Action, len(acts))
+	copy(sorted, acts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].SrcAddr() != sorted[j].SrcAddr() {
+			return sorted[i].SrcAddr() < sorted[j].SrcAddr()
+		}
+		return sorted[i].Nonce() < sorted[j].Nonce()
+	})
+	return sorted
+}
+
+// CanonicalOrderByType sorts the transfers, votes and executions to be put into a block in CanonicalOrder in place, so
+// that the proposers picking the same actions assemble the same block regardless of the order they are picked
+func CanonicalOrderByType(transfers []*Transfer, votes []*Vote, executions []*Execution) {
+	acts := make([]Action, 0, len(transfers))
+	for _, tsf := range transfers {
+		acts = append(acts, tsf)
+	}
+	for i, act := range CanonicalOrder(acts) {
+		transfers[i] = act.(*Transfer)
+	}
+	acts = make([]Action, 0, len(votes))
+	for _, vote := range votes {
+		acts = append(acts, vote)
+	}
+	for i, act := range CanonicalOrder(acts) {
+		votes[i] = act.(*Vote)
+	}
+	acts = make([]Action, 0, len(executions))
+	for _, execution := range executions {
+		acts = append(acts, execution)
+	}
+	for i, act := range CanonicalOrder(acts) {
+		executions[i] = act.(*Execution)
+	}
+}
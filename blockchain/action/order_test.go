@@ -0,0 +1,55 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalOrder(t *testing.T) {
+	require := require.New(t)
+
+	newTsf := func(sender string, nonce uint64, amount int64) *Transfer {
+		tsf, err := NewTransfer(nonce, big.NewInt(amount), sender, "io1recipient", []byte{}, uint64(100000),
+			big.NewInt(10))
+		require.NoError(err)
+		return tsf
+	}
+	a1 := newTsf("io1a", 1, 10)
+	a2 := newTsf("io1a", 2, 10)
+	b1 := newTsf("io1b", 1, 10)
+	b2 := newTsf("io1b", 2, 10)
+	// the same sender and nonce as b2, which arrives later
+	b2Later := newTsf("io1b", 2, 20)
+
+	acts := []Action{b2, a2, b1, b2Later, a1}
+	sorted := CanonicalOrder(acts)
+	require.Equal([]Action{a1, a2, b1, b2, b2Later}, sorted)
+	// acts is not modified
+	require.Equal([]Action{b2, a2, b1, b2Later, a1}, acts)
+
+	// the order is the same regardless of the order of arrival of the actions with different senders or nonces
+	for i := 0; i < 10; i++ {
+		shuffled := []Action{a1, a2, b1, b2}
+		rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		require.Equal([]Action{a1, a2, b1, b2}, CanonicalOrder(shuffled))
+	}
+
+	vote1, err := NewVote(1, "io1b", "io1b", uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	vote2, err := NewVote(1, "io1a", "io1a", uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	transfers := []*Transfer{b1, a2, a1}
+	votes := []*Vote{vote1, vote2}
+	CanonicalOrderByType(transfers, votes, nil)
+	require.Equal([]*Transfer{a1, a2, b1}, transfers)
+	require.Equal([]*Vote{vote2, vote1}, votes)
+}
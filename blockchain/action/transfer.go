@@ -296,6 +296,12 @@ func (tsf *Transfer) Hash() hash.Hash32B {
 	return blake2b.Sum256(tsf.ByteStream())
 }
 
+// ChainBoundHash returns the hash of the Transfer bound to the chain, which is signed to prevent the transfer from being
+// replayed on other chains
+func (tsf *Transfer) ChainBoundHash(chainID uint32) hash.Hash32B {
+	return chainBoundHash(tsf.ByteStream(), chainID)
+}
+
 // IntrinsicGas returns the intrinsic gas of a transfer
 func (tsf *Transfer) IntrinsicGas() (uint64, error) {
 	payloadSize := uint64(len(tsf.Payload()))
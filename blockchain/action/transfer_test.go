@@ -36,6 +36,30 @@ func TestTransferSignVerify(t *testing.T) {
 	require.NoError(Verify(tsf))
 }
 
+func TestTransferSignVerifyWithChainID(t *testing.T) {
+	require := require.New(t)
+	sender, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, chainid)
+	require.NoError(err)
+	recipient, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, chainid)
+	require.NoError(err)
+
+	tsf, err := NewTransfer(0, big.NewInt(10), sender.RawAddress, recipient.RawAddress, []byte{}, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	require.NoError(SignWithChainID(tsf, sender.PrivateKey, 1))
+	require.NoError(VerifyWithChainID(tsf, 1, false))
+	// The signature cannot be replayed on other chains
+	require.Error(VerifyWithChainID(tsf, 2, false))
+	require.Error(VerifyWithChainID(tsf, 2, true))
+	require.Error(Verify(tsf))
+	// The hash identifying the transfer doesn't change
+	require.NotEqual(tsf.Hash(), tsf.ChainBoundHash(1))
+
+	// The unbound signature is only accepted if allowed
+	require.NoError(Sign(tsf, sender.PrivateKey))
+	require.Error(VerifyWithChainID(tsf, 1, false))
+	require.NoError(VerifyWithChainID(tsf, 1, true))
+}
+
 func TestTransferSerializeDeserialize(t *testing.T) {
 	require := require.New(t)
 	sender, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, chainid)
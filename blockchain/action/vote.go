@@ -220,6 +220,12 @@ func (v *Vote) Hash() hash.Hash32B {
 	return blake2b.Sum256(v.ByteStream())
 }
 
+// ChainBoundHash returns the hash of the Vote bound to the chain, which is signed to prevent the vote from being
+// replayed on other chains
+func (v *Vote) ChainBoundHash(chainID uint32) hash.Hash32B {
+	return chainBoundHash(v.ByteStream(), chainID)
+}
+
 // IntrinsicGas returns the intrinsic gas of a vote
 func (v *Vote) IntrinsicGas() (uint64, error) {
 	return VoteIntrinsicGas, nil
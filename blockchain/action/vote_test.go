@@ -28,6 +28,25 @@ func TestVoteSignVerify(t *testing.T) {
 	require.NoError(Verify(v))
 }
 
+func TestVoteSignVerifyWithChainID(t *testing.T) {
+	require := require.New(t)
+	sender, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, chainid)
+	require.NoError(err)
+	recipient, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, chainid)
+	require.NoError(err)
+	v, err := NewVote(0, sender.RawAddress, recipient.RawAddress, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+
+	require.NoError(SignWithChainID(v, sender.PrivateKey, 1))
+	require.NoError(VerifyWithChainID(v, 1, false))
+	require.Error(VerifyWithChainID(v, 2, true))
+	require.Error(Verify(v))
+
+	require.NoError(Sign(v, sender.PrivateKey))
+	require.Error(VerifyWithChainID(v, 1, false))
+	require.NoError(VerifyWithChainID(v, 1, true))
+}
+
 func TestVoteSerializedDeserialize(t *testing.T) {
 	require := require.New(t)
 	sender, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, chainid)
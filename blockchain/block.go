@@ -8,10 +8,10 @@ package blockchain
 
 import (
 	"bytes"
-	"errors"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotexproject/iotex-core/blockchain/action"
@@ -307,6 +307,32 @@ func (b *Block) Deserialize(buf []byte) error {
 
 // TxRoot returns the Merkle root of all txs and actions in this block.
 func (b *Block) TxRoot() hash.Hash32B {
+	h := b.txHashes()
+	if len(h) == 0 {
+		return hash.ZeroHash32B
+	}
+	return crypto.NewMerkleTree(h).HashTree()
+}
+
+// TxProof returns the index of the action among the leaves of the merkle tree of TxRoot, and the merkle branch which
+// proves that the action is included in the block
+func (b *Block) TxProof(actHash hash.Hash32B) (int, []hash.Hash32B, error) {
+	h := b.txHashes()
+	for i := range h {
+		if h[i] != actHash {
+			continue
+		}
+		branch, err := crypto.NewMerkleTree(h).Proof(i)
+		if err != nil {
+			return 0, nil, err
+		}
+		return i, branch, nil
+	}
+	return 0, nil, errors.Errorf("action %x is not in block %d", actHash, b.Height())
+}
+
+// txHashes returns the hashes of the actions in the order of the leaves of the merkle tree of TxRoot
+func (b *Block) txHashes() []hash.Hash32B {
 	var h []hash.Hash32B
 	for _, t := range b.Transfers {
 		h = append(h, t.Hash())
@@ -323,10 +349,7 @@ func (b *Block) TxRoot() hash.Hash32B {
 	if b.SecretWitness != nil {
 		h = append(h, b.SecretWitness.Hash())
 	}
-	if len(h) == 0 {
-		return hash.ZeroHash32B
-	}
-	return crypto.NewMerkleTree(h).HashTree()
+	return h
 }
 
 // HashBlock return the hash of this block (actually hash of block header)
@@ -370,3 +393,14 @@ func (b *Block) ProducerAddress() string {
 	}
 	return addr.RawAddress
 }
+
+// RewardAddress returns the recipient of the block reward, which is the recipient of the coinbase transfer, or the
+// producer if the block has no coinbase transfer
+func (b *Block) RewardAddress() string {
+	for _, tsf := range b.Transfers {
+		if tsf.IsCoinbase() {
+			return tsf.Recipient()
+		}
+	}
+	return b.ProducerAddress()
+}
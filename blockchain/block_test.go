@@ -197,7 +197,7 @@ func TestConvertFromBlockPb(t *testing.T) {
 
 func TestWrongRootHash(t *testing.T) {
 	require := require.New(t)
-	val := validator{nil, ""}
+	val := validator{}
 	tsf1, err := action.NewTransfer(1, big.NewInt(20), ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["alfa"].RawAddress, []byte{}, uint64(100000), big.NewInt(10))
 	require.NoError(err)
 	require.NoError(action.Sign(tsf1, ta.Addrinfo["producer"].PrivateKey))
@@ -216,7 +216,7 @@ func TestWrongRootHash(t *testing.T) {
 
 func TestSignBlock(t *testing.T) {
 	require := require.New(t)
-	val := validator{nil, ""}
+	val := validator{}
 	tsf1, err := action.NewTransfer(1, big.NewInt(20), ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["alfa"].RawAddress, []byte{}, uint64(100000), big.NewInt(10))
 	require.NoError(err)
 	require.NoError(action.Sign(tsf1, ta.Addrinfo["producer"].PrivateKey))
@@ -230,6 +230,26 @@ func TestSignBlock(t *testing.T) {
 	require.Nil(val.Validate(blk, 2, hash, true))
 }
 
+func TestTxProof(t *testing.T) {
+	require := require.New(t)
+	tsf1, err := action.NewTransfer(1, big.NewInt(20), ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["alfa"].RawAddress, []byte{}, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	tsf2, err := action.NewTransfer(2, big.NewInt(30), ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["bravo"].RawAddress, []byte{}, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	vote, err := action.NewVote(3, ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["producer"].RawAddress, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	blk := NewBlock(1, 3, hash.ZeroHash32B, testutil.TimestampNow(), []*action.Transfer{tsf1, tsf2}, []*action.Vote{vote}, nil)
+
+	for i, h := range []hash.Hash32B{tsf1.Hash(), tsf2.Hash(), vote.Hash()} {
+		index, branch, err := blk.TxProof(h)
+		require.NoError(err)
+		require.Equal(i, index)
+		require.True(crypto.VerifyMerkleProof(h, index, branch, blk.TxRoot()))
+	}
+	_, _, err = blk.TxProof(hash.ZeroHash32B)
+	require.Error(err)
+}
+
 func TestWrongNonce(t *testing.T) {
 	cfg := &config.Default
 	testutil.CleanupPath(t, cfg.Chain.TrieDBPath)
@@ -242,7 +262,7 @@ func TestWrongNonce(t *testing.T) {
 	require.NoError(sf.Start(context.Background()))
 	_, err = sf.LoadOrCreateState(ta.Addrinfo["producer"].RawAddress, Gen.TotalSupply)
 	require.NoError(err)
-	val := validator{sf, ""}
+	val := validator{sf: sf}
 	_, err = sf.RunActions(0, nil, nil, nil)
 	require.Nil(err)
 	require.Nil(sf.Commit())
@@ -354,7 +374,7 @@ func TestWrongCoinbaseTsf(t *testing.T) {
 	require.NoError(sf.Start(context.Background()))
 	_, err = sf.LoadOrCreateState(ta.Addrinfo["producer"].RawAddress, Gen.TotalSupply)
 	require.Nil(err)
-	val := validator{sf, ""}
+	val := validator{sf: sf}
 	_, err = sf.RunActions(0, nil, nil, nil)
 	require.Nil(err)
 
@@ -496,7 +516,7 @@ func TestValidateSecretBlock(t *testing.T) {
 	err = blk.SignBlock(ta.Addrinfo["producer"])
 	require.NoError(err)
 
-	val := validator{sf, delegates[1]}
+	val := validator{sf: sf, validatorAddr: delegates[1]}
 	require.NoError(val.Validate(blk, 2, hash, false))
 
 	// Falsify secret proposal
@@ -510,3 +530,26 @@ func TestValidateSecretBlock(t *testing.T) {
 	require.Error(err)
 	require.Equal(ErrDKGSecretProposal, errors.Cause(err))
 }
+
+func TestVerifyActionSignature(t *testing.T) {
+	require := require.New(t)
+
+	chainID := config.Default.Chain.ID
+	unbound, err := action.NewTransfer(1, big.NewInt(10), ta.Addrinfo["alfa"].RawAddress, ta.Addrinfo["bravo"].RawAddress,
+		nil, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	require.NoError(action.Sign(unbound, ta.Addrinfo["alfa"].PrivateKey))
+	bound, err := action.NewVote(2, ta.Addrinfo["alfa"].RawAddress, ta.Addrinfo["alfa"].RawAddress, uint64(100000),
+		big.NewInt(10))
+	require.NoError(err)
+	require.NoError(action.SignWithChainID(bound, ta.Addrinfo["alfa"].PrivateKey, chainID))
+
+	// Unbound signatures are always accepted if the activation height is not set
+	require.NoError(verifyActionSignature(unbound, chainID, 100, 0))
+	require.NoError(verifyActionSignature(bound, chainID, 100, 0))
+	// Unbound signatures are accepted below the activation height only
+	require.NoError(verifyActionSignature(unbound, chainID, 9, 10))
+	require.Error(verifyActionSignature(unbound, chainID, 10, 10))
+	require.NoError(verifyActionSignature(bound, chainID, 10, 10))
+	require.Error(verifyActionSignature(bound, chainID+1, 10, 10))
+}
@@ -51,12 +51,16 @@ type Blockchain interface {
 	GetBlockByHeight(height uint64) (*Block, error)
 	// GetBlockByHash returns Block by hash
 	GetBlockByHash(h hash.Hash32B) (*Block, error)
+	// GetBlockHeaderByHeight returns the header of the block by height, which is kept even if the block is pruned
+	GetBlockHeaderByHeight(height uint64) (*BlockHeader, error)
 	// GetTotalTransfers returns the total number of transfers
 	GetTotalTransfers() (uint64, error)
 	// GetTotalVotes returns the total number of votes
 	GetTotalVotes() (uint64, error)
 	// GetTotalExecutions returns the total number of executions
 	GetTotalExecutions() (uint64, error)
+	// GetActionCounts returns the number of each type of actions in the blocks from genesis up to the height
+	GetActionCounts(height uint64) (*ActionCounts, error)
 	// GetTransfersFromAddress returns transaction from address
 	GetTransfersFromAddress(address string) ([]hash.Hash32B, error)
 	// GetTransfersToAddress returns transaction to address
@@ -109,6 +113,13 @@ type Blockchain interface {
 	MintNewDummyBlock() *Block
 	// CommitBlock validates and appends a block to the chain
 	CommitBlock(blk *Block) error
+	// PruneBelow discards the full data of the blocks below the height while keeping their headers. Getting a pruned
+	// block returns ErrBlockPruned. The states on those heights are discarded as well, and reading them returns
+	// state.ErrHistoricalStateUnavailable. The state trie nodes no longer used are collected in the background
+	PruneBelow(height uint64) error
+	// Reprocess re-executes the blocks from genesis to toHeight against a fresh state factory, and returns the
+	// resulting state root. The roots of the blocks from fromHeight on are checked against their headers
+	Reprocess(fromHeight uint64, toHeight uint64) (hash.Hash32B, error)
 	// ValidateBlock validates a new block before adding it to the blockchain
 	ValidateBlock(blk *Block, containCoinbase bool) error
 
@@ -117,11 +128,48 @@ type Blockchain interface {
 	Validator() Validator
 	// SetValidator sets the current validator object
 	SetValidator(val Validator)
+	// VerifyActionSignature verifies the signature of an action to be included in the next block. The signatures of
+	// transfers and votes must be bound to the chain ID from config.Chain.ChainBoundSignatureHeight on
+	VerifyActionSignature(act action.Action) error
+	// VerifyActionSignatureAtHeight verifies the signature of an action to be included in the block of the given height.
+	// Unlike VerifyActionSignature, it doesn't take the chain's lock to read the tip height
+	VerifyActionSignatureAtHeight(act action.Action, height uint64) error
 
 	// For smart contract operations
 	// ExecuteContractRead runs a read-only smart contract operation, this is done off the network since it does not
 	// cause any state change
 	ExecuteContractRead(*action.Execution) ([]byte, error)
+	// SimulateExecution runs the execution against the current state and discards the state changes afterwards, which
+	// returns the receipt of the execution
+	SimulateExecution(*action.Execution) (*Receipt, error)
+	// SimulateExecutionOnPending runs the execution like SimulateExecution, but on top of the given pending actions,
+	// which are applied in order before the execution and discarded afterwards as well
+	SimulateExecutionOnPending(*action.Execution, []action.Action) (*Receipt, error)
+
+	// AddSubscriber makes the subscriber get notified of every produced block
+	AddSubscriber(BlockCreationSubscriber) error
+	// RemoveSubscriber stops notifying the subscriber of the produced blocks
+	RemoveSubscriber(BlockCreationSubscriber) error
+	// SubscribeBlockCommit returns a channel which receives every committed block, and the function to cancel the
+	// subscription. Blocks are dropped if the channel is full, so that a slow subscriber doesn't block committing
+	SubscribeBlockCommit() (<-chan *Block, func())
+}
+
+// blockCommitBufferSize is the number of committed blocks buffered for a slow subscriber of SubscribeBlockCommit
+const blockCommitBufferSize = 16
+
+// maxPrunedBlocksPerCommit is the max number of old blocks pruned when committing a block, so that catching up with
+// the pruning on a long existing chain is spread over the following commits instead of stalling one of them
+const maxPrunedBlocksPerCommit = 100
+
+// trieNodesPerBatch is the number of the state trie nodes checked while the chain is locked during the collection
+const trieNodesPerBatch = 1000
+
+// BlockCreationSubscriber is an interface which will get notified when a block is committed. HandleBlock is called
+// in the order of the block heights while the blockchain is locked, so it must neither block nor call back into the
+// blockchain.
+type BlockCreationSubscriber interface {
+	HandleBlock(*Block) error
 }
 
 // blockchain implements the Blockchain interface
@@ -135,6 +183,15 @@ type blockchain struct {
 	validator Validator
 	lifecycle lifecycle.Lifecycle
 	clk       clock.Clock
+	// keepBlocks is the number of the latest blocks whose full data is kept, or 0 to keep all the blocks
+	keepBlocks uint64
+	// the state trie nodes are collected in the background once the pruned height has moved on since the last time
+	collectedHeight uint64
+	collectTrigger  chan struct{}
+	collectCancel   context.CancelFunc
+	collectDone     chan struct{}
+
+	blocklistener []BlockCreationSubscriber
 
 	// used by account-based model
 	sf state.Factory
@@ -202,6 +259,15 @@ func BoltDBDaoOption() Option {
 	}
 }
 
+// BoltDBDaoOptionWithConfig sets blockchain's dao with BoltDB from config.Chain.ChainDBPath, which is tuned by opts
+func BoltDBDaoOptionWithConfig(opts db.BoltDBOptions) Option {
+	return func(bc *blockchain, cfg *config.Config) error {
+		bc.dao = newBlockDAO(cfg, db.NewBoltDBWithOptions(cfg.Chain.ChainDBPath, &cfg.DB, opts))
+
+		return nil
+	}
+}
+
 // InMemDaoOption sets blockchain's dao with MemKVStore
 func InMemDaoOption() Option {
 	return func(bc *blockchain, cfg *config.Config) error {
@@ -211,6 +277,19 @@ func InMemDaoOption() Option {
 	}
 }
 
+// PruningOption makes blockchain keep the full data of only the latest keepBlocks blocks, and prune the older ones
+// every time a block is committed, up to maxPrunedBlocksPerCommit blocks at a time. 0 means keeping all the blocks.
+// The block data, the receipts and the states on the pruned heights are pruned, and the state trie nodes no longer used
+// are collected in the background every time another keepBlocks blocks are pruned. keepBlocks should cover an epoch,
+// as the delegates of an epoch are elected from the candidates on the height it starts
+func PruningOption(keepBlocks uint64) Option {
+	return func(bc *blockchain, conf *config.Config) error {
+		bc.keepBlocks = keepBlocks
+
+		return nil
+	}
+}
+
 // ClockOption overrides the default clock
 func ClockOption(clk clock.Clock) Option {
 	return func(bc *blockchain, conf *config.Config) error {
@@ -246,7 +325,11 @@ func NewBlockchain(cfg *config.Config, opts ...Option) Blockchain {
 		logger.Error().Err(err).Msg("Failed to get producer's address by public key")
 		return nil
 	}
-	chain.validator = &validator{sf: chain.sf, validatorAddr: address.IotxAddress()}
+	chain.validator = &validator{
+		sf:                  chain.sf,
+		validatorAddr:       address.IotxAddress(),
+		chainBoundSigHeight: cfg.Chain.ChainBoundSignatureHeight,
+	}
 
 	if chain.dao != nil {
 		chain.lifecycle.Add(chain.dao)
@@ -273,14 +356,22 @@ func (bc *blockchain) Start(ctx context.Context) (err error) {
 		return err
 	}
 	if bc.tipHeight == 0 {
-		return bc.startEmptyBlockchain()
+		err = bc.startEmptyBlockchain()
+	} else {
+		// get blockchain tip hash
+		if bc.tipHash, err = bc.dao.getBlockHash(bc.tipHeight); err != nil {
+			return err
+		}
+		recoveryHeight, _ := ctx.Value(RecoveryHeightKey).(uint64)
+		err = bc.startExistingBlockchain(recoveryHeight)
 	}
-	// get blockchain tip hash
-	if bc.tipHash, err = bc.dao.getBlockHash(bc.tipHeight); err != nil {
+	if err != nil {
 		return err
 	}
-	recoveryHeight, _ := ctx.Value(RecoveryHeightKey).(uint64)
-	return bc.startExistingBlockchain(recoveryHeight)
+	if bc.sf != nil {
+		bc.startCollectingTrieNodes()
+	}
+	return nil
 }
 
 func (bc *blockchain) startEmptyBlockchain() error {
@@ -292,10 +383,10 @@ func (bc *blockchain) startEmptyBlockchain() error {
 	if genesis.Header.height != 0 {
 		return errors.New(fmt.Sprintf("genesis block has height %d but expects 0", genesis.Height()))
 	}
-	// add producer into Trie
+	// add initial accounts into Trie
 	if bc.sf != nil {
-		if _, err := bc.sf.LoadOrCreateState(Gen.CreatorAddr(bc.ChainID()), Gen.TotalSupply); err != nil {
-			return errors.Wrap(err, "failed to add Creator into StateFactory")
+		if err := bc.createGenesisStates(genesis); err != nil {
+			return errors.Wrap(err, "failed to add genesis states into StateFactory")
 		}
 	}
 	// run execution and update account trie root hash
@@ -314,6 +405,39 @@ func (bc *blockchain) startEmptyBlockchain() error {
 	return nil
 }
 
+// createGenesisStates creates the states of the initial accounts loaded from the genesis allocation file. If the file
+// isn't configured, the creator's state holding the total supply is created. Allocated candidates should self-nominate
+// in the genesis block to join the candidate pool
+func (bc *blockchain) createGenesisStates(genesis *Block) error {
+	if bc.config.Chain.GenesisAllocPath == "" {
+		_, err := bc.sf.LoadOrCreateState(Gen.CreatorAddr(bc.ChainID()), Gen.TotalSupply)
+		return err
+	}
+	alloc, err := LoadGenesisAlloc(bc.config.Chain.GenesisAllocPath, new(big.Int).SetUint64(Gen.TotalSupply))
+	if err != nil {
+		return err
+	}
+	selfNominators := make(map[string]bool)
+	for _, vote := range genesis.Votes {
+		if vote.Voter() == vote.Votee() {
+			selfNominators[vote.Voter()] = true
+		}
+	}
+	for _, account := range alloc {
+		if account.IsCandidate && !selfNominators[account.Address] {
+			return errors.Errorf("genesis candidate %s doesn't self-nominate in genesis block", account.Address)
+		}
+		state, err := bc.sf.LoadOrCreateState(account.Address, 0)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the genesis state of %s", account.Address)
+		}
+		state.Balance.Set(account.Balance)
+		state.IsCandidate = account.IsCandidate
+		state.VotingWeight.Set(account.VotingWeight)
+	}
+	return nil
+}
+
 func (bc *blockchain) startExistingBlockchain(recoveryHeight uint64) error {
 	// populate state factory
 	if bc.sf == nil {
@@ -326,9 +450,13 @@ func (bc *blockchain) startExistingBlockchain(recoveryHeight uint64) error {
 		}
 		startHeight = factoryHeight + 1
 	}
-	// If restarting factory from fresh db, first create creator's state
+	// If restarting factory from fresh db, first create genesis states
 	if startHeight == 0 {
-		if _, err := bc.sf.LoadOrCreateState(Gen.CreatorAddr(bc.ChainID()), Gen.TotalSupply); err != nil {
+		genesis, err := bc.GetBlockByHeight(0)
+		if err != nil {
+			return errors.Wrap(err, "failed to get genesis block")
+		}
+		if err := bc.createGenesisStates(genesis); err != nil {
 			return err
 		}
 	}
@@ -364,7 +492,14 @@ func (bc *blockchain) startExistingBlockchain(recoveryHeight uint64) error {
 }
 
 // Stop stops the blockchain.
-func (bc *blockchain) Stop(ctx context.Context) error { return bc.lifecycle.OnStop(ctx) }
+func (bc *blockchain) Stop(ctx context.Context) error {
+	if bc.collectCancel != nil {
+		bc.collectCancel()
+		<-bc.collectDone
+		bc.collectCancel = nil
+	}
+	return bc.lifecycle.OnStop(ctx)
+}
 
 // Balance returns balance of address
 func (bc *blockchain) Balance(addr string) (*big.Int, error) {
@@ -415,6 +550,15 @@ func (bc *blockchain) GetBlockByHash(h hash.Hash32B) (*Block, error) {
 	return bc.dao.getBlock(h)
 }
 
+// GetBlockHeaderByHeight returns the header of the block by height
+func (bc *blockchain) GetBlockHeaderByHeight(height uint64) (*BlockHeader, error) {
+	hash, err := bc.GetHashByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return bc.dao.getBlockHeader(hash)
+}
+
 // GetTotalTransfers returns the total number of transfers
 func (bc *blockchain) GetTotalTransfers() (uint64, error) {
 	if !bc.config.Explorer.Enabled {
@@ -439,6 +583,14 @@ func (bc *blockchain) GetTotalExecutions() (uint64, error) {
 	return bc.dao.getTotalExecutions()
 }
 
+// GetActionCounts returns the number of each type of actions in the blocks from genesis up to the height
+func (bc *blockchain) GetActionCounts(height uint64) (*ActionCounts, error) {
+	if !bc.config.Explorer.Enabled {
+		return nil, errors.New("explorer not enabled")
+	}
+	return bc.dao.getActionCounts(height)
+}
+
 // GetTransfersFromAddress returns transfers from address
 func (bc *blockchain) GetTransfersFromAddress(address string) ([]hash.Hash32B, error) {
 	if !bc.config.Explorer.Enabled {
@@ -610,13 +762,15 @@ func (bc *blockchain) ValidateBlock(blk *Block, containCoinbase bool) error {
 
 // MintNewBlock creates a new block with given actions
 // Note: the coinbase transfer will be added to the given transfers
-// when minting a new block
+// when minting a new block. It credits the block reward to Consensus.RewardAddress if it's set,
+// or to the producer otherwise
 func (bc *blockchain) MintNewBlock(tsf []*action.Transfer, vote []*action.Vote, executions []*action.Execution,
 	producer *iotxaddress.Address, data string) (*Block, error) {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
 
-	tsf = append(tsf, action.NewCoinBaseTransfer(big.NewInt(int64(bc.genesis.BlockReward)), producer.RawAddress))
+	tsf, vote, executions = FitActions(&bc.config.Chain, tsf, vote, executions)
+	tsf = append(tsf, action.NewCoinBaseTransfer(big.NewInt(int64(bc.genesis.BlockReward)), bc.rewardAddress(producer)))
 	blk := NewBlock(bc.config.Chain.ID, bc.tipHeight+1, bc.tipHash, bc.now(), tsf, vote, executions)
 	blk.Header.DKGID = []byte{}
 	blk.Header.DKGPubkey = []byte{}
@@ -635,13 +789,15 @@ func (bc *blockchain) MintNewBlock(tsf []*action.Transfer, vote []*action.Vote,
 
 // MintNewDKGBlock creates a new block with given actions and dkg keys
 // Note: the coinbase transfer will be added to the given transfers
-// when minting a new block
+// when minting a new block. It credits the block reward to Consensus.RewardAddress if it's set,
+// or to the producer otherwise
 func (bc *blockchain) MintNewDKGBlock(tsf []*action.Transfer, vote []*action.Vote, executions []*action.Execution,
 	producer *iotxaddress.Address, dkgAddress *iotxaddress.DKGAddress, seed []byte, data string) (*Block, error) {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
 
-	tsf = append(tsf, action.NewCoinBaseTransfer(big.NewInt(int64(bc.genesis.BlockReward)), producer.RawAddress))
+	tsf, vote, executions = FitActions(&bc.config.Chain, tsf, vote, executions)
+	tsf = append(tsf, action.NewCoinBaseTransfer(big.NewInt(int64(bc.genesis.BlockReward)), bc.rewardAddress(producer)))
 	blk := NewBlock(bc.config.Chain.ID, bc.tipHeight+1, bc.tipHash, bc.now(), tsf, vote, executions)
 	blk.Header.DKGID = []byte{}
 	blk.Header.DKGPubkey = []byte{}
@@ -666,6 +822,14 @@ func (bc *blockchain) MintNewDKGBlock(tsf []*action.Transfer, vote []*action.Vot
 	return blk, nil
 }
 
+// rewardAddress returns the recipient of the block reward of the blocks produced by the producer
+func (bc *blockchain) rewardAddress(producer *iotxaddress.Address) string {
+	if bc.config.Consensus.RewardAddress != "" {
+		return bc.config.Consensus.RewardAddress
+	}
+	return producer.RawAddress
+}
+
 // MintNewSecretBlock creates a new block with given DKG secrets and witness
 func (bc *blockchain) MintNewSecretBlock(
 	secretProposals []*action.SecretProposal,
@@ -713,6 +877,69 @@ func (bc *blockchain) CommitBlock(blk *Block) error {
 	return bc.commitBlock(blk)
 }
 
+// PruneBelow discards the full data of the blocks below the height, as well as the states on those heights. The state
+// trie nodes no longer used are collected in the background afterwards
+func (bc *blockchain) PruneBelow(height uint64) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if err := bc.pruneBelow(height); err != nil {
+		return err
+	}
+	bc.triggerCollectingTrieNodes(height)
+	return nil
+}
+
+// Reprocess re-executes the blocks from genesis to toHeight against a fresh in-memory state factory, and returns the
+// resulting state root. The current state is left untouched. A block whose reprocessed state root differs from the one
+// in its header is logged if it's at or above fromHeight, which indicates non-deterministic state transition.
+func (bc *blockchain) Reprocess(fromHeight uint64, toHeight uint64) (hash.Hash32B, error) {
+	if tipHeight := bc.TipHeight(); fromHeight > toHeight || toHeight > tipHeight {
+		return hash.ZeroHash32B, errors.Errorf(
+			"invalid range [%d, %d] to reprocess with tip height %d", fromHeight, toHeight, tipHeight)
+	}
+	sf, err := state.NewFactory(bc.config, state.InMemTrieOption())
+	if err != nil {
+		return hash.ZeroHash32B, errors.Wrap(err, "failed to create state factory")
+	}
+	ctx := context.Background()
+	if err := sf.Start(ctx); err != nil {
+		return hash.ZeroHash32B, errors.Wrap(err, "failed to start state factory")
+	}
+	defer func() {
+		if err := sf.Stop(ctx); err != nil {
+			logger.Error().Err(err).Msg("failed to stop the state factory for reprocessing")
+		}
+	}()
+	// the replayer shares the blocks but runs the actions against the fresh state factory
+	replayer := &blockchain{config: bc.config, genesis: bc.genesis, dao: bc.dao, clk: bc.clk, sf: sf}
+	var root hash.Hash32B
+	for height := uint64(0); height <= toHeight; height++ {
+		blk, err := replayer.GetBlockByHeight(height)
+		if err != nil {
+			return hash.ZeroHash32B, errors.Wrapf(err, "failed to get block %d", height)
+		}
+		if height == 0 {
+			if err := replayer.createGenesisStates(blk); err != nil {
+				return hash.ZeroHash32B, errors.Wrap(err, "failed to create genesis states")
+			}
+		}
+		if root, err = replayer.runActions(blk, false); err != nil {
+			return hash.ZeroHash32B, errors.Wrapf(err, "failed to run the actions of block %d", height)
+		}
+		if err := sf.Commit(); err != nil {
+			return hash.ZeroHash32B, errors.Wrapf(err, "failed to commit the state of block %d", height)
+		}
+		if height >= fromHeight && root != blk.Header.stateRoot {
+			logger.Warn().
+				Uint64("height", height).
+				Hex("reprocessedRoot", root[:]).
+				Hex("blockRoot", blk.Header.stateRoot[:]).
+				Msg("state root mismatches after reprocessing the block")
+		}
+	}
+	return root, nil
+}
+
 // StateByAddr returns the state of an address
 func (bc *blockchain) StateByAddr(address string) (*state.State, error) {
 	if bc.sf != nil {
@@ -736,33 +963,120 @@ func (bc *blockchain) Validator() Validator {
 	return bc.validator
 }
 
+// VerifyActionSignature verifies the signature of an action to be included in the next block
+func (bc *blockchain) VerifyActionSignature(act action.Action) error {
+	return bc.VerifyActionSignatureAtHeight(act, bc.TipHeight()+1)
+}
+
+// VerifyActionSignatureAtHeight verifies the signature of an action to be included in the block of the given height
+func (bc *blockchain) VerifyActionSignatureAtHeight(act action.Action, height uint64) error {
+	return verifyActionSignature(act, bc.ChainID(), height, bc.config.Chain.ChainBoundSignatureHeight)
+}
+
 // ExecuteContractRead runs a read-only smart contract operation, this is done off the network since it does not
 // cause any state change
 func (bc *blockchain) ExecuteContractRead(ex *action.Execution) ([]byte, error) {
+	receipt, err := bc.SimulateExecution(ex)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to run execution in ExecuteContractRead")
+	}
+	return receipt.ReturnValue, nil
+}
+
+// SimulateExecution runs the execution against the current state and discards the state changes afterwards, which
+// returns the receipt of the execution
+func (bc *blockchain) SimulateExecution(ex *action.Execution) (*Receipt, error) {
+	return bc.SimulateExecutionOnPending(ex, nil)
+}
+
+// SimulateExecutionOnPending runs the execution like SimulateExecution, but on top of the given pending actions, e.g.,
+// the ones of the executor queued in the action pool. The pending transfers move the balances, and the pending
+// executions are run in the EVM, in the given order before the execution. All the state changes are discarded
+// afterwards. The chain is locked during the simulation, so that no block is minted or committed between taking the
+// snapshot of the state factory and reverting to it
+func (bc *blockchain) SimulateExecutionOnPending(ex *action.Execution, pending []action.Action) (*Receipt, error) {
+	if bc.sf == nil {
+		return nil, errors.New("statefactory cannot be nil")
+	}
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
 	// use latest block as carrier to run the offline execution
 	// the block itself is not used
-	h := bc.TipHeight()
-	blk, err := bc.GetBlockByHeight(h)
+	blk, err := bc.GetBlockByHeight(bc.tipHeight)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get block in ExecuteContractRead")
+		return nil, errors.Wrap(err, "failed to get block in SimulateExecution")
 	}
 	blk.Executions = nil
-	blk.Executions = []*action.Execution{ex}
-	blk.receipts = nil
-	ExecuteContracts(blk, bc)
+	for _, act := range pending {
+		if execution, ok := act.(*action.Execution); ok {
+			blk.Executions = append(blk.Executions, execution)
+		}
+	}
+	blk.Executions = append(blk.Executions, ex)
+	blk.receipts = make(map[hash.Hash32B]*Receipt)
+	// the state changes made by the pending actions and the execution are reverted, so nothing is committed
+	snapshot := bc.sf.Snapshot()
+	err = bc.runPendingActions(blk, pending)
+	if err == nil {
+		gasLimit := action.GasLimit
+		if receipt, _, _ := executeContract(blk, len(blk.Executions)-1, ex, bc, &gasLimit); receipt != nil {
+			blk.receipts[ex.Hash()] = receipt
+		}
+	}
+	if err := bc.sf.RevertToSnapshot(snapshot); err != nil {
+		return nil, errors.Wrap(err, "failed to revert state changes in SimulateExecution")
+	}
+	if err != nil {
+		return nil, err
+	}
 	// pull the results from receipt
-	exHash := ex.Hash()
-	receipt, ok := blk.receipts[exHash]
+	receipt, ok := blk.receipts[ex.Hash()]
 	if !ok {
-		return nil, errors.Wrap(err, "failed to get receipt in ExecuteContractRead")
+		return nil, errors.New("failed to get receipt in SimulateExecution")
 	}
-	return receipt.ReturnValue, nil
+	return receipt, nil
 }
 
 //======================================
 // private functions
 //=====================================
 
+// runPendingActions applies the pending actions to the cached states in order, whose pending executions are the
+// leading ones of the block. Pending votes only take the nonces of the voters, so they are skipped
+func (bc *blockchain) runPendingActions(blk *Block, pending []action.Action) error {
+	gasLimit := action.GasLimit
+	idx := 0
+	for _, act := range pending {
+		switch act := act.(type) {
+		case *action.Transfer:
+			if act.IsCoinbase() || act.IsContract() {
+				continue
+			}
+			sender, err := bc.sf.LoadOrCreateState(act.Sender(), 0)
+			if err != nil {
+				return errors.Wrapf(err, "failed to load the state of sender %s", act.Sender())
+			}
+			if err := sender.SubBalance(act.Amount()); err != nil {
+				return errors.Wrapf(err, "failed to run pending transfer %x", act.Hash())
+			}
+			recipient, err := bc.sf.LoadOrCreateState(act.Recipient(), 0)
+			if err != nil {
+				return errors.Wrapf(err, "failed to load the state of recipient %s", act.Recipient())
+			}
+			if err := recipient.AddBalance(act.Amount()); err != nil {
+				return errors.Wrapf(err, "failed to run pending transfer %x", act.Hash())
+			}
+		case *action.Execution:
+			if receipt, _, _ := executeContract(blk, idx, act, bc, &gasLimit); receipt != nil {
+				blk.receipts[act.Hash()] = receipt
+			}
+			idx++
+		}
+	}
+	return nil
+}
+
 func (bc *blockchain) validateBlock(blk *Block, containCoinbase bool) error {
 	if bc.validator == nil {
 		logger.Panic().Msg("no block validator")
@@ -776,10 +1090,76 @@ func (bc *blockchain) validateBlock(blk *Block, containCoinbase bool) error {
 	if err := bc.validator.Validate(blk, tipHeight, tipHash, containCoinbase); err != nil {
 		return errors.Wrapf(err, "Failed to validate block on height %d", tipHeight)
 	}
-	// run actions and update state factory
+	// run actions and update state factory, which is rolled back if it fails to apply the block
+	var snapshot int
+	if bc.sf != nil {
+		snapshot = bc.sf.Snapshot()
+	}
 	// TODO: disable validation before resolve the state root doesn't match issue
 	if _, err := bc.runActions(blk, false); err != nil {
-		logger.Panic().Err(err).Msgf("Failed to update state on height %d", tipHeight)
+		if revertErr := bc.sf.RevertToSnapshot(snapshot); revertErr != nil {
+			logger.Panic().Err(revertErr).Msgf("Failed to revert state on height %d", tipHeight)
+		}
+		return errors.Wrapf(err, "Failed to update state on height %d", tipHeight)
+	}
+	return nil
+}
+
+// AddSubscriber adds a new block subscriber
+func (bc *blockchain) AddSubscriber(s BlockCreationSubscriber) error {
+	if s == nil {
+		return errors.New("subscriber could not be nil")
+	}
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.blocklistener = append(bc.blocklistener, s)
+	return nil
+}
+
+// RemoveSubscriber deletes a block subscriber
+func (bc *blockchain) RemoveSubscriber(s BlockCreationSubscriber) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	for i, sub := range bc.blocklistener {
+		if sub == s {
+			bc.blocklistener = append(bc.blocklistener[:i], bc.blocklistener[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("cannot find subscription")
+}
+
+// SubscribeBlockCommit returns a channel which receives every committed block, and the function to cancel the
+// subscription
+func (bc *blockchain) SubscribeBlockCommit() (<-chan *Block, func()) {
+	sub := &blockCommitSubscriber{blocks: make(chan *Block, blockCommitBufferSize)}
+	bc.mu.Lock()
+	bc.blocklistener = append(bc.blocklistener, sub)
+	bc.mu.Unlock()
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			if err := bc.RemoveSubscriber(sub); err != nil {
+				logger.Error().Err(err).Msg("failed to remove the block commit subscriber")
+			}
+			// no more block will be sent once the subscriber is removed
+			close(sub.blocks)
+		})
+	}
+	return sub.blocks, cancel
+}
+
+// blockCommitSubscriber forwards the committed blocks to a subscription channel
+type blockCommitSubscriber struct {
+	blocks chan *Block
+}
+
+// HandleBlock sends the block to the channel, or drops it if the subscriber falls behind
+func (s *blockCommitSubscriber) HandleBlock(blk *Block) error {
+	select {
+	case s.blocks <- blk:
+	default:
+		logger.Warn().Uint64("height", blk.Height()).Msg("block commit subscriber is too slow, drop the block")
 	}
 	return nil
 }
@@ -803,16 +1183,112 @@ func (bc *blockchain) commitBlock(blk *Block) error {
 		}
 	}
 	logger.Info().Uint64("height", blk.Header.height).Msg("commit a block")
+	bc.emitToSubscribers(blk)
+	// the block is committed regardless of whether the old blocks are pruned, which could be retried next time
+	if bc.keepBlocks > 0 && blk.Height() >= bc.keepBlocks {
+		if err := bc.pruneOldBlocks(blk.Height() - bc.keepBlocks + 1); err != nil {
+			logger.Error().Err(err).Uint64("height", blk.Height()).Msg("failed to prune blocks")
+		}
+	}
+	return nil
+}
+
+// pruneOldBlocks prunes the blocks below the height, but no more than maxPrunedBlocksPerCommit blocks, and starts
+// collecting the state trie nodes once another keepBlocks blocks have been pruned
+func (bc *blockchain) pruneOldBlocks(height uint64) error {
+	prunedHeight, err := bc.dao.getPrunedHeight()
+	if err != nil {
+		return err
+	}
+	// the genesis block is never pruned
+	if prunedHeight == 0 {
+		prunedHeight = 1
+	}
+	if height > prunedHeight+maxPrunedBlocksPerCommit {
+		height = prunedHeight + maxPrunedBlocksPerCommit
+	}
+	if err := bc.pruneBelow(height); err != nil {
+		return err
+	}
+	if height >= bc.collectedHeight+bc.keepBlocks {
+		bc.triggerCollectingTrieNodes(height)
+	}
 	return nil
 }
 
+// pruneBelow discards the full data of the blocks, and the states on the heights below the height
+func (bc *blockchain) pruneBelow(height uint64) error {
+	if err := bc.dao.pruneBelow(height); err != nil {
+		return err
+	}
+	if bc.sf == nil {
+		return nil
+	}
+	return bc.sf.PruneBelow(height)
+}
+
+// startCollectingTrieNodes starts collecting the state trie nodes in the background whenever it is triggered
+func (bc *blockchain) startCollectingTrieNodes() {
+	ctx, cancel := context.WithCancel(context.Background())
+	bc.collectTrigger = make(chan struct{}, 1)
+	bc.collectCancel = cancel
+	bc.collectDone = make(chan struct{})
+	go func() {
+		defer close(bc.collectDone)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-bc.collectTrigger:
+			}
+			// the chain is locked only while deleting a batch of nodes, so the blocks keep being committed
+			deleted, err := bc.sf.CollectTrieNodes(ctx, &bc.mu, trieNodesPerBatch)
+			if err != nil {
+				if errors.Cause(err) != context.Canceled {
+					logger.Error().Err(err).Int("deleted", deleted).Msg("failed to collect state trie nodes")
+				}
+				continue
+			}
+			logger.Info().Int("deleted", deleted).Msg("collected state trie nodes")
+		}
+	}()
+}
+
+// triggerCollectingTrieNodes starts collecting the state trie nodes after pruning the heights below the height, unless
+// a collection is pending already, which would collect the nodes of the heights as well
+func (bc *blockchain) triggerCollectingTrieNodes(height uint64) {
+	bc.collectedHeight = height
+	select {
+	case bc.collectTrigger <- struct{}{}:
+	default:
+	}
+}
+
+func (bc *blockchain) emitToSubscribers(blk *Block) {
+	for _, s := range bc.blocklistener {
+		if err := s.HandleBlock(blk); err != nil {
+			logger.Error().Err(err).Uint64("height", blk.Height()).Msg("failed to handle the committed block")
+		}
+	}
+}
+
 func (bc *blockchain) runActions(blk *Block, verify bool) (root hash.Hash32B, err error) {
 	if bc.sf == nil {
 		return root, nil
 	}
-	// run executions
-	if blk.Executions != nil && !bc.sf.HasRun() {
-		ExecuteContracts(blk, bc)
+	if !bc.sf.HasRun() {
+		// run executions
+		fees := big.NewInt(0)
+		if blk.Executions != nil {
+			fees = ExecuteContracts(blk, bc)
+		}
+		// credit the gas fees collected in the block to the recipient of the block reward, whose coinbase transfer
+		// is run together with the other actions
+		if fees.Sign() > 0 {
+			if err = bc.sf.ApplyBlockReward(blk.RewardAddress(), nil, fees); err != nil {
+				return root, errors.Wrapf(err, "failed to apply the block reward of block %d", blk.Height())
+			}
+		}
 	}
 	// update state factory
 	if root, err = bc.sf.RunActions(blk.Height(), blk.Transfers, blk.Votes, blk.Executions); err != nil {
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -580,7 +581,7 @@ func TestBlockchain_MintNewDummyBlock(t *testing.T) {
 	sf, err := state.NewFactory(cfg, state.DefaultTrieOption())
 	require.NoError(err)
 	require.NoError(sf.Start(context.Background()))
-	val := validator{sf, ""}
+	val := validator{sf: sf}
 
 	ctx := context.Background()
 	bc := NewBlockchain(cfg, InMemDaoOption(), InMemStateFactoryOption())
@@ -604,6 +605,205 @@ func TestBlockchain_MintNewDummyBlock(t *testing.T) {
 	)
 }
 
+type heightRecorder struct {
+	heights []uint64
+}
+
+func (r *heightRecorder) HandleBlock(blk *Block) error {
+	r.heights = append(r.heights, blk.Height())
+	return nil
+}
+
+func TestBlockchain_Subscriber(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	ctx := context.Background()
+	bc := NewBlockchain(&cfg, InMemDaoOption(), InMemStateFactoryOption())
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	r := &heightRecorder{}
+	require.Error(bc.AddSubscriber(nil))
+	require.NoError(bc.AddSubscriber(r))
+	for i := 0; i < 2; i++ {
+		blk, err := bc.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk, true))
+		require.NoError(bc.CommitBlock(blk))
+	}
+	require.Equal([]uint64{1, 2}, r.heights)
+
+	// the removed subscriber is not notified any more
+	require.NoError(bc.RemoveSubscriber(r))
+	require.Error(bc.RemoveSubscriber(r))
+	blk, err := bc.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(blk, true))
+	require.NoError(bc.CommitBlock(blk))
+	require.Equal([]uint64{1, 2}, r.heights)
+}
+
+func TestBlockchain_SubscribeBlockCommit(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	ctx := context.Background()
+	bc := NewBlockchain(&cfg, InMemDaoOption(), InMemStateFactoryOption())
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	blocks, cancel := bc.SubscribeBlockCommit()
+	// the blocks beyond the buffer are dropped rather than blocking the commits
+	for i := 0; i < blockCommitBufferSize+1; i++ {
+		blk, err := bc.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk, true))
+		require.NoError(bc.CommitBlock(blk))
+	}
+	for i := 1; i <= blockCommitBufferSize; i++ {
+		blk := <-blocks
+		require.Equal(uint64(i), blk.Height())
+	}
+	select {
+	case blk := <-blocks:
+		require.Fail("unexpected block", "height %d", blk.Height())
+	default:
+	}
+
+	// the channel is closed after the subscription is cancelled
+	cancel()
+	cancel()
+	_, ok := <-blocks
+	require.False(ok)
+	blk, err := bc.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(blk, true))
+	require.NoError(bc.CommitBlock(blk))
+}
+
+func TestBlockchain_Pruning(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	ctx := context.Background()
+	bc := NewBlockchain(&cfg, InMemDaoOption(), InMemStateFactoryOption(), PruningOption(2))
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	for i := 0; i < 4; i++ {
+		blk, err := bc.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk, true))
+		require.NoError(bc.CommitBlock(blk))
+	}
+	// Only the latest 2 blocks and the genesis block are kept in full
+	for height := uint64(0); height <= 4; height++ {
+		blk, err := bc.GetBlockByHeight(height)
+		if height == 1 || height == 2 {
+			require.Equal(ErrBlockPruned, errors.Cause(err))
+		} else {
+			require.NoError(err)
+			require.Equal(height, blk.Height())
+		}
+		header, err := bc.GetBlockHeaderByHeight(height)
+		require.NoError(err)
+		require.Equal(height, header.height)
+	}
+
+	// Blocks could be pruned manually as well
+	require.NoError(bc.PruneBelow(4))
+	_, err := bc.GetBlockByHeight(3)
+	require.Equal(ErrBlockPruned, errors.Cause(err))
+	_, err = bc.GetBlockByHeight(4)
+	require.NoError(err)
+	require.Error(bc.PruneBelow(5))
+
+	// The states on the pruned heights are discarded as well
+	producer := ta.Addrinfo["producer"].RawAddress
+	_, err = bc.GetFactory().StateAtHeight(producer, 3)
+	require.Equal(state.ErrHistoricalStateUnavailable, errors.Cause(err))
+	_, err = bc.GetFactory().StateAtHeight(producer, 4)
+	require.NoError(err)
+}
+
+func TestBlockchain_PruningCatchUp(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	ctx := context.Background()
+	bc := NewBlockchain(&cfg, InMemDaoOption(), InMemStateFactoryOption())
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	commitBlock := func() {
+		blk, err := bc.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk, true))
+		require.NoError(bc.CommitBlock(blk))
+	}
+	numBlocks := uint64(maxPrunedBlocksPerCommit + 5)
+	for i := uint64(0); i < numBlocks; i++ {
+		commitBlock()
+	}
+	// Enabling the pruning on an existing chain prunes the old blocks over the following commits
+	bc.(*blockchain).keepBlocks = 2
+	commitBlock()
+	_, err := bc.GetBlockByHeight(maxPrunedBlocksPerCommit)
+	require.Equal(ErrBlockPruned, errors.Cause(err))
+	_, err = bc.GetBlockByHeight(maxPrunedBlocksPerCommit + 1)
+	require.NoError(err)
+	commitBlock()
+	_, err = bc.GetBlockByHeight(numBlocks)
+	require.Equal(ErrBlockPruned, errors.Cause(err))
+	_, err = bc.GetBlockByHeight(numBlocks + 1)
+	require.NoError(err)
+
+	// The states on the heights kept are intact while the state trie nodes are collected
+	producer := ta.Addrinfo["producer"].RawAddress
+	for i := 0; i < 3; i++ {
+		commitBlock()
+		_, err = bc.GetFactory().StateAtHeight(producer, bc.TipHeight()-1)
+		require.NoError(err)
+	}
+}
+
+func TestBlockchain_Reprocess(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	ctx := context.Background()
+	bc := NewBlockchain(&cfg, InMemDaoOption(), InMemStateFactoryOption())
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(bc))
+	tipHeight := bc.TipHeight()
+
+	root, err := bc.Reprocess(0, tipHeight)
+	require.NoError(err)
+	require.Equal(bc.GetFactory().RootHash(), root)
+	for height := uint64(0); height <= tipHeight; height++ {
+		root, err := bc.Reprocess(height, height)
+		require.NoError(err)
+		header, err := bc.GetBlockHeaderByHeight(height)
+		require.NoError(err)
+		require.Equal(header.stateRoot, root)
+	}
+	// The current state is untouched
+	require.Equal(tipHeight, bc.TipHeight())
+	require.Equal(root, bc.GetFactory().RootHash())
+
+	_, err = bc.Reprocess(0, tipHeight+1)
+	require.Error(err)
+	_, err = bc.Reprocess(2, 1)
+	require.Error(err)
+}
+
 func TestBlockchainInitialCandidate(t *testing.T) {
 	require := require.New(t)
 
@@ -679,6 +879,115 @@ func TestCoinbaseTransfer(t *testing.T) {
 	require.True(b.String() == strconv.Itoa(int(Gen.TotalSupply)+int(Gen.BlockReward)))
 }
 
+func TestCoinbaseTransferToRewardAddress(t *testing.T) {
+	require := require.New(t)
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	cfg := config.Default
+	cfg.Chain.TrieDBPath = testTriePath
+	cfg.Chain.ChainDBPath = testDBPath
+	cfg.Consensus.RewardAddress = ta.Addrinfo["alfa"].RawAddress
+
+	sf, err := state.NewFactory(&cfg, state.DefaultTrieOption())
+	require.Nil(err)
+	require.NoError(sf.Start(context.Background()))
+	_, err = sf.LoadOrCreateState(ta.Addrinfo["producer"].RawAddress, Gen.TotalSupply)
+	require.NoError(err)
+
+	Gen.BlockReward = uint64(10)
+
+	bc := NewBlockchain(&cfg, PrecreatedStateFactoryOption(sf), BoltDBDaoOption())
+	require.NotNil(bc)
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+
+	blk, err := bc.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+	require.NoError(err)
+	require.Equal(1, len(blk.Transfers))
+	require.True(blk.Transfers[0].IsCoinbase())
+	require.Equal(ta.Addrinfo["alfa"].RawAddress, blk.Transfers[0].Recipient())
+	require.NoError(bc.ValidateBlock(blk, true))
+	require.NoError(bc.CommitBlock(blk))
+
+	s, err := bc.StateByAddr(ta.Addrinfo["alfa"].RawAddress)
+	require.NoError(err)
+	require.Equal(big.NewInt(int64(Gen.BlockReward)), s.Balance)
+	s, err = bc.StateByAddr(ta.Addrinfo["producer"].RawAddress)
+	require.NoError(err)
+	require.Equal(new(big.Int).SetUint64(Gen.TotalSupply), s.Balance)
+}
+
+func TestSimulateExecutionWithConcurrentCommits(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	sf, err := state.NewFactory(&cfg, state.InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	producer := ta.Addrinfo["producer"]
+	_, err = sf.LoadOrCreateState(producer.RawAddress, Gen.TotalSupply)
+	require.NoError(err)
+	bc := NewBlockchain(&cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption())
+	require.NotNil(bc)
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+
+	const numBlocks = 10
+	done := make(chan error)
+	go func() {
+		for i := uint64(1); i <= numBlocks; i++ {
+			tsf, err := action.NewTransfer(i, big.NewInt(1), producer.RawAddress, ta.Addrinfo["alfa"].RawAddress,
+				[]byte{}, uint64(100000), big.NewInt(0))
+			if err == nil {
+				err = action.Sign(tsf, producer.PrivateKey)
+			}
+			var blk *Block
+			if err == nil {
+				blk, err = bc.MintNewBlock([]*action.Transfer{tsf}, nil, nil, producer, "")
+			}
+			if err == nil {
+				err = bc.CommitBlock(blk)
+			}
+			if err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	// the simulations don't roll back the blocks committed meanwhile
+	committed := false
+	for !committed {
+		ex, err := action.NewExecution(
+			producer.RawAddress, action.EmptyAddress, 1, big.NewInt(0), uint64(100000), big.NewInt(0), nil)
+		require.NoError(err)
+		_, err = bc.SimulateExecution(ex)
+		require.NoError(err)
+		select {
+		case err := <-done:
+			require.NoError(err)
+			committed = true
+		default:
+		}
+	}
+	require.Equal(uint64(numBlocks), bc.TipHeight())
+	s, err := bc.StateByAddr(ta.Addrinfo["alfa"].RawAddress)
+	require.NoError(err)
+	require.Equal(big.NewInt(numBlocks), s.Balance)
+	nonce, err := bc.Nonce(producer.RawAddress)
+	require.NoError(err)
+	require.Equal(uint64(numBlocks), nonce)
+}
+
 func TestBlockchain_StateByAddr(t *testing.T) {
 	require := require.New(t)
 
@@ -767,7 +1076,7 @@ func TestActions(t *testing.T) {
 	sf.LoadOrCreateState(a.RawAddress, uint64(100000))
 	sf.LoadOrCreateState(c.RawAddress, uint64(100000))
 
-	val := validator{sf, ""}
+	val := validator{sf: sf}
 	tsfs := []*action.Transfer{}
 	votes := []*action.Vote{}
 	for i := 0; i < 5000; i++ {
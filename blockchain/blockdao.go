@@ -9,14 +9,17 @@ package blockchain
 import (
 	"context"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/proto"
 )
 
 const (
@@ -32,6 +35,7 @@ const (
 	blockAddressVoteCountMappingNS      = "address<->votecount"
 	blockAddressExecutionMappingNS      = "address<->execution"
 	blockAddressExecutionCountMappingNS = "address<->executioncount"
+	blockHeaderNS                       = "block-headers"
 )
 
 var (
@@ -52,8 +56,53 @@ var (
 	voteToPrefix        = []byte("vote-to.")
 	executionFromPrefix = []byte("execution-from")
 	executionToPrefix   = []byte("execution-to")
+	prunedHeightKey     = []byte("pruned-height")
+	actionCountsPrefix  = []byte("action-counts.")
 )
 
+// ErrBlockPruned indicates the full data of the block has been pruned and only its header is kept. The block is
+// available on archival nodes.
+var ErrBlockPruned = errors.New("block not available, it has been pruned")
+
+// ActionCounts is the number of each type of actions in the blocks from genesis up to a height
+type ActionCounts struct {
+	Transfers         uint64
+	CoinbaseTransfers uint64
+	Votes             uint64
+	Executions        uint64
+}
+
+// add adds the actions of the block to the counts
+func (c *ActionCounts) add(blk *Block) {
+	c.Transfers += uint64(len(blk.Transfers))
+	for _, transfer := range blk.Transfers {
+		if transfer.IsCoinbase() {
+			c.CoinbaseTransfers++
+		}
+	}
+	c.Votes += uint64(len(blk.Votes))
+	c.Executions += uint64(len(blk.Executions))
+}
+
+func (c *ActionCounts) serialize() []byte {
+	var b []byte
+	for _, count := range []uint64{c.Transfers, c.CoinbaseTransfers, c.Votes, c.Executions} {
+		b = append(b, byteutil.Uint64ToBytes(count)...)
+	}
+	return b
+}
+
+func (c *ActionCounts) deserialize(b []byte) error {
+	if len(b) != 32 {
+		return errors.New("action counts are broken")
+	}
+	c.Transfers = enc.MachineEndian.Uint64(b[0:8])
+	c.CoinbaseTransfers = enc.MachineEndian.Uint64(b[8:16])
+	c.Votes = enc.MachineEndian.Uint64(b[16:24])
+	c.Executions = enc.MachineEndian.Uint64(b[24:32])
+	return nil
+}
+
 var _ lifecycle.StartStopper = (*blockDAO)(nil)
 
 type blockDAO struct {
@@ -80,6 +129,11 @@ func (dao *blockDAO) Start(ctx context.Context) error {
 	if err := dao.kvstore.PutIfNotExists(blockNS, topHeightKey, make([]byte, 8)); err != nil {
 		// ok on none-fresh db
 		if err == db.ErrAlreadyExist {
+			if dao.config.Explorer.Enabled {
+				if err := dao.indexActionCounts(); err != nil {
+					logger.Warn().Err(err).Msg("Failed to index the action counts of the existing blocks")
+				}
+			}
 			return nil
 		}
 
@@ -139,6 +193,10 @@ func (dao *blockDAO) getBlockHeight(hash hash.Hash32B) (uint64, error) {
 func (dao *blockDAO) getBlock(hash hash.Hash32B) (*Block, error) {
 	value, err := dao.kvstore.Get(blockNS, hash[:])
 	if err != nil {
+		// the header is only stored separately after the block is pruned
+		if _, headerErr := dao.kvstore.Get(blockHeaderNS, hash[:]); headerErr == nil {
+			return nil, errors.Wrapf(ErrBlockPruned, "block %x", hash)
+		}
 		return nil, errors.Wrapf(err, "failed to get block %x", hash)
 	}
 	if len(value) == 0 {
@@ -151,6 +209,40 @@ func (dao *blockDAO) getBlock(hash hash.Hash32B) (*Block, error) {
 	return &blk, nil
 }
 
+// getBlockHeader returns the header of a block, which is available even if the block has been pruned
+func (dao *blockDAO) getBlockHeader(hash hash.Hash32B) (*BlockHeader, error) {
+	value, err := dao.kvstore.Get(blockHeaderNS, hash[:])
+	if err != nil {
+		blk, err := dao.getBlock(hash)
+		if err != nil {
+			return nil, err
+		}
+		return blk.Header, nil
+	}
+	pbBlock := iproto.BlockPb{}
+	if err := proto.Unmarshal(value, &pbBlock); err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize the header of block %x", hash)
+	}
+	blk := Block{}
+	blk.ConvertFromBlockHeaderPb(&pbBlock)
+	return blk.Header, nil
+}
+
+// getPrunedHeight returns the height below which the blocks have been pruned. The genesis block is never pruned.
+func (dao *blockDAO) getPrunedHeight() (uint64, error) {
+	value, err := dao.kvstore.Get(blockNS, prunedHeightKey)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "failed to get pruned height")
+	}
+	if len(value) == 0 {
+		return 0, errors.New("pruned height is broken")
+	}
+	return enc.MachineEndian.Uint64(value), nil
+}
+
 func (dao *blockDAO) getBlockHashByTransferHash(h hash.Hash32B) (hash.Hash32B, error) {
 	blkHash := hash.ZeroHash32B
 	key := append(transferPrefix, h[:]...)
@@ -482,6 +574,52 @@ func (dao *blockDAO) getTotalExecutions() (uint64, error) {
 	return enc.MachineEndian.Uint64(value), nil
 }
 
+// getActionCounts returns the number of actions in the blocks from genesis up to the height
+func (dao *blockDAO) getActionCounts(height uint64) (*ActionCounts, error) {
+	value, err := dao.kvstore.Get(blockNS, append(actionCountsPrefix, byteutil.Uint64ToBytes(height)...))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the action counts on height %d", height)
+	}
+	counts := &ActionCounts{}
+	if err := counts.deserialize(value); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the action counts on height %d", height)
+	}
+	return counts, nil
+}
+
+// indexActionCounts puts the action counts of the blocks committed before the counts were kept. It stops at the first
+// block which is unavailable, e.g., pruned
+func (dao *blockDAO) indexActionCounts() error {
+	tipHeight, err := dao.getBlockchainHeight()
+	if err != nil {
+		return err
+	}
+	if _, err := dao.getActionCounts(tipHeight); err == nil {
+		return nil
+	}
+	counts := &ActionCounts{}
+	for h := uint64(0); h <= tipHeight; h++ {
+		if indexed, err := dao.getActionCounts(h); err == nil {
+			counts = indexed
+			continue
+		}
+		hash, err := dao.getBlockHash(h)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the hash of block %d", h)
+		}
+		blk, err := dao.getBlock(hash)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block %d", h)
+		}
+		counts.add(blk)
+		key := append(actionCountsPrefix, byteutil.Uint64ToBytes(h)...)
+		if err := dao.kvstore.Put(blockNS, key, counts.serialize()); err != nil {
+			return errors.Wrapf(err, "failed to put the action counts on height %d", h)
+		}
+	}
+	return nil
+}
+
 // getReceiptByExecutionHash returns the receipt by execution hash
 func (dao *blockDAO) getReceiptByExecutionHash(h hash.Hash32B) (*Receipt, error) {
 	value, err := dao.kvstore.Get(blockExecutionReceiptMappingNS, h[:])
@@ -555,6 +693,19 @@ func (dao *blockDAO) putBlock(blk *Block) error {
 	totalExecutionsBytes := byteutil.Uint64ToBytes(totalExecutions)
 	batch.Put(blockNS, totalExecutionsKey, totalExecutionsBytes, "failed to put total executions")
 
+	// put the action counts up to this block, which are skipped if the previous block isn't indexed
+	counts := &ActionCounts{}
+	if blk.Height() > 0 {
+		if counts, err = dao.getActionCounts(blk.Height() - 1); err != nil {
+			logger.Warn().Err(err).Uint64("height", blk.Height()).Msg("Failed to index the action counts")
+		}
+	}
+	if counts != nil {
+		counts.add(blk)
+		batch.Put(blockNS, append(actionCountsPrefix, height...), counts.serialize(),
+			"failed to put the action counts on height %d", blk.Height())
+	}
+
 	// map Transfer hash to block hash
 	for _, transfer := range blk.Transfers {
 		transferHash := transfer.Hash()
@@ -788,6 +939,54 @@ func (dao *blockDAO) putReceipts(blk *Block) error {
 	return batch.Commit()
 }
 
+// pruneBelow discards the full data of the blocks below the height, i.e., the actions and receipts, while keeping their
+// headers. The tip block and the genesis block are always kept. The indexes of the actions are kept as well, so that
+// looking up a pruned action results in ErrBlockPruned rather than not found.
+func (dao *blockDAO) pruneBelow(height uint64) error {
+	tipHeight, err := dao.getBlockchainHeight()
+	if err != nil {
+		return err
+	}
+	if height > tipHeight {
+		return errors.Errorf("cannot prune blocks below %d, which is higher than tip height %d", height, tipHeight)
+	}
+	prunedHeight, err := dao.getPrunedHeight()
+	if err != nil {
+		return err
+	}
+	if prunedHeight == 0 {
+		prunedHeight = 1
+	}
+	// prune block by block, so that the progress is kept if it's interrupted
+	for h := prunedHeight; h < height; h++ {
+		hash, err := dao.getBlockHash(h)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the hash of block %d", h)
+		}
+		blk, err := dao.getBlock(hash)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block %d", h)
+		}
+		header, err := proto.Marshal(&iproto.BlockPb{Header: blk.ConvertToBlockHeaderPb()})
+		if err != nil {
+			return errors.Wrapf(err, "failed to serialize the header of block %d", h)
+		}
+		batch := dao.kvstore.Batch()
+		batch.Put(blockHeaderNS, hash[:], header, "failed to put the header of block %d", h)
+		batch.Put(blockNS, prunedHeightKey, byteutil.Uint64ToBytes(h+1), "failed to put pruned height")
+		batch.Delete(blockNS, hash[:], "failed to delete block %d", h)
+		for _, execution := range blk.Executions {
+			executionHash := execution.Hash()
+			batch.Delete(blockExecutionReceiptMappingNS, executionHash[:], "failed to delete receipt for execution %x",
+				executionHash)
+		}
+		if err := batch.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // deleteBlock deletes the tip block
 func (dao *blockDAO) deleteTipBlock() error {
 	batch := dao.kvstore.Batch()
@@ -831,6 +1030,9 @@ func (dao *blockDAO) deleteTipBlock() error {
 	}
 
 	// Only delete Tsf/Vote/Execution index if enable explorer
+	// Delete the action counts up to the tip block
+	batch.Delete(blockNS, append(actionCountsPrefix, heightValue...), "failed to delete the action counts")
+
 	// Update total transfer count
 	value, err := dao.kvstore.Get(blockNS, totalTransfersKey)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -504,6 +505,98 @@ func TestBlockDAO(t *testing.T) {
 		require.Equal(uint64(2), execToDeltaCount)
 	}
 
+	testPruneDao := func(kvstore db.KVStore, t *testing.T) {
+		require := require.New(t)
+
+		ctx := context.Background()
+		cfg := config.Default
+		dao := newBlockDAO(&cfg, kvstore)
+		require.NoError(dao.Start(ctx))
+		defer func() {
+			require.NoError(dao.Stop(ctx))
+		}()
+		for _, blk := range blks {
+			require.NoError(dao.putBlock(blk))
+		}
+
+		// The tip block cannot be pruned
+		require.Error(dao.pruneBelow(4))
+		require.NoError(dao.pruneBelow(3))
+		prunedHeight, err := dao.getPrunedHeight()
+		require.NoError(err)
+		require.Equal(uint64(3), prunedHeight)
+		// Pruning below a lower height is a no-op
+		require.NoError(dao.pruneBelow(2))
+
+		for _, blk := range blks[:2] {
+			blkHash := blk.HashBlock()
+			_, err := dao.getBlock(blkHash)
+			require.Equal(ErrBlockPruned, errors.Cause(err))
+			// The header and the indexes are kept
+			header, err := dao.getBlockHeader(blkHash)
+			require.NoError(err)
+			require.Equal(blk.Height(), header.height)
+			require.Equal(blkHash, (&Block{Header: header}).HashBlock())
+			height, err := dao.getBlockHeight(blkHash)
+			require.NoError(err)
+			require.Equal(blk.Height(), height)
+			transferHash := blk.Transfers[0].Hash()
+			transferBlkHash, err := dao.getBlockHashByTransferHash(transferHash)
+			require.NoError(err)
+			require.Equal(blkHash, transferBlkHash)
+		}
+
+		blkHash := blks[2].HashBlock()
+		blk, err := dao.getBlock(blkHash)
+		require.NoError(err)
+		require.Equal(blks[2].Transfers[0].Hash(), blk.Transfers[0].Hash())
+		header, err := dao.getBlockHeader(blkHash)
+		require.NoError(err)
+		require.Equal(blkHash, (&Block{Header: header}).HashBlock())
+	}
+
+	testActionCountsDao := func(kvstore db.KVStore, t *testing.T) {
+		require := require.New(t)
+
+		ctx := context.Background()
+		cfg := config.Default
+		cfg.Explorer.Enabled = true
+		dao := newBlockDAO(&cfg, kvstore)
+		require.NoError(dao.Start(ctx))
+		defer func() {
+			require.NoError(dao.Stop(ctx))
+		}()
+		genesis := NewBlock(0, 0, hash.ZeroHash32B, testutil.TimestampNow(), nil, nil, nil)
+		require.NoError(dao.putBlock(genesis))
+		for _, blk := range blks {
+			require.NoError(dao.putBlock(blk))
+		}
+		for i := uint64(0); i <= 3; i++ {
+			counts, err := dao.getActionCounts(i)
+			require.NoError(err)
+			require.Equal(ActionCounts{Transfers: i, CoinbaseTransfers: i, Votes: i, Executions: i}, *counts)
+		}
+
+		// The counts of the pruned blocks are kept
+		require.NoError(dao.pruneBelow(3))
+		counts, err := dao.getActionCounts(2)
+		require.NoError(err)
+		require.Equal(uint64(2), counts.Votes)
+
+		// The counts of the deleted tip block are deleted as well
+		require.NoError(dao.deleteTipBlock())
+		_, err = dao.getActionCounts(3)
+		require.Error(err)
+		require.NoError(dao.putBlock(blks[2]))
+
+		// The counts missing in the chains indexed before are put when starting
+		require.NoError(kvstore.Delete(blockNS, append(actionCountsPrefix, byteutil.Uint64ToBytes(3)...)))
+		require.NoError(dao.indexActionCounts())
+		counts, err = dao.getActionCounts(3)
+		require.NoError(err)
+		require.Equal(uint64(3), counts.Executions)
+	}
+
 	t.Run("In-memory KV Store for blocks", func(t *testing.T) {
 		testBlockDao(db.NewMemKVStore(), t)
 	})
@@ -535,4 +628,24 @@ func TestBlockDAO(t *testing.T) {
 		defer testutil.CleanupPath(t, path)
 		testDeleteDao(db.NewBoltDB(path, cfg), t)
 	})
+
+	t.Run("In-memory KV Store pruning", func(t *testing.T) {
+		testPruneDao(db.NewMemKVStore(), t)
+	})
+
+	t.Run("Bolt DB pruning", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		testPruneDao(db.NewBoltDB(path, cfg), t)
+	})
+
+	t.Run("In-memory KV Store action counts", func(t *testing.T) {
+		testActionCountsDao(db.NewMemKVStore(), t)
+	})
+
+	t.Run("Bolt DB action counts", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		testActionCountsDao(db.NewBoltDB(path, cfg), t)
+	})
 }
@@ -0,0 +1,169 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/logger"
+	iproto "github.com/iotexproject/iotex-core/proto"
+)
+
+// ErrBlockTooLarge indicates that a block exceeds the configured maximum size or number of actions
+var ErrBlockTooLarge = errors.New("block is too large")
+
+// blockOverheadSize is the room reserved for the block header and the coinbase transfer when fitting the actions into
+// a block of the maximum size. Either of a signed header with the DKG fields and a coinbase transfer is less than 512
+// bytes.
+const blockOverheadSize = 1024
+
+type actionPbConverter interface {
+	ConvertToActionPb() *iproto.ActionPb
+}
+
+// ValidateBlockSize returns ErrBlockTooLarge if the block has more actions or more serialized bytes than configured
+func ValidateBlockSize(blk *Block, cfg *config.Chain) error {
+	if cfg.MaxActionsPerBlock == 0 && cfg.MaxBlockSize == 0 {
+		return nil
+	}
+	blkPb := blk.ConvertToBlockPb()
+	if cfg.MaxActionsPerBlock > 0 && uint64(len(blkPb.Actions)) > cfg.MaxActionsPerBlock {
+		return errors.Wrapf(
+			ErrBlockTooLarge,
+			"block %d has %d actions, more than %d",
+			blk.Height(),
+			len(blkPb.Actions),
+			cfg.MaxActionsPerBlock,
+		)
+	}
+	if size := proto.Size(blkPb); cfg.MaxBlockSize > 0 && uint64(size) > cfg.MaxBlockSize {
+		return errors.Wrapf(
+			ErrBlockTooLarge,
+			"block %d has %d bytes, more than %d",
+			blk.Height(),
+			size,
+			cfg.MaxBlockSize,
+		)
+	}
+	return nil
+}
+
+// FitActions returns the actions which fit in a block of the configured maximum size and number of actions, leaving
+// room for the block header and the coinbase transfer. The actions are taken in the order the actpool picks them, i.e.,
+// preferring the ones with higher gas prices while keeping the actions of each sender in nonce order. Once an action
+// doesn't fit, the subsequent actions of the same sender are left out, so that the nonces of every sender stay
+// continuous. The ones returned of each type are in action.CanonicalOrder.
+func FitActions(
+	cfg *config.Chain,
+	transfers []*action.Transfer,
+	votes []*action.Vote,
+	executions []*action.Execution,
+) ([]*action.Transfer, []*action.Vote, []*action.Execution) {
+	if cfg.MaxActionsPerBlock == 0 && cfg.MaxBlockSize == 0 {
+		return transfers, votes, executions
+	}
+	acts := make([]action.Action, 0, len(transfers)+len(votes)+len(executions))
+	for _, tsf := range transfers {
+		acts = append(acts, tsf)
+	}
+	for _, vote := range votes {
+		acts = append(acts, vote)
+	}
+	for _, execution := range executions {
+		acts = append(acts, execution)
+	}
+
+	maxActs := len(acts)
+	if cfg.MaxActionsPerBlock > 0 && uint64(maxActs) >= cfg.MaxActionsPerBlock {
+		// one is left for the coinbase transfer
+		maxActs = int(cfg.MaxActionsPerBlock) - 1
+	}
+	fitted := make([]action.Action, 0, maxActs)
+	leftOut := make(map[string]bool)
+	var size uint64 = blockOverheadSize
+	for _, act := range pickOrder(acts) {
+		if len(fitted) >= maxActs {
+			break
+		}
+		if leftOut[act.SrcAddr()] {
+			continue
+		}
+		if cfg.MaxBlockSize > 0 {
+			n := proto.Size(act.(actionPbConverter).ConvertToActionPb())
+			// an action is serialized as a length-delimited field of the block
+			actSize := uint64(1 + proto.SizeVarint(uint64(n)) + n)
+			if size+actSize > cfg.MaxBlockSize {
+				leftOut[act.SrcAddr()] = true
+				continue
+			}
+			size += actSize
+		}
+		fitted = append(fitted, act)
+	}
+	if len(fitted) < len(acts) {
+		logger.Warn().
+			Int("picked", len(acts)).
+			Int("fitted", len(fitted)).
+			Uint64("max-block-size", cfg.MaxBlockSize).
+			Uint64("max-actions-per-block", cfg.MaxActionsPerBlock).
+			Msg("leave out the actions exceeding the block limits")
+	}
+
+	transfers, votes, executions = nil, nil, nil
+	for _, act := range action.CanonicalOrder(fitted) {
+		switch act := act.(type) {
+		case *action.Transfer:
+			transfers = append(transfers, act)
+		case *action.Vote:
+			votes = append(votes, act)
+		case *action.Execution:
+			executions = append(executions, act)
+		}
+	}
+	return transfers, votes, executions
+}
+
+// pickOrder returns the actions in the order the actpool picks them, i.e., the next action of the sender offering the
+// highest gas price goes first, and the actions of each sender are in nonce order. The senders offering the same gas
+// price are taken in the order of their addresses, so that the result doesn't depend on the order of acts.
+func pickOrder(acts []action.Action) []action.Action {
+	var senders []string
+	queues := make(map[string][]action.Action)
+	// the senders are collected in the order of their addresses, and the actions of each sender in nonce order
+	for _, act := range action.CanonicalOrder(acts) {
+		if _, ok := queues[act.SrcAddr()]; !ok {
+			senders = append(senders, act.SrcAddr())
+		}
+		queues[act.SrcAddr()] = append(queues[act.SrcAddr()], act)
+	}
+	ordered := make([]action.Action, 0, len(acts))
+	for len(ordered) < len(acts) {
+		var next string
+		var nextPrice *big.Int
+		for _, sender := range senders {
+			queue := queues[sender]
+			if len(queue) == 0 {
+				continue
+			}
+			price := queue[0].GasPrice()
+			if price == nil {
+				price = big.NewInt(0)
+			}
+			if nextPrice == nil || price.Cmp(nextPrice) > 0 {
+				next, nextPrice = sender, price
+			}
+		}
+		ordered = append(ordered, queues[next][0])
+		queues[next] = queues[next][1:]
+	}
+	return ordered
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+func TestFitActions(t *testing.T) {
+	require := require.New(t)
+
+	newTsf := func(sender string, nonce uint64) *action.Transfer {
+		tsf, err := action.NewTransfer(nonce, big.NewInt(10), sender, "io1recipient", []byte{}, uint64(100000),
+			big.NewInt(10))
+		require.NoError(err)
+		return tsf
+	}
+	a1 := newTsf("io1a", 1)
+	a2 := newTsf("io1a", 2)
+	b1 := newTsf("io1b", 1)
+	vote, err := action.NewVote(3, "io1a", "io1a", uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	execution, err := action.NewExecution("io1b", "io1contract", 2, big.NewInt(0), uint64(100000), big.NewInt(10),
+		make([]byte, 2048))
+	require.NoError(err)
+	transfers := []*action.Transfer{b1, a2, a1}
+	votes := []*action.Vote{vote}
+	executions := []*action.Execution{execution}
+
+	// no limit
+	cfg := config.Default.Chain
+	tsfs, vs, exes := FitActions(&cfg, transfers, votes, executions)
+	require.Equal(transfers, tsfs)
+	require.Equal(votes, vs)
+	require.Equal(executions, exes)
+
+	// the canonical order is a1, a2, vote, b1, execution, and one action is left for the coinbase transfer
+	cfg.MaxActionsPerBlock = 4
+	tsfs, vs, exes = FitActions(&cfg, transfers, votes, executions)
+	require.Equal([]*action.Transfer{a1, a2}, tsfs)
+	require.Equal([]*action.Vote{vote}, vs)
+	require.Empty(exes)
+
+	cfg.MaxActionsPerBlock = 1
+	tsfs, vs, exes = FitActions(&cfg, transfers, votes, executions)
+	require.Empty(tsfs)
+	require.Empty(vs)
+	require.Empty(exes)
+
+	// the execution with the large data doesn't fit
+	cfg.MaxActionsPerBlock = 0
+	cfg.MaxBlockSize = 2048
+	tsfs, vs, exes = FitActions(&cfg, transfers, votes, executions)
+	require.Equal([]*action.Transfer{a1, a2, b1}, tsfs)
+	require.Equal([]*action.Vote{vote}, vs)
+	require.Empty(exes)
+
+	cfg.MaxBlockSize = blockOverheadSize
+	tsfs, vs, exes = FitActions(&cfg, transfers, votes, executions)
+	require.Empty(tsfs)
+	require.Empty(vs)
+	require.Empty(exes)
+}
+
+func TestFitActionsByGasPrice(t *testing.T) {
+	require := require.New(t)
+
+	newTsf := func(sender string, nonce uint64, gasPrice int64) *action.Transfer {
+		tsf, err := action.NewTransfer(nonce, big.NewInt(10), sender, "io1recipient", []byte{}, uint64(100000),
+			big.NewInt(gasPrice))
+		require.NoError(err)
+		return tsf
+	}
+	a1 := newTsf("io1a", 1, 10)
+	a2 := newTsf("io1a", 2, 10)
+	z1 := newTsf("io1z", 1, 100)
+	z2 := newTsf("io1z", 2, 1)
+	z3 := newTsf("io1z", 3, 1000)
+
+	// the action with the highest gas price survives even though its sender is the last in the canonical order
+	cfg := config.Default.Chain
+	cfg.MaxActionsPerBlock = 3
+	tsfs, _, _ := FitActions(&cfg, []*action.Transfer{a1, a2, z1}, nil, nil)
+	require.Equal([]*action.Transfer{a1, z1}, tsfs)
+
+	// the actions of a sender are taken in nonce order, so z3 cannot go before z2, which offers the lowest gas price
+	cfg.MaxActionsPerBlock = 4
+	tsfs, _, _ = FitActions(&cfg, []*action.Transfer{z3, z2, a2, z1, a1}, nil, nil)
+	require.Equal([]*action.Transfer{a1, a2, z1}, tsfs)
+
+	// once an action of a sender doesn't fit, its subsequent actions are left out too
+	large, err := action.NewExecution("io1z", "io1contract", 2, big.NewInt(0), uint64(100000), big.NewInt(100),
+		make([]byte, 2048))
+	require.NoError(err)
+	cfg.MaxActionsPerBlock = 0
+	cfg.MaxBlockSize = 2048
+	tsfs, _, exes := FitActions(&cfg, []*action.Transfer{a1, a2, z1, z3}, nil, []*action.Execution{large})
+	require.Equal([]*action.Transfer{a1, a2, z1}, tsfs)
+	require.Empty(exes)
+}
+
+func TestValidateBlockSize(t *testing.T) {
+	require := require.New(t)
+
+	transfers := make([]*action.Transfer, 0, 3)
+	for i := 0; i < 3; i++ {
+		transfers = append(transfers, action.NewCoinBaseTransfer(big.NewInt(10), "io1recipient"))
+	}
+	blk := NewBlock(1, 1, hash.ZeroHash32B, 0, transfers, nil, nil)
+	blkBytes, err := blk.Serialize()
+	require.NoError(err)
+
+	cfg := config.Default.Chain
+	require.NoError(ValidateBlockSize(blk, &cfg))
+
+	cfg.MaxActionsPerBlock = 3
+	require.NoError(ValidateBlockSize(blk, &cfg))
+	cfg.MaxActionsPerBlock = 2
+	err = ValidateBlockSize(blk, &cfg)
+	require.Equal(ErrBlockTooLarge, errors.Cause(err))
+
+	cfg.MaxActionsPerBlock = 0
+	cfg.MaxBlockSize = uint64(len(blkBytes))
+	require.NoError(ValidateBlockSize(blk, &cfg))
+	cfg.MaxBlockSize = uint64(len(blkBytes)) - 1
+	err = ValidateBlockSize(blk, &cfg)
+	require.Equal(ErrBlockTooLarge, errors.Cause(err))
+}
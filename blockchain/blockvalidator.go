@@ -14,12 +14,10 @@ import (
 
 	"github.com/pkg/errors"
 
-	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/blockchain/action"
 	"github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/pkg/hash"
-	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/state"
 )
 
@@ -32,6 +30,8 @@ type Validator interface {
 type validator struct {
 	sf            state.Factory
 	validatorAddr string
+	// chainBoundSigHeight is the height from which the signatures of transfers and votes must be bound to the chain
+	chainBoundSigHeight uint64
 }
 
 var (
@@ -117,17 +117,17 @@ func (v *validator) verifyActions(blk *Block, containCoinbase bool) error {
 
 		go func(tsf *action.Transfer, correctTsf *uint64, correctCoinbase *uint64) {
 			defer wg.Done()
-			// Verify coinbase transfer
+			// Verify coinbase transfer, whose recipient could be any address, e.g., a staking pool, other than the
+			// producer's
 			if tsf.IsCoinbase() {
-				pkHash := keypair.HashPubKey(blk.Header.Pubkey)
-				addr := address.New(blk.Header.chainID, pkHash[:])
-				if addr.IotxAddress() != tsf.Recipient() {
+				if _, err := iotxaddress.GetPubkeyHash(tsf.Recipient()); err != nil {
 					return
 				}
 				atomic.AddUint64(correctCoinbase, uint64(1))
 				return
 			}
-			if err := action.Verify(tsf); err != nil {
+			err := verifyActionSignature(tsf, blk.Header.chainID, blk.Header.height, v.chainBoundSigHeight)
+			if err != nil {
 				return
 			}
 			atomic.AddUint64(correctTsf, uint64(1))
@@ -173,7 +173,8 @@ func (v *validator) verifyActions(blk *Block, containCoinbase bool) error {
 		// Verify signature
 		go func(vote *action.Vote, correctVote *uint64) {
 			defer wg.Done()
-			if err := action.Verify(vote); err != nil {
+			err := verifyActionSignature(vote, blk.Header.chainID, blk.Header.height, v.chainBoundSigHeight)
+			if err != nil {
 				return
 			}
 			atomic.AddUint64(correctVote, uint64(1))
@@ -212,7 +213,8 @@ func (v *validator) verifyActions(blk *Block, containCoinbase bool) error {
 		// Verify signature
 		go func(execution *action.Execution, correctVote *uint64) {
 			defer wg.Done()
-			if err := action.Verify(execution); err != nil {
+			err := verifyActionSignature(execution, blk.Header.chainID, blk.Header.height, v.chainBoundSigHeight)
+			if err != nil {
 				return
 			}
 			atomic.AddUint64(correctVote, uint64(1))
@@ -314,6 +316,13 @@ func (v *validator) verifyActions(blk *Block, containCoinbase bool) error {
 	return nil
 }
 
+// verifyActionSignature verifies the signature of an action in the block at height on the chain. The signatures of
+// transfers and votes must be bound to the chain from chainBoundSigHeight on, unless it is 0.
+func verifyActionSignature(act action.Action, chainID uint32, height uint64, chainBoundSigHeight uint64) error {
+	allowUnbound := chainBoundSigHeight == 0 || height < chainBoundSigHeight
+	return action.VerifyWithChainID(act, chainID, allowUnbound)
+}
+
 func verifyHeightAndHash(blk *Block, tipHeight uint64, tipHash hash.Hash32B) error {
 	if blk == nil {
 		return ErrInvalidBlock
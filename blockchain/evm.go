@@ -136,24 +136,36 @@ func securityDeposit(ps *EVMParams, stateDB vm.StateDB, gasLimit *uint64) error
 	return nil
 }
 
-// ExecuteContracts process the contracts in a block
-func ExecuteContracts(blk *Block, bc Blockchain) {
+// ExecuteContracts process the contracts in a block, and returns the gas fees paid by the executions, which are left
+// for the recipient of the block reward to collect
+func ExecuteContracts(blk *Block, bc Blockchain) *big.Int {
 	gasLimit := action.GasLimit
+	fees := big.NewInt(0)
 	blk.receipts = make(map[hash.Hash32B]*Receipt)
 	for idx, execution := range blk.Executions {
 		// TODO (zhi) log receipt to stateDB
-		if receipt, _ := executeContract(blk, idx, execution, bc, &gasLimit); receipt != nil {
+		receipt, fee, _ := executeContract(blk, idx, execution, bc, &gasLimit)
+		if receipt != nil {
 			blk.receipts[execution.Hash()] = receipt
 		}
+		fees.Add(fees, fee)
 	}
+	return fees
 }
 
-// executeContract processes a transfer which contains a contract
-func executeContract(blk *Block, idx int, execution *action.Execution, bc Blockchain, gasLimit *uint64) (*Receipt, error) {
+// executeContract processes a transfer which contains a contract, and returns the gas fee paid by the executor
+func executeContract(
+	blk *Block,
+	idx int,
+	execution *action.Execution,
+	bc Blockchain,
+	gasLimit *uint64,
+) (*Receipt, *big.Int, error) {
+	fee := big.NewInt(0)
 	stateDB := NewEVMStateDBAdapter(bc, blk.Height(), blk.HashBlock(), uint(idx), execution.Hash())
 	ps, err := NewEVMParams(blk, execution, stateDB)
 	if err != nil {
-		return nil, err
+		return nil, fee, err
 	}
 	retval, depositGas, remainingGas, contractAddress, err := executeInEVM(ps, stateDB, gasLimit)
 	receipt := &Receipt{
@@ -173,12 +185,11 @@ func executeContract(blk *Block, idx int, execution *action.Execution, bc Blockc
 		stateDB.AddBalance(ps.context.Origin, remainingValue)
 	}
 	if depositGas-remainingGas > 0 {
-		gasValue := new(big.Int).Mul(new(big.Int).SetUint64(depositGas-remainingGas), ps.context.GasPrice)
-		stateDB.AddBalance(ps.context.Coinbase, gasValue)
+		fee.Mul(new(big.Int).SetUint64(depositGas-remainingGas), ps.context.GasPrice)
 	}
 	receipt.Logs = stateDB.Logs()
 	logger.Debug().Msgf("Receipt: %+v, %v", receipt, err)
-	return receipt, err
+	return receipt, fee, err
 }
 
 func getChainConfig() *params.ChainConfig {
@@ -212,7 +223,7 @@ func executeInEVM(evmParams *EVMParams, stateDB *EVMStateDBAdapter, gasLimit *ui
 		// create contract
 		var evmContractAddress common.Address
 		ret, evmContractAddress, remainingGas, err = evm.Create(executor, evmParams.data, remainingGas, evmParams.amount)
-		logger.Warn().Hex("contract addrHash", evmContractAddress[:]).Msg("evm.Create")
+		logger.Debug().Hex("contract addrHash", evmContractAddress[:]).Msg("evm.Create")
 		if err != nil {
 			return nil, evmParams.gas, remainingGas, action.EmptyAddress, err
 		}
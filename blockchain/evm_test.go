@@ -246,6 +246,56 @@ func TestRollDice(t *testing.T) {
 	require.Equal(0, balance.Cmp(big.NewInt(12000000+100000000-274950)))
 }
 
+func TestExecutionFeeToRewardAddress(t *testing.T) {
+	require := require.New(t)
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	ctx := context.Background()
+	cfg := config.Default
+	cfg.Chain.TrieDBPath = testTriePath
+	cfg.Chain.ChainDBPath = testDBPath
+	cfg.Consensus.RewardAddress = ta.Addrinfo["alfa"].RawAddress
+	bc := NewBlockchain(&cfg, DefaultStateFactoryOption(), BoltDBDaoOption())
+	require.NoError(bc.Start(ctx))
+	require.NotNil(bc)
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	_, err := bc.CreateState(ta.Addrinfo["producer"].RawAddress, Gen.TotalSupply)
+	require.NoError(err)
+	data, _ := hex.DecodeString("6080604052348015600f57600080fd5b50603580601d6000396000f3006080604052600080fd00")
+	execution, err := action.NewExecution(
+		ta.Addrinfo["producer"].RawAddress, action.EmptyAddress, 1, big.NewInt(0), uint64(100000), big.NewInt(10), data)
+	require.NoError(err)
+	require.NoError(action.Sign(execution, ta.Addrinfo["producer"].PrivateKey))
+	blk, err := bc.MintNewBlock(nil, nil, []*action.Execution{execution}, ta.Addrinfo["producer"], "")
+	require.NoError(err)
+	require.Equal(ta.Addrinfo["alfa"].RawAddress, blk.RewardAddress())
+	require.Nil(bc.CommitBlock(blk))
+
+	// the gas fee paid by the executor is collected by the reward address together with the block reward
+	r, err := bc.GetReceiptByExecutionHash(execution.Hash())
+	require.NoError(err)
+	require.True(r.GasConsumed > 0)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(r.GasConsumed), big.NewInt(10))
+	balance, err := bc.Balance(ta.Addrinfo["producer"].RawAddress)
+	require.NoError(err)
+	require.Equal(new(big.Int).Sub(new(big.Int).SetUint64(Gen.TotalSupply), fee), balance)
+	balance, err = bc.Balance(ta.Addrinfo["alfa"].RawAddress)
+	require.NoError(err)
+	require.Equal(new(big.Int).Add(new(big.Int).SetUint64(Gen.BlockReward), fee), balance)
+
+	// only the minted block reward is accumulated, since the fee is moved from the executor
+	reward, err := bc.GetFactory().AccumulatedBlockReward(blk.Height())
+	require.NoError(err)
+	prevReward, err := bc.GetFactory().AccumulatedBlockReward(blk.Height() - 1)
+	require.NoError(err)
+	require.Equal(new(big.Int).SetUint64(Gen.BlockReward), reward.Sub(reward, prevReward))
+}
+
 func TestERC20(t *testing.T) {
 	require := require.New(t)
 	testutil.CleanupPath(t, testTriePath)
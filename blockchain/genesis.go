@@ -8,9 +8,13 @@ package blockchain
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"math/big"
 
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
 	"gopkg.in/yaml.v2"
 
 	"github.com/iotexproject/iotex-core/address"
@@ -19,6 +23,7 @@ import (
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
 	"github.com/iotexproject/iotex-core/pkg/version"
 )
@@ -66,6 +71,15 @@ type Transfer struct {
 	Signature string `yaml:"signature"`
 }
 
+// GenesisAlloc is an initial account allocated in the genesis allocation file. The balance and voting weight are JSON
+// numbers, which could exceed the range of int64
+type GenesisAlloc struct {
+	Address      string   `json:"address"`
+	Balance      *big.Int `json:"balance"`
+	IsCandidate  bool     `json:"isCandidate"`
+	VotingWeight *big.Int `json:"votingWeight"`
+}
+
 // Gen hardcodes genesis default settings
 var Gen = &Genesis{
 	TotalSupply:         uint64(10000000000),
@@ -78,7 +92,7 @@ var Gen = &Genesis{
 	CreatorPrivKey: "d2df3528ff384d41cc9688c354cd301a09f91d95582eb8034a6eff140e7539cb17b53401",
 }
 
-// NewGenesisBlock creates a new genesis block
+// NewGenesisBlock creates a new genesis block of the chain ID and the genesis timestamp configured in cfg.Chain
 func NewGenesisBlock(cfg *config.Config) *Block {
 	var filePath string
 	if cfg != nil && cfg.Chain.GenesisActionsPath != "" {
@@ -139,12 +153,17 @@ func NewGenesisBlock(cfg *config.Config) *Block {
 		transfers = append(transfers, tsf)
 	}
 
+	// the genesis timestamp falls back to the hardcoded one if not configured
+	timestamp := Gen.Timestamp
+	if cfg.Chain.GenesisTimestamp != 0 {
+		timestamp = uint64(cfg.Chain.GenesisTimestamp)
+	}
 	block := &Block{
 		Header: &BlockHeader{
 			version:       version.ProtocolVersion,
 			chainID:       cfg.Chain.ID,
 			height:        uint64(0),
-			timestamp:     Gen.Timestamp,
+			timestamp:     timestamp,
 			prevBlockHash: Gen.ParentHash,
 			txRoot:        hash.ZeroHash32B,
 			stateRoot:     hash.ZeroHash32B,
@@ -157,3 +176,73 @@ func NewGenesisBlock(cfg *config.Config) *Block {
 	block.Header.txRoot = block.TxRoot()
 	return block
 }
+
+// GenesisHash returns the hash of the genesis block together with the initial accounts and the config which every node
+// of a chain must agree on, i.e., the consensus scheme, the delegate rotation and the signature rules. Nodes with
+// different genesis hashes would never agree on a block, so they must not be peers.
+func GenesisHash(cfg *config.Config) (hash.Hash32B, error) {
+	blkHash := NewGenesisBlock(cfg).HashBlock()
+	stream := blkHash[:]
+	if cfg.Chain.GenesisAllocPath != "" {
+		alloc, err := LoadGenesisAlloc(cfg.Chain.GenesisAllocPath, new(big.Int).SetUint64(Gen.TotalSupply))
+		if err != nil {
+			return hash.ZeroHash32B, errors.Wrap(err, "failed to load the genesis allocation")
+		}
+		for _, a := range alloc {
+			stream = append(stream, fmt.Sprintf("%s:%s:%t:%s;", a.Address, a.Balance, a.IsCandidate, a.VotingWeight)...)
+		}
+	}
+	stream = append(stream, byteutil.Uint64ToBytes(Gen.TotalSupply)...)
+	stream = append(stream, byteutil.Uint64ToBytes(Gen.BlockReward)...)
+	stream = append(stream, byteutil.Uint32ToBytes(cfg.Chain.ID)...)
+	stream = append(stream, byteutil.Uint64ToBytes(uint64(cfg.Chain.NumCandidates))...)
+	stream = append(stream, byteutil.Uint64ToBytes(cfg.Chain.ChainBoundSignatureHeight)...)
+	stream = append(stream, []byte(cfg.Consensus.Scheme)...)
+	stream = append(stream, byteutil.Uint64ToBytes(uint64(cfg.Consensus.RollDPoS.NumDelegates))...)
+	stream = append(stream, byteutil.Uint64ToBytes(uint64(cfg.Consensus.RollDPoS.NumSubEpochs))...)
+	if cfg.Consensus.RollDPoS.TimeBasedRotation {
+		stream = append(stream, 1)
+	} else {
+		stream = append(stream, 0)
+	}
+	return blake2b.Sum256(stream), nil
+}
+
+// LoadGenesisAlloc loads the initial accounts from the JSON genesis allocation file. It returns an error if an address
+// is invalid or allocated more than once, or if the total balance doesn't match the total supply
+func LoadGenesisAlloc(path string, totalSupply *big.Int) ([]*GenesisAlloc, error) {
+	allocBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read genesis allocation file %s", path)
+	}
+	var alloc []*GenesisAlloc
+	if err := json.Unmarshal(allocBytes, &alloc); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal genesis allocation file %s", path)
+	}
+	allocated := make(map[hash.PKHash]bool, len(alloc))
+	total := big.NewInt(0)
+	for _, account := range alloc {
+		addr, err := address.IotxAddressToAddress(account.Address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid address %s in genesis allocation", account.Address)
+		}
+		if allocated[addr.PublicKeyHash()] {
+			return nil, errors.Errorf("address %s is allocated more than once in genesis allocation", account.Address)
+		}
+		allocated[addr.PublicKeyHash()] = true
+		if account.Balance == nil {
+			account.Balance = big.NewInt(0)
+		}
+		if account.VotingWeight == nil {
+			account.VotingWeight = big.NewInt(0)
+		}
+		if account.Balance.Sign() < 0 || account.VotingWeight.Sign() < 0 {
+			return nil, errors.Errorf("negative balance or voting weight of %s in genesis allocation", account.Address)
+		}
+		total.Add(total, account.Balance)
+	}
+	if total.Cmp(totalSupply) != 0 {
+		return nil, errors.Errorf("total genesis allocation %s doesn't match total supply %s", total, totalSupply)
+	}
+	return alloc, nil
+}
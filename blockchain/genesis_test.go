@@ -7,12 +7,21 @@
 package blockchain
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
 )
 
 func TestGenesis(t *testing.T) {
@@ -38,3 +47,159 @@ func TestGenesis(t *testing.T) {
 	assert.Equal(uint64(1524676419), genesisBlk.Header.timestamp)
 	assert.Equal(expectedParentHash, genesisBlk.Header.prevBlockHash)
 }
+
+func TestGenesisChainIDAndTimestamp(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Chain.ID = 2
+	cfg.Chain.GenesisTimestamp = 1540000000
+	genesisBlk := NewGenesisBlock(&cfg)
+	require.Equal(uint32(2), genesisBlk.Header.chainID)
+	require.Equal(uint64(1540000000), genesisBlk.Header.timestamp)
+	// the genesis block is deterministic
+	require.Equal(genesisBlk.HashBlock(), NewGenesisBlock(&cfg).HashBlock())
+
+	defaultCfg := config.Default
+	require.NotEqual(NewGenesisBlock(&defaultCfg).HashBlock(), genesisBlk.HashBlock())
+}
+
+func TestGenesisHash(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	h, err := GenesisHash(&cfg)
+	require.NoError(err)
+	require.NotEqual(hash.ZeroHash32B, h)
+	// the genesis hash is deterministic
+	h2, err := GenesisHash(&cfg)
+	require.NoError(err)
+	require.Equal(h, h2)
+
+	// a different genesis block results in a different hash
+	cfg1 := config.Default
+	cfg1.Chain.GenesisTimestamp = 1540000000
+	h1, err := GenesisHash(&cfg1)
+	require.NoError(err)
+	require.NotEqual(h, h1)
+
+	// so does a different consensus config
+	cfg2 := config.Default
+	cfg2.Consensus.RollDPoS.NumDelegates++
+	h2, err = GenesisHash(&cfg2)
+	require.NoError(err)
+	require.NotEqual(h, h2)
+
+	// but not the config which doesn't matter to consensus
+	cfg3 := config.Default
+	cfg3.Network.Port++
+	h3, err := GenesisHash(&cfg3)
+	require.NoError(err)
+	require.Equal(h, h3)
+
+	cfg4 := config.Default
+	cfg4.Chain.GenesisAllocPath = "not-exist.json"
+	_, err = GenesisHash(&cfg4)
+	require.Error(err)
+}
+
+func TestLoadGenesisAlloc(t *testing.T) {
+	require := require.New(t)
+
+	allocPath := filepath.Join(os.TempDir(), "genesis_alloc.json")
+	defer os.Remove(allocPath)
+	a := ta.Addrinfo["alfa"].RawAddress
+	b := ta.Addrinfo["bravo"].RawAddress
+	totalSupply := big.NewInt(100)
+
+	tests := []struct {
+		alloc string
+		valid bool
+	}{
+		{
+			fmt.Sprintf(
+				`[{"address":"%s","balance":60,"isCandidate":true,"votingWeight":10},{"address":"%s","balance":40}]`,
+				a,
+				b,
+			),
+			true,
+		},
+		{fmt.Sprintf(`[{"address":"%s","balance":60},{"address":"%s","balance":40}]`, a, a), false},
+		{fmt.Sprintf(`[{"address":"%s","balance":60},{"address":"%s","balance":30}]`, a, b), false},
+		{fmt.Sprintf(`[{"address":"%s","balance":110},{"address":"%s","balance":-10}]`, a, b), false},
+		{`[{"address":"invalid","balance":100}]`, false},
+		{`{"address":"invalid"}`, false},
+	}
+	for _, test := range tests {
+		require.NoError(ioutil.WriteFile(allocPath, []byte(test.alloc), 0666))
+		alloc, err := LoadGenesisAlloc(allocPath, totalSupply)
+		if !test.valid {
+			require.Error(err, test.alloc)
+			continue
+		}
+		require.NoError(err)
+		require.Equal(2, len(alloc))
+		require.Equal(a, alloc[0].Address)
+		require.Equal(big.NewInt(60), alloc[0].Balance)
+		require.True(alloc[0].IsCandidate)
+		require.Equal(big.NewInt(10), alloc[0].VotingWeight)
+		require.Equal(b, alloc[1].Address)
+		require.Equal(big.NewInt(40), alloc[1].Balance)
+		require.False(alloc[1].IsCandidate)
+		require.Equal(big.NewInt(0), alloc[1].VotingWeight)
+	}
+
+	_, err := LoadGenesisAlloc(filepath.Join(os.TempDir(), "nonexistent_genesis_alloc.json"), totalSupply)
+	require.Error(err)
+}
+
+func TestGenesisAlloc(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	allocPath := filepath.Join(os.TempDir(), "genesis_alloc.json")
+	defer os.Remove(allocPath)
+	cfg := config.Default
+	cfg.Chain.GenesisAllocPath = allocPath
+	creator := Gen.CreatorAddr(cfg.Chain.ID)
+	candidate := NewGenesisBlock(&cfg).Votes[0].Voter()
+	a := ta.Addrinfo["alfa"].RawAddress
+	alloc := fmt.Sprintf(
+		`[{"address":"%s","balance":%d},{"address":"%s","balance":100},`+
+			`{"address":"%s","isCandidate":true,"votingWeight":50}]`,
+		creator,
+		Gen.TotalSupply-100,
+		a,
+		candidate,
+	)
+	require.NoError(ioutil.WriteFile(allocPath, []byte(alloc), 0666))
+
+	sf, err := state.NewFactory(&cfg, state.InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	bc := NewBlockchain(&cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption())
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	balance, err := sf.Balance(a)
+	require.NoError(err)
+	require.Equal(big.NewInt(100), balance)
+	s, err := sf.State(candidate)
+	require.NoError(err)
+	require.True(s.IsCandidate)
+	require.Equal(big.NewInt(50), s.VotingWeight)
+
+	// the candidate not self-nominating in the genesis block is rejected
+	alloc = fmt.Sprintf(
+		`[{"address":"%s","balance":%d},{"address":"%s","balance":100,"isCandidate":true}]`,
+		creator,
+		Gen.TotalSupply-100,
+		a,
+	)
+	require.NoError(ioutil.WriteFile(allocPath, []byte(alloc), 0666))
+	sf, err = state.NewFactory(&cfg, state.InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	require.Error(NewBlockchain(&cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption()).Start(ctx))
+}
@@ -8,6 +8,9 @@ package blocksync
 
 import (
 	"context"
+	"encoding/hex"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -17,7 +20,9 @@ import (
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
 	"github.com/iotexproject/iotex-core/network/node"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	pb "github.com/iotexproject/iotex-core/proto"
 )
 
@@ -29,6 +34,29 @@ type BlockSync interface {
 	ProcessSyncRequest(sender string, sync *pb.BlockSync) error
 	ProcessBlock(blk *blockchain.Block) error
 	ProcessBlockSync(blk *blockchain.Block) error
+	SyncStatus() SyncStatus
+	SetCheckpoints(checkpoints []Checkpoint)
+}
+
+// ErrCheckpointMismatch indicates that a block doesn't match the checkpoint at the same height
+var ErrCheckpointMismatch = errors.New("block mismatches the checkpoint")
+
+// Checkpoint is a trusted block. The block received at the checkpoint height must have the checkpoint hash, so that
+// the node won't follow a chain forked before the checkpoint.
+type Checkpoint struct {
+	Height uint64
+	Hash   hash.Hash32B
+}
+
+// SyncStatus reports the progress of syncing blocks from the peers
+type SyncStatus struct {
+	CurrentHeight uint64
+	// TargetHeight is the highest block height observed from the peers
+	TargetHeight uint64
+	// BlocksPerSecond is the average number of blocks committed per second since the block syncer started
+	BlocksPerSecond float64
+	// Caught indicates that the current height has caught up with the target height
+	Caught bool
 }
 
 // blockSyncer implements BlockSync interface
@@ -40,6 +68,12 @@ type blockSyncer struct {
 	worker         *syncWorker
 	bc             blockchain.Blockchain
 	p2p            network.Overlay
+	chainCfg       config.Chain
+	// mu guards startTime, startHeight and checkpoints
+	mu          sync.RWMutex
+	startTime   time.Time
+	startHeight uint64
+	checkpoints map[uint64]hash.Hash32B
 }
 
 // NewBlockSyncer returns a new block syncer instance
@@ -60,7 +94,7 @@ func NewBlockSyncer(
 		size:   cfg.BlockSync.BufferSize,
 	}
 	w := newSyncWorker(chain.ChainID(), cfg, p2p, buf)
-	return &blockSyncer{
+	bs := &blockSyncer{
 		ackBlockCommit: cfg.IsDelegate() || cfg.IsFullnode(),
 		ackBlockSync:   cfg.IsDelegate() || cfg.IsFullnode(),
 		ackSyncReq:     cfg.IsDelegate() || cfg.IsFullnode(),
@@ -68,7 +102,18 @@ func NewBlockSyncer(
 		buf:            buf,
 		p2p:            p2p,
 		worker:         w,
-	}, nil
+		chainCfg:       cfg.Chain,
+	}
+	checkpoints := make([]Checkpoint, 0, len(cfg.BlockSync.Checkpoints))
+	for _, cp := range cfg.BlockSync.Checkpoints {
+		h, err := hex.DecodeString(cp.Hash)
+		if err != nil || len(h) != len(hash.ZeroHash32B) {
+			return nil, errors.Errorf("cannot create BlockSync: invalid checkpoint hash %s at height %d", cp.Hash, cp.Height)
+		}
+		checkpoints = append(checkpoints, Checkpoint{Height: cp.Height, Hash: byteutil.BytesTo32B(h)})
+	}
+	bs.SetCheckpoints(checkpoints)
+	return bs, nil
 }
 
 // P2P returns the network overlay object
@@ -85,6 +130,10 @@ func (bs *blockSyncer) Start(ctx context.Context) error {
 	}
 	bs.buf.startHeight = startHeight
 	bs.buf.confirmedHeight = startHeight - 1
+	bs.mu.Lock()
+	bs.startTime = time.Now()
+	bs.startHeight = bs.bc.TipHeight()
+	bs.mu.Unlock()
 	return bs.worker.Start(ctx)
 }
 
@@ -94,12 +143,48 @@ func (bs *blockSyncer) Stop(ctx context.Context) error {
 	return bs.worker.Start(ctx)
 }
 
+// SetCheckpoints replaces the checkpoints which the incoming blocks are checked against
+func (bs *blockSyncer) SetCheckpoints(checkpoints []Checkpoint) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.checkpoints = make(map[uint64]hash.Hash32B, len(checkpoints))
+	for _, cp := range checkpoints {
+		bs.checkpoints[cp.Height] = cp.Hash
+	}
+}
+
+// checkCheckpoint returns error if the block is at a checkpoint height but has a different hash
+func (bs *blockSyncer) checkCheckpoint(blk *blockchain.Block) error {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+	expected, ok := bs.checkpoints[blk.Height()]
+	if !ok {
+		return nil
+	}
+	if actual := blk.HashBlock(); actual != expected {
+		return errors.Wrapf(
+			ErrCheckpointMismatch,
+			"block %x at height %d, checkpoint %x",
+			actual,
+			blk.Height(),
+			expected,
+		)
+	}
+	return nil
+}
+
 // ProcessBlock processes an incoming latest committed block
 func (bs *blockSyncer) ProcessBlock(blk *blockchain.Block) error {
 	if !bs.ackBlockCommit {
 		// node is not meant to handle latest committed block, simply exit
 		return nil
 	}
+	if err := bs.checkCheckpoint(blk); err != nil {
+		return err
+	}
+	if err := blockchain.ValidateBlockSize(blk, &bs.chainCfg); err != nil {
+		return err
+	}
 
 	var needSync bool
 	moved, re := bs.buf.Flush(blk)
@@ -125,10 +210,37 @@ func (bs *blockSyncer) ProcessBlockSync(blk *blockchain.Block) error {
 		// node is not meant to handle sync block, simply exit
 		return nil
 	}
+	if err := bs.checkCheckpoint(blk); err != nil {
+		return err
+	}
+	if err := blockchain.ValidateBlockSize(blk, &bs.chainCfg); err != nil {
+		return err
+	}
 	bs.buf.Flush(blk)
 	return nil
 }
 
+// SyncStatus returns the current sync progress
+func (bs *blockSyncer) SyncStatus() SyncStatus {
+	current := bs.bc.TipHeight()
+	target := bs.worker.TargetHeight()
+	if target < current {
+		target = current
+	}
+	status := SyncStatus{
+		CurrentHeight: current,
+		TargetHeight:  target,
+		Caught:        current >= target,
+	}
+
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+	if elapsed := time.Since(bs.startTime).Seconds(); !bs.startTime.IsZero() && elapsed > 0 && current > bs.startHeight {
+		status.BlocksPerSecond = float64(current-bs.startHeight) / elapsed
+	}
+	return status
+}
+
 // ProcessSyncRequest processes a block sync request
 func (bs *blockSyncer) ProcessSyncRequest(sender string, sync *pb.BlockSync) error {
 	if !bs.ackSyncReq {
@@ -138,6 +250,9 @@ func (bs *blockSyncer) ProcessSyncRequest(sender string, sync *pb.BlockSync) err
 
 	for i := sync.Start; i <= sync.End; i++ {
 		blk, err := bs.bc.GetBlockByHeight(i)
+		if errors.Cause(err) == blockchain.ErrBlockPruned {
+			return errors.Wrapf(err, "blocks from height %d are not available on this node, sync from an archival node", i)
+		}
 		if err != nil {
 			return err
 		}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -22,7 +23,6 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	pb "github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
-	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -128,30 +128,6 @@ func TestNewBlockSyncer(t *testing.T) {
 	assert.Equal(p2p, bs.P2P())
 }
 
-func TestBlockSyncerStart(t *testing.T) {
-	assert := assert.New(t)
-
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	mBs := mock_blocksync.NewMockBlockSync(ctrl)
-	mBs.EXPECT().Start(gomock.Any()).Times(1)
-	assert.Nil(mBs.Start(ctx))
-}
-
-func TestBlockSyncerStop(t *testing.T) {
-	assert := assert.New(t)
-
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	mBs := mock_blocksync.NewMockBlockSync(ctrl)
-	mBs.EXPECT().Stop(gomock.Any()).Times(1)
-	assert.Nil(mBs.Stop(ctx))
-}
-
 func TestBlockSyncerProcessSyncRequest(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -265,6 +241,137 @@ func TestBlockSyncerProcessBlockTipHeight(t *testing.T) {
 	assert.Equal(t, h3, h4)
 }
 
+func TestBlockSyncerCheckpoints(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.Nil(err)
+	testutil.CleanupPath(t, cfg.Chain.ChainDBPath)
+	testutil.CleanupPath(t, cfg.Chain.TrieDBPath)
+
+	chain := bc.NewBlockchain(cfg, bc.InMemStateFactoryOption(), bc.InMemDaoOption())
+	require.NoError(chain.Start(ctx))
+	require.NotNil(chain)
+	ap, err := actpool.NewActPool(chain, cfg.ActPool)
+	require.NotNil(ap)
+	require.NoError(err)
+
+	defer func() {
+		require.Nil(chain.Stop(ctx))
+		testutil.CleanupPath(t, cfg.Chain.ChainDBPath)
+		testutil.CleanupPath(t, cfg.Chain.TrieDBPath)
+	}()
+
+	h := chain.TipHeight()
+	cfgWithBadCheckpoint := *cfg
+	cfgWithBadCheckpoint.BlockSync.Checkpoints = []config.Checkpoint{{Height: h + 1, Hash: "abcd"}}
+	_, err = NewBlockSyncer(&cfgWithBadCheckpoint, chain, ap, network.NewOverlay(&cfg.Network))
+	require.Error(err)
+
+	bs, err := NewBlockSyncer(cfg, chain, ap, network.NewOverlay(&cfg.Network))
+	require.Nil(err)
+	require.Nil(bs.Start(ctx))
+	defer func() {
+		require.Nil(bs.Stop(ctx))
+	}()
+
+	blk, err := chain.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+	require.NoError(err)
+	bs.SetCheckpoints([]Checkpoint{{Height: h + 1, Hash: hash.ZeroHash32B}})
+	err = bs.ProcessBlockSync(blk)
+	require.Equal(ErrCheckpointMismatch, errors.Cause(err))
+	require.Equal(h, chain.TipHeight())
+
+	bs.SetCheckpoints([]Checkpoint{{Height: h + 1, Hash: blk.HashBlock()}})
+	require.Nil(bs.ProcessBlockSync(blk))
+	require.Equal(h+1, chain.TipHeight())
+}
+
+func TestBlockSyncerBlockTooLarge(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.Nil(err)
+	testutil.CleanupPath(t, cfg.Chain.ChainDBPath)
+	testutil.CleanupPath(t, cfg.Chain.TrieDBPath)
+
+	chain := bc.NewBlockchain(cfg, bc.InMemStateFactoryOption(), bc.InMemDaoOption())
+	require.NoError(chain.Start(ctx))
+	require.NotNil(chain)
+	ap, err := actpool.NewActPool(chain, cfg.ActPool)
+	require.NotNil(ap)
+	require.NoError(err)
+
+	defer func() {
+		require.Nil(chain.Stop(ctx))
+		testutil.CleanupPath(t, cfg.Chain.ChainDBPath)
+		testutil.CleanupPath(t, cfg.Chain.TrieDBPath)
+	}()
+
+	h := chain.TipHeight()
+	blk, err := chain.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+	require.NoError(err)
+
+	// the block with only the coinbase transfer is still larger than 100 bytes
+	cfgWithLimit := *cfg
+	cfgWithLimit.Chain.MaxBlockSize = 100
+	bs, err := NewBlockSyncer(&cfgWithLimit, chain, ap, network.NewOverlay(&cfg.Network))
+	require.Nil(err)
+	err = bs.ProcessBlock(blk)
+	require.Equal(bc.ErrBlockTooLarge, errors.Cause(err))
+	err = bs.ProcessBlockSync(blk)
+	require.Equal(bc.ErrBlockTooLarge, errors.Cause(err))
+	require.Equal(h, chain.TipHeight())
+
+	cfgWithLimit.Chain.MaxBlockSize = 0
+	cfgWithLimit.Chain.MaxActionsPerBlock = 1
+	bs, err = NewBlockSyncer(&cfgWithLimit, chain, ap, network.NewOverlay(&cfg.Network))
+	require.Nil(err)
+	require.Nil(bs.Start(ctx))
+	defer func() {
+		require.Nil(bs.Stop(ctx))
+	}()
+	require.Nil(bs.ProcessBlockSync(blk))
+	require.Equal(h+1, chain.TipHeight())
+}
+
+func TestBlockSyncerSyncStatus(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.Nil(err)
+	testutil.CleanupPath(t, cfg.Chain.ChainDBPath)
+	testutil.CleanupPath(t, cfg.Chain.TrieDBPath)
+
+	chain := bc.NewBlockchain(cfg, bc.InMemStateFactoryOption(), bc.InMemDaoOption())
+	require.NoError(chain.Start(ctx))
+	require.NotNil(chain)
+	ap, err := actpool.NewActPool(chain, cfg.ActPool)
+	require.NotNil(ap)
+	require.NoError(err)
+	bs, err := NewBlockSyncer(cfg, chain, ap, network.NewOverlay(&cfg.Network))
+	require.Nil(err)
+
+	defer func() {
+		require.Nil(chain.Stop(ctx))
+		testutil.CleanupPath(t, cfg.Chain.ChainDBPath)
+		testutil.CleanupPath(t, cfg.Chain.TrieDBPath)
+	}()
+
+	h := chain.TipHeight()
+	status := bs.SyncStatus()
+	require.Equal(h, status.CurrentHeight)
+	require.Equal(h, status.TargetHeight)
+	require.True(status.Caught)
+
+	// a higher block observed from the peers raises the target height
+	bs.(*blockSyncer).worker.SetTargetHeight(h + 10)
+	status = bs.SyncStatus()
+	require.Equal(h, status.CurrentHeight)
+	require.Equal(h+10, status.TargetHeight)
+	require.False(status.Caught)
+}
+
 func TestBlockSyncerProcessBlockOutOfOrder(t *testing.T) {
 	require := require.New(t)
 	ctx := context.Background()
@@ -31,6 +31,8 @@ type syncWorker struct {
 	rrIdx        int
 	buf          *blockBuffer
 	task         *routine.RecurringTask
+	chunkSize    uint64
+	concurrency  uint
 }
 
 func newSyncWorker(chainID uint32, cfg *config.Config, p2p network.Overlay, buf *blockBuffer) *syncWorker {
@@ -40,6 +42,8 @@ func newSyncWorker(chainID uint32, cfg *config.Config, p2p network.Overlay, buf
 		buf:          buf,
 		targetHeight: 0,
 		rrIdx:        0,
+		chunkSize:    cfg.BlockSync.ChunkSize,
+		concurrency:  cfg.BlockSync.Concurrency,
 	}
 	if interval := syncTaskInterval(cfg); interval != 0 {
 		w.task = routine.NewRecurringTask(w.Sync, cfg.BlockSync.Interval)
@@ -69,26 +73,78 @@ func (w *syncWorker) SetTargetHeight(h uint64) {
 	}
 }
 
-// Sync checks the sliding window and send more sync request if needed
+// TargetHeight returns the highest block height observed from the peers
+func (w *syncWorker) TargetHeight() uint64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.targetHeight
+}
+
+// Sync checks the sliding window and send more sync request if needed. The missing blocks are split into chunks,
+// which are requested from the peers in round robin by a bounded number of concurrent requests. The blocks arriving
+// out of order are kept in the buffer until their predecessors are committed.
 func (w *syncWorker) Sync() {
 	w.mu.Lock()
-	defer w.mu.Unlock()
-
 	peers := w.p2p.GetPeers()
 	if len(peers) == 0 {
+		w.mu.Unlock()
 		logger.Info().Msg("No peer exist to sync with.")
 		return
 	}
-	intervals := w.buf.GetBlocksIntervalsToSync(w.targetHeight)
+	intervals := splitIntervals(w.buf.GetBlocksIntervalsToSync(w.targetHeight), w.chunkSize)
 	logger.Info().Interface("intervals", intervals).Uint64("targetHeight", w.targetHeight).Msg("block sync intervals.")
-	for _, interval := range intervals {
+	reqs := make([]syncRequest, len(intervals))
+	for i, interval := range intervals {
 		w.rrIdx = w.rrIdx % len(peers)
-		p := peers[w.rrIdx]
-		if err := w.sync(p, interval); err != nil {
-			logger.Warn().Err(err).Msg("Failed to sync block.")
-		}
+		reqs[i] = syncRequest{peer: peers[w.rrIdx], interval: interval}
 		w.rrIdx++
 	}
+	w.mu.Unlock()
+
+	concurrency := int(w.concurrency)
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, req := range reqs {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(req syncRequest) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := w.sync(req.peer, req.interval); err != nil {
+				logger.Warn().Err(err).Msg("Failed to sync block.")
+			}
+		}(req)
+	}
+	wg.Wait()
+}
+
+type syncRequest struct {
+	peer     net.Addr
+	interval syncBlocksInterval
+}
+
+// splitIntervals splits the intervals into the ones having at most chunkSize blocks. The intervals are returned as is
+// if chunkSize is 0.
+func splitIntervals(intervals []syncBlocksInterval, chunkSize uint64) []syncBlocksInterval {
+	if chunkSize == 0 {
+		return intervals
+	}
+	var chunks []syncBlocksInterval
+	for _, interval := range intervals {
+		for start := interval.Start; start <= interval.End; start += chunkSize {
+			end := start + chunkSize - 1
+			if end > interval.End {
+				end = interval.End
+			}
+			chunks = append(chunks, syncBlocksInterval{Start: start, End: end})
+		}
+	}
+	return chunks
 }
 
 func (w *syncWorker) sync(p net.Addr, interval syncBlocksInterval) error {
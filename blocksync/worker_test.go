@@ -0,0 +1,79 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/network/node"
+	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+)
+
+func TestSplitIntervals(t *testing.T) {
+	intervals := []syncBlocksInterval{{Start: 1, End: 10}, {Start: 12, End: 12}}
+	assert.Equal(t, intervals, splitIntervals(intervals, 0))
+	assert.Equal(
+		t,
+		[]syncBlocksInterval{{Start: 1, End: 4}, {Start: 5, End: 8}, {Start: 9, End: 10}, {Start: 12, End: 12}},
+		splitIntervals(intervals, 4),
+	)
+}
+
+func TestSyncWorkerSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	peers := []net.Addr{
+		node.NewTCPNode("127.0.0.1:10001"),
+		node.NewTCPNode("127.0.0.1:10002"),
+		node.NewTCPNode("127.0.0.1:10003"),
+	}
+	var mu sync.Mutex
+	requested := make(map[string][]syncBlocksInterval)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	p2p.EXPECT().GetPeers().Return(peers).Times(1)
+	p2p.EXPECT().Tell(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ uint32, peer net.Addr, msg proto.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			req := msg.(*pb.BlockSync)
+			requested[peer.String()] = append(
+				requested[peer.String()],
+				syncBlocksInterval{Start: req.Start, End: req.End},
+			)
+			return nil
+		},
+	).Times(4)
+
+	buf := &blockBuffer{
+		blocks:          make(map[uint64]*blockchain.Block),
+		size:            16,
+		startHeight:     1,
+		confirmedHeight: 0,
+	}
+	w := &syncWorker{
+		p2p:         p2p,
+		buf:         buf,
+		chunkSize:   4,
+		concurrency: 2,
+	}
+	w.SetTargetHeight(14)
+	w.Sync()
+
+	// 14 missing blocks are requested in 4 chunks from the peers in round robin
+	assert.ElementsMatch(t, []syncBlocksInterval{{Start: 1, End: 4}, {Start: 13, End: 14}}, requested[peers[0].String()])
+	assert.Equal(t, []syncBlocksInterval{{Start: 5, End: 8}}, requested[peers[1].String()])
+	assert.Equal(t, []syncBlocksInterval{{Start: 9, End: 12}}, requested[peers[2].String()])
+}
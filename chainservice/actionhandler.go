@@ -0,0 +1,111 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"reflect"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	pb "github.com/iotexproject/iotex-core/proto"
+)
+
+// ActionHandler decodes the incoming actions of a type and submits them into the actpool
+type ActionHandler interface {
+	// Decode converts the action from its protobuf, and returns it with its sender
+	Decode(act *pb.ActionPb) (action.Action, string)
+	// Submit adds the action into the actpool, and returns whether an action is evicted to make room for it
+	Submit(ap actpool.ActPool, act *pb.ActionPb) (bool, error)
+}
+
+// BatchSubmitter is implemented by the ActionHandlers that add a batch of their actions into the actpool in bulk.
+// HandleActions submits the actions of the handlers not implementing it one by one.
+type BatchSubmitter interface {
+	// SubmitBatch adds the decoded actions into the actpool, and returns the error of each action
+	SubmitBatch(ap actpool.ActPool, acts []action.Action) []error
+}
+
+// actionHandlers are the handlers of the action types, keyed by the type of the protobuf's action field
+var actionHandlers = map[reflect.Type]ActionHandler{
+	reflect.TypeOf(&pb.ActionPb_Transfer{}):  transferHandler{},
+	reflect.TypeOf(&pb.ActionPb_Vote{}):      voteHandler{},
+	reflect.TypeOf(&pb.ActionPb_Execution{}): executionHandler{},
+}
+
+// RegisterActionHandler registers the handler of the actions whose protobuf action field has the same type as actType,
+// e.g., &pb.ActionPb_Transfer{}, replacing the handler registered before. It is not safe to call it while actions are
+// being handled, so it should be called on initialization.
+func RegisterActionHandler(actType interface{}, h ActionHandler) {
+	actionHandlers[reflect.TypeOf(actType)] = h
+}
+
+// actionHandler returns the handler of the action, or nil if the action's type is not registered
+func actionHandler(act *pb.ActionPb) ActionHandler {
+	return actionHandlers[reflect.TypeOf(act.GetAction())]
+}
+
+// actPoolSubmitter submits the actions into the actpool as they are
+type actPoolSubmitter struct{}
+
+// Submit adds the action into the actpool
+func (actPoolSubmitter) Submit(ap actpool.ActPool, act *pb.ActionPb) (bool, error) {
+	return ap.AddAction(act)
+}
+
+type transferHandler struct{ actPoolSubmitter }
+
+// Decode converts the transfer from its protobuf
+func (transferHandler) Decode(act *pb.ActionPb) (action.Action, string) {
+	tsf := &action.Transfer{}
+	tsf.ConvertFromActionPb(act)
+	return tsf, tsf.Sender()
+}
+
+// SubmitBatch adds the transfers into the actpool in bulk
+func (transferHandler) SubmitBatch(ap actpool.ActPool, acts []action.Action) []error {
+	tsfs := make([]*action.Transfer, len(acts))
+	for i, act := range acts {
+		tsfs[i] = act.(*action.Transfer)
+	}
+	return ap.AddTsfs(tsfs)
+}
+
+type voteHandler struct{ actPoolSubmitter }
+
+// Decode converts the vote from its protobuf
+func (voteHandler) Decode(act *pb.ActionPb) (action.Action, string) {
+	vote := &action.Vote{}
+	vote.ConvertFromActionPb(act)
+	return vote, vote.Voter()
+}
+
+// SubmitBatch adds the votes into the actpool in bulk
+func (voteHandler) SubmitBatch(ap actpool.ActPool, acts []action.Action) []error {
+	votes := make([]*action.Vote, len(acts))
+	for i, act := range acts {
+		votes[i] = act.(*action.Vote)
+	}
+	return ap.AddVotes(votes)
+}
+
+type executionHandler struct{ actPoolSubmitter }
+
+// Decode converts the execution from its protobuf
+func (executionHandler) Decode(act *pb.ActionPb) (action.Action, string) {
+	execution := &action.Execution{}
+	execution.ConvertFromActionPb(act)
+	return execution, execution.Executor()
+}
+
+// SubmitBatch adds the executions into the actpool in bulk
+func (executionHandler) SubmitBatch(ap actpool.ActPool, acts []action.Action) []error {
+	executions := make([]*action.Execution, len(acts))
+	for i, act := range acts {
+		executions[i] = act.(*action.Execution)
+	}
+	return ap.AddExecutions(executions)
+}
@@ -1,12 +1,19 @@
 package chainservice
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"os"
+	"reflect"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 
 	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/action"
 	"github.com/iotexproject/iotex-core/blocksync"
@@ -15,11 +22,24 @@ import (
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/explorer"
 	explorerapi "github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/ratelimit"
 	pb "github.com/iotexproject/iotex-core/proto"
 )
 
+var (
+	// ErrRateLimited indicates that an action is dropped because its sender exceeds the rate limit
+	ErrRateLimited = errors.New("action is rate limited")
+	// ErrInvalidSignature indicates that an action is dropped because it is not signed by its sender
+	ErrInvalidSignature = errors.New("invalid action signature")
+	// ErrChainIDMismatch indicates that an action is dropped because it targets another chain
+	ErrChainIDMismatch = errors.New("action chain ID mismatch")
+)
+
 // ChainService is a blockchain service with all blockchain components.
 type ChainService struct {
 	actpool   actpool.ActPool
@@ -27,11 +47,29 @@ type ChainService struct {
 	consensus consensus.Consensus
 	chain     blockchain.Blockchain
 	explorer  *explorer.Server
+	// limiter limits the rate of incoming actions of each sender if it's not nil
+	limiter *ratelimit.Limiter
+
+	stopTimeout time.Duration
+	hook        lifecycle.Hook
+
+	mutex  sync.RWMutex
+	status Status
+}
+
+// Status tells whether each component of a ChainService has been started successfully.
+type Status struct {
+	Chain     bool
+	Consensus bool
+	BlockSync bool
+	Explorer  bool
 }
 
 type optionParams struct {
-	rootChainAPI explorerapi.Explorer
-	isTesting    bool
+	rootChainAPI     explorerapi.Explorer
+	isTesting        bool
+	chain            blockchain.Blockchain
+	consensusFactory consensus.SchemeFactory
 }
 
 // Option sets ChainService construction parameter.
@@ -53,6 +91,23 @@ func WithTesting() Option {
 	}
 }
 
+// WithBlockchain is an option to build ChainService on top of an existing blockchain instead of creating a new one.
+func WithBlockchain(chain blockchain.Blockchain) Option {
+	return func(ops *optionParams) error {
+		ops.chain = chain
+		return nil
+	}
+}
+
+// WithConsensusFactory is an option to build the consensus scheme by the given factory instead of the one registered
+// for the configured scheme.
+func WithConsensusFactory(factory consensus.SchemeFactory) Option {
+	return func(ops *optionParams) error {
+		ops.consensusFactory = factory
+		return nil
+	}
+}
+
 // New creates a ChainService from config and network.Overlay and dispatcher.Dispatcher.
 func New(cfg *config.Config, p2p network.Overlay, dispatcher dispatcher.Dispatcher, opts ...Option) (*ChainService, error) {
 	var ops optionParams
@@ -62,24 +117,30 @@ func New(cfg *config.Config, p2p network.Overlay, dispatcher dispatcher.Dispatch
 		}
 	}
 
-	var chainOpts []blockchain.Option
-	if ops.isTesting {
-		chainOpts = []blockchain.Option{blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption()}
-	} else {
-		chainOpts = []blockchain.Option{blockchain.DefaultStateFactoryOption(), blockchain.BoltDBDaoOption()}
-	}
+	chain := ops.chain
+	if chain == nil {
+		var chainOpts []blockchain.Option
+		if ops.isTesting {
+			chainOpts = []blockchain.Option{blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption()}
+		} else {
+			chainOpts = []blockchain.Option{blockchain.DefaultStateFactoryOption(), blockchain.BoltDBDaoOption()}
+		}
 
-	// create Blockchain
-	chain := blockchain.NewBlockchain(cfg, chainOpts...)
-	if chain == nil && cfg.Chain.EnableFallBackToFreshDB {
-		logger.Warn().Msg("Chain db and trie db are falling back to fresh ones")
-		if err := os.Rename(cfg.Chain.ChainDBPath, cfg.Chain.ChainDBPath+".old"); err != nil {
-			return nil, errors.Wrap(err, "failed to rename old chain db")
+		// create Blockchain
+		chain = blockchain.NewBlockchain(cfg, chainOpts...)
+		if chain == nil && cfg.Chain.EnableFallBackToFreshDB {
+			logger.Warn().Msg("Chain db and trie db are falling back to fresh ones")
+			if err := os.Rename(cfg.Chain.ChainDBPath, cfg.Chain.ChainDBPath+".old"); err != nil {
+				return nil, errors.Wrap(err, "failed to rename old chain db")
+			}
+			if err := os.Rename(cfg.Chain.TrieDBPath, cfg.Chain.TrieDBPath+".old"); err != nil {
+				return nil, errors.Wrap(err, "failed to rename old trie db")
+			}
+			chain = blockchain.NewBlockchain(cfg, blockchain.DefaultStateFactoryOption(), blockchain.BoltDBDaoOption())
 		}
-		if err := os.Rename(cfg.Chain.TrieDBPath, cfg.Chain.TrieDBPath+".old"); err != nil {
-			return nil, errors.Wrap(err, "failed to rename old trie db")
+		if chain == nil {
+			return nil, errors.New("failed to create blockchain")
 		}
-		chain = blockchain.NewBlockchain(cfg, blockchain.DefaultStateFactoryOption(), blockchain.BoltDBDaoOption())
 	}
 
 	// Create ActPool
@@ -94,19 +155,29 @@ func New(cfg *config.Config, p2p network.Overlay, dispatcher dispatcher.Dispatch
 
 	var copts []consensus.Option
 	if ops.rootChainAPI != nil {
-		copts = []consensus.Option{consensus.WithRootChainAPI(ops.rootChainAPI)}
+		copts = append(copts, consensus.WithRootChainAPI(ops.rootChainAPI))
 	}
-	consensus := consensus.NewConsensus(cfg, chain, actPool, p2p, copts...)
-	if consensus == nil {
+	if ops.consensusFactory != nil {
+		copts = append(copts, consensus.WithSchemeFactory(ops.consensusFactory))
+	}
+	consensus, err := consensus.NewConsensus(cfg, chain, actPool, p2p, copts...)
+	if err != nil {
 		return nil, errors.Wrap(err, "failed to create consensus")
 	}
 
 	var exp *explorer.Server
-	if cfg.Explorer.IsTest || os.Getenv("APP_ENV") == "development" {
+	switch {
+	case !cfg.Explorer.Enabled:
+		logger.Info().Msg("Explorer is disabled")
+	case cfg.Explorer.IsTest || os.Getenv("APP_ENV") == "development":
 		logger.Warn().Msg("Using test server with fake data...")
 		exp = explorer.NewTestSever(cfg.Explorer)
-	} else {
-		exp = explorer.NewServer(cfg.Explorer, chain, consensus, dispatcher, actPool, p2p)
+	default:
+		exp = explorer.NewServer(cfg.Explorer, chain, chain.GetFactory(), consensus, dispatcher, actPool, p2p)
+	}
+	var limiter *ratelimit.Limiter
+	if cfg.ActPool.SenderRateLimit > 0 {
+		limiter = ratelimit.NewLimiter(cfg.ActPool.SenderRateLimit, cfg.ActPool.SenderRateBurst)
 	}
 	return &ChainService{
 		actpool:   actPool,
@@ -114,70 +185,243 @@ func New(cfg *config.Config, p2p network.Overlay, dispatcher dispatcher.Dispatch
 		blocksync: bs,
 		consensus: consensus,
 		explorer:  exp,
+		limiter:   limiter,
+
+		stopTimeout: cfg.System.StopTimeout,
+		hook:        lifecycle.NopHook{},
 	}, nil
 }
 
 // Start starts the server
 func (cs *ChainService) Start(ctx context.Context) error {
-	if err := cs.chain.Start(ctx); err != nil {
-		return errors.Wrap(err, "error when starting blockchain")
+	if err := cs.startComponent(ctx, "blockchain", cs.chain); err != nil {
+		return err
 	}
-	if err := cs.consensus.Start(ctx); err != nil {
-		return errors.Wrap(err, "error when starting consensus")
+	cs.setStatus(func(s *Status) { s.Chain = true })
+	if err := cs.startComponent(ctx, "consensus", cs.consensus); err != nil {
+		return err
 	}
-	if err := cs.blocksync.Start(ctx); err != nil {
-		return errors.Wrap(err, "error when starting blocksync")
+	cs.setStatus(func(s *Status) { s.Consensus = true })
+	if err := cs.startComponent(ctx, "blocksync", cs.blocksync); err != nil {
+		return err
 	}
-	if err := cs.explorer.Start(ctx); err != nil {
-		return errors.Wrap(err, "error when starting explorer")
+	cs.setStatus(func(s *Status) { s.BlockSync = true })
+	if cs.explorer == nil {
+		return nil
 	}
+	if err := cs.startComponent(ctx, "explorer", cs.explorer); err != nil {
+		return err
+	}
+	cs.setStatus(func(s *Status) { s.Explorer = true })
 	return nil
 }
 
-// Stop stops the server
+// Stop stops the server. Every component is given a chance to stop even if a previous one fails or times out, and all
+// the errors are returned together.
 func (cs *ChainService) Stop(ctx context.Context) error {
-	if err := cs.explorer.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping explorer")
+	cs.setStatus(func(s *Status) { *s = Status{} })
+	var err error
+	if cs.explorer != nil {
+		err = cs.stopComponent(ctx, "explorer", cs.explorer)
 	}
-	if err := cs.consensus.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping consensus")
+	return multierr.Combine(
+		err,
+		cs.stopComponent(ctx, "consensus", cs.consensus),
+		cs.stopComponent(ctx, "blocksync", cs.blocksync),
+		cs.stopComponent(ctx, "blockchain", cs.chain),
+	)
+}
+
+// SetLifecycleHook sets the hook which is notified when a component starts or stops. The component name passed to the
+// hook is suffixed with the chain ID, e.g., "consensus:1".
+func (cs *ChainService) SetLifecycleHook(hook lifecycle.Hook) {
+	if hook == nil {
+		hook = lifecycle.NopHook{}
 	}
-	if err := cs.blocksync.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping blocksync")
+	cs.hook = hook
+}
+
+func (cs *ChainService) startComponent(ctx context.Context, name string, starter lifecycle.Starter) error {
+	hookName := fmt.Sprintf("%s:%d", name, cs.ChainID())
+	cs.hook.OnPhase(hookName, lifecycle.Starting)
+	if err := starter.Start(ctx); err != nil {
+		return errors.Wrapf(err, "error when starting %s", name)
 	}
-	if err := cs.chain.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping blockchain")
+	cs.hook.OnPhase(hookName, lifecycle.Started)
+	return nil
+}
+
+func (cs *ChainService) stopComponent(ctx context.Context, name string, stopper lifecycle.Stopper) error {
+	hookName := fmt.Sprintf("%s:%d", name, cs.ChainID())
+	cs.hook.OnPhase(hookName, lifecycle.Stopping)
+	if err := lifecycle.StopWithTimeout(ctx, stopper, cs.stopTimeout); err != nil {
+		return errors.Wrapf(err, "error when stopping %s", name)
 	}
+	cs.hook.OnPhase(hookName, lifecycle.Stopped)
 	return nil
 }
 
-// HandleAction handles incoming action request.
+// Status returns the running status of the components.
+func (cs *ChainService) Status() Status {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+	return cs.status
+}
+
+func (cs *ChainService) setStatus(update func(*Status)) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	update(&cs.status)
+}
+
+// HandleAction handles incoming action request. The action is dropped with ErrChainIDMismatch if its sender's address
+// belongs to another chain, which prevents actions from being replayed across chains, with ErrInvalidSignature if it is
+// not signed by its sender, and with ErrRateLimited if its sender exceeds the rate limit. If the actpool is full, another action may
+// be evicted to make room for it according to the actpool's eviction policy, which is logged. The action is decoded and
+// submitted by the ActionHandler registered for its type, and the actions of unregistered types are ignored.
 func (cs *ChainService) HandleAction(act *pb.ActionPb) error {
-	if pbTsf := act.GetTransfer(); pbTsf != nil {
-		tsf := &action.Transfer{}
-		tsf.ConvertFromActionPb(act)
-		if err := cs.actpool.AddTsf(tsf); err != nil {
-			logger.Debug().Err(err)
-			return err
+	handler, _, sender, err := cs.admitAction(act)
+	if handler == nil || err != nil {
+		return err
+	}
+	evicted, err := handler.Submit(cs.actpool, act)
+	if err != nil {
+		logger.Debug().Err(err).Msg("Failed to add action")
+		return err
+	}
+	if evicted {
+		logger.Info().Str("sender", sender).Msg("Evicted an action from the full actpool to add the incoming one")
+	}
+	return nil
+}
+
+// HandleActions handles a batch of incoming actions. Each action goes through the same checks as in HandleAction, and
+// the admitted ones are grouped by type and submitted by the registered ActionHandlers, in bulk if the handler is a
+// BatchSubmitter. The returned error combines the errors of all the failed actions, each of which is annotated with the
+// action's index in acts.
+func (cs *ChainService) HandleActions(acts []*pb.ActionPb) error {
+	type batch struct {
+		handler ActionHandler
+		pbs     []*pb.ActionPb
+		acts    []action.Action
+		senders []string
+		idx     []int
+	}
+	var (
+		batches = make(map[reflect.Type]*batch)
+		types   []reflect.Type
+		err     error
+	)
+	for i, act := range acts {
+		handler, decoded, sender, e := cs.admitAction(act)
+		if e != nil {
+			err = multierr.Append(err, errors.Wrapf(e, "failed to add action %d", i))
+			continue
+		}
+		if handler == nil {
+			continue
+		}
+		actType := reflect.TypeOf(act.GetAction())
+		b, ok := batches[actType]
+		if !ok {
+			b = &batch{handler: handler}
+			batches[actType] = b
+			types = append(types, actType)
 		}
-	} else if pbVote := act.GetVote(); pbVote != nil {
-		vote := &action.Vote{}
-		vote.ConvertFromActionPb(act)
-		if err := cs.actpool.AddVote(vote); err != nil {
-			logger.Debug().Err(err)
-			return err
+		b.pbs = append(b.pbs, act)
+		b.acts = append(b.acts, decoded)
+		b.senders = append(b.senders, sender)
+		b.idx = append(b.idx, i)
+	}
+
+	for _, actType := range types {
+		b := batches[actType]
+		if submitter, ok := b.handler.(BatchSubmitter); ok {
+			for i, e := range submitter.SubmitBatch(cs.actpool, b.acts) {
+				if e != nil {
+					err = multierr.Append(err, errors.Wrapf(e, "failed to add action %d", b.idx[i]))
+				}
+			}
+			continue
 		}
-	} else if pbExecution := act.GetExecution(); pbExecution != nil {
-		execution := &action.Execution{}
-		execution.ConvertFromActionPb(act)
-		if err := cs.actpool.AddExecution(execution); err != nil {
-			logger.Debug().Err(err).Msg("Failed to add execution")
-			return err
+		for i, act := range b.pbs {
+			evicted, e := b.handler.Submit(cs.actpool, act)
+			if e != nil {
+				err = multierr.Append(err, errors.Wrapf(e, "failed to add action %d", b.idx[i]))
+				continue
+			}
+			if evicted {
+				logger.Info().Str("sender", b.senders[i]).
+					Msg("Evicted an action from the full actpool to add the incoming one")
+			}
 		}
 	}
+	if err != nil {
+		logger.Debug().Err(err).Msg("Failed to add actions")
+	}
+	return err
+}
+
+// admitAction decodes the action with the ActionHandler registered for its type, and checks its chain ID, signature and
+// its sender's rate. It returns a nil handler if the action's type is not registered.
+func (cs *ChainService) admitAction(act *pb.ActionPb) (ActionHandler, action.Action, string, error) {
+	handler := actionHandler(act)
+	if handler == nil {
+		return nil, nil, "", nil
+	}
+	decoded, sender := handler.Decode(act)
+	if err := cs.checkChainID(sender); err != nil {
+		return nil, nil, "", err
+	}
+	if err := cs.verifySignature(sender, decoded); err != nil {
+		return nil, nil, "", err
+	}
+	if err := cs.checkRate(sender); err != nil {
+		return nil, nil, "", err
+	}
+	return handler, decoded, sender, nil
+}
+
+// checkChainID returns ErrChainIDMismatch if the sender's address doesn't belong to the chain
+func (cs *ChainService) checkChainID(sender string) error {
+	addr, err := address.IotxAddressToAddress(sender)
+	if err != nil {
+		return errors.Wrapf(err, "invalid sender address %s", sender)
+	}
+	if expected := cs.ChainID(); addr.ChainID() != expected {
+		logger.Debug().Str("sender", sender).Msg("Dropped action targeting another chain")
+		return errors.Wrapf(ErrChainIDMismatch, "expected chain ID %d, received %d", expected, addr.ChainID())
+	}
 	return nil
 }
 
+// verifySignature returns ErrInvalidSignature if the action's public key doesn't belong to the sender or the signature
+// doesn't match the action as required by the chain, so that forged actions gossiped by peers never enter the actpool
+func (cs *ChainService) verifySignature(sender string, act action.Action) error {
+	pkHash, err := iotxaddress.GetPubkeyHash(sender)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidSignature, "invalid sender address %s", sender)
+	}
+	if srcPkHash := keypair.HashPubKey(act.SrcPubkey()); !bytes.Equal(pkHash, srcPkHash[:]) {
+		logger.Debug().Str("sender", sender).Msg("Dropped action whose public key doesn't belong to its sender")
+		return errors.Wrapf(ErrInvalidSignature, "public key doesn't belong to sender %s", sender)
+	}
+	if err := cs.chain.VerifyActionSignature(act); err != nil {
+		logger.Debug().Str("sender", sender).Err(err).Msg("Dropped action with invalid signature")
+		return errors.Wrapf(ErrInvalidSignature, "sender %s: %v", sender, err)
+	}
+	return nil
+}
+
+// checkRate returns ErrRateLimited if the sender exceeds the rate limit
+func (cs *ChainService) checkRate(sender string) error {
+	if cs.limiter == nil || cs.limiter.Allow(sender) {
+		return nil
+	}
+	logger.Debug().Str("sender", sender).Msg("Dropped action due to rate limit")
+	return errors.Wrapf(ErrRateLimited, "sender %s", sender)
+}
+
 // HandleBlock handles incoming block request.
 func (cs *ChainService) HandleBlock(pbBlock *pb.BlockPb) error {
 	blk := &blockchain.Block{}
@@ -230,7 +474,7 @@ func (cs *ChainService) BlockSync() blocksync.BlockSync {
 	return cs.blocksync
 }
 
-// Explorer returns the explorer instance
+// Explorer returns the explorer instance, or nil if the explorer is disabled
 func (cs *ChainService) Explorer() *explorer.Server {
 	return cs.explorer
 }
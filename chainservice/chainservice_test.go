@@ -0,0 +1,314 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
+	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestNewWithFailedFallback(t *testing.T) {
+	require := require.New(t)
+
+	testChainDBPath := "chain.db.test"
+	testTrieDBPath := "trie.db.test"
+	paths := []string{testChainDBPath, testTrieDBPath, testChainDBPath + ".old", testTrieDBPath + ".old"}
+	for _, path := range paths {
+		testutil.CleanupPath(t, path)
+	}
+	defer func() {
+		for _, path := range paths {
+			testutil.CleanupPath(t, path)
+		}
+	}()
+	require.NoError(ioutil.WriteFile(testChainDBPath, []byte("corrupted"), 0600))
+	require.NoError(ioutil.WriteFile(testTrieDBPath, []byte("corrupted"), 0600))
+
+	cfg := config.Default
+	cfg.Chain.ChainDBPath = testChainDBPath
+	cfg.Chain.TrieDBPath = testTrieDBPath
+	cfg.Chain.EnableFallBackToFreshDB = true
+	// An invalid producer key fails creating the blockchain with either the old or the fresh dbs
+	cfg.Chain.ProducerPrivKey = "invalid"
+
+	require.NotPanics(func() {
+		cs, err := New(&cfg, nil, nil)
+		require.Error(err)
+		require.Nil(cs)
+	})
+}
+
+func TestHandleActionSignature(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ap := mock_actpool.NewMockActPool(ctrl)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().ChainID().Return(config.Default.Chain.ID).AnyTimes()
+	bc.EXPECT().VerifyActionSignature(gomock.Any()).DoAndReturn(action.Verify).AnyTimes()
+	cs := &ChainService{actpool: ap, chain: bc}
+
+	alfa := testaddress.Addrinfo["alfa"]
+	bravo := testaddress.Addrinfo["bravo"]
+	bravoPubKey := bravo.PublicKey[:]
+	tsf, err := testutil.SignedTransfer(alfa, bravo, 1, big.NewInt(10), nil, 100000, big.NewInt(10))
+	require.NoError(err)
+	vote, err := testutil.SignedVote(alfa, alfa, 2, 100000, big.NewInt(10))
+	require.NoError(err)
+	execution, err := testutil.SignedExecution(alfa, bravo.RawAddress, 3, big.NewInt(0), 100000, big.NewInt(10), nil)
+	require.NoError(err)
+
+	tests := []struct {
+		name          string
+		act           func() *pb.ActionPb
+		replacePubKey func(*pb.ActionPb)
+	}{
+		{
+			name: "transfer",
+			act:  tsf.ConvertToActionPb,
+			replacePubKey: func(act *pb.ActionPb) {
+				act.GetTransfer().SenderPubKey = bravoPubKey
+			},
+		},
+		{
+			name: "vote",
+			act:  vote.ConvertToActionPb,
+			replacePubKey: func(act *pb.ActionPb) {
+				act.GetVote().SelfPubkey = bravoPubKey
+			},
+		},
+		{
+			name: "execution",
+			act:  execution.ConvertToActionPb,
+			replacePubKey: func(act *pb.ActionPb) {
+				act.GetExecution().ExecutorPubKey = bravoPubKey
+			},
+		},
+	}
+	for _, test := range tests {
+		// The actpool is only called with the intact action
+		ap.EXPECT().AddAction(gomock.Any()).Return(false, nil).Times(1)
+		require.NoError(cs.HandleAction(test.act()), test.name)
+
+		// Tampered signature
+		act := test.act()
+		act.Signature = tamper(act.Signature)
+		require.Equal(ErrInvalidSignature, errors.Cause(cs.HandleAction(act)), test.name)
+
+		// Tampered content
+		act = test.act()
+		act.Nonce++
+		require.Equal(ErrInvalidSignature, errors.Cause(cs.HandleAction(act)), test.name)
+
+		// Public key not belonging to the sender
+		act = test.act()
+		test.replacePubKey(act)
+		require.Equal(ErrInvalidSignature, errors.Cause(cs.HandleAction(act)), test.name)
+	}
+
+	// Forged actions are filtered out of a batch before reaching the actpool
+	forged := tsf.ConvertToActionPb()
+	forged.Signature = tamper(forged.Signature)
+	ap.EXPECT().AddTsfs(gomock.Any()).DoAndReturn(func(tsfs []*action.Transfer) []error {
+		require.Equal(1, len(tsfs))
+		return []error{nil}
+	}).Times(1)
+	err = cs.HandleActions([]*pb.ActionPb{forged, tsf.ConvertToActionPb()})
+	require.Equal(ErrInvalidSignature, errors.Cause(err))
+}
+
+// tamper returns a copy of the signature with the first byte flipped
+func tamper(signature []byte) []byte {
+	tampered := make([]byte, len(signature))
+	copy(tampered, signature)
+	tampered[0] ^= 0xff
+	return tampered
+}
+
+func TestHandleActionChainID(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ap := mock_actpool.NewMockActPool(ctrl)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().ChainID().Return(config.Default.Chain.ID).AnyTimes()
+	cs := &ChainService{actpool: ap, chain: bc}
+
+	// alfa's key pair on another chain
+	alfa := testaddress.Addrinfo["alfa"]
+	pkHash := keypair.HashPubKey(alfa.PublicKey)
+	foreign := &iotxaddress.Address{
+		PublicKey:  alfa.PublicKey,
+		PrivateKey: alfa.PrivateKey,
+		RawAddress: address.New(config.Default.Chain.ID+1, pkHash[:]).IotxAddress(),
+	}
+	bravo := testaddress.Addrinfo["bravo"]
+
+	tsf, err := testutil.SignedTransfer(foreign, bravo, 1, big.NewInt(10), nil, 100000, big.NewInt(10))
+	require.NoError(err)
+	err = cs.HandleAction(tsf.ConvertToActionPb())
+	require.Equal(ErrChainIDMismatch, errors.Cause(err))
+	require.Contains(err.Error(), fmt.Sprintf("expected chain ID %d, received %d", config.Default.Chain.ID,
+		config.Default.Chain.ID+1))
+
+	// The rejected actions of a batch never reach the actpool
+	err = cs.HandleActions([]*pb.ActionPb{tsf.ConvertToActionPb()})
+	require.Equal(ErrChainIDMismatch, errors.Cause(err))
+}
+
+type countingHandler struct {
+	ActionHandler
+	submitted int
+}
+
+func (h *countingHandler) Submit(ap actpool.ActPool, act *pb.ActionPb) (bool, error) {
+	h.submitted++
+	return h.ActionHandler.Submit(ap, act)
+}
+
+func TestRegisterActionHandler(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ap := mock_actpool.NewMockActPool(ctrl)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().ChainID().Return(config.Default.Chain.ID).AnyTimes()
+	bc.EXPECT().VerifyActionSignature(gomock.Any()).DoAndReturn(action.Verify).AnyTimes()
+	cs := &ChainService{actpool: ap, chain: bc}
+
+	tsf, err := testutil.SignedTransfer(testaddress.Addrinfo["alfa"], testaddress.Addrinfo["bravo"], 1,
+		big.NewInt(10), nil, 100000, big.NewInt(10))
+	require.NoError(err)
+
+	// Actions of unregistered types are ignored
+	require.NoError(cs.HandleAction(&pb.ActionPb{}))
+
+	h := &countingHandler{ActionHandler: transferHandler{}}
+	defer RegisterActionHandler(&pb.ActionPb_Transfer{}, transferHandler{})
+	RegisterActionHandler(&pb.ActionPb_Transfer{}, h)
+	ap.EXPECT().AddAction(gomock.Any()).Return(false, nil).Times(1)
+	require.NoError(cs.HandleAction(tsf.ConvertToActionPb()))
+	require.Equal(1, h.submitted)
+
+	// A batch goes through the registered handlers too. The handler not implementing BatchSubmitter submits its actions
+	// one by one, while the votes are still added in bulk
+	tsf2, err := testutil.SignedTransfer(testaddress.Addrinfo["alfa"], testaddress.Addrinfo["bravo"], 2,
+		big.NewInt(10), nil, 100000, big.NewInt(10))
+	require.NoError(err)
+	vote, err := testutil.SignedVote(testaddress.Addrinfo["alfa"], testaddress.Addrinfo["alfa"], 3, 100000,
+		big.NewInt(10))
+	require.NoError(err)
+	forged := tsf2.ConvertToActionPb()
+	forged.Signature = tamper(forged.Signature)
+	ap.EXPECT().AddAction(gomock.Any()).Return(false, nil).Times(2)
+	ap.EXPECT().AddVotes(gomock.Any()).DoAndReturn(func(votes []*action.Vote) []error {
+		require.Equal(1, len(votes))
+		return []error{nil}
+	}).Times(1)
+	err = cs.HandleActions([]*pb.ActionPb{
+		tsf.ConvertToActionPb(),
+		vote.ConvertToActionPb(),
+		forged,
+		tsf2.ConvertToActionPb(),
+		{},
+	})
+	require.Equal(ErrInvalidSignature, errors.Cause(err))
+	require.Contains(err.Error(), "failed to add action 2")
+	require.Equal(3, h.submitted)
+}
+
+// recordingHook records the phases of the components in the order they are notified
+type recordingHook struct {
+	phases []string
+}
+
+func (h *recordingHook) OnPhase(name string, phase lifecycle.Phase) {
+	h.phases = append(h.phases, fmt.Sprintf("%s %s", name, phase))
+}
+
+func TestLifecycleHook(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	consensus := mock_consensus.NewMockConsensus(ctrl)
+	bs := mock_blocksync.NewMockBlockSync(ctrl)
+	cs := &ChainService{chain: bc, consensus: consensus, blocksync: bs}
+	hook := &recordingHook{}
+	cs.SetLifecycleHook(hook)
+
+	ctx := context.Background()
+	bc.EXPECT().Start(gomock.Any()).Return(nil).Times(1)
+	consensus.EXPECT().Start(gomock.Any()).Return(nil).Times(1)
+	bs.EXPECT().Start(gomock.Any()).Return(nil).Times(1)
+	require.NoError(cs.Start(ctx))
+	consensus.EXPECT().Stop(gomock.Any()).Return(nil).Times(1)
+	bs.EXPECT().Stop(gomock.Any()).Return(nil).Times(1)
+	bc.EXPECT().Stop(gomock.Any()).Return(nil).Times(1)
+	require.NoError(cs.Stop(ctx))
+	require.Equal([]string{
+		"blockchain:1 starting",
+		"blockchain:1 started",
+		"consensus:1 starting",
+		"consensus:1 started",
+		"blocksync:1 starting",
+		"blocksync:1 started",
+		"consensus:1 stopping",
+		"consensus:1 stopped",
+		"blocksync:1 stopping",
+		"blocksync:1 stopped",
+		"blockchain:1 stopping",
+		"blockchain:1 stopped",
+	}, hook.phases)
+
+	// A component failing to start or stop doesn't reach the started or stopped phase
+	hook.phases = nil
+	bc.EXPECT().Start(gomock.Any()).Return(nil).Times(1)
+	consensus.EXPECT().Start(gomock.Any()).Return(errors.New("error")).Times(1)
+	require.Error(cs.Start(ctx))
+	consensus.EXPECT().Stop(gomock.Any()).Return(nil).Times(1)
+	bs.EXPECT().Stop(gomock.Any()).Return(errors.New("error")).Times(1)
+	bc.EXPECT().Stop(gomock.Any()).Return(nil).Times(1)
+	require.Error(cs.Stop(ctx))
+	require.Equal([]string{
+		"blockchain:1 starting",
+		"blockchain:1 started",
+		"consensus:1 starting",
+		"consensus:1 stopping",
+		"consensus:1 stopped",
+		"blocksync:1 stopping",
+		"blockchain:1 stopping",
+		"blockchain:1 stopped",
+	}, hook.phases)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricsCollector collects the metrics of a chain service on each scrape
+type metricsCollector struct {
+	cs                   *ChainService
+	actPoolSizeDesc      *prometheus.Desc
+	chainHeightDesc      *prometheus.Desc
+	syncHeightDesc       *prometheus.Desc
+	syncTargetHeightDesc *prometheus.Desc
+	consensusRoundDesc   *prometheus.Desc
+}
+
+// Metrics returns the collector of the chain service's metrics, i.e., the actpool size, the blockchain height, the
+// synced and target heights of blocksync and the consensus round, which are labeled by the chain ID
+func (cs *ChainService) Metrics() prometheus.Collector {
+	labels := prometheus.Labels{"chain_id": strconv.FormatUint(uint64(cs.ChainID()), 10)}
+	return &metricsCollector{
+		cs: cs,
+		actPoolSizeDesc: prometheus.NewDesc(
+			"iotex_actpool_size",
+			"Number of actions in actpool.",
+			nil,
+			labels,
+		),
+		chainHeightDesc: prometheus.NewDesc(
+			"iotex_blockchain_height",
+			"Tip height of blockchain.",
+			nil,
+			labels,
+		),
+		syncHeightDesc: prometheus.NewDesc(
+			"iotex_blocksync_height",
+			"Height of the blocks synced by blocksync.",
+			nil,
+			labels,
+		),
+		syncTargetHeightDesc: prometheus.NewDesc(
+			"iotex_blocksync_target_height",
+			"Highest block height observed from peers by blocksync.",
+			nil,
+			labels,
+		),
+		consensusRoundDesc: prometheus.NewDesc(
+			"iotex_consensus_round",
+			"Ordinal number of the consensus round at the height being produced.",
+			nil,
+			labels,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.actPoolSizeDesc
+	ch <- c.chainHeightDesc
+	ch <- c.syncHeightDesc
+	ch <- c.syncTargetHeightDesc
+	ch <- c.consensusRoundDesc
+}
+
+// Collect implements prometheus.Collector. The consensus round is skipped if the consensus scheme doesn't report it.
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(c.actPoolSizeDesc, prometheus.GaugeValue, float64(c.cs.actpool.GetSize()))
+	ch <- prometheus.MustNewConstMetric(c.chainHeightDesc, prometheus.GaugeValue, float64(c.cs.chain.TipHeight()))
+	status := c.cs.blocksync.SyncStatus()
+	ch <- prometheus.MustNewConstMetric(c.syncHeightDesc, prometheus.GaugeValue, float64(status.CurrentHeight))
+	ch <- prometheus.MustNewConstMetric(c.syncTargetHeightDesc, prometheus.GaugeValue, float64(status.TargetHeight))
+	if metrics, err := c.cs.consensus.Metrics(); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.consensusRoundDesc, prometheus.GaugeValue, float64(metrics.Round))
+	}
+}
@@ -51,6 +51,28 @@ const (
 	StandaloneScheme = "STANDALONE"
 	// NOOPScheme means that the node does not create only block
 	NOOPScheme = "NOOP"
+
+	// NoEvictionPolicy means that the incoming action is rejected when the actpool is full
+	NoEvictionPolicy = "NONE"
+	// OldestEvictionPolicy means that the oldest evictable action is evicted to make room for the incoming action when
+	// the actpool is full
+	OldestEvictionPolicy = "OLDEST"
+	// LowestFeeEvictionPolicy means that the evictable action with the lowest gas price is evicted to make room for the
+	// incoming action when the actpool is full, if its gas price is lower than the incoming action's
+	LowestFeeEvictionPolicy = "LOWEST_FEE"
+
+	// LinearVotingDecay means that a vote loses a fixed percent of its original weight every period
+	LinearVotingDecay = "LINEAR"
+	// ExponentialVotingDecay means that a vote loses a fixed percent of its remaining weight every period
+	ExponentialVotingDecay = "EXPONENTIAL"
+
+	// JSONLogFormat means that the logs are written as JSON objects
+	JSONLogFormat = "json"
+	// ConsoleLogFormat means that the logs are written in a human-readable and colorful format
+	ConsoleLogFormat = "console"
+
+	// RedactedValue replaces the secrets in the config returned by Config.Redacted
+	RedactedValue = "<redacted>"
 )
 
 var (
@@ -85,6 +107,12 @@ var (
 			PeerDiscovery:                       true,
 			TopologyPath:                        "",
 			TTL:                                 3,
+			AllowList:                           make([]string, 0),
+			DenyList:                            make([]string, 0),
+			BanDuration:                         10 * time.Minute,
+			GossipSeenCacheSize:                 10000,
+			MinPeersToStart:                     0,
+			MinPeersTimeout:                     2 * time.Minute,
 		},
 		Chain: Chain{
 			ChainDBPath:             "/tmp/chain.db",
@@ -94,13 +122,24 @@ var (
 			ProducerPrivKey:         keypair.EncodePrivateKey(keypair.ZeroPrivateKey),
 			InMemTest:               false,
 			GenesisActionsPath:      "",
+			GenesisAllocPath:        "",
 			NumCandidates:           101,
 			EnableFallBackToFreshDB: false,
+			GenesisTimestamp:        1524676419,
+			MaxBlockSize:            0,
+			MaxActionsPerBlock:      0,
+			VotingDecay: VotingDecay{
+				EpochLength: 0,
+				Curve:       LinearVotingDecay,
+				Period:      0,
+				Percent:     0,
+			},
 		},
 		ActPool: ActPool{
 			MaxNumActsPerPool: 32000,
 			MaxNumActsPerAcct: 2000,
 			MaxNumActsToPick:  0,
+			EvictionPolicy:    NoEvictionPolicy,
 		},
 		Consensus: Consensus{
 			Scheme: NOOPScheme,
@@ -121,29 +160,41 @@ var (
 				TimeBasedRotation: false,
 			},
 			BlockCreationInterval: 10 * time.Second,
+			MinBlockInterval:      time.Second,
 		},
 		BlockSync: BlockSync{
-			Interval:   10 * time.Second,
-			BufferSize: 16,
+			Interval:    10 * time.Second,
+			BufferSize:  16,
+			ChunkSize:   4,
+			Concurrency: 4,
 		},
 		Dispatcher: Dispatcher{
 			EventChanSize: 10000,
 		},
 		Explorer: Explorer{
-			Enabled:                 false,
+			Enabled:                 true,
 			IsTest:                  false,
 			Port:                    14004,
 			TpsWindow:               10,
 			MaxTransferPayloadBytes: 1024,
+			RequestTimeout:          30 * time.Second,
+			FinalityConfirmations:   0,
+			StateCacheSize:          10000,
+			BreakerCooldown:         10 * time.Second,
 		},
 		System: System{
 			HeartbeatInterval: 10 * time.Second,
 			HTTPProfilingPort: 0,
 			HTTPMetricsPort:   8080,
+			StopTimeout:       10 * time.Second,
 		},
 		DB: DB{
 			NumRetries: 3,
 		},
+		Log: Log{
+			Level:  "info",
+			Format: JSONLogFormat,
+		},
 	}
 
 	// ErrInvalidCfg indicates the invalid config value
@@ -153,12 +204,14 @@ var (
 	Validates = []Validate{
 		ValidateKeyPair,
 		ValidateConsensusScheme,
+		ValidateRewardAddress,
 		ValidateRollDPoS,
 		ValidateDispatcher,
 		ValidateExplorer,
 		ValidateNetwork,
 		ValidateActPool,
 		ValidateChain,
+		ValidateLog,
 	}
 )
 
@@ -193,6 +246,24 @@ type (
 		PeerDiscovery                       bool                        `yaml:"peerDiscovery"`
 		TopologyPath                        string                      `yaml:"topologyPath"`
 		TTL                                 int32                       `yaml:"ttl"`
+		// AllowList contains the hosts or host:port addresses that are allowed to be peers. Empty means all.
+		AllowList []string `yaml:"allowList"`
+		// DenyList contains the hosts or host:port addresses that are never allowed to be peers
+		DenyList []string `yaml:"denyList"`
+		// BanDuration is how long a banned peer is refused reconnection
+		BanDuration time.Duration `yaml:"banDuration"`
+		// GossipSeenCacheSize is the number of the recently seen gossip messages to remember for deduplication
+		GossipSeenCacheSize int `yaml:"gossipSeenCacheSize"`
+		// Disabled runs the node standalone, which neither listens nor connects to any peer, e.g., for development and
+		// CI
+		Disabled bool `yaml:"disabled"`
+		// MinPeersToStart is the number of peers to connect to before the node starts proposing and endorsing blocks
+		// on startup, while the blocks agreed by the others are still synced and committed. Default is 0, which means
+		// the node takes part in consensus immediately. It's ignored if the network is disabled.
+		MinPeersToStart int `yaml:"minPeersToStart"`
+		// MinPeersTimeout is the maximum time to wait for MinPeersToStart peers, after which the node takes part in
+		// consensus anyway. 0 means waiting until the peers are connected.
+		MinPeersTimeout time.Duration `yaml:"minPeersTimeout"`
 	}
 
 	// Chain is the config struct for blockchain package
@@ -209,6 +280,41 @@ type (
 		GenesisActionsPath      string `yaml:"genesisActionsPath"`
 		NumCandidates           uint   `yaml:"numCandidates"`
 		EnableFallBackToFreshDB bool   `yaml:"enablefallbacktofreshdb"`
+
+		// GenesisAllocPath is the path of the JSON file allocating the total supply to the initial accounts, instead of
+		// allocating all of it to the genesis creator
+		GenesisAllocPath string `yaml:"genesisAllocPath"`
+
+		// ChainBoundSignatureHeight is the height from which the signatures of transfers and votes must be bound to the
+		// chain ID, so that they cannot be replayed on other chains. The signatures not bound to any chain are still
+		// accepted below it. Default is 0, which means unbound signatures are always accepted.
+		ChainBoundSignatureHeight uint64 `yaml:"chainBoundSignatureHeight"`
+
+		// GenesisTimestamp is the unix timestamp in seconds of the genesis block. Together with ID, it lets a private
+		// chain have a genesis block, and therefore a chain, distinct from the others.
+		GenesisTimestamp int64 `yaml:"genesisTimestamp"`
+
+		// MaxBlockSize is the maximum size in bytes of a serialized block, and MaxActionsPerBlock is the maximum number
+		// of actions in a block including the coinbase transfer. The proposer leaves out the actions exceeding them,
+		// and the blocks received exceeding them are rejected. Default is 0, which means no limit.
+		MaxBlockSize       uint64 `yaml:"maxBlockSize"`
+		MaxActionsPerBlock uint64 `yaml:"maxActionsPerBlock"`
+
+		// VotingDecay decays the votes of the voters who haven't refreshed them. It's a part of the consensus rules, so
+		// all the nodes of the chain must configure it the same since genesis
+		VotingDecay VotingDecay `yaml:"votingDecay"`
+	}
+
+	// VotingDecay is the config of decaying the votes according to the number of blocks since they were cast
+	VotingDecay struct {
+		// EpochLength is the number of blocks in an epoch, at the beginning of which the votes are decayed. Default is
+		// 0, which means no decay
+		EpochLength uint64 `yaml:"epochLength"`
+		// Curve is how a vote decays, either LINEAR or EXPONENTIAL
+		Curve string `yaml:"curve"`
+		// Period is the number of blocks in which a vote loses Percent percent of its weight
+		Period  uint64 `yaml:"period"`
+		Percent uint64 `yaml:"percent"`
 	}
 
 	// Consensus is the config struct for consensus package
@@ -217,12 +323,29 @@ type (
 		Scheme                string        `yaml:"scheme"`
 		RollDPoS              RollDPoS      `yaml:"rollDPoS"`
 		BlockCreationInterval time.Duration `yaml:"blockCreationInterval"`
+		// MinBlockInterval is the lower bound of the block interval changed at runtime
+		MinBlockInterval time.Duration `yaml:"minBlockInterval"`
+		// RewardAddress is the address credited with the block reward of the blocks produced by the node, e.g., the
+		// address of a staking pool. Default is empty, which means the producer's own address.
+		RewardAddress string `yaml:"rewardAddress"`
 	}
 
 	// BlockSync is the config struct for the BlockSync
 	BlockSync struct {
 		Interval   time.Duration `yaml:"interval"` // update duration
 		BufferSize uint64        `yaml:"bufferSize"`
+		// ChunkSize is the max number of blocks requested from a peer in one sync request
+		ChunkSize uint64 `yaml:"chunkSize"`
+		// Concurrency is the max number of sync requests sent to the peers concurrently
+		Concurrency uint `yaml:"concurrency"`
+		// Checkpoints are the trusted blocks, which the synced blocks at the same heights must match
+		Checkpoints []Checkpoint `yaml:"checkpoints"`
+	}
+
+	// Checkpoint is a trusted block identified by its height and hex encoded hash
+	Checkpoint struct {
+		Height uint64 `yaml:"height"`
+		Hash   string `yaml:"hash"`
 	}
 
 	// RollDPoS is the config struct for RollDPoS consensus package
@@ -250,12 +373,52 @@ type (
 
 	// Explorer is the explorer service config
 	Explorer struct {
+		// Enabled indicates whether to index the blockchain for and run the explorer. Default is true. Nodes which don't
+		// serve the explorer API, e.g., validators, could disable it to reduce the attack surface.
 		Enabled   bool `yaml:"enabled"`
 		IsTest    bool `yaml:"isTest"`
 		Port      int  `yaml:"addr"`
 		TpsWindow int  `yaml:"tpsWindow"`
 		// MaxTransferPayloadBytes limits how many bytes a playload can contain at most
 		MaxTransferPayloadBytes uint64 `yaml:"maxTransferPayloadBytes"`
+		// RequestTimeout is the maximum time to serve a JSON-RPC request before replying with 503 Service Unavailable
+		// and cancelling the context passed to the explorer methods. The requests submitting actions are not timed out. 0
+		// means no timeout
+		RequestTimeout time.Duration `yaml:"requestTimeout"`
+		// TLSCertPath and TLSKeyPath are the paths of the PEM encoded certificate and private key to serve HTTPS. The
+		// explorer serves plain HTTP if they are empty. The certificate is reloaded on SIGHUP.
+		TLSCertPath string `yaml:"tlsCertPath"`
+		TLSKeyPath  string `yaml:"tlsKeyPath"`
+		// APIKeys are the keys accepted by the methods submitting actions, which are carried in the X-API-Key header.
+		// Default is empty, which means these methods are open to everyone. The read methods are always open, while
+		// the admin methods, e.g., getServerConfig, are always closed if there is no API key.
+		APIKeys []string `yaml:"apiKeys"`
+		// FinalityConfirmations is the number of blocks committed on top of a block to consider it finalized. Default
+		// is 0, because RollDPoS commits a block only after more than 2/3 of the delegates endorse it, which makes it
+		// irreversible once committed. Chains run by a consensus without such guarantee, e.g., standalone, should
+		// set it higher.
+		FinalityConfirmations uint64 `yaml:"finalityConfirmations"`
+		// ReadRateLimit and WriteRateLimit are the max numbers of requests per second each IP could make to the read
+		// methods and the methods submitting actions respectively, and ReadRateBurst and WriteRateBurst are the max
+		// numbers of requests allowed in a burst. A rate of 0 means no limit.
+		ReadRateLimit  float64 `yaml:"readRateLimit"`
+		ReadRateBurst  int     `yaml:"readRateBurst"`
+		WriteRateLimit float64 `yaml:"writeRateLimit"`
+		WriteRateBurst int     `yaml:"writeRateBurst"`
+		// CORSAllowedOrigins are the origins allowed to make cross-origin requests from browsers, or "*" to allow any
+		// origin, e.g., for local development. Default is empty, which means same-origin only. CORSAllowedMethods and
+		// CORSAllowedHeaders default to POST, and Content-Type and X-API-Key respectively if they are empty.
+		CORSAllowedOrigins []string `yaml:"corsAllowedOrigins"`
+		CORSAllowedMethods []string `yaml:"corsAllowedMethods"`
+		CORSAllowedHeaders []string `yaml:"corsAllowedHeaders"`
+		// StateCacheSize is the number of the account states cached for the balance and nonce lookups, which is flushed
+		// on every committed block. 0 disables the cache.
+		StateCacheSize int `yaml:"stateCacheSize"`
+		// BreakerFailureThreshold is the number of consecutive backend failures after which the read methods are served
+		// with the last known results, or fail fast if there is none, while the backend is probed by one request every
+		// BreakerCooldown. 0 disables the circuit breaker.
+		BreakerFailureThreshold int           `yaml:"breakerFailureThreshold"`
+		BreakerCooldown         time.Duration `yaml:"breakerCooldown"`
 	}
 
 	// System is the system config
@@ -265,6 +428,9 @@ type (
 		// 0 by default, meaning performance profiling has been disabled
 		HTTPProfilingPort int `yaml:"httpProfilingPort"`
 		HTTPMetricsPort   int `yaml:"httpMetricsPort"`
+		// StopTimeout is the maximum time to wait for each component to stop when shutting down the server. 0 means
+		// waiting until the context passed to Stop is done
+		StopTimeout time.Duration `yaml:"stopTimeout"`
 	}
 
 	// ActPool is the actpool config
@@ -276,6 +442,23 @@ type (
 		// MaxNumActsToPick indicates maximum number of actions to pick to mint a block. Default is 0, which means no
 		// limit on the number of actions to pick.
 		MaxNumActsToPick uint64 `yaml:"maxNumActsToPick"`
+		// WALPath is the path of the write-ahead log persisting the pending actions, which are recovered when the node
+		// restarts. Default is empty, which means the pending actions are not persisted.
+		WALPath string `yaml:"walPath"`
+		// SenderRateLimit indicates the maximum number of actions per second accepted from a sender. Default is 0, which
+		// means no rate limiting.
+		SenderRateLimit float64 `yaml:"senderRateLimit"`
+		// SenderRateBurst indicates the maximum number of actions accepted from a sender in a burst
+		SenderRateBurst int `yaml:"senderRateBurst"`
+		// EvictionPolicy indicates which action to evict to make room for the incoming action when the actpool is full.
+		// It could be NONE, OLDEST or LOWEST_FEE. Default is NONE, which means the incoming action is rejected.
+		EvictionPolicy string `yaml:"evictionPolicy"`
+		// MinGasPrice indicates the minimum gas price of the actions accepted into the pool. Default is 0, which means
+		// no floor. It could be raised at runtime by ActPool.SetMinGasPrice.
+		MinGasPrice uint64 `yaml:"minGasPrice"`
+		// MinTransferFee indicates the minimum fee of the transfers accepted into the pool, which is the gas price times
+		// the intrinsic gas of the transfer. Default is 0, which means no floor.
+		MinTransferFee uint64 `yaml:"minTransferFee"`
 	}
 
 	// DB is the blotDB config
@@ -301,6 +484,17 @@ type (
 		AwsDBName string `yaml:"awsDBName"`
 	}
 
+	// Log is the logger config
+	Log struct {
+		// Level is the minimum level of the logs, which could be debug, info, warn, error, fatal or panic. Default is
+		// info. It could be changed at runtime by Server.SetLogLevel.
+		Level string `yaml:"level"`
+		// Format could be json or console. Default is json.
+		Format string `yaml:"format"`
+		// Path is the path of the file to write the logs to. Default is empty, which means stderr.
+		Path string `yaml:"path"`
+	}
+
 	// Config is the root config struct, each package's config should be put as its sub struct
 	Config struct {
 		NodeType   string     `yaml:"nodeType"`
@@ -313,6 +507,7 @@ type (
 		Explorer   Explorer   `yaml:"explorer"`
 		System     System     `yaml:"system"`
 		DB         DB         `yaml:"db"`
+		Log        Log        `yaml:"log"`
 	}
 
 	// Validate is the interface of validating the config
@@ -403,6 +598,25 @@ func (cfg *Config) IsLightweight() bool {
 	return cfg.NodeType == LightweightType
 }
 
+// Redacted returns a copy of the config, in which the secrets, i.e., the producer private key, the explorer API keys
+// and the RDS password, are replaced by RedactedValue if they are set
+func (cfg *Config) Redacted() Config {
+	redacted := *cfg
+	if redacted.Chain.ProducerPrivKey != "" {
+		redacted.Chain.ProducerPrivKey = RedactedValue
+	}
+	if redacted.DB.RDS.AwsPass != "" {
+		redacted.DB.RDS.AwsPass = RedactedValue
+	}
+	if len(cfg.Explorer.APIKeys) > 0 {
+		redacted.Explorer.APIKeys = make([]string, len(cfg.Explorer.APIKeys))
+		for i := range redacted.Explorer.APIKeys {
+			redacted.Explorer.APIKeys[i] = RedactedValue
+		}
+	}
+	return redacted
+}
+
 // BlockchainAddress returns the address derived from the configured chain ID and public key
 func (cfg *Config) BlockchainAddress() (address.Address, error) {
 	pk, err := keypair.DecodePublicKey(cfg.Chain.ProducerPubKey)
@@ -451,12 +665,31 @@ func ValidateKeyPair(cfg *Config) error {
 
 // ValidateChain validates the chain configure
 func ValidateChain(cfg *Config) error {
+	if cfg.Chain.ID == 0 {
+		return errors.Wrap(ErrInvalidCfg, "chain ID should be greater than 0")
+	}
+	if !time.Unix(cfg.Chain.GenesisTimestamp, 0).Before(time.Now()) {
+		return errors.Wrapf(ErrInvalidCfg, "genesis timestamp %d should be in the past", cfg.Chain.GenesisTimestamp)
+	}
 	if cfg.Chain.NumCandidates <= 0 {
 		return errors.Wrapf(ErrInvalidCfg, "candidate number should be greater than 0")
 	}
 	if cfg.Consensus.Scheme == RollDPoSScheme && cfg.Chain.NumCandidates < cfg.Consensus.RollDPoS.NumDelegates {
 		return errors.Wrapf(ErrInvalidCfg, "candidate number should be greater than or equal to delegate number")
 	}
+	if decay := cfg.Chain.VotingDecay; decay.EpochLength > 0 {
+		switch decay.Curve {
+		case LinearVotingDecay, ExponentialVotingDecay:
+		default:
+			return errors.Wrapf(ErrInvalidCfg, "unknown voting decay curve %s", decay.Curve)
+		}
+		if decay.Period == 0 {
+			return errors.Wrap(ErrInvalidCfg, "voting decay period should be greater than 0")
+		}
+		if decay.Percent > 100 {
+			return errors.Wrap(ErrInvalidCfg, "voting decay percent should be no more than 100")
+		}
+	}
 	return nil
 }
 
@@ -486,6 +719,17 @@ func ValidateDispatcher(cfg *Config) error {
 	return nil
 }
 
+// ValidateRewardAddress validates the block reward recipient if it's set
+func ValidateRewardAddress(cfg *Config) error {
+	if cfg.Consensus.RewardAddress == "" {
+		return nil
+	}
+	if err := address.ValidateAddress(cfg.Consensus.RewardAddress); err != nil {
+		return errors.Wrapf(ErrInvalidCfg, "invalid reward address %s: %v", cfg.Consensus.RewardAddress, err)
+	}
+	return nil
+}
+
 // ValidateRollDPoS validates the roll-DPoS configs
 func ValidateRollDPoS(cfg *Config) error {
 	if cfg.Consensus.Scheme == RollDPoSScheme && cfg.Consensus.RollDPoS.EventChanSize <= 0 {
@@ -507,14 +751,23 @@ func ValidateExplorer(cfg *Config) error {
 	if cfg.Explorer.Enabled && cfg.Explorer.TpsWindow <= 0 {
 		return errors.Wrap(ErrInvalidCfg, "tps window is not a positive integer when the explorer is enabled")
 	}
+	if (cfg.Explorer.TLSCertPath == "") != (cfg.Explorer.TLSKeyPath == "") {
+		return errors.Wrap(ErrInvalidCfg, "TLS certificate and key of the explorer should be given together")
+	}
 	return nil
 }
 
 // ValidateNetwork validates the network configs
 func ValidateNetwork(cfg *Config) error {
+	if cfg.Network.Disabled {
+		return nil
+	}
 	if !cfg.Network.PeerDiscovery && cfg.Network.TopologyPath == "" {
 		return errors.Wrap(ErrInvalidCfg, "either peer discover should be enabled or a topology should be given")
 	}
+	if cfg.Network.MinPeersToStart < 0 {
+		return errors.Wrap(ErrInvalidCfg, "min peers to start should not be negative")
+	}
 	return nil
 }
 
@@ -534,6 +787,26 @@ func ValidateActPool(cfg *Config) error {
 			"maximum number of actions per pool cannot be less than maximum number of actions per account",
 		)
 	}
+	switch cfg.ActPool.EvictionPolicy {
+	case "", NoEvictionPolicy, OldestEvictionPolicy, LowestFeeEvictionPolicy:
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown actpool eviction policy %s", cfg.ActPool.EvictionPolicy)
+	}
+	return nil
+}
+
+// ValidateLog validates the logger configs
+func ValidateLog(cfg *Config) error {
+	switch cfg.Log.Level {
+	case "debug", "info", "warn", "error", "fatal", "panic":
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown log level %s", cfg.Log.Level)
+	}
+	switch cfg.Log.Format {
+	case JSONLogFormat, ConsoleLogFormat:
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown log format %s", cfg.Log.Format)
+	}
 	return nil
 }
 
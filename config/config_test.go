@@ -13,11 +13,13 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 )
@@ -206,13 +208,36 @@ func TestValidateExplorer(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "tps window is not a positive integer when the explorer is enabled"),
 	)
+
+	cfg.Explorer.TpsWindow = 10
+	cfg.Explorer.TLSCertPath = "cert.pem"
+	err = ValidateExplorer(&cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(
+		t,
+		strings.Contains(err.Error(), "TLS certificate and key of the explorer should be given together"),
+	)
 }
 
 func TestValidateChain(t *testing.T) {
 	cfg := Default
-	cfg.Chain.NumCandidates = 0
+	require.NoError(t, ValidateChain(&cfg))
 
+	cfg.Chain.ID = 0
 	err := ValidateChain(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.Contains(t, err.Error(), "chain ID should be greater than 0")
+	cfg.Chain.ID = Default.Chain.ID
+
+	cfg.Chain.GenesisTimestamp = time.Now().Add(time.Hour).Unix()
+	err = ValidateChain(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.Contains(t, err.Error(), "should be in the past")
+	cfg.Chain.GenesisTimestamp = Default.Chain.GenesisTimestamp
+
+	cfg.Chain.NumCandidates = 0
+	err = ValidateChain(&cfg)
 	require.Error(t, err)
 	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
 	require.True(
@@ -231,6 +256,22 @@ func TestValidateChain(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "candidate number should be greater than or equal to delegate number"),
 	)
+
+	cfg = Default
+	cfg.Chain.VotingDecay = VotingDecay{EpochLength: 100, Curve: ExponentialVotingDecay, Period: 100, Percent: 10}
+	require.NoError(t, ValidateChain(&cfg))
+	cfg.Chain.VotingDecay.Curve = "UNKNOWN"
+	err = ValidateChain(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.Contains(t, err.Error(), "unknown voting decay curve")
+	cfg.Chain.VotingDecay.Curve = LinearVotingDecay
+	cfg.Chain.VotingDecay.Period = 0
+	err = ValidateChain(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	cfg.Chain.VotingDecay.Period = 100
+	cfg.Chain.VotingDecay.Percent = 101
+	err = ValidateChain(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
 }
 
 func TestValidateConsensusScheme(t *testing.T) {
@@ -276,6 +317,22 @@ func TestValidateDispatcher(t *testing.T) {
 	)
 }
 
+func TestValidateRewardAddress(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateRewardAddress(&cfg))
+
+	pk, _, err := crypto.EC283.NewKeyPair()
+	require.NoError(t, err)
+	pkHash := keypair.HashPubKey(pk)
+	cfg.Consensus.RewardAddress = address.New(cfg.Chain.ID, pkHash[:]).IotxAddress()
+	require.NoError(t, ValidateRewardAddress(&cfg))
+
+	cfg.Consensus.RewardAddress = "io1invalid"
+	err = ValidateRewardAddress(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.Contains(t, err.Error(), "invalid reward address io1invalid")
+}
+
 func TestValidateRollDPoS(t *testing.T) {
 	cfg := Default
 	cfg.NodeType = DelegateType
@@ -321,6 +378,16 @@ func TestValidateNetwork(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "either peer discover should be enabled or a topology should be given"),
 	)
+
+	cfg = Default
+	cfg.Network.MinPeersToStart = -1
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.Contains(t, err.Error(), "min peers to start should not be negative")
+
+	// no peer is needed in standalone mode
+	cfg.Network.Disabled = true
+	require.NoError(t, ValidateNetwork(&cfg))
 }
 
 func TestValidateActPool(t *testing.T) {
@@ -361,6 +428,37 @@ func TestValidateActPool(t *testing.T) {
 			"maximum number of actions per pool cannot be less than maximum number of actions per account",
 		),
 	)
+
+	cfg.ActPool.MaxNumActsPerPool = 100
+	cfg.ActPool.EvictionPolicy = "RANDOM"
+	err = ValidateActPool(&cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown actpool eviction policy RANDOM"))
+
+	cfg.ActPool.EvictionPolicy = LowestFeeEvictionPolicy
+	require.NoError(t, ValidateActPool(&cfg))
+}
+
+func TestValidateLog(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateLog(&cfg))
+
+	cfg.Log.Level = "verbose"
+	err := ValidateLog(&cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown log level verbose"))
+
+	cfg.Log.Level = "debug"
+	cfg.Log.Format = "xml"
+	err = ValidateLog(&cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown log format xml"))
+
+	cfg.Log.Format = ConsoleLogFormat
+	require.NoError(t, ValidateLog(&cfg))
 }
 
 func TestCheckNodeType(t *testing.T) {
@@ -379,3 +477,27 @@ func TestCheckNodeType(t *testing.T) {
 	require.False(t, cfg.IsDelegate())
 	require.True(t, cfg.IsLightweight())
 }
+
+func TestRedacted(t *testing.T) {
+	cfg := Default
+	cfg.Explorer.APIKeys = []string{"key1", "key2"}
+	cfg.DB.RDS.AwsPass = "pass"
+	redacted := cfg.Redacted()
+	require.Equal(t, RedactedValue, redacted.Chain.ProducerPrivKey)
+	require.Equal(t, []string{RedactedValue, RedactedValue}, redacted.Explorer.APIKeys)
+	require.Equal(t, RedactedValue, redacted.DB.RDS.AwsPass)
+	require.Equal(t, cfg.Chain.ProducerPubKey, redacted.Chain.ProducerPubKey)
+	// the original config is not changed
+	require.Equal(t, []string{"key1", "key2"}, cfg.Explorer.APIKeys)
+	require.Equal(t, "pass", cfg.DB.RDS.AwsPass)
+	require.NotEqual(t, RedactedValue, cfg.Chain.ProducerPrivKey)
+
+	// the secrets which aren't set are left empty
+	cfg.Chain.ProducerPrivKey = ""
+	cfg.Explorer.APIKeys = nil
+	cfg.DB.RDS.AwsPass = ""
+	redacted = cfg.Redacted()
+	require.Empty(t, redacted.Chain.ProducerPrivKey)
+	require.Empty(t, redacted.Explorer.APIKeys)
+	require.Empty(t, redacted.DB.RDS.AwsPass)
+}
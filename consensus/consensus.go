@@ -8,17 +8,14 @@ package consensus
 
 import (
 	"context"
-	"math/big"
+	"time"
 
-	"github.com/facebookgo/clock"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/actpool"
-	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
-	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
 	explorerapi "github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/logger"
@@ -26,7 +23,6 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/proto"
-	"github.com/iotexproject/iotex-core/state"
 )
 
 // Consensus is the interface for handling IotxConsensus view change.
@@ -36,8 +32,24 @@ type Consensus interface {
 	HandleBlockPropose(*iproto.ProposePb) error
 	HandleEndorse(*iproto.EndorsePb) error
 	Metrics() (scheme.ConsensusMetrics, error)
+	// EpochMeta returns the heights, the delegates and the numbers of blocks produced by the delegates of an epoch
+	EpochMeta(epochNum uint64) (scheme.EpochMeta, error)
+	// Pause stops proposing and endorsing blocks, while still validating and committing the blocks agreed by others
+	Pause()
+	// Resume resumes proposing and endorsing blocks
+	Resume()
+	// SetBlockInterval changes the interval of producing blocks at runtime
+	SetBlockInterval(time.Duration) error
+	// GetBlockInterval returns the active interval of producing blocks
+	GetBlockInterval() time.Duration
+	// SetProposerHook sets the hook deciding the actions of the blocks proposed by the node, or unsets it if nil. The
+	// actions returned by the hook are validated, and the ones picked from the action pool are used instead if invalid.
+	SetProposerHook(scheme.ProposerHook)
 }
 
+// ErrBlockIntervalTooShort indicates the block interval is below the configured minimum
+var ErrBlockIntervalTooShort = errors.New("block interval is too short")
+
 // IotxConsensus implements Consensus
 type IotxConsensus struct {
 	cfg    *config.Consensus
@@ -45,7 +57,8 @@ type IotxConsensus struct {
 }
 
 type optionParams struct {
-	rootChainAPI explorerapi.Explorer
+	rootChainAPI  explorerapi.Explorer
+	schemeFactory SchemeFactory
 }
 
 // Option sets Consensus construction parameter.
@@ -59,6 +72,15 @@ func WithRootChainAPI(exp explorerapi.Explorer) Option {
 	}
 }
 
+// WithSchemeFactory is an option to build the consensus scheme by the given factory instead of the one registered for
+// the configured scheme.
+func WithSchemeFactory(factory SchemeFactory) Option {
+	return func(ops *optionParams) error {
+		ops.schemeFactory = factory
+		return nil
+	}
+}
+
 // NewConsensus creates a IotxConsensus struct.
 func NewConsensus(
 	cfg *config.Config,
@@ -66,119 +88,36 @@ func NewConsensus(
 	ap actpool.ActPool,
 	p2p network.Overlay,
 	opts ...Option,
-) Consensus {
+) (Consensus, error) {
 	if bc == nil || ap == nil || p2p == nil {
-		logger.Panic().Msg("Try to attach to nil blockchain, action pool or p2p interface")
+		return nil, errors.New("try to attach to nil blockchain, action pool or p2p interface")
 	}
 
 	var ops optionParams
 	for _, opt := range opts {
 		if err := opt(&ops); err != nil {
-			return nil
+			return nil, errors.Wrap(err, "failed to apply consensus option")
 		}
 	}
 
-	cs := &IotxConsensus{cfg: &cfg.Consensus}
-	mintBlockCB := func() (*blockchain.Block, error) {
-		transfers, votes, executions := ap.PickActs()
-		logger.Debug().
-			Int("transfer", len(transfers)).
-			Int("votes", len(votes)).
-			Int("Executions", len(executions)).
-			Msg("pick actions")
-
-		blk, err := bc.MintNewBlock(transfers, votes, executions, GetAddr(cfg), "")
-		if err != nil {
-			logger.Error().Msg("Failed to mint a block")
-			return nil, err
+	factory := ops.schemeFactory
+	if factory == nil {
+		var ok bool
+		if factory, ok = lookupScheme(cfg.Consensus.Scheme); !ok {
+			return nil, errors.Errorf("unexpected IotxConsensus scheme %s", cfg.Consensus.Scheme)
 		}
-		logger.Info().
-			Uint64("height", blk.Height()).
-			Int("length", len(blk.Transfers)).
-			Msg("created a new block")
-		return blk, nil
 	}
-
-	commitBlockCB := func(blk *blockchain.Block) error {
-		err := bc.CommitBlock(blk)
-		if err != nil {
-			logger.Error().Err(err).Int64("Height", int64(blk.Height())).Msg("Failed to commit the block")
-		}
-		// Remove transfers in this block from ActPool and reset ActPool state
-		ap.Reset()
-		return err
-	}
-
-	broadcastBlockCB := func(blk *blockchain.Block) error {
-		if blkPb := blk.ConvertToBlockPb(); blkPb != nil {
-			return p2p.Broadcast(bc.ChainID(), blkPb)
-		}
-		return nil
-	}
-
-	var err error
-	clock := clock.New()
-	switch cfg.Consensus.Scheme {
-	case config.RollDPoSScheme:
-		bd := rolldpos.NewRollDPoSBuilder().
-			SetAddr(GetAddr(cfg)).
-			SetConfig(cfg.Consensus.RollDPoS).
-			SetBlockchain(bc).
-			SetActPool(ap).
-			SetClock(clock).
-			SetP2P(p2p)
-		if ops.rootChainAPI != nil {
-			bd = bd.SetCandidatesByHeightFunc(func(h uint64) ([]*state.Candidate, error) {
-				rawcs, err := ops.rootChainAPI.GetCandidateMetricsByHeight(int64(h))
-				if err != nil {
-					return nil, errors.Wrapf(err, "error when get root chain candidates at height %d", h)
-				}
-				cs := make([]*state.Candidate, 0, len(rawcs.Candidates))
-				for _, rawc := range rawcs.Candidates {
-					// TODO: this is a short term walk around. We don't need to convert root chain address to sub chain
-					// address. Instead we should use public key to identify the block producer
-					rootChainAddr, err := address.IotxAddressToAddress(rawc.Address)
-					if err != nil {
-						return nil, errors.Wrapf(err, "error when get converting iotex address to address")
-					}
-					subChainAddr := address.New(cfg.Chain.ID, rootChainAddr.Payload())
-					pubKey, err := keypair.DecodePublicKey(rawc.PubKey)
-					if err != nil {
-						logger.Error().Err(err).Msg("error when convert candidate PublicKey")
-					}
-					cs = append(cs, &state.Candidate{
-						Address:          subChainAddr.IotxAddress(),
-						PublicKey:        pubKey,
-						Votes:            big.NewInt(rawc.TotalVote),
-						CreationHeight:   uint64(rawc.CreationHeight),
-						LastUpdateHeight: uint64(rawc.LastUpdateHeight),
-					})
-				}
-				return cs, nil
-			})
-		}
-		cs.scheme, err = bd.Build()
-		if err != nil {
-			logger.Panic().Err(err).Msg("error when constructing RollDPoS")
-		}
-	case config.NOOPScheme:
-		cs.scheme = scheme.NewNoop()
-	case config.StandaloneScheme:
-		cs.scheme = scheme.NewStandalone(
-			mintBlockCB,
-			commitBlockCB,
-			broadcastBlockCB,
-			bc,
-			cfg.Consensus.BlockCreationInterval,
-		)
-	default:
-		logger.Error().
-			Str("scheme", cfg.Consensus.Scheme).
-			Msg("Unexpected IotxConsensus scheme")
-		return nil
+	sch, err := factory(SchemeParams{
+		Config:       cfg,
+		Blockchain:   bc,
+		ActPool:      ap,
+		P2P:          p2p,
+		RootChainAPI: ops.rootChainAPI,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to construct consensus scheme %s", cfg.Consensus.Scheme)
 	}
-
-	return cs
+	return &IotxConsensus{cfg: &cfg.Consensus, scheme: sch}, nil
 }
 
 // Start starts running the consensus algorithm
@@ -212,6 +151,61 @@ func (c *IotxConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 	return c.scheme.Metrics()
 }
 
+// EpochMeta returns the metadata of an epoch
+func (c *IotxConsensus) EpochMeta(epochNum uint64) (scheme.EpochMeta, error) {
+	return c.scheme.EpochMeta(epochNum)
+}
+
+// Pause pauses the consensus scheme
+func (c *IotxConsensus) Pause() {
+	logger.Info().
+		Str("scheme", c.cfg.Scheme).
+		Msg("Pausing IotxConsensus scheme")
+	c.scheme.Pause()
+}
+
+// Resume resumes the consensus scheme
+func (c *IotxConsensus) Resume() {
+	logger.Info().
+		Str("scheme", c.cfg.Scheme).
+		Msg("Resuming IotxConsensus scheme")
+	c.scheme.Resume()
+}
+
+// SetBlockInterval changes the interval of producing blocks, which should be no less than the configured minimum
+func (c *IotxConsensus) SetBlockInterval(interval time.Duration) error {
+	if interval < c.cfg.MinBlockInterval {
+		return errors.Wrapf(
+			ErrBlockIntervalTooShort,
+			"block interval %s is less than the minimum %s",
+			interval,
+			c.cfg.MinBlockInterval,
+		)
+	}
+	if err := c.scheme.SetBlockInterval(interval); err != nil {
+		return errors.Wrapf(err, "failed to set block interval of scheme %s", c.cfg.Scheme)
+	}
+	logger.Info().
+		Str("scheme", c.cfg.Scheme).
+		Dur("interval", interval).
+		Msg("Changed the block interval")
+	return nil
+}
+
+// GetBlockInterval returns the active interval of producing blocks
+func (c *IotxConsensus) GetBlockInterval() time.Duration {
+	return c.scheme.GetBlockInterval()
+}
+
+// SetProposerHook sets the hook deciding the actions of the blocks proposed by the node
+func (c *IotxConsensus) SetProposerHook(hook scheme.ProposerHook) {
+	logger.Info().
+		Str("scheme", c.cfg.Scheme).
+		Bool("set", hook != nil).
+		Msg("Changed the proposer hook")
+	c.scheme.SetProposerHook(hook)
+}
+
 // HandleBlockPropose handles a proposed block
 func (c *IotxConsensus) HandleBlockPropose(propose *iproto.ProposePb) error {
 	return c.scheme.HandleBlockPropose(propose)
@@ -227,23 +221,23 @@ func (c *IotxConsensus) Scheme() scheme.Scheme {
 	return c.scheme
 }
 
-// GetAddr returns the iotex address
-func GetAddr(cfg *config.Config) *iotxaddress.Address {
+// GetAddr returns the iotex address of the block producer configured
+func GetAddr(cfg *config.Config) (*iotxaddress.Address, error) {
 	addr, err := cfg.BlockchainAddress()
 	if err != nil {
-		logger.Panic().Err(err).Msg("Fail to create new consensus")
+		return nil, errors.Wrap(err, "failed to get the producer's address")
 	}
 	pk, err := keypair.DecodePublicKey(cfg.Chain.ProducerPubKey)
 	if err != nil {
-		logger.Panic().Err(err).Msg("Fail to create new consensus")
+		return nil, errors.Wrap(err, "failed to decode the producer's public key")
 	}
 	sk, err := keypair.DecodePrivateKey(cfg.Chain.ProducerPrivKey)
 	if err != nil {
-		logger.Panic().Err(err).Msg("Fail to create new consensus")
+		return nil, errors.Wrap(err, "failed to decode the producer's private key")
 	}
 	return &iotxaddress.Address{
 		PublicKey:  pk,
 		PrivateKey: sk,
 		RawAddress: addr.IotxAddress(),
-	}
+	}, nil
 }
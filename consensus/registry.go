@@ -0,0 +1,180 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	explorerapi "github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/network"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// SchemeParams are the components which a consensus scheme is built on
+type SchemeParams struct {
+	Config     *config.Config
+	Blockchain blockchain.Blockchain
+	ActPool    actpool.ActPool
+	P2P        network.Overlay
+	// RootChainAPI is the explorer API of the root chain, which is nil unless the node runs a sub chain
+	RootChainAPI explorerapi.Explorer
+}
+
+// SchemeFactory builds a consensus scheme
+type SchemeFactory func(SchemeParams) (scheme.Scheme, error)
+
+var (
+	schemesMutex sync.RWMutex
+	schemes      = make(map[string]SchemeFactory)
+)
+
+func init() {
+	RegisterScheme(config.RollDPoSScheme, newRollDPoS)
+	RegisterScheme(config.NOOPScheme, func(SchemeParams) (scheme.Scheme, error) { return scheme.NewNoop(), nil })
+	RegisterScheme(config.StandaloneScheme, newStandalone)
+}
+
+// RegisterScheme makes the consensus scheme built by the factory available under the name, which is what the
+// Consensus.Scheme config refers to. It panics if the name is registered twice or the factory is nil.
+func RegisterScheme(name string, factory SchemeFactory) {
+	schemesMutex.Lock()
+	defer schemesMutex.Unlock()
+	if factory == nil {
+		logger.Panic().Str("scheme", name).Msg("Registering a nil consensus scheme factory")
+	}
+	if _, ok := schemes[name]; ok {
+		logger.Panic().Str("scheme", name).Msg("Registering a consensus scheme twice")
+	}
+	schemes[name] = factory
+}
+
+// lookupScheme returns the factory registered under the name
+func lookupScheme(name string) (SchemeFactory, bool) {
+	schemesMutex.RLock()
+	defer schemesMutex.RUnlock()
+	factory, ok := schemes[name]
+	return factory, ok
+}
+
+func newRollDPoS(params SchemeParams) (scheme.Scheme, error) {
+	cfg := params.Config
+	addr, err := GetAddr(cfg)
+	if err != nil {
+		return nil, err
+	}
+	bd := rolldpos.NewRollDPoSBuilder().
+		SetAddr(addr).
+		SetConfig(cfg.Consensus.RollDPoS).
+		SetBlockchain(params.Blockchain).
+		SetActPool(params.ActPool).
+		SetClock(clock.New()).
+		SetP2P(params.P2P)
+	if rootChainAPI := params.RootChainAPI; rootChainAPI != nil {
+		bd = bd.SetCandidatesByHeightFunc(func(h uint64) ([]*state.Candidate, error) {
+			rawcs, err := rootChainAPI.GetCandidateMetricsByHeight(int64(h))
+			if err != nil {
+				return nil, errors.Wrapf(err, "error when get root chain candidates at height %d", h)
+			}
+			cs := make([]*state.Candidate, 0, len(rawcs.Candidates))
+			for _, rawc := range rawcs.Candidates {
+				// TODO: this is a short term walk around. We don't need to convert root chain address to sub chain
+				// address. Instead we should use public key to identify the block producer
+				rootChainAddr, err := address.IotxAddressToAddress(rawc.Address)
+				if err != nil {
+					return nil, errors.Wrapf(err, "error when get converting iotex address to address")
+				}
+				subChainAddr := address.New(cfg.Chain.ID, rootChainAddr.Payload())
+				pubKey, err := keypair.DecodePublicKey(rawc.PubKey)
+				if err != nil {
+					logger.Error().Err(err).Msg("error when convert candidate PublicKey")
+				}
+				cs = append(cs, &state.Candidate{
+					Address:          subChainAddr.IotxAddress(),
+					PublicKey:        pubKey,
+					Votes:            big.NewInt(rawc.TotalVote),
+					CreationHeight:   uint64(rawc.CreationHeight),
+					LastUpdateHeight: uint64(rawc.LastUpdateHeight),
+				})
+			}
+			return cs, nil
+		})
+	}
+	sch, err := bd.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when constructing RollDPoS")
+	}
+	return sch, nil
+}
+
+func newStandalone(params SchemeParams) (scheme.Scheme, error) {
+	cfg := params.Config
+	bc := params.Blockchain
+	ap := params.ActPool
+	addr, err := GetAddr(cfg)
+	if err != nil {
+		return nil, err
+	}
+	mintBlockCB := func(hook scheme.ProposerHook) (*blockchain.Block, error) {
+		transfers, votes, executions := ap.PickActions(action.GasLimit)
+		logger.Debug().
+			Int("transfer", len(transfers)).
+			Int("votes", len(votes)).
+			Int("Executions", len(executions)).
+			Msg("pick actions")
+		action.CanonicalOrderByType(transfers, votes, executions)
+		transfers, votes, executions = scheme.ApplyProposerHook(hook, transfers, votes, executions)
+
+		blk, err := bc.MintNewBlock(transfers, votes, executions, addr, "")
+		if err != nil {
+			logger.Error().Msg("Failed to mint a block")
+			return nil, err
+		}
+		logger.Info().
+			Uint64("height", blk.Height()).
+			Int("length", len(blk.Transfers)).
+			Msg("created a new block")
+		return blk, nil
+	}
+
+	commitBlockCB := func(blk *blockchain.Block) error {
+		err := bc.CommitBlock(blk)
+		if err != nil {
+			logger.Error().Err(err).Int64("Height", int64(blk.Height())).Msg("Failed to commit the block")
+		}
+		// Remove transfers in this block from ActPool and reset ActPool state
+		ap.Reset()
+		return err
+	}
+
+	broadcastBlockCB := func(blk *blockchain.Block) error {
+		if blkPb := blk.ConvertToBlockPb(); blkPb != nil {
+			return params.P2P.Broadcast(bc.ChainID(), blkPb)
+		}
+		return nil
+	}
+
+	return scheme.NewStandalone(
+		mintBlockCB,
+		commitBlockCB,
+		broadcastBlockCB,
+		bc,
+		cfg.Consensus.BlockCreationInterval,
+	), nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+)
+
+func TestRegisterScheme(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	cfg := config.Default
+
+	// the built-in schemes are registered
+	for _, name := range []string{config.RollDPoSScheme, config.NOOPScheme, config.StandaloneScheme} {
+		_, ok := lookupScheme(name)
+		require.True(ok, name)
+	}
+	noop := func(SchemeParams) (scheme.Scheme, error) { return scheme.NewNoop(), nil }
+	require.Panics(func() { RegisterScheme(config.NOOPScheme, noop) })
+	require.Panics(func() { RegisterScheme("TEST_NIL", nil) })
+
+	// a registered scheme is picked by the config
+	var params SchemeParams
+	RegisterScheme("TEST_REGISTRY", func(p SchemeParams) (scheme.Scheme, error) {
+		params = p
+		return scheme.NewNoop(), nil
+	})
+	cfg.Consensus.Scheme = "TEST_REGISTRY"
+	cs, err := NewConsensus(&cfg, bc, ap, p2p)
+	require.NoError(err)
+	require.NotNil(cs)
+	require.Equal(&cfg, params.Config)
+	require.Equal(bc, params.Blockchain)
+	require.Nil(params.RootChainAPI)
+
+	cfg.Consensus.Scheme = "TEST_UNKNOWN"
+	cs, err = NewConsensus(&cfg, bc, ap, p2p)
+	require.Error(err)
+	require.Nil(cs)
+
+	// the factory given by the option overrides the registered ones
+	called := false
+	cs, err = NewConsensus(&cfg, bc, ap, p2p, WithSchemeFactory(func(SchemeParams) (scheme.Scheme, error) {
+		called = true
+		return scheme.NewNoop(), nil
+	}))
+	require.NoError(err)
+	require.NotNil(cs)
+	require.True(called)
+	cs, err = NewConsensus(&cfg, bc, ap, p2p, WithSchemeFactory(func(SchemeParams) (scheme.Scheme, error) {
+		return nil, errors.New("failed to build")
+	}))
+	require.Error(err)
+	require.Nil(cs)
+}
+
+func TestNewConsensusErrors(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	cfg := config.Default
+
+	cs, err := NewConsensus(&cfg, nil, ap, p2p)
+	require.Error(err)
+	require.Nil(cs)
+
+	// a bad producer key fails the construction instead of crashing the process
+	cfg.Chain.ProducerPrivKey = "bad key"
+	for _, name := range []string{config.RollDPoSScheme, config.StandaloneScheme} {
+		cfg.Consensus.Scheme = name
+		cs, err = NewConsensus(&cfg, bc, ap, p2p)
+		require.Error(err, name)
+		require.Nil(cs, name)
+	}
+	_, err = GetAddr(&cfg)
+	require.Error(err)
+	_, err = GetAddr(&config.Default)
+	require.NoError(err)
+}
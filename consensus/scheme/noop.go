@@ -8,6 +8,7 @@ package scheme
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -34,6 +35,26 @@ func (n *Noop) Stop(_ context.Context) error { return nil }
 // SetDoneStream does nothing for Noop (only used in simulator)
 func (n *Noop) SetDoneStream(done chan bool) {}
 
+// Pause does nothing here
+func (n *Noop) Pause() {}
+
+// Resume does nothing here
+func (n *Noop) Resume() {}
+
+// SetBlockInterval is not implemented for noop scheme
+func (n *Noop) SetBlockInterval(_ time.Duration) error {
+	return errors.Wrapf(
+		errcode.ErrNotImplemented,
+		"noop scheme does not produce blocks",
+	)
+}
+
+// GetBlockInterval returns 0 as noop scheme does not produce blocks
+func (n *Noop) GetBlockInterval() time.Duration { return 0 }
+
+// SetProposerHook does nothing as noop scheme does not produce blocks
+func (n *Noop) SetProposerHook(_ ProposerHook) {}
+
 // HandleBlockPropose handles incoming block propose
 func (n *Noop) HandleBlockPropose(propose *iproto.ProposePb) error {
 	logger.Warn().Msg("Noop scheme does not handle incoming block propose requests")
@@ -53,3 +74,11 @@ func (n *Noop) Metrics() (ConsensusMetrics, error) {
 		"noop scheme does not supported metrics yet",
 	)
 }
+
+// EpochMeta is not implemented for noop scheme
+func (n *Noop) EpochMeta(epochNum uint64) (EpochMeta, error) {
+	return EpochMeta{}, errors.Wrapf(
+		errcode.ErrNotImplemented,
+		"noop scheme does not supported epochs",
+	)
+}
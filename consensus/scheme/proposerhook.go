@@ -0,0 +1,124 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package scheme
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// ProposerHook lets external logic decide the actions of the blocks proposed by the node. It is called with the
+// actions picked from the action pool in action.CanonicalOrder, and returns the ones to put into the block in the
+// order wanted, which must be a subset of the given ones. If the block size or number of actions is limited by the
+// config, the returned actions are still cut to fit and sorted back into action.CanonicalOrder.
+type ProposerHook interface {
+	ProposeActions(
+		transfers []*action.Transfer,
+		votes []*action.Vote,
+		executions []*action.Execution,
+	) ([]*action.Transfer, []*action.Vote, []*action.Execution, error)
+}
+
+// ApplyProposerHook returns the actions decided by the hook out of the given ones, or the given ones if the hook is
+// nil. If the hook fails, or returns the actions which are not among the given ones, are duplicated, or leave a gap
+// in the nonces of a sender, the given ones are returned instead, so that the hook cannot make the block invalid.
+func ApplyProposerHook(
+	hook ProposerHook,
+	transfers []*action.Transfer,
+	votes []*action.Vote,
+	executions []*action.Execution,
+) ([]*action.Transfer, []*action.Vote, []*action.Execution) {
+	if hook == nil {
+		return transfers, votes, executions
+	}
+	candidates, _ := actionList(transfers, votes, executions)
+	hookTransfers, hookVotes, hookExecutions, err := hook.ProposeActions(
+		append([]*action.Transfer(nil), transfers...),
+		append([]*action.Vote(nil), votes...),
+		append([]*action.Execution(nil), executions...),
+	)
+	var proposed []action.Action
+	if err == nil {
+		proposed, err = actionList(hookTransfers, hookVotes, hookExecutions)
+	}
+	if err == nil {
+		err = validateProposedActions(candidates, proposed)
+	}
+	if err != nil {
+		logger.Error().Err(err).Msg("Ignore the actions proposed by the proposer hook")
+		return transfers, votes, executions
+	}
+	return hookTransfers, hookVotes, hookExecutions
+}
+
+// validateProposedActions checks that the proposed actions are distinct ones among the candidates, and that the
+// proposed nonces of every sender are the lowest ones of the candidates of the sender
+func validateProposedActions(candidates []action.Action, proposed []action.Action) error {
+	candidateNonces := make(map[string][]uint64)
+	candidateHashes := make(map[hash.Hash32B]bool)
+	for _, act := range candidates {
+		candidateHashes[act.Hash()] = true
+		candidateNonces[act.SrcAddr()] = append(candidateNonces[act.SrcAddr()], act.Nonce())
+	}
+	proposedNonces := make(map[string][]uint64)
+	proposedHashes := make(map[hash.Hash32B]bool)
+	for _, act := range proposed {
+		h := act.Hash()
+		if !candidateHashes[h] {
+			return errors.Errorf("proposed action %x is not among the picked ones", h)
+		}
+		if proposedHashes[h] {
+			return errors.Errorf("proposed action %x is duplicated", h)
+		}
+		proposedHashes[h] = true
+		proposedNonces[act.SrcAddr()] = append(proposedNonces[act.SrcAddr()], act.Nonce())
+	}
+	for sender, nonces := range proposedNonces {
+		all := candidateNonces[sender]
+		sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+		sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+		for i, nonce := range nonces {
+			if nonce != all[i] {
+				return errors.Errorf("proposed actions of %s leave a gap before nonce %d", sender, nonce)
+			}
+		}
+	}
+	return nil
+}
+
+// actionList puts the actions of all types into one list, and returns an error if any of them is nil
+func actionList(
+	transfers []*action.Transfer,
+	votes []*action.Vote,
+	executions []*action.Execution,
+) ([]action.Action, error) {
+	acts := make([]action.Action, 0, len(transfers)+len(votes)+len(executions))
+	for _, tsf := range transfers {
+		if tsf == nil {
+			return nil, errors.New("transfer is nil")
+		}
+		acts = append(acts, tsf)
+	}
+	for _, vote := range votes {
+		if vote == nil {
+			return nil, errors.New("vote is nil")
+		}
+		acts = append(acts, vote)
+	}
+	for _, execution := range executions {
+		if execution == nil {
+			return nil, errors.New("execution is nil")
+		}
+		acts = append(acts, execution)
+	}
+	return acts, nil
+}
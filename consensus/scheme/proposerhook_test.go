@@ -0,0 +1,94 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package scheme
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/action"
+)
+
+type hookFunc func([]*action.Transfer, []*action.Vote, []*action.Execution) (
+	[]*action.Transfer, []*action.Vote, []*action.Execution, error)
+
+func (f hookFunc) ProposeActions(
+	transfers []*action.Transfer,
+	votes []*action.Vote,
+	executions []*action.Execution,
+) ([]*action.Transfer, []*action.Vote, []*action.Execution, error) {
+	return f(transfers, votes, executions)
+}
+
+func TestApplyProposerHook(t *testing.T) {
+	require := require.New(t)
+
+	newTsf := func(sender string, nonce uint64) *action.Transfer {
+		tsf, err := action.NewTransfer(nonce, big.NewInt(10), sender, "io1recipient", []byte{}, uint64(100000),
+			big.NewInt(10))
+		require.NoError(err)
+		return tsf
+	}
+	a1 := newTsf("io1a", 1)
+	a2 := newTsf("io1a", 2)
+	b1 := newTsf("io1b", 1)
+	vote, err := action.NewVote(3, "io1a", "io1a", uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	transfers := []*action.Transfer{a1, a2, b1}
+	votes := []*action.Vote{vote}
+
+	apply := func(hook ProposerHook) ([]*action.Transfer, []*action.Vote, []*action.Execution) {
+		return ApplyProposerHook(hook, transfers, votes, nil)
+	}
+	propose := func(tsfs []*action.Transfer, vs []*action.Vote, err error) ProposerHook {
+		return hookFunc(func([]*action.Transfer, []*action.Vote, []*action.Execution) (
+			[]*action.Transfer, []*action.Vote, []*action.Execution, error) {
+			return tsfs, vs, nil, err
+		})
+	}
+
+	// no hook
+	tsfs, vs, exes := apply(nil)
+	require.Equal(transfers, tsfs)
+	require.Equal(votes, vs)
+	require.Nil(exes)
+
+	// the hook reorders the transfers and leaves out the vote and the highest nonce of a sender
+	tsfs, vs, _ = apply(propose([]*action.Transfer{b1, a1}, nil, nil))
+	require.Equal([]*action.Transfer{b1, a1}, tsfs)
+	require.Empty(vs)
+
+	// the hook changing the given slices doesn't change the ones picked
+	tsfs, _, _ = apply(hookFunc(func(tsfs []*action.Transfer, vs []*action.Vote, exes []*action.Execution) (
+		[]*action.Transfer, []*action.Vote, []*action.Execution, error) {
+		tsfs[0], tsfs[2] = tsfs[2], tsfs[0]
+		return tsfs, vs, exes, nil
+	}))
+	require.Equal([]*action.Transfer{b1, a2, a1}, tsfs)
+	require.Equal([]*action.Transfer{a1, a2, b1}, transfers)
+
+	// the invalid proposals are ignored
+	for _, hook := range []ProposerHook{
+		propose(nil, nil, errors.New("hook failed")),
+		// not picked
+		propose([]*action.Transfer{a1, newTsf("io1c", 1)}, nil, nil),
+		// duplicated
+		propose([]*action.Transfer{a1, a1}, nil, nil),
+		// nil
+		propose([]*action.Transfer{a1, nil}, nil, nil),
+		// a gap in the nonces of io1a
+		propose([]*action.Transfer{a2, b1}, nil, nil),
+		propose([]*action.Transfer{a1, b1}, votes, nil),
+	} {
+		tsfs, vs, _ = apply(hook)
+		require.Equal(transfers, tsfs)
+		require.Equal(votes, vs)
+	}
+}
@@ -540,14 +540,21 @@ func (m *cFSM) handleStartRoundEvt(_ fsm.Event) (fsm.State, error) {
 			Msg("error when getting the proposer")
 		return sInvalid, err
 	}
+	var number uint64
+	if m.ctx.round.height == height {
+		number = m.ctx.round.number + 1
+	}
+	m.ctx.roundMutex.Lock()
 	m.ctx.round = roundCtx{
+		number:           number,
 		height:           height,
 		timestamp:        m.ctx.clock.Now(),
 		proposalEndorses: make(map[hash.Hash32B]map[string]bool),
 		commitEndorses:   make(map[hash.Hash32B]map[string]bool),
 		proposer:         proposer,
 	}
-	if proposer == m.ctx.addr.RawAddress {
+	m.ctx.roundMutex.Unlock()
+	if proposer == m.ctx.addr.RawAddress && m.ctx.startProposal() {
 		logger.Info().
 			Str("proposer", proposer).
 			Uint64("height", height).
@@ -556,16 +563,24 @@ func (m *cFSM) handleStartRoundEvt(_ fsm.Event) (fsm.State, error) {
 		// TODO: we may need timeout event for block producer too
 		return sInitPropose, nil
 	}
-	logger.Info().
-		Str("proposer", proposer).
-		Uint64("height", height).
-		Msg("current node is not the proposer")
+	if proposer == m.ctx.addr.RawAddress {
+		logger.Warn().
+			Str("proposer", proposer).
+			Uint64("height", height).
+			Msg("current node is the proposer, but it is paused")
+	} else {
+		logger.Info().
+			Str("proposer", proposer).
+			Uint64("height", height).
+			Msg("current node is not the proposer")
+	}
 	// Setup timeout for waiting for proposed block
 	m.produce(m.newTimeoutEvt(eProposeBlockTimeout, m.ctx.round.height), m.ctx.cfg.AcceptProposeTTL)
 	return sAcceptPropose, nil
 }
 
 func (m *cFSM) handleInitBlockEvt(evt fsm.Event) (fsm.State, error) {
+	defer m.ctx.finishProposal()
 	blk, err := m.ctx.mintBlock()
 	if err != nil {
 		return sInvalid, errors.Wrap(err, "error when minting a block")
@@ -648,6 +663,10 @@ func (m *cFSM) handleProposeBlockEvt(evt fsm.Event) (fsm.State, error) {
 		return sAcceptPropose, nil
 	}
 	m.ctx.round.block = proposeBlkEvt.block
+	if m.ctx.isPaused() {
+		// Don't endorse the proposal while being paused
+		return m.moveToAcceptProposalEndorse()
+	}
 	endorseEvt, err := m.newEndorseProposalEvt(m.ctx.round.block.HashBlock(), true)
 	if err != nil {
 		return sInvalid, errors.Wrap(err, "error when generating new endorse proposal event")
@@ -727,6 +746,10 @@ func (m *cFSM) handleEndorseProposalEvt(evt fsm.Event) (fsm.State, error) {
 		return sAcceptProposalEndorse, nil
 	}
 	// Reached the agreement
+	if m.ctx.isPaused() {
+		// Don't endorse the commit while being paused
+		return m.moveToAcceptCommitEndorse()
+	}
 	cEvt, err := m.newEndorseCommitEvt(blkHash, yes && !no)
 	if err != nil {
 		return sInvalid, errors.Wrap(err, "failed to generate endorse commit event")
@@ -891,10 +914,11 @@ func (m *cFSM) produceStartRoundEvt() error {
 	}
 	// If the proposal interval is not set (not zero), the next round will only be started after the configured duration
 	// after last block's creation time, so that we could keep the constant
-	if duration >= m.ctx.cfg.ProposerInterval {
+	interval := m.ctx.proposerInterval()
+	if duration >= interval {
 		m.produce(m.newCEvt(eStartRound), 0)
 	} else {
-		m.produce(m.newCEvt(eStartRound), m.ctx.cfg.ProposerInterval-duration)
+		m.produce(m.newCEvt(eStartRound), interval-duration)
 	}
 	return nil
 }
@@ -250,6 +250,74 @@ func TestStartRoundEvt(t *testing.T) {
 		assert.NotNil(t, cfsm.ctx.round.commitEndorses, s)
 		assert.Equal(t, eProposeBlockTimeout, (<-cfsm.evtq).Type())
 	})
+	t.Run("is-proposer-but-paused", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		delegates := make([]string, 4)
+		for i := 0; i < 4; i++ {
+			delegates[i] = testAddrs[i].RawAddress
+		}
+		cfsm := newTestCFSM(t, testAddrs[2], testAddrs[2], ctrl, delegates, nil, nil, clock.New())
+		cfsm.ctx.epoch = epochCtx{
+			delegates:    delegates,
+			num:          uint64(1),
+			height:       uint64(1),
+			numSubEpochs: uint(1),
+		}
+		r := &RollDPoS{cfsm: cfsm, ctx: cfsm.ctx}
+		r.Pause()
+		s, err := cfsm.handleStartRoundEvt(cfsm.newCEvt(eStartRound))
+		require.NoError(t, err)
+		require.Equal(t, sAcceptPropose, s)
+		assert.Equal(t, eProposeBlockTimeout, (<-cfsm.evtq).Type())
+
+		r.Resume()
+		s, err = cfsm.handleStartRoundEvt(cfsm.newCEvt(eStartRound))
+		require.NoError(t, err)
+		require.Equal(t, sInitPropose, s)
+		// the round at the same height is counted
+		assert.Equal(t, uint64(1), cfsm.ctx.round.number)
+		assert.Equal(t, eInitBlock, (<-cfsm.evtq).Type())
+	})
+	t.Run("pause-during-proposal", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		delegates := make([]string, 4)
+		for i := 0; i < 4; i++ {
+			delegates[i] = testAddrs[i].RawAddress
+		}
+		cfsm := newTestCFSM(t, testAddrs[2], testAddrs[2], ctrl, delegates, nil, nil, clock.New())
+		cfsm.ctx.epoch = epochCtx{
+			delegates:    delegates,
+			num:          uint64(1),
+			height:       uint64(1),
+			numSubEpochs: uint(1),
+		}
+		s, err := cfsm.handleStartRoundEvt(cfsm.newCEvt(eStartRound))
+		require.NoError(t, err)
+		require.Equal(t, sInitPropose, s)
+
+		r := &RollDPoS{cfsm: cfsm, ctx: cfsm.ctx}
+		paused := make(chan struct{})
+		go func() {
+			r.Pause()
+			close(paused)
+		}()
+		select {
+		case <-paused:
+			t.Fatal("pause returns before the proposal is finished")
+		case <-time.After(50 * time.Millisecond):
+		}
+		cfsm.ctx.finishProposal()
+		select {
+		case <-paused:
+		case <-time.After(time.Second):
+			t.Fatal("pause doesn't return after the proposal is finished")
+		}
+		assert.True(t, cfsm.ctx.isPaused())
+	})
 }
 
 func TestHandleInitBlockEvt(t *testing.T) {
@@ -904,7 +972,7 @@ func newTestCFSM(
 		},
 		func(actPool *mock_actpool.MockActPool) {
 			actPool.EXPECT().
-				PickActs().
+				PickActions(gomock.Any()).
 				Return([]*action.Transfer{transfer}, []*action.Vote{vote}, []*action.Execution{}).
 				AnyTimes()
 			actPool.EXPECT().Reset().AnyTimes()
@@ -8,6 +8,7 @@ package rolldpos
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/facebookgo/clock"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/action"
 	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
@@ -58,7 +60,19 @@ type rollDPoSCtx struct {
 	p2p     network.Overlay
 	epoch   epochCtx
 	round   roundCtx
-	clock   clock.Clock
+	// roundMutex guards round against the reads from outside the FSM goroutine
+	roundMutex sync.RWMutex
+	// pauseMutex guards paused and proposed
+	pauseMutex sync.Mutex
+	paused     bool
+	// proposed is closed when the proposal in progress is finished, or nil if there isn't one
+	proposed chan struct{}
+	// intervalMutex guards cfg.ProposerInterval, which could be changed at runtime
+	intervalMutex sync.RWMutex
+	// hookMutex guards proposerHook, which could be changed at runtime
+	hookMutex    sync.RWMutex
+	proposerHook scheme.ProposerHook
+	clock        clock.Clock
 	// candidatesByHeightFunc is only used for testing purpose
 	candidatesByHeightFunc func(uint64) ([]*state.Candidate, error)
 	sync                   blocksync.BlockSync
@@ -108,6 +122,41 @@ func (ctx *rollDPoSCtx) calcEpochNumAndHeight() (uint64, uint64, error) {
 	return epochNum, epochHeight, nil
 }
 
+// startProposal marks a proposal in progress if the node isn't paused, and returns whether it could propose
+func (ctx *rollDPoSCtx) startProposal() bool {
+	ctx.pauseMutex.Lock()
+	defer ctx.pauseMutex.Unlock()
+	if ctx.paused {
+		return false
+	}
+	ctx.proposed = make(chan struct{})
+	return true
+}
+
+// finishProposal marks the proposal in progress as finished
+func (ctx *rollDPoSCtx) finishProposal() {
+	ctx.pauseMutex.Lock()
+	defer ctx.pauseMutex.Unlock()
+	if ctx.proposed != nil {
+		close(ctx.proposed)
+		ctx.proposed = nil
+	}
+}
+
+// proposerInterval returns the interval between proposals
+func (ctx *rollDPoSCtx) proposerInterval() time.Duration {
+	ctx.intervalMutex.RLock()
+	defer ctx.intervalMutex.RUnlock()
+	return ctx.cfg.ProposerInterval
+}
+
+// isPaused returns whether the node is paused
+func (ctx *rollDPoSCtx) isPaused() bool {
+	ctx.pauseMutex.Lock()
+	defer ctx.pauseMutex.Unlock()
+	return ctx.paused
+}
+
 // generateDKG generates a pseudo DKG bytes
 func (ctx *rollDPoSCtx) generateDKG() (hash.DKGHash, error) {
 	var dkg hash.DKGHash
@@ -147,7 +196,7 @@ func (ctx *rollDPoSCtx) calcProposer(height uint64, delegates []string) (string,
 	if err != nil {
 		return "", errors.Wrap(err, "error when computing the duration since last block time")
 	}
-	timeSlotIndex := int64(duration/ctx.cfg.ProposerInterval) - 1
+	timeSlotIndex := int64(duration/ctx.proposerInterval()) - 1
 	if timeSlotIndex < 0 {
 		timeSlotIndex = 0
 	}
@@ -159,11 +208,16 @@ func (ctx *rollDPoSCtx) calcProposer(height uint64, delegates []string) (string,
 
 // mintBlock picks the actions and creates an block to propose
 func (ctx *rollDPoSCtx) mintBlock() (*blockchain.Block, error) {
-	transfers, votes, executions := ctx.actPool.PickActs()
+	transfers, votes, executions := ctx.actPool.PickActions(action.GasLimit)
 	logger.Debug().
 		Int("transfer", len(transfers)).
 		Int("votes", len(votes)).
 		Msg("pick actions from the action pool")
+	action.CanonicalOrderByType(transfers, votes, executions)
+	ctx.hookMutex.RLock()
+	hook := ctx.proposerHook
+	ctx.hookMutex.RUnlock()
+	transfers, votes, executions = scheme.ApplyProposerHook(hook, transfers, votes, executions)
 	blk, err := ctx.chain.MintNewBlock(transfers, votes, executions, ctx.addr, "")
 	if err != nil {
 		logger.Error().Msg("error when minting a block")
@@ -230,6 +284,9 @@ type epochCtx struct {
 
 // roundCtx keeps the context data for the current round and block.
 type roundCtx struct {
+	// number is the ordinal number of the round at the height, which is increased when the height isn't produced in
+	// the previous round
+	number           uint64
 	height           uint64
 	timestamp        time.Time
 	block            *blockchain.Block
@@ -255,7 +312,52 @@ func (r *RollDPoS) Start(ctx context.Context) error {
 
 // Stop stops RollDPoS consensus
 func (r *RollDPoS) Stop(ctx context.Context) error {
-	return errors.Wrap(r.cfsm.Stop(ctx), "error when stopping the consensus FSM")
+	err := r.cfsm.Stop(ctx)
+	// the proposal in progress won't be finished once the FSM is stopped
+	r.ctx.finishProposal()
+	return errors.Wrap(err, "error when stopping the consensus FSM")
+}
+
+// Pause stops the node from proposing and endorsing blocks. It waits for the proposal in progress to be finished.
+func (r *RollDPoS) Pause() {
+	r.ctx.pauseMutex.Lock()
+	r.ctx.paused = true
+	proposed := r.ctx.proposed
+	r.ctx.pauseMutex.Unlock()
+	if proposed != nil {
+		<-proposed
+	}
+	logger.Info().Msg("RollDPoS is paused")
+}
+
+// SetBlockInterval changes the proposer interval, which takes effect from the next round. When doing time based
+// rotation, all the delegates should be set to the same interval, otherwise they won't agree on the proposer.
+func (r *RollDPoS) SetBlockInterval(interval time.Duration) error {
+	r.ctx.intervalMutex.Lock()
+	defer r.ctx.intervalMutex.Unlock()
+	r.ctx.cfg.ProposerInterval = interval
+	return nil
+}
+
+// GetBlockInterval returns the active proposer interval
+func (r *RollDPoS) GetBlockInterval() time.Duration {
+	return r.ctx.proposerInterval()
+}
+
+// SetProposerHook sets the hook deciding the actions of the blocks proposed by the node, which takes effect from the
+// next proposal
+func (r *RollDPoS) SetProposerHook(hook scheme.ProposerHook) {
+	r.ctx.hookMutex.Lock()
+	defer r.ctx.hookMutex.Unlock()
+	r.ctx.proposerHook = hook
+}
+
+// Resume lets the node propose and endorse blocks again
+func (r *RollDPoS) Resume() {
+	r.ctx.pauseMutex.Lock()
+	defer r.ctx.pauseMutex.Unlock()
+	r.ctx.paused = false
+	logger.Info().Msg("RollDPoS is resumed")
 }
 
 // HandleBlockPropose handles incoming block propose
@@ -313,15 +415,63 @@ func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 
 	crypto.SortCandidates(candidateAddresses, epochNum)
 
+	r.ctx.roundMutex.RLock()
+	round := r.ctx.round.number
+	roundStartTime := r.ctx.round.timestamp
+	r.ctx.roundMutex.RUnlock()
+
 	return scheme.ConsensusMetrics{
 		LatestEpoch:         epochNum,
 		LatestHeight:        height,
 		LatestDelegates:     delegates,
 		LatestBlockProducer: producer,
 		Candidates:          candidateAddresses,
+		Round:               round,
+		Phase:               string(r.cfsm.currentState()),
+		RoundStartTime:      roundStartTime,
 	}, nil
 }
 
+// EpochMeta returns the heights, the delegates and the numbers of blocks scheduled for and produced by the delegates of
+// an epoch. The blocks are counted up to the tip of the chain, so the counts of an ongoing epoch are partial.
+func (r *RollDPoS) EpochMeta(epochNum uint64) (scheme.EpochMeta, error) {
+	if epochNum == 0 {
+		return scheme.EpochMeta{}, errors.New("epoch number should be greater than 0")
+	}
+	delegates, err := r.ctx.rollingDelegates(epochNum)
+	if err != nil {
+		return scheme.EpochMeta{}, errors.Wrapf(err, "error when getting the delegates of epoch %d", epochNum)
+	}
+	epochSize := uint64(r.ctx.cfg.NumDelegates) * uint64(r.ctx.getNumSubEpochs())
+	meta := scheme.EpochMeta{
+		Num:            epochNum,
+		StartHeight:    epochSize*(epochNum-1) + 1,
+		EndHeight:      epochSize * epochNum,
+		Delegates:      delegates,
+		ExpectedBlocks: make(map[string]uint64, len(delegates)),
+		ProducedBlocks: make(map[string]uint64, len(delegates)),
+	}
+	for _, delegate := range delegates {
+		meta.ExpectedBlocks[delegate] = 0
+		meta.ProducedBlocks[delegate] = 0
+	}
+	tipHeight := r.ctx.chain.TipHeight()
+	for height := meta.StartHeight; height <= meta.EndHeight && height <= tipHeight; height++ {
+		// the slots are scheduled by height, regardless of the time based rotation, which skips the slots of the
+		// delegates failing to propose in time
+		meta.ExpectedBlocks[delegates[height%uint64(len(delegates))]]++
+		blk, err := r.ctx.chain.GetBlockByHeight(height)
+		if err != nil {
+			return scheme.EpochMeta{}, errors.Wrapf(err, "error when getting the block at height %d", height)
+		}
+		// dummy blocks are not produced by any delegate
+		if _, ok := meta.ProducedBlocks[blk.ProducerAddress()]; ok {
+			meta.ProducedBlocks[blk.ProducerAddress()]++
+		}
+	}
+	return meta, nil
+}
+
 // NumPendingEvts returns the number of pending events
 func (r *RollDPoS) NumPendingEvts() int {
 	return len(r.cfsm.evtq)
@@ -28,6 +28,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/network"
 	"github.com/iotexproject/iotex-core/network/node"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
@@ -258,6 +259,8 @@ func TestRollDPoS_Metrics(t *testing.T) {
 		Build()
 	require.NoError(t, err)
 	require.NotNil(t, r)
+	roundStartTime := time.Unix(1000, 0)
+	r.ctx.round = roundCtx{number: 2, height: 9, timestamp: roundStartTime}
 
 	m, err := r.Metrics()
 	require.NoError(t, err)
@@ -266,6 +269,95 @@ func TestRollDPoS_Metrics(t *testing.T) {
 	assert.Equal(t, candidates[:4], m.LatestDelegates)
 	assert.Equal(t, candidates[1], m.LatestBlockProducer)
 	assert.Equal(t, candidates, m.Candidates)
+	assert.Equal(t, uint64(2), m.Round)
+	assert.Equal(t, string(sEpochStart), m.Phase)
+	assert.Equal(t, roundStartTime, m.RoundStartTime)
+}
+
+func TestRollDPoS_EpochMeta(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	candidates := make([]string, 5)
+	for i := 0; i < len(candidates); i++ {
+		candidates[i] = testAddrs[i].RawAddress
+	}
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().Return(uint64(6)).AnyTimes()
+	chain.EXPECT().CandidatesByHeight(gomock.Any()).Return([]*state.Candidate{
+		{Address: candidates[0]},
+		{Address: candidates[1]},
+		{Address: candidates[2]},
+		{Address: candidates[3]},
+		{Address: candidates[4]},
+	}, nil).AnyTimes()
+	crypto.SortCandidates(candidates, 2)
+	// blocks 5 and 6 are produced by the same delegate
+	var producer *iotxaddress.Address
+	for _, addr := range testAddrs {
+		if addr.RawAddress == candidates[0] {
+			producer = addr
+		}
+	}
+	chain.EXPECT().GetBlockByHeight(gomock.Any()).DoAndReturn(func(height uint64) (*blockchain.Block, error) {
+		blk := blockchain.NewBlock(config.Default.Chain.ID, height, hash.ZeroHash32B, 0, nil, nil, nil)
+		return blk, blk.SignBlock(producer)
+	}).Times(2)
+
+	r, err := NewRollDPoSBuilder().
+		SetConfig(config.RollDPoS{NumDelegates: 4}).
+		SetAddr(newTestAddr()).
+		SetBlockchain(chain).
+		SetActPool(mock_actpool.NewMockActPool(ctrl)).
+		SetP2P(mock_network.NewMockOverlay(ctrl)).
+		Build()
+	require.NoError(t, err)
+
+	_, err = r.EpochMeta(0)
+	require.Error(t, err)
+
+	meta, err := r.EpochMeta(2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), meta.Num)
+	assert.Equal(t, uint64(5), meta.StartHeight)
+	assert.Equal(t, uint64(8), meta.EndHeight)
+	assert.Equal(t, candidates[:4], meta.Delegates)
+	// heights 5 and 6 are the slots of the 2nd and the 3rd delegates
+	assert.Equal(t, map[string]uint64{
+		candidates[0]: 0,
+		candidates[1]: 1,
+		candidates[2]: 1,
+		candidates[3]: 0,
+	}, meta.ExpectedBlocks)
+	assert.Equal(t, map[string]uint64{
+		candidates[0]: 2,
+		candidates[1]: 0,
+		candidates[2]: 0,
+		candidates[3]: 0,
+	}, meta.ProducedBlocks)
+}
+
+func TestRollDPoS_SetBlockInterval(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r, err := NewRollDPoSBuilder().
+		SetConfig(config.RollDPoS{ProposerInterval: 10 * time.Second}).
+		SetAddr(newTestAddr()).
+		SetBlockchain(mock_blockchain.NewMockBlockchain(ctrl)).
+		SetActPool(mock_actpool.NewMockActPool(ctrl)).
+		SetP2P(mock_network.NewMockOverlay(ctrl)).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, r.GetBlockInterval())
+	require.NoError(t, r.SetBlockInterval(2*time.Second))
+	assert.Equal(t, 2*time.Second, r.GetBlockInterval())
+	assert.Equal(t, 2*time.Second, r.ctx.proposerInterval())
 }
 
 func TestRollDPoS_convertToConsensusEvt(t *testing.T) {
@@ -408,6 +500,22 @@ func (o *directOverlay) GetPeers() []net.Addr {
 	return addrs
 }
 
+func (o *directOverlay) Peers() []network.PeerInfo {
+	peers := make([]network.PeerInfo, 0, len(o.peers))
+	for addr := range o.peers {
+		peers = append(peers, network.PeerInfo{Addr: addr.String(), Direction: network.Outbound})
+	}
+	return peers
+}
+
+func (o *directOverlay) Ban(string) {}
+
+func (o *directOverlay) Unban(string) {}
+
+func (o *directOverlay) SetGenesisHash(hash.Hash32B) {}
+
+func (o *directOverlay) GenesisHash() hash.Hash32B { return hash.ZeroHash32B }
+
 func TestRollDPoSConsensus(t *testing.T) {
 	t.Parallel()
 
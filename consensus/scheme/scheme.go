@@ -7,6 +7,8 @@
 package scheme
 
 import (
+	"time"
+
 	"github.com/golang/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -14,8 +16,9 @@ import (
 	"github.com/iotexproject/iotex-core/proto"
 )
 
-// CreateBlockCB defines the callback to create a new block
-type CreateBlockCB func() (*blockchain.Block, error)
+// CreateBlockCB defines the callback to create a new block, with the actions decided by the proposer hook if it isn't
+// nil
+type CreateBlockCB func(ProposerHook) (*blockchain.Block, error)
 
 // TellPeerCB defines the callback to tell (which is a unicast) message to peers on P2P network
 type TellPeerCB func(proto.Message) error
@@ -34,6 +37,19 @@ type Scheme interface {
 	HandleEndorse(endorse *iproto.EndorsePb) error
 	SetDoneStream(chan bool)
 	Metrics() (ConsensusMetrics, error)
+	// EpochMeta returns the heights, the delegates and the numbers of blocks produced by the delegates of an epoch
+	EpochMeta(epochNum uint64) (EpochMeta, error)
+	// Pause stops the node from proposing and endorsing blocks, while the blocks agreed by the others are still
+	// validated and committed. It returns after the proposal in progress, if any, is finished.
+	Pause()
+	// Resume lets the node take part in proposing and endorsing blocks again
+	Resume()
+	// SetBlockInterval changes the interval of producing blocks at runtime
+	SetBlockInterval(time.Duration) error
+	// GetBlockInterval returns the active interval of producing blocks
+	GetBlockInterval() time.Duration
+	// SetProposerHook sets the hook deciding the actions of the blocks proposed by the node, or unsets it if nil
+	SetProposerHook(ProposerHook)
 }
 
 // ConsensusMetrics contains consensus metrics to expose
@@ -43,4 +59,23 @@ type ConsensusMetrics struct {
 	LatestDelegates     []string
 	LatestBlockProducer string
 	Candidates          []string
+	// Round is the ordinal number of the current round at the height being produced, starting from 0
+	Round uint64
+	// Phase is the state of the current round
+	Phase          string
+	RoundStartTime time.Time
+}
+
+// EpochMeta contains the metadata of an epoch
+type EpochMeta struct {
+	Num         uint64
+	StartHeight uint64
+	EndHeight   uint64
+	// Delegates are the delegates of the epoch in the order of producing blocks
+	Delegates []string
+	// ExpectedBlocks are the numbers of blocks each delegate is scheduled to produce in the epoch, up to the tip of the
+	// chain
+	ExpectedBlocks map[string]uint64
+	// ProducedBlocks are the numbers of blocks produced by each delegate in the epoch, up to the tip of the chain
+	ProducedBlocks map[string]uint64
 }
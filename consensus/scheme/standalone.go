@@ -8,6 +8,7 @@ package scheme
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -21,7 +22,8 @@ import (
 
 // Standalone is the consensus scheme that periodically create blocks
 type Standalone struct {
-	task *routine.RecurringTask
+	task    *routine.RecurringTask
+	handler *standaloneHandler
 }
 
 type standaloneHandler struct {
@@ -29,13 +31,23 @@ type standaloneHandler struct {
 	createCb CreateBlockCB
 	commitCb ConsensusDoneCB
 	pubCb    BroadcastCB
+	// mutex is held while creating a block, so that pausing waits for the block in progress
+	mutex  sync.Mutex
+	paused bool
+	hook   ProposerHook
 }
 
 func (s *standaloneHandler) Run() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.paused {
+		logger.Debug().Msg("standalone scheme is paused, skip creating a new block")
+		return
+	}
 	logger.Info().
 		Str("at", time.Now().String()).
 		Msg("created a new block")
-	blk, err := s.createCb()
+	blk, err := s.createCb(s.hook)
 	if err != nil {
 		logger.Error().Err(err)
 		return
@@ -57,7 +69,8 @@ func NewStandalone(create CreateBlockCB, commit ConsensusDoneCB, pub BroadcastCB
 		pubCb:    pub,
 	}
 	return &Standalone{
-		task: routine.NewRecurringTask(h.Run, interval),
+		task:    routine.NewRecurringTask(h.Run, interval),
+		handler: h,
 	}
 }
 
@@ -69,6 +82,39 @@ func (n *Standalone) Start(ctx context.Context) error {
 // SetDoneStream does nothing in Standalone (only used in simulator)
 func (n *Standalone) SetDoneStream(done chan bool) {}
 
+// Pause stops creating blocks after the block in progress is committed
+func (n *Standalone) Pause() {
+	n.handler.mutex.Lock()
+	defer n.handler.mutex.Unlock()
+	n.handler.paused = true
+}
+
+// Resume resumes creating blocks
+func (n *Standalone) Resume() {
+	n.handler.mutex.Lock()
+	defer n.handler.mutex.Unlock()
+	n.handler.paused = false
+}
+
+// SetBlockInterval changes the interval of creating blocks
+func (n *Standalone) SetBlockInterval(interval time.Duration) error {
+	n.task.SetInterval(interval)
+	return nil
+}
+
+// GetBlockInterval returns the interval of creating blocks
+func (n *Standalone) GetBlockInterval() time.Duration {
+	return n.task.Interval()
+}
+
+// SetProposerHook sets the hook deciding the actions of the blocks created, which takes effect after the block in
+// progress
+func (n *Standalone) SetProposerHook(hook ProposerHook) {
+	n.handler.mutex.Lock()
+	defer n.handler.mutex.Unlock()
+	n.handler.hook = hook
+}
+
 // Stop stops the service for a standalone
 func (n *Standalone) Stop(ctx context.Context) error {
 	return n.task.Stop(ctx)
@@ -93,3 +139,11 @@ func (n *Standalone) Metrics() (ConsensusMetrics, error) {
 		"standalone scheme does not supported metrics yet",
 	)
 }
+
+// EpochMeta is not implemented for standalone scheme
+func (n *Standalone) EpochMeta(epochNum uint64) (EpochMeta, error) {
+	return EpochMeta{}, errors.Wrapf(
+		errcode.ErrNotImplemented,
+		"standalone scheme does not supported epochs",
+	)
+}
@@ -107,9 +107,12 @@ func NewSim(
 		}
 	*/
 
-	var err error
+	addr, err := consensus.GetAddr(cfg)
+	if err != nil {
+		logger.Panic().Err(err).Msg("error when getting the producer's address")
+	}
 	cs.scheme, err = rolldpos.NewRollDPoSBuilder().
-		SetAddr(consensus.GetAddr(cfg)).
+		SetAddr(addr).
 		SetConfig(cfg.Consensus.RollDPoS).
 		SetBlockchain(bc).
 		SetActPool(ap).
@@ -177,9 +180,12 @@ func NewSimByzantine(
 		}
 	*/
 
-	var err error
+	addr, err := consensus.GetAddr(cfg)
+	if err != nil {
+		logger.Panic().Err(err).Msg("error when getting the producer's address")
+	}
 	cs.scheme, err = rolldpos.NewRollDPoSBuilder().
-		SetAddr(consensus.GetAddr(cfg)).
+		SetAddr(addr).
 		SetConfig(cfg.Consensus.RollDPoS).
 		SetBlockchain(bc).
 		SetActPool(ap).
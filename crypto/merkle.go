@@ -7,6 +7,7 @@
 package crypto
 
 import (
+	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotexproject/iotex-core/logger"
@@ -83,3 +84,49 @@ func (mk *Merkle) HashTree() hash.Hash32B {
 	mk.root = merkle[0]
 	return mk.root
 }
+
+// Proof returns the merkle branch of the leaf at index, i.e., the sibling hashes from the leaf up to the root
+func (mk *Merkle) Proof(index int) ([]hash.Hash32B, error) {
+	if index < 0 || index >= mk.size {
+		return nil, errors.Errorf("leaf index %d is out of range [0, %d)", index, mk.size)
+	}
+	var branch []hash.Hash32B
+	level := make([]hash.Hash32B, mk.size)
+	copy(level, mk.leaf[:mk.size])
+	for len(level) > 1 {
+		// copy the last hash if the level has odd number of hashes, the same as HashTree
+		if len(level)&1 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+		branch = append(branch, level[index^1])
+		for i := 0; i < len(level)>>1; i++ {
+			level[i] = hashPair(level[i<<1], level[i<<1+1])
+		}
+		level = level[:len(level)>>1]
+		index >>= 1
+	}
+	return branch, nil
+}
+
+// VerifyMerkleProof checks that the leaf at index is included in the merkle tree of the root by the branch
+func VerifyMerkleProof(leaf hash.Hash32B, index int, branch []hash.Hash32B, root hash.Hash32B) bool {
+	if index < 0 {
+		return false
+	}
+	h := leaf
+	for _, sibling := range branch {
+		if index&1 == 0 {
+			h = hashPair(h, sibling)
+		} else {
+			h = hashPair(sibling, h)
+		}
+		index >>= 1
+	}
+	return index == 0 && h == root
+}
+
+func hashPair(left hash.Hash32B, right hash.Hash32B) hash.Hash32B {
+	h := left[:]
+	h = append(h, right[:]...)
+	return blake2b.Sum256(h)
+}
@@ -12,6 +12,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotexproject/iotex-core/pkg/hash"
 )
@@ -69,3 +71,28 @@ func TestMerkleTree(t *testing.T) {
 	assert.Equal(t, 0, bytes.Compare(expected[:], actual5[:]))
 	assert.Equal(t, -1, bytes.Compare(actual5[:], actual4[:]))
 }
+
+func TestMerkleProof(t *testing.T) {
+	require := require.New(t)
+
+	var leaves []hash.Hash32B
+	for i := 0; i < 7; i++ {
+		leaves = append(leaves, blake2b.Sum256([]byte{byte(i)}))
+		m := NewMerkleTree(leaves)
+		root := m.HashTree()
+		for j := range leaves {
+			branch, err := m.Proof(j)
+			require.NoError(err)
+			require.True(VerifyMerkleProof(leaves[j], j, branch, root))
+			// the branch does not prove other leaves or positions
+			if j+1 < len(leaves) {
+				require.False(VerifyMerkleProof(leaves[j], j+1, branch, root))
+				require.False(VerifyMerkleProof(leaves[j+1], j, branch, root))
+			}
+		}
+		_, err := m.Proof(-1)
+		require.Error(err)
+		_, err = m.Proof(len(leaves) + 1)
+		require.Error(err)
+	}
+}
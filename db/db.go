@@ -8,6 +8,7 @@ package db
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/boltdb/bolt"
@@ -38,6 +39,9 @@ type KVStore interface {
 	Get(string, []byte) ([]byte, error)
 	// Delete deletes a record by (namespace, key)
 	Delete(string, []byte) error
+	// ForEach calls the function with each record in the namespace until it returns an error, which is returned. The
+	// key and value passed to the function are only valid during the call
+	ForEach(string, func([]byte, []byte) error) error
 	// Batch return a kv store batch api object
 	Batch() KVStoreBatch
 }
@@ -49,6 +53,7 @@ const (
 // memKVStore is the in-memory implementation of KVStore for testing purpose
 type memKVStore struct {
 	data   *sync.Map
+	mutex  sync.RWMutex // mutex to protect bucket, as the store could be read while being written
 	bucket map[string]struct{}
 }
 
@@ -66,14 +71,14 @@ func (m *memKVStore) Stop(_ context.Context) error { return nil }
 
 // Put inserts a <key, value> record
 func (m *memKVStore) Put(namespace string, key, value []byte) error {
-	m.bucket[namespace] = struct{}{}
+	m.addBucket(namespace)
 	m.data.Store(namespace+keyDelimiter+string(key), value)
 	return nil
 }
 
 // PutIfNotExists inserts a <key, value> record only if it does not exist yet, otherwise return ErrAlreadyExist
 func (m *memKVStore) PutIfNotExists(namespace string, key, value []byte) error {
-	m.bucket[namespace] = struct{}{}
+	m.addBucket(namespace)
 	_, loaded := m.data.LoadOrStore(namespace+keyDelimiter+string(key), value)
 	if loaded {
 		return ErrAlreadyExist
@@ -83,7 +88,10 @@ func (m *memKVStore) PutIfNotExists(namespace string, key, value []byte) error {
 
 // Get retrieves a record
 func (m *memKVStore) Get(namespace string, key []byte) ([]byte, error) {
-	if _, ok := m.bucket[namespace]; !ok {
+	m.mutex.RLock()
+	_, ok := m.bucket[namespace]
+	m.mutex.RUnlock()
+	if !ok {
 		return nil, errors.Wrapf(bolt.ErrBucketNotFound, "bucket = %s", namespace)
 	}
 	value, _ := m.data.Load(namespace + keyDelimiter + string(key))
@@ -99,24 +107,62 @@ func (m *memKVStore) Delete(namespace string, key []byte) error {
 	return nil
 }
 
+// ForEach calls the function with each record in the namespace
+func (m *memKVStore) ForEach(namespace string, fn func([]byte, []byte) error) error {
+	prefix := namespace + keyDelimiter
+	var err error
+	m.data.Range(func(k, v interface{}) bool {
+		key := k.(string)
+		if !strings.HasPrefix(key, prefix) {
+			return true
+		}
+		err = fn([]byte(key[len(prefix):]), v.([]byte))
+		return err == nil
+	})
+	return err
+}
+
 // Batch return a kv store batch api object
 func (m *memKVStore) Batch() KVStoreBatch {
 	return NewMemKVStoreBatch(m)
 }
 
+func (m *memKVStore) addBucket(namespace string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.bucket[namespace] = struct{}{}
+}
+
 const fileMode = 0600
 
+// BoltDBOptions tunes the bolt DB, trading durability or space for throughput. The zero value keeps bolt's defaults.
+type BoltDBOptions struct {
+	// NoSync skips fsync after each commit, which could lose the latest commits or corrupt the DB if the OS crashes
+	NoSync bool
+	// MmapFlags are the flags passed to mmap when the DB file is mapped, e.g., syscall.MAP_POPULATE
+	MmapFlags int
+	// FillPercent is the percentage of a page filled before it's split. 0 means bolt.DefaultFillPercent. A higher
+	// value makes the DB more compact if keys are mostly appended in order.
+	FillPercent float64
+}
+
 // boltDB is KVStore implementation based bolt DB
 type boltDB struct {
-	mutex  sync.RWMutex
-	db     *bolt.DB
-	path   string
-	config *config.DB
+	mutex   sync.RWMutex
+	db      *bolt.DB
+	path    string
+	config  *config.DB
+	options BoltDBOptions
 }
 
 // NewBoltDB instantiates a boltdb based KV store
 func NewBoltDB(path string, cfg *config.DB) KVStore {
-	return &boltDB{db: nil, path: path, config: cfg}
+	return NewBoltDBWithOptions(path, cfg, BoltDBOptions{})
+}
+
+// NewBoltDBWithOptions instantiates a boltdb based KV store tuned by the options
+func NewBoltDBWithOptions(path string, cfg *config.DB, opts BoltDBOptions) KVStore {
+	return &boltDB{db: nil, path: path, config: cfg, options: opts}
 }
 
 // Start opens the BoltDB (creates new file if not existing yet)
@@ -128,10 +174,11 @@ func (b *boltDB) Start(_ context.Context) error {
 		return nil
 	}
 
-	db, err := bolt.Open(b.path, fileMode, nil)
+	db, err := bolt.Open(b.path, fileMode, &bolt.Options{MmapFlags: b.options.MmapFlags})
 	if err != nil {
 		return err
 	}
+	db.NoSync = b.options.NoSync
 	b.db = db
 	return nil
 }
@@ -155,7 +202,7 @@ func (b *boltDB) Put(namespace string, key, value []byte) error {
 	numRetries := b.config.NumRetries
 	for c := uint8(0); c < numRetries; c++ {
 		err = b.db.Update(func(tx *bolt.Tx) error {
-			bucket, err := tx.CreateBucketIfNotExists([]byte(namespace))
+			bucket, err := b.createBucketIfNotExists(tx, namespace)
 			if err != nil {
 				return err
 			}
@@ -176,7 +223,7 @@ func (b *boltDB) PutIfNotExists(namespace string, key, value []byte) error {
 	numRetries := b.config.NumRetries
 	for c := uint8(0); c < numRetries; c++ {
 		err = b.db.Update(func(tx *bolt.Tx) error {
-			bucket, err := tx.CreateBucketIfNotExists([]byte(namespace))
+			bucket, err := b.createBucketIfNotExists(tx, namespace)
 			if err != nil {
 				return err
 			}
@@ -235,6 +282,17 @@ func (b *boltDB) Delete(namespace string, key []byte) error {
 	return err
 }
 
+// ForEach calls the function with each record in the namespace, all of which are read in one transaction
+func (b *boltDB) ForEach(namespace string, fn func([]byte, []byte) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(namespace))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(fn)
+	})
+}
+
 // Batch return a kv store batch api object
 func (b *boltDB) Batch() KVStoreBatch {
 	return NewBoltDBBatch(b)
@@ -244,6 +302,19 @@ func (b *boltDB) Batch() KVStoreBatch {
 // private functions
 //======================================
 
+// createBucketIfNotExists returns the bucket of the namespace in the transaction, which is tuned by the fill percent
+// option. Bolt doesn't persist the fill percent, so it's set every time the bucket is retrieved for writes.
+func (b *boltDB) createBucketIfNotExists(tx *bolt.Tx, namespace string) (*bolt.Bucket, error) {
+	bucket, err := tx.CreateBucketIfNotExists([]byte(namespace))
+	if err != nil {
+		return nil, err
+	}
+	if b.options.FillPercent > 0 {
+		bucket.FillPercent = b.options.FillPercent
+	}
+	return bucket, nil
+}
+
 // intentionally fail to test DB can successfully rollback
 func (b *boltDB) batchPutForceFail(namespace string, key [][]byte, value [][]byte) error {
 	return b.db.Update(func(tx *bolt.Tx) error {
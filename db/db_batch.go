@@ -144,7 +144,7 @@ func (b *boltDBBatch) Commit() error {
 		err = b.bdb.db.Update(func(tx *bolt.Tx) error {
 			for _, write := range b.writeQueue {
 				if write.writeType == Put {
-					bucket, err := tx.CreateBucketIfNotExists([]byte(write.namespace))
+					bucket, err := b.bdb.createBucketIfNotExists(tx, write.namespace)
 					if err != nil {
 						return errors.Wrapf(err, write.errorFormat, write.errorArgs)
 					}
@@ -152,7 +152,7 @@ func (b *boltDBBatch) Commit() error {
 						return errors.Wrapf(err, write.errorFormat, write.errorArgs)
 					}
 				} else if write.writeType == PutIfNotExists {
-					bucket, err := tx.CreateBucketIfNotExists([]byte(write.namespace))
+					bucket, err := b.bdb.createBucketIfNotExists(tx, write.namespace)
 					if err != nil {
 						return errors.Wrapf(err, write.errorFormat, write.errorArgs)
 					}
@@ -87,6 +87,12 @@ func (c *cachedKVStore) Delete(namespace string, key []byte) error {
 	return c.KVStoreBatch.Delete(namespace, key, "failed to delete key = %x", key)
 }
 
+// ForEach calls the function with each record in the namespace of the underlying KVStore, which doesn't include the
+// pending writes
+func (c *cachedKVStore) ForEach(namespace string, fn func([]byte, []byte) error) error {
+	return c.kv.ForEach(namespace, fn)
+}
+
 // Clear clear write queue
 func (c *cachedKVStore) Clear() error {
 	c.mutex.Lock()
@@ -78,6 +78,97 @@ func TestKVStorePutGet(t *testing.T) {
 		defer testutil.CleanupPath(t, path)
 		testKVStorePutGet(NewBoltDB(path, cfg), t)
 	})
+
+	t.Run("Tuned Bolt DB", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		testKVStorePutGet(NewBoltDBWithOptions(path, cfg, BoltDBOptions{NoSync: true, FillPercent: 1}), t)
+	})
+}
+
+func TestKVStoreForEach(t *testing.T) {
+	testKVStoreForEach := func(kvStore KVStore, t *testing.T) {
+		require := require.New(t)
+		ctx := context.Background()
+
+		require.NoError(kvStore.Start(ctx))
+		defer func() {
+			require.NoError(kvStore.Stop(ctx))
+		}()
+
+		// a missing namespace has no record
+		require.NoError(kvStore.ForEach(bucket1, func([]byte, []byte) error {
+			return errors.New("unexpected record")
+		}))
+		for i := range testK1 {
+			require.NoError(kvStore.Put(bucket1, testK1[i], testV1[i]))
+			require.NoError(kvStore.Put(bucket2, testK2[i], testV2[i]))
+		}
+		records := make(map[string]string)
+		require.NoError(kvStore.ForEach(bucket1, func(k, v []byte) error {
+			records[string(k)] = string(v)
+			return nil
+		}))
+		require.Equal(map[string]string{"key_1": "value_1", "key_2": "value_2", "key_3": "value_3"}, records)
+
+		// the error of the function stops the iteration
+		errStop := errors.New("stop")
+		count := 0
+		err := kvStore.ForEach(bucket2, func([]byte, []byte) error {
+			count++
+			return errStop
+		})
+		require.Equal(errStop, errors.Cause(err))
+		require.Equal(1, count)
+	}
+
+	t.Run("In-memory KV Store", func(t *testing.T) {
+		testKVStoreForEach(NewMemKVStore(), t)
+	})
+
+	path := "/tmp/test-kv-store-" + strconv.Itoa(rand.Int())
+	t.Run("Bolt DB", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		testKVStoreForEach(NewBoltDB(path, cfg), t)
+	})
+}
+
+func TestBoltDBOptions(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	path := "/tmp/test-kv-store-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+
+	kvStore := NewBoltDBWithOptions(path, cfg, BoltDBOptions{NoSync: true, FillPercent: 0.9})
+	require.NoError(kvStore.Start(ctx))
+	defer func() {
+		require.NoError(kvStore.Stop(ctx))
+	}()
+	bdb := kvStore.(*boltDB)
+	require.True(bdb.db.NoSync)
+
+	batch := kvStore.Batch()
+	for i := range testK1 {
+		require.NoError(batch.Put(bucket1, testK1[i], testV1[i], ""))
+	}
+	require.NoError(batch.Commit())
+	for i := range testK1 {
+		value, err := kvStore.Get(bucket1, testK1[i])
+		require.NoError(err)
+		require.Equal(testV1[i], value)
+	}
+
+	// The default options keep fsync on
+	defaultStore := NewBoltDB(path+"-default", cfg)
+	testutil.CleanupPath(t, path+"-default")
+	defer testutil.CleanupPath(t, path+"-default")
+	require.NoError(defaultStore.Start(ctx))
+	defer func() {
+		require.NoError(defaultStore.Stop(ctx))
+	}()
+	require.False(defaultStore.(*boltDB).db.NoSync)
 }
 
 func TestBatchRollback(t *testing.T) {
@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
@@ -38,12 +39,14 @@ type Dispatcher interface {
 
 	// AddSubscriber adds to dispatcher
 	AddSubscriber(uint32, Subscriber)
+	// RemoveSubscriber removes the subscriber of the chain from dispatcher
+	RemoveSubscriber(uint32)
 	// HandleBroadcast handles the incoming broadcast message. The transportation layer semantics is at least once.
 	// That said, the handler is likely to receive duplicate messages.
-	HandleBroadcast(uint32, proto.Message, chan bool)
+	HandleBroadcast(uint32, proto.Message, chan bool) error
 	// HandleTell handles the incoming tell message. The transportation layer semantics is exact once. The sender is
 	// given for the sake of replying the message
-	HandleTell(uint32, net.Addr, proto.Message, chan bool)
+	HandleTell(uint32, net.Addr, proto.Message, chan bool) error
 }
 
 var requestMtc = prometheus.NewCounterVec(
@@ -103,18 +106,49 @@ type IotxDispatcher struct {
 	wg             sync.WaitGroup
 	quit           chan struct{}
 
-	subscribers map[uint32]Subscriber
+	subscribers   map[uint32]Subscriber
+	subscribersMU sync.RWMutex
+
+	// sync indicates that the messages are handled in the caller's goroutine instead of being queued
+	sync     bool
+	syncLock sync.Mutex
+}
+
+type optionParams struct {
+	sync bool
+}
+
+// Option sets Dispatcher construction parameter.
+type Option func(ops *optionParams) error
+
+// WithSyncDispatch is an option to handle the messages synchronously, which is useful for testing. The incoming
+// message has been handled when HandleBroadcast or HandleTell returns, and the error of the subscriber is returned to
+// the caller. The done channel passed along with the message must be either nil or buffered, because it's signaled in
+// the caller's goroutine.
+func WithSyncDispatch() Option {
+	return func(ops *optionParams) error {
+		ops.sync = true
+		return nil
+	}
 }
 
 // NewDispatcher creates a new Dispatcher
 func NewDispatcher(
 	cfg *config.Config,
+	opts ...Option,
 ) (Dispatcher, error) {
+	var ops optionParams
+	for _, opt := range opts {
+		if err := opt(&ops); err != nil {
+			return nil, err
+		}
+	}
 	d := &IotxDispatcher{
 		eventChan:   make(chan interface{}, cfg.Dispatcher.EventChanSize),
 		eventAudit:  make(map[uint32]int),
 		quit:        make(chan struct{}),
 		subscribers: make(map[uint32]Subscriber),
+		sync:        ops.sync,
 	}
 	return d, nil
 }
@@ -124,9 +158,19 @@ func (d *IotxDispatcher) AddSubscriber(
 	chainID uint32,
 	subscriber Subscriber,
 ) {
+	d.subscribersMU.Lock()
+	defer d.subscribersMU.Unlock()
 	d.subscribers[chainID] = subscriber
 }
 
+// RemoveSubscriber removes the subscriber of the chain from dispatcher. It blocks until the messages being handled by
+// the subscriber are done. The messages of the chain received or still queued afterwards are dropped.
+func (d *IotxDispatcher) RemoveSubscriber(chainID uint32) {
+	d.subscribersMU.Lock()
+	defer d.subscribersMU.Unlock()
+	delete(d.subscribers, chainID)
+}
+
 // Start starts the dispatcher.
 func (d *IotxDispatcher) Start(ctx context.Context) error {
 	if atomic.AddInt32(&d.started, 1) != 1 {
@@ -172,19 +216,7 @@ loop:
 	for {
 		select {
 		case m := <-d.eventChan:
-			switch msg := m.(type) {
-			case *actionMsg:
-				d.handleActionMsg(msg)
-			case *blockMsg:
-				d.handleBlockMsg(msg)
-			case *blockSyncMsg:
-				d.handleBlockSyncMsg(msg)
-
-			default:
-				logger.Warn().
-					Str("msg", msg.(string)).
-					Msg("Invalid message type in block handler")
-			}
+			d.handleEvent(m)
 
 		case <-d.quit:
 			break loop
@@ -195,13 +227,39 @@ loop:
 	logger.Info().Msg("News handler done")
 }
 
+// handleEvent dispatches the event to the handler of its type.
+func (d *IotxDispatcher) handleEvent(m interface{}) error {
+	switch msg := m.(type) {
+	case *actionMsg:
+		return d.handleActionMsg(msg)
+	case *blockMsg:
+		return d.handleBlockMsg(msg)
+	case *blockSyncMsg:
+		return d.handleBlockSyncMsg(msg)
+
+	default:
+		logger.Warn().
+			Str("msg", msg.(string)).
+			Msg("Invalid message type in block handler")
+		return nil
+	}
+}
+
 // handleActionMsg handles actionMsg from all peers.
-func (d *IotxDispatcher) handleActionMsg(m *actionMsg) {
+func (d *IotxDispatcher) handleActionMsg(m *actionMsg) error {
+	d.subscribersMU.RLock()
+	defer d.subscribersMU.RUnlock()
+
+	var err error
 	d.updateEventAudit(pb.MsgActionType)
 	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
-		if err := subscriber.HandleAction(m.action); err != nil {
+		if err = subscriber.HandleAction(m.action); err != nil {
 			requestMtc.WithLabelValues("AddAction", "false").Inc()
-			logger.Debug().Err(err)
+			if actpool.IsDuplicate(err) {
+				logger.Debug().Err(err).Msg("Dropped duplicate action")
+			} else {
+				logger.Warn().Err(err).Msg("Failed to handle action")
+			}
 		}
 	} else {
 		logger.Info().Uint32("ChainID", m.ChainID()).Msg("No subscriber specified in the dispatcher")
@@ -210,19 +268,24 @@ func (d *IotxDispatcher) handleActionMsg(m *actionMsg) {
 	if m.done != nil {
 		m.done <- true
 	}
+	return err
 }
 
 // handleBlockMsg handles blockMsg from peers.
-func (d *IotxDispatcher) handleBlockMsg(m *blockMsg) {
+func (d *IotxDispatcher) handleBlockMsg(m *blockMsg) error {
+	d.subscribersMU.RLock()
+	defer d.subscribersMU.RUnlock()
+
+	var err error
 	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
 		if m.blkType == pb.MsgBlockProtoMsgType {
 			d.updateEventAudit(pb.MsgBlockProtoMsgType)
-			if err := subscriber.HandleBlock(m.block); err != nil {
+			if err = subscriber.HandleBlock(m.block); err != nil {
 				logger.Error().Err(err).Msg("Fail to handle the block")
 			}
 		} else if m.blkType == pb.MsgBlockSyncDataType {
 			d.updateEventAudit(pb.MsgBlockSyncDataType)
-			if err := subscriber.HandleBlockSync(m.block); err != nil {
+			if err = subscriber.HandleBlockSync(m.block); err != nil {
 				logger.Error().Err(err).Msg("Fail to sync the block")
 			}
 		}
@@ -234,20 +297,24 @@ func (d *IotxDispatcher) handleBlockMsg(m *blockMsg) {
 	if m.done != nil {
 		m.done <- true
 	}
+	return err
 }
 
 // handleBlockSyncMsg handles block messages from peers.
-func (d *IotxDispatcher) handleBlockSyncMsg(m *blockSyncMsg) {
+func (d *IotxDispatcher) handleBlockSyncMsg(m *blockSyncMsg) error {
 	logger.Info().
 		Str("src", m.sender).
 		Uint64("start", m.sync.Start).
 		Uint64("end", m.sync.End).
 		Msg("receive blockSyncMsg")
 
+	d.subscribersMU.RLock()
+	defer d.subscribersMU.RUnlock()
+	var err error
 	d.updateEventAudit(pb.MsgBlockSyncReqType)
 	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
 		// dispatch to block sync
-		if err := subscriber.HandleSyncRequest(m.sender, m.sync); err != nil {
+		if err = subscriber.HandleSyncRequest(m.sender, m.sync); err != nil {
 			logger.Error().Err(err)
 		}
 	} else {
@@ -257,103 +324,120 @@ func (d *IotxDispatcher) handleBlockSyncMsg(m *blockSyncMsg) {
 	if m.done != nil {
 		m.done <- true
 	}
+	return err
 }
 
 // dispatchAction adds the passed action message to the news handling queue.
-func (d *IotxDispatcher) dispatchAction(chainID uint32, msg proto.Message, done chan bool) {
+func (d *IotxDispatcher) dispatchAction(chainID uint32, msg proto.Message, done chan bool) error {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
 		if done != nil {
 			close(done)
 		}
-		return
+		return nil
 	}
-	d.enqueueEvent(&actionMsg{chainID, (msg).(*pb.ActionPb), done})
+	return d.enqueueEvent(&actionMsg{chainID, (msg).(*pb.ActionPb), done})
 }
 
 // dispatchBlockCommit adds the passed block message to the news handling queue.
-func (d *IotxDispatcher) dispatchBlockCommit(chainID uint32, msg proto.Message, done chan bool) {
+func (d *IotxDispatcher) dispatchBlockCommit(chainID uint32, msg proto.Message, done chan bool) error {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
 		if done != nil {
 			close(done)
 		}
-		return
+		return nil
 	}
-	d.enqueueEvent(&blockMsg{chainID, (msg).(*pb.BlockPb), pb.MsgBlockProtoMsgType, done})
+	return d.enqueueEvent(&blockMsg{chainID, (msg).(*pb.BlockPb), pb.MsgBlockProtoMsgType, done})
 }
 
 // dispatchBlockSyncReq adds the passed block sync request to the news handling queue.
-func (d *IotxDispatcher) dispatchBlockSyncReq(chainID uint32, sender string, msg proto.Message, done chan bool) {
+func (d *IotxDispatcher) dispatchBlockSyncReq(chainID uint32, sender string, msg proto.Message, done chan bool) error {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
 		if done != nil {
 			close(done)
 		}
-		return
+		return nil
 	}
-	d.enqueueEvent(&blockSyncMsg{chainID, sender, (msg).(*pb.BlockSync), done})
+	return d.enqueueEvent(&blockSyncMsg{chainID, sender, (msg).(*pb.BlockSync), done})
 }
 
 // dispatchBlockSyncData handles block sync data
-func (d *IotxDispatcher) dispatchBlockSyncData(chainID uint32, msg proto.Message, done chan bool) {
+func (d *IotxDispatcher) dispatchBlockSyncData(chainID uint32, msg proto.Message, done chan bool) error {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
 		if done != nil {
 			close(done)
 		}
-		return
+		return nil
 	}
 	data := (msg).(*pb.BlockContainer)
-	d.enqueueEvent(&blockMsg{chainID, data.Block, pb.MsgBlockSyncDataType, done})
+	return d.enqueueEvent(&blockMsg{chainID, data.Block, pb.MsgBlockSyncDataType, done})
 }
 
 // HandleBroadcast handles incoming broadcast message
-func (d *IotxDispatcher) HandleBroadcast(chainID uint32, message proto.Message, done chan bool) {
+func (d *IotxDispatcher) HandleBroadcast(chainID uint32, message proto.Message, done chan bool) error {
 	msgType, err := pb.GetTypeFromProtoMsg(message)
 	if err != nil {
 		logger.Warn().
 			Str("error", err.Error()).
 			Msg("unexpected message handled by HandleBroadcast")
 	}
-	subscriber, ok := d.subscribers[chainID]
+	d.subscribersMU.RLock()
+	_, ok := d.subscribers[chainID]
+	d.subscribersMU.RUnlock()
 	if !ok {
 		logger.Warn().
 			Uint32("chainID", chainID).
 			Msg("chainID has not been registered in dispatcher")
-		return
+		return nil
 	}
 
 	switch msgType {
-	case pb.MsgProposeProtoMsgType:
-		err := subscriber.HandleBlockPropose(message.(*pb.ProposePb))
-		if err != nil {
+	case pb.MsgProposeProtoMsgType, pb.MsgEndorseProtoMsgType:
+		return d.handleConsensusMsg(chainID, msgType, message, done)
+	case pb.MsgActionType:
+		return d.dispatchAction(chainID, message, done)
+	case pb.MsgBlockProtoMsgType:
+		return d.dispatchBlockCommit(chainID, message, done)
+	default:
+		logger.Warn().
+			Uint32("msgType", msgType).
+			Msg("unexpected msgType handled by HandleBroadcast")
+		return nil
+	}
+}
+
+// handleConsensusMsg hands the consensus message to the subscriber right away. The read lock of the subscribers is held
+// until the subscriber is done, so that RemoveSubscriber waits for the message being handled
+func (d *IotxDispatcher) handleConsensusMsg(chainID uint32, msgType uint32, message proto.Message, done chan bool) error {
+	d.subscribersMU.RLock()
+	defer d.subscribersMU.RUnlock()
+	subscriber, ok := d.subscribers[chainID]
+	if !ok {
+		// the subscriber has just been removed
+		return nil
+	}
+
+	var err error
+	if msgType == pb.MsgProposeProtoMsgType {
+		if err = subscriber.HandleBlockPropose(message.(*pb.ProposePb)); err != nil {
 			logger.Error().
 				Err(err).
 				Msg("failed to handle block propose")
 		}
-		if done != nil {
-			done <- true
-		}
-	case pb.MsgEndorseProtoMsgType:
-		err := subscriber.HandleEndorse(message.(*pb.EndorsePb))
-		if err != nil {
+	} else {
+		if err = subscriber.HandleEndorse(message.(*pb.EndorsePb)); err != nil {
 			logger.Error().
 				Err(err).
 				Msg("failed to handle endorse")
 		}
-		if done != nil {
-			done <- true
-		}
-	case pb.MsgActionType:
-		d.dispatchAction(chainID, message, done)
-	case pb.MsgBlockProtoMsgType:
-		d.dispatchBlockCommit(chainID, message, done)
-	default:
-		logger.Warn().
-			Uint32("msgType", msgType).
-			Msg("unexpected msgType handled by HandleBroadcast")
 	}
+	if done != nil {
+		done <- true
+	}
+	return err
 }
 
 // HandleTell handles incoming unicast message
-func (d *IotxDispatcher) HandleTell(chainID uint32, sender net.Addr, message proto.Message, done chan bool) {
+func (d *IotxDispatcher) HandleTell(chainID uint32, sender net.Addr, message proto.Message, done chan bool) error {
 	msgType, err := pb.GetTypeFromProtoMsg(message)
 	if err != nil {
 		logger.Warn().
@@ -362,17 +446,25 @@ func (d *IotxDispatcher) HandleTell(chainID uint32, sender net.Addr, message pro
 	}
 	switch msgType {
 	case pb.MsgBlockSyncReqType:
-		d.dispatchBlockSyncReq(chainID, sender.String(), message, done)
+		return d.dispatchBlockSyncReq(chainID, sender.String(), message, done)
 	case pb.MsgBlockSyncDataType:
-		d.dispatchBlockSyncData(chainID, message, done)
+		return d.dispatchBlockSyncData(chainID, message, done)
 	default:
 		logger.Warn().
 			Uint32("msgType", msgType).
 			Msg("unexpected msgType handled by HandleTell")
+		return nil
 	}
 }
 
-func (d *IotxDispatcher) enqueueEvent(event interface{}) {
+// enqueueEvent queues the event to be handled by the news handler, or handles it right away in sync mode, in which
+// case the error of the handler is returned
+func (d *IotxDispatcher) enqueueEvent(event interface{}) error {
+	if d.sync {
+		d.syncLock.Lock()
+		defer d.syncLock.Unlock()
+		return d.handleEvent(event)
+	}
 	go func() {
 		if len(d.eventChan) == cap(d.eventChan) {
 			logger.Warn().Msg("dispatcher event chan is full, drop an event")
@@ -380,6 +472,7 @@ func (d *IotxDispatcher) enqueueEvent(event interface{}) {
 		}
 		d.eventChan <- event
 	}()
+	return nil
 }
 
 func (d *IotxDispatcher) updateEventAudit(t uint32) {
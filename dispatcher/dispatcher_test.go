@@ -8,10 +8,13 @@ package dispatcher
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/iotexproject/iotex-core/config"
@@ -89,6 +92,125 @@ func TestHandleTell(t *testing.T) {
 	}
 }
 
+func TestRemoveSubscriber(t *testing.T) {
+	ctx, d := startDispatcher(t)
+	defer stopDispatcher(ctx, d, t)
+
+	chainID := config.Default.Chain.ID + 1
+	subscriber := &countingSubscriber{}
+	d.AddSubscriber(chainID, subscriber)
+
+	done := make(chan bool, 1)
+	d.HandleBroadcast(chainID, &pb.ActionPb{}, done)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("action is not handled")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&subscriber.numActions))
+
+	d.RemoveSubscriber(chainID)
+	d.HandleBroadcast(chainID, &pb.ActionPb{}, done)
+	d.HandleBroadcast(chainID, &pb.EndorsePb{}, done)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&subscriber.numActions))
+	assert.Equal(t, 0, len(done))
+}
+
+func TestRemoveSubscriberWhileHandling(t *testing.T) {
+	ctx, d := startDispatcher(t)
+	defer stopDispatcher(ctx, d, t)
+
+	chainID := config.Default.Chain.ID + 1
+	subscriber := &blockingSubscriber{started: make(chan struct{}), release: make(chan struct{})}
+	d.AddSubscriber(chainID, subscriber)
+
+	go d.HandleBroadcast(chainID, &pb.ProposePb{}, nil)
+	select {
+	case <-subscriber.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("propose is not handled")
+	}
+	removed := make(chan struct{})
+	go func() {
+		d.RemoveSubscriber(chainID)
+		close(removed)
+	}()
+	// RemoveSubscriber waits for the propose being handled
+	select {
+	case <-removed:
+		t.Fatal("subscriber is removed while handling the propose")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(subscriber.release)
+	select {
+	case <-removed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscriber is not removed")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&subscriber.numProposes))
+}
+
+func TestSyncDispatch(t *testing.T) {
+	cfg := &config.Config{
+		Consensus:  config.Consensus{Scheme: config.NOOPScheme},
+		Dispatcher: config.Dispatcher{EventChanSize: 1024},
+	}
+	d, err := NewDispatcher(cfg, WithSyncDispatch())
+	assert.NoError(t, err)
+	subscriber := &countingSubscriber{}
+	d.AddSubscriber(config.Default.Chain.ID, subscriber)
+	ctx := context.Background()
+	assert.NoError(t, d.Start(ctx))
+	defer stopDispatcher(ctx, d, t)
+
+	done := make(chan bool, 1)
+	for i := 1; i <= 10; i++ {
+		assert.NoError(t, d.HandleBroadcast(config.Default.Chain.ID, &pb.ActionPb{}, done))
+		// the action has been handled without waiting
+		assert.Equal(t, int32(i), atomic.LoadInt32(&subscriber.numActions))
+		assert.True(t, <-done)
+	}
+
+	// the error of the subscriber is returned to the caller
+	d.AddSubscriber(config.Default.Chain.ID+1, &failingSubscriber{})
+	err = d.HandleBroadcast(config.Default.Chain.ID+1, &pb.ActionPb{}, nil)
+	assert.Equal(t, errHandleAction, errors.Cause(err))
+}
+
+type countingSubscriber struct {
+	DummySubscriber
+	numActions int32
+}
+
+func (s *countingSubscriber) HandleAction(*pb.ActionPb) error {
+	atomic.AddInt32(&s.numActions, 1)
+	return nil
+}
+
+type blockingSubscriber struct {
+	DummySubscriber
+	started     chan struct{}
+	release     chan struct{}
+	numProposes int32
+}
+
+func (s *blockingSubscriber) HandleBlockPropose(*pb.ProposePb) error {
+	close(s.started)
+	<-s.release
+	atomic.AddInt32(&s.numProposes, 1)
+	return nil
+}
+
+var errHandleAction = errors.New("failed to handle action")
+
+type failingSubscriber struct {
+	DummySubscriber
+}
+
+func (s *failingSubscriber) HandleAction(*pb.ActionPb) error {
+	return errHandleAction
+}
+
 type DummySubscriber struct {
 }
 
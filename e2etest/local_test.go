@@ -9,10 +9,14 @@ package e2etest
 import (
 	"context"
 	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -850,6 +854,85 @@ func TestBlockchainRecovery(t *testing.T) {
 	require.NoError(svr.Start(ctx))
 }
 
+func TestServerMetrics(t *testing.T) {
+	require := require.New(t)
+
+	testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+
+	cfg, err := newTestConfig()
+	require.Nil(err)
+
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg, itx.WithMetricsRegisterer(prometheus.NewRegistry()))
+	require.Nil(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.Nil(svr.Stop(ctx))
+		testutil.CleanupPath(t, testTriePath)
+		testutil.CleanupPath(t, testDBPath)
+	}()
+
+	rec := httptest.NewRecorder()
+	svr.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	for _, name := range []string{
+		"iotex_p2p_peers",
+		"iotex_actpool_size",
+		"iotex_blockchain_height",
+		"iotex_blocksync_height",
+		"iotex_blocksync_target_height",
+	} {
+		require.Contains(body, name)
+	}
+}
+
+func TestStandaloneServer(t *testing.T) {
+	require := require.New(t)
+
+	testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+
+	cfg, err := newTestConfig()
+	require.Nil(err)
+	cfg.Network.Disabled = true
+	// the configured port stays free, as nothing listens on it
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	cfg.Network.Port = l.Addr().(*net.TCPAddr).Port
+	require.NoError(l.Close())
+
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg)
+	require.Nil(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.Nil(svr.Stop(ctx))
+		testutil.CleanupPath(t, testTriePath)
+		testutil.CleanupPath(t, testDBPath)
+	}()
+
+	_, ok := svr.P2P().(*network.NoopOverlay)
+	require.True(ok)
+	l, err = net.Listen("tcp", svr.P2P().Self().String())
+	require.NoError(err)
+	require.NoError(l.Close())
+
+	// the chain works locally
+	bc := svr.ChainService(cfg.Chain.ID).Blockchain()
+	require.NoError(addTestingTsfBlocks(bc))
+	status := svr.Status()
+	require.False(status.P2P)
+	require.Equal(0, status.NumPeers)
+	require.Equal(bc.TipHeight(), status.Height)
+
+	// the genesis hash is reported even if there is no peer to check it against
+	genesisHash, err := blockchain.GenesisHash(cfg)
+	require.NoError(err)
+	require.Equal(genesisHash, svr.GenesisHash())
+}
+
 func newTestConfig() (*config.Config, error) {
 	cfg := config.Default
 	cfg.Chain.TrieDBPath = testTriePath
@@ -0,0 +1,227 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coopernurse/barrister-go"
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// serviceUnavailableCode is the JSON-RPC error code of the requests rejected by breakerFilter while the backend is
+// failing, which is the equivalent of HTTP 503 Service Unavailable
+const serviceUnavailableCode = -32003
+
+// DegradedHeader is the HTTP header set to "true" on the responses served while the backend is failing, in which the
+// results of the read methods could be the last known ones instead of the current ones
+const DegradedHeader = "X-Explorer-Degraded"
+
+// maxLastKnownResults is the max number of the last known results kept by a circuit breaker
+const maxLastKnownResults = 1000
+
+// requestErrors are the causes of the errors due to the requests rather than the backend, which don't count as
+// failures of the backend
+var requestErrors = map[error]bool{
+	ErrTransfer:                         true,
+	ErrVote:                             true,
+	ErrExecution:                        true,
+	ErrReceipt:                          true,
+	ErrAction:                           true,
+	ErrBlock:                            true,
+	ErrInvalidAddress:                   true,
+	db.ErrNotExist:                      true,
+	state.ErrAccountNotExist:            true,
+	state.ErrHistoricalStateUnavailable: true,
+}
+
+// circuitBreaker stops calling the backend after a number of consecutive failures, and lets one request through to
+// probe it after every cooldown, until the probe succeeds. Meanwhile, the read methods are served with the last known
+// results. It is safe for concurrent access.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	clk       clock.Clock
+	mutex     sync.Mutex
+	failures  int
+	// openedAt is when the breaker opened or the last probe was let through, and is zero if the breaker is closed
+	openedAt time.Time
+	results  map[string]interface{}
+}
+
+// newCircuitBreaker creates a circuit breaker opening after threshold consecutive failures. It returns nil, i.e., the
+// backend is always called, if threshold is not positive.
+func newCircuitBreaker(threshold int, cooldown time.Duration, clk clock.Clock) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		clk:       clk,
+		results:   make(map[string]interface{}),
+	}
+}
+
+// degraded returns true if the breaker is open
+func (b *circuitBreaker) degraded() bool {
+	if b == nil {
+		return false
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return !b.openedAt.IsZero()
+}
+
+// status returns the state of the breaker
+func (b *circuitBreaker) status() explorer.ExplorerStatus {
+	if b == nil {
+		return explorer.ExplorerStatus{}
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return explorer.ExplorerStatus{
+		Degraded:            !b.openedAt.IsZero(),
+		ConsecutiveFailures: int64(b.failures),
+	}
+}
+
+// allow returns true if the backend should be called, which is either when the breaker is closed, or when a probe is
+// due after the cooldown
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.openedAt.IsZero() {
+		return true
+	}
+	now := b.clk.Now()
+	if now.Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.openedAt = now
+	return true
+}
+
+// succeed closes the breaker and records the result of the request
+func (b *circuitBreaker) succeed(key string, result interface{}) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if !b.openedAt.IsZero() {
+		logger.Info().Int("failures", b.failures).Msg("Explorer backend recovered")
+	}
+	b.failures = 0
+	b.openedAt = time.Time{}
+	if _, ok := b.results[key]; !ok && len(b.results) >= maxLastKnownResults {
+		// the map is iterated in random order, so a random result is evicted
+		for k := range b.results {
+			delete(b.results, k)
+			break
+		}
+	}
+	b.results[key] = result
+}
+
+// fail counts a failure, and opens the breaker if the failures reach the threshold
+func (b *circuitBreaker) fail(err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.failures++
+	if b.failures < b.threshold {
+		return
+	}
+	if b.openedAt.IsZero() {
+		logger.Error().Err(err).Int("failures", b.failures).Msg("Explorer backend is failing, serving degraded")
+	}
+	b.openedAt = b.clk.Now()
+}
+
+// lastKnownResult returns the result of the last successful request of the key
+func (b *circuitBreaker) lastKnownResult(key string) (interface{}, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	result, ok := b.results[key]
+	return result, ok
+}
+
+// breakerFilter guards the backend of the read methods by a circuit breaker. While the breaker is open, the requests
+// are replied with the last known results of the same method and params, or with an error if there is none.
+type breakerFilter struct {
+	breaker *circuitBreaker
+}
+
+// PreInvoke short-circuits the request if the breaker is open
+func (f breakerFilter) PreInvoke(r *barrister.RequestResponse) bool {
+	if !guarded(r.Method) || f.breaker.allow() {
+		return true
+	}
+	key, err := requestKey(r)
+	if err == nil {
+		if result, ok := f.breaker.lastKnownResult(key); ok {
+			r.Result = result
+			return false
+		}
+	}
+	r.Err = &barrister.JsonRpcError{Code: serviceUnavailableCode, Message: "explorer backend unavailable"}
+	return false
+}
+
+// PostInvoke records the outcome of the request in the breaker
+func (f breakerFilter) PostInvoke(r *barrister.RequestResponse) bool {
+	if !guarded(r.Method) {
+		return true
+	}
+	if r.Err != nil {
+		if _, ok := r.Err.(*barrister.JsonRpcError); !ok && !requestErrors[errors.Cause(r.Err)] {
+			f.breaker.fail(r.Err)
+		}
+		return true
+	}
+	key, err := requestKey(r)
+	if err != nil {
+		logger.Warn().Err(err).Str("method", r.Method).Msg("Failed to record the result of explorer request")
+		return true
+	}
+	f.breaker.succeed(key, r.Result)
+	return true
+}
+
+// guarded returns true if the method is guarded by the breaker, which are the read methods except the admin ones and
+// the one reporting the state of the breaker
+func guarded(method string) bool {
+	return !writeMethods[method] && !adminMethods[method] && method != statusMethod
+}
+
+// requestKey identifies the requests of the same method and params
+func requestKey(r *barrister.RequestResponse) (string, error) {
+	params, err := json.Marshal(r.Params)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal the params of method %s", r.Method)
+	}
+	return r.Method + string(params), nil
+}
+
+// withDegradedHeader sets DegradedHeader on the responses if the breaker is open when the request comes
+func withDegradedHeader(next http.Handler, breaker *circuitBreaker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if breaker.degraded() {
+			w.Header().Set(DegradedHeader, "true")
+		}
+		next.ServeHTTP(w, req)
+	})
+}
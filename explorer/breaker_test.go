@@ -0,0 +1,130 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coopernurse/barrister-go"
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(newCircuitBreaker(0, time.Second, clock.New()))
+	var noBreaker *circuitBreaker
+	require.True(noBreaker.allow())
+	require.False(noBreaker.degraded())
+	require.Equal(explorer.ExplorerStatus{}, noBreaker.status())
+
+	clk := clock.NewMock()
+	b := newCircuitBreaker(2, time.Second, clk)
+	b.fail(errors.New("db down"))
+	require.True(b.allow())
+	// a success resets the consecutive failures
+	b.succeed("key", int64(1))
+	b.fail(errors.New("db down"))
+	require.True(b.allow())
+	b.fail(errors.New("db down"))
+	require.False(b.allow())
+	require.True(b.degraded())
+	require.Equal(explorer.ExplorerStatus{Degraded: true, ConsecutiveFailures: 2}, b.status())
+
+	// one request probes the backend after the cooldown
+	clk.Add(time.Second)
+	require.True(b.allow())
+	require.False(b.allow())
+	b.fail(errors.New("db down"))
+	clk.Add(500 * time.Millisecond)
+	require.False(b.allow())
+	clk.Add(500 * time.Millisecond)
+	require.True(b.allow())
+	b.succeed("key", int64(2))
+	require.False(b.degraded())
+	require.True(b.allow())
+	result, ok := b.lastKnownResult("key")
+	require.True(ok)
+	require.Equal(int64(2), result)
+}
+
+func TestBreakerFilter(t *testing.T) {
+	require := require.New(t)
+
+	clk := clock.NewMock()
+	f := breakerFilter{breaker: newCircuitBreaker(2, time.Second, clk)}
+	svc := Service{breaker: f.breaker}
+	request := func(method string, params ...interface{}) *barrister.RequestResponse {
+		return &barrister.RequestResponse{Method: method, Params: params}
+	}
+	call := func(r *barrister.RequestResponse, result interface{}, err error) bool {
+		if !f.PreInvoke(r) {
+			return false
+		}
+		r.Result, r.Err = result, err
+		f.PostInvoke(r)
+		return true
+	}
+
+	require.True(call(request("Explorer.getAddressBalance", "io1a"), int64(10), nil))
+	// the errors due to the requests don't count
+	for i := 0; i < 3; i++ {
+		require.True(call(request("Explorer.getAddressBalance", "io1"), nil, ErrInvalidAddress))
+	}
+	require.True(call(request("Explorer.getBlockchainHeight"), nil, errors.New("db down")))
+	require.True(call(request("Explorer.getBlockchainHeight"), nil, errors.New("db down")))
+	status, err := svc.GetExplorerStatus()
+	require.Nil(err)
+	require.True(status.Degraded)
+	require.Equal(int64(2), status.ConsecutiveFailures)
+
+	// the last known result is returned while the backend is failing
+	r := request("Explorer.getAddressBalance", "io1a")
+	require.False(call(r, nil, nil))
+	require.Nil(r.Err)
+	require.Equal(int64(10), r.Result)
+	r = request("Explorer.getAddressBalance", "io1b")
+	require.False(call(r, nil, nil))
+	rpcErr, ok := r.Err.(*barrister.JsonRpcError)
+	require.True(ok)
+	require.Equal(serviceUnavailableCode, rpcErr.Code)
+
+	// the writes and the status are not guarded
+	require.True(call(request("Explorer.sendTransfer"), nil, errors.New("db down")))
+	require.True(call(request("Explorer.getExplorerStatus"), explorer.ExplorerStatus{}, nil))
+
+	// the backend recovers
+	clk.Add(time.Second)
+	require.True(call(request("Explorer.getAddressBalance", "io1a"), int64(20), nil))
+	status, err = svc.GetExplorerStatus()
+	require.Nil(err)
+	require.False(status.Degraded)
+	r = request("Explorer.getAddressBalance", "io1b")
+	require.True(f.PreInvoke(r))
+}
+
+func TestWithDegradedHeader(t *testing.T) {
+	require := require.New(t)
+
+	b := newCircuitBreaker(1, time.Second, clock.NewMock())
+	handler := withDegradedHeader(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}), b)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+	require.Empty(w.Header().Get(DegradedHeader))
+
+	b.fail(errors.New("db down"))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+	require.Equal("true", w.Header().Get(DegradedHeader))
+}
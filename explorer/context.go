@@ -0,0 +1,1188 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+)
+
+// ContextExplorer is the explorer whose methods have a ...Ctx variant taking a context as the first parameter. A
+// ...Ctx method stops its work and returns ctx.Err() once ctx is cancelled or its deadline is exceeded, except that an
+// action is never cancelled once it is being submitted.
+type ContextExplorer interface {
+	explorer.Explorer
+	GetBlockchainHeightCtx(ctx context.Context) (int64, error)
+	GetAddressBalanceCtx(ctx context.Context, address string) (int64, error)
+	GetAddressBalanceAtHeightCtx(ctx context.Context, address string, height int64) (int64, error)
+	GetAddressDetailsCtx(ctx context.Context, address string) (explorer.AddressDetails, error)
+	GetAddressNonceCtx(ctx context.Context, address string) (int64, error)
+	GetAddressStateCtx(ctx context.Context, address string) (explorer.AccountState, error)
+	GetLastTransfersByRangeCtx(ctx context.Context, startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]explorer.Transfer, error)
+	GetLastTransfersByRangeV2Ctx(ctx context.Context, startBlockHeight int64, offset int64, limit int64, showCoinBase bool) (explorer.TransferPage, error)
+	GetTransferByIDCtx(ctx context.Context, transferID string) (explorer.Transfer, error)
+	GetTransferProofCtx(ctx context.Context, transferID string) (explorer.MerkleProof, error)
+	GetTransfersByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Transfer, error)
+	GetUnconfirmedTransfersByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Transfer, error)
+	GetTransfersByBlockIDCtx(ctx context.Context, blkID string, offset int64, limit int64) ([]explorer.Transfer, error)
+	GetTransfersByAmountRangeCtx(ctx context.Context, minAmount int64, maxAmount int64, offset int64, limit int64) ([]explorer.Transfer, error)
+	GetLastVotesByRangeCtx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) ([]explorer.Vote, error)
+	GetLastVotesByRangeV2Ctx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) (explorer.VotePage, error)
+	GetVoteByIDCtx(ctx context.Context, voteID string) (explorer.Vote, error)
+	GetVotesByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error)
+	GetVotesFromAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error)
+	GetVotesToAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error)
+	GetUnconfirmedVotesByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error)
+	GetVotesByBlockIDCtx(ctx context.Context, blkID string, offset int64, limit int64) ([]explorer.Vote, error)
+	GetLastExecutionsByRangeCtx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) ([]explorer.Execution, error)
+	GetLastExecutionsByRangeV2Ctx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) (explorer.ExecutionPage, error)
+	GetExecutionByIDCtx(ctx context.Context, executionID string) (explorer.Execution, error)
+	GetExecutionsByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Execution, error)
+	GetUnconfirmedExecutionsByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Execution, error)
+	GetPendingActionsByAddressCtx(ctx context.Context, address string, offset int64, limit int64) (explorer.PendingActions, error)
+	GetExecutionsByBlockIDCtx(ctx context.Context, blkID string, offset int64, limit int64) ([]explorer.Execution, error)
+	GetLastBlocksByRangeCtx(ctx context.Context, offset int64, limit int64) ([]explorer.Block, error)
+	GetBlockByIDCtx(ctx context.Context, blkID string) (explorer.Block, error)
+	GetBlockByHeightCtx(ctx context.Context, height int64) (explorer.Block, error)
+	GetCoinStatisticCtx(ctx context.Context) (explorer.CoinStatistic, error)
+	GetFeeStatisticCtx(ctx context.Context, blockCount int64) (explorer.FeeStatistic, error)
+	GetConsensusMetricsCtx(ctx context.Context) (explorer.ConsensusMetrics, error)
+	GetEpochMetaCtx(ctx context.Context, epochNum int64) (explorer.EpochMeta, error)
+	GetProducerStatsCtx(ctx context.Context, address string) (explorer.ProducerStats, error)
+	GetGenesisHashCtx(ctx context.Context) (string, error)
+	GetExplorerStatusCtx(ctx context.Context) (explorer.ExplorerStatus, error)
+	GetServerConfigCtx(ctx context.Context) (string, error)
+	GetCandidateMetricsCtx(ctx context.Context) (explorer.CandidateMetrics, error)
+	GetCandidateMetricsByHeightCtx(ctx context.Context, h int64) (explorer.CandidateMetrics, error)
+	GetCandidatesCtx(ctx context.Context, height int64) ([]explorer.Candidate, error)
+	SendTransferCtx(ctx context.Context, request explorer.SendTransferRequest) (explorer.SendTransferResponse, error)
+	SendVoteCtx(ctx context.Context, request explorer.SendVoteRequest) (explorer.SendVoteResponse, error)
+	SendSmartContractCtx(ctx context.Context, request explorer.Execution) (explorer.SendSmartContractResponse, error)
+	SendActionCtx(ctx context.Context, rawAction string) (string, error)
+	GetPeersCtx(ctx context.Context) (explorer.GetPeersResponse, error)
+	GetReceiptByExecutionIDCtx(ctx context.Context, id string) (explorer.Receipt, error)
+	GetReceiptByActionIDCtx(ctx context.Context, id string) (explorer.Receipt, error)
+	GetLogsByBlockRangeCtx(ctx context.Context, fromHeight int64, toHeight int64, contract string, topics []string) ([]explorer.Log, error)
+	ReadExecutionStateCtx(ctx context.Context, request explorer.Execution) (string, error)
+	EstimateGasCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.GasEstimate, error)
+	CallContractCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.CallResult, error)
+	EstimateGasPendingCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.GasEstimate, error)
+	CallContractPendingCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.CallResult, error)
+	MulticallCtx(ctx context.Context, requests []explorer.Request) ([]explorer.Response, error)
+	GetBlockOrActionByHashCtx(ctx context.Context, hashStr string) (explorer.GetBlkOrActResponse, error)
+	GetActionByIDCtx(ctx context.Context, actionID string) (explorer.Action, error)
+}
+
+// cancelledBy returns a copy of the service whose reads of the blockchain are cancelled by ctx. The methods run in
+// the calling goroutine, and check ctx before reading every block or action, so nothing keeps using the backend
+// after ctx is done
+func (exp *Service) cancelledBy(ctx context.Context) *Service {
+	svc := *exp
+	svc.ctx = ctx
+	return &svc
+}
+
+// ctxErr returns the error of the context cancelling the service, or nil if the service isn't cancellable
+func (exp *Service) ctxErr() error {
+	if exp.ctx == nil {
+		return nil
+	}
+	return exp.ctx.Err()
+}
+
+// withContext binds the context to the ...Ctx methods of the explorer, so that the JSON-RPC server, which invokes the
+// explorer methods without a context, calls them with the context of the request
+func withContext(ctx context.Context, exp ContextExplorer) explorer.Explorer {
+	return &contextBoundExplorer{ctx: ctx, exp: exp}
+}
+
+// contextBoundExplorer implements the explorer by calling the ...Ctx methods with a bound context
+type contextBoundExplorer struct {
+	ctx context.Context
+	exp ContextExplorer
+}
+
+// GetBlockchainHeight calls GetBlockchainHeightCtx with the bound context
+func (b *contextBoundExplorer) GetBlockchainHeight() (int64, error) {
+	return b.exp.GetBlockchainHeightCtx(b.ctx)
+}
+
+// GetAddressBalance calls GetAddressBalanceCtx with the bound context
+func (b *contextBoundExplorer) GetAddressBalance(address string) (int64, error) {
+	return b.exp.GetAddressBalanceCtx(b.ctx, address)
+}
+
+// GetAddressBalanceAtHeight calls GetAddressBalanceAtHeightCtx with the bound context
+func (b *contextBoundExplorer) GetAddressBalanceAtHeight(address string, height int64) (int64, error) {
+	return b.exp.GetAddressBalanceAtHeightCtx(b.ctx, address, height)
+}
+
+// GetAddressDetails calls GetAddressDetailsCtx with the bound context
+func (b *contextBoundExplorer) GetAddressDetails(address string) (explorer.AddressDetails, error) {
+	return b.exp.GetAddressDetailsCtx(b.ctx, address)
+}
+
+// GetAddressNonce calls GetAddressNonceCtx with the bound context
+func (b *contextBoundExplorer) GetAddressNonce(address string) (int64, error) {
+	return b.exp.GetAddressNonceCtx(b.ctx, address)
+}
+
+// GetAddressState calls GetAddressStateCtx with the bound context
+func (b *contextBoundExplorer) GetAddressState(address string) (explorer.AccountState, error) {
+	return b.exp.GetAddressStateCtx(b.ctx, address)
+}
+
+// GetLastTransfersByRange calls GetLastTransfersByRangeCtx with the bound context
+func (b *contextBoundExplorer) GetLastTransfersByRange(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]explorer.Transfer, error) {
+	return b.exp.GetLastTransfersByRangeCtx(b.ctx, startBlockHeight, offset, limit, showCoinBase)
+}
+
+// GetLastTransfersByRangeV2 calls GetLastTransfersByRangeV2Ctx with the bound context
+func (b *contextBoundExplorer) GetLastTransfersByRangeV2(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) (explorer.TransferPage, error) {
+	return b.exp.GetLastTransfersByRangeV2Ctx(b.ctx, startBlockHeight, offset, limit, showCoinBase)
+}
+
+// GetTransferByID calls GetTransferByIDCtx with the bound context
+func (b *contextBoundExplorer) GetTransferByID(transferID string) (explorer.Transfer, error) {
+	return b.exp.GetTransferByIDCtx(b.ctx, transferID)
+}
+
+// GetTransferProof calls GetTransferProofCtx with the bound context
+func (b *contextBoundExplorer) GetTransferProof(transferID string) (explorer.MerkleProof, error) {
+	return b.exp.GetTransferProofCtx(b.ctx, transferID)
+}
+
+// GetTransfersByAddress calls GetTransfersByAddressCtx with the bound context
+func (b *contextBoundExplorer) GetTransfersByAddress(address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	return b.exp.GetTransfersByAddressCtx(b.ctx, address, offset, limit)
+}
+
+// GetUnconfirmedTransfersByAddress calls GetUnconfirmedTransfersByAddressCtx with the bound context
+func (b *contextBoundExplorer) GetUnconfirmedTransfersByAddress(address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	return b.exp.GetUnconfirmedTransfersByAddressCtx(b.ctx, address, offset, limit)
+}
+
+// GetTransfersByBlockID calls GetTransfersByBlockIDCtx with the bound context
+func (b *contextBoundExplorer) GetTransfersByBlockID(blkID string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	return b.exp.GetTransfersByBlockIDCtx(b.ctx, blkID, offset, limit)
+}
+
+// GetTransfersByAmountRange calls GetTransfersByAmountRangeCtx with the bound context
+func (b *contextBoundExplorer) GetTransfersByAmountRange(minAmount int64, maxAmount int64, offset int64, limit int64) ([]explorer.Transfer, error) {
+	return b.exp.GetTransfersByAmountRangeCtx(b.ctx, minAmount, maxAmount, offset, limit)
+}
+
+// GetLastVotesByRange calls GetLastVotesByRangeCtx with the bound context
+func (b *contextBoundExplorer) GetLastVotesByRange(startBlockHeight int64, offset int64, limit int64) ([]explorer.Vote, error) {
+	return b.exp.GetLastVotesByRangeCtx(b.ctx, startBlockHeight, offset, limit)
+}
+
+// GetLastVotesByRangeV2 calls GetLastVotesByRangeV2Ctx with the bound context
+func (b *contextBoundExplorer) GetLastVotesByRangeV2(startBlockHeight int64, offset int64, limit int64) (explorer.VotePage, error) {
+	return b.exp.GetLastVotesByRangeV2Ctx(b.ctx, startBlockHeight, offset, limit)
+}
+
+// GetVoteByID calls GetVoteByIDCtx with the bound context
+func (b *contextBoundExplorer) GetVoteByID(voteID string) (explorer.Vote, error) {
+	return b.exp.GetVoteByIDCtx(b.ctx, voteID)
+}
+
+// GetVotesByAddress calls GetVotesByAddressCtx with the bound context
+func (b *contextBoundExplorer) GetVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	return b.exp.GetVotesByAddressCtx(b.ctx, address, offset, limit)
+}
+
+// GetVotesFromAddress calls GetVotesFromAddressCtx with the bound context
+func (b *contextBoundExplorer) GetVotesFromAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	return b.exp.GetVotesFromAddressCtx(b.ctx, address, offset, limit)
+}
+
+// GetVotesToAddress calls GetVotesToAddressCtx with the bound context
+func (b *contextBoundExplorer) GetVotesToAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	return b.exp.GetVotesToAddressCtx(b.ctx, address, offset, limit)
+}
+
+// GetUnconfirmedVotesByAddress calls GetUnconfirmedVotesByAddressCtx with the bound context
+func (b *contextBoundExplorer) GetUnconfirmedVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	return b.exp.GetUnconfirmedVotesByAddressCtx(b.ctx, address, offset, limit)
+}
+
+// GetVotesByBlockID calls GetVotesByBlockIDCtx with the bound context
+func (b *contextBoundExplorer) GetVotesByBlockID(blkID string, offset int64, limit int64) ([]explorer.Vote, error) {
+	return b.exp.GetVotesByBlockIDCtx(b.ctx, blkID, offset, limit)
+}
+
+// GetLastExecutionsByRange calls GetLastExecutionsByRangeCtx with the bound context
+func (b *contextBoundExplorer) GetLastExecutionsByRange(startBlockHeight int64, offset int64, limit int64) ([]explorer.Execution, error) {
+	return b.exp.GetLastExecutionsByRangeCtx(b.ctx, startBlockHeight, offset, limit)
+}
+
+// GetLastExecutionsByRangeV2 calls GetLastExecutionsByRangeV2Ctx with the bound context
+func (b *contextBoundExplorer) GetLastExecutionsByRangeV2(startBlockHeight int64, offset int64, limit int64) (explorer.ExecutionPage, error) {
+	return b.exp.GetLastExecutionsByRangeV2Ctx(b.ctx, startBlockHeight, offset, limit)
+}
+
+// GetExecutionByID calls GetExecutionByIDCtx with the bound context
+func (b *contextBoundExplorer) GetExecutionByID(executionID string) (explorer.Execution, error) {
+	return b.exp.GetExecutionByIDCtx(b.ctx, executionID)
+}
+
+// GetExecutionsByAddress calls GetExecutionsByAddressCtx with the bound context
+func (b *contextBoundExplorer) GetExecutionsByAddress(address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	return b.exp.GetExecutionsByAddressCtx(b.ctx, address, offset, limit)
+}
+
+// GetUnconfirmedExecutionsByAddress calls GetUnconfirmedExecutionsByAddressCtx with the bound context
+func (b *contextBoundExplorer) GetUnconfirmedExecutionsByAddress(address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	return b.exp.GetUnconfirmedExecutionsByAddressCtx(b.ctx, address, offset, limit)
+}
+
+// GetPendingActionsByAddress calls GetPendingActionsByAddressCtx with the bound context
+func (b *contextBoundExplorer) GetPendingActionsByAddress(address string, offset int64, limit int64) (explorer.PendingActions, error) {
+	return b.exp.GetPendingActionsByAddressCtx(b.ctx, address, offset, limit)
+}
+
+// GetExecutionsByBlockID calls GetExecutionsByBlockIDCtx with the bound context
+func (b *contextBoundExplorer) GetExecutionsByBlockID(blkID string, offset int64, limit int64) ([]explorer.Execution, error) {
+	return b.exp.GetExecutionsByBlockIDCtx(b.ctx, blkID, offset, limit)
+}
+
+// GetLastBlocksByRange calls GetLastBlocksByRangeCtx with the bound context
+func (b *contextBoundExplorer) GetLastBlocksByRange(offset int64, limit int64) ([]explorer.Block, error) {
+	return b.exp.GetLastBlocksByRangeCtx(b.ctx, offset, limit)
+}
+
+// GetBlockByID calls GetBlockByIDCtx with the bound context
+func (b *contextBoundExplorer) GetBlockByID(blkID string) (explorer.Block, error) {
+	return b.exp.GetBlockByIDCtx(b.ctx, blkID)
+}
+
+// GetBlockByHeight calls GetBlockByHeightCtx with the bound context
+func (b *contextBoundExplorer) GetBlockByHeight(height int64) (explorer.Block, error) {
+	return b.exp.GetBlockByHeightCtx(b.ctx, height)
+}
+
+// GetCoinStatistic calls GetCoinStatisticCtx with the bound context
+func (b *contextBoundExplorer) GetCoinStatistic() (explorer.CoinStatistic, error) {
+	return b.exp.GetCoinStatisticCtx(b.ctx)
+}
+
+// GetFeeStatistic calls GetFeeStatisticCtx with the bound context
+func (b *contextBoundExplorer) GetFeeStatistic(blockCount int64) (explorer.FeeStatistic, error) {
+	return b.exp.GetFeeStatisticCtx(b.ctx, blockCount)
+}
+
+// GetConsensusMetrics calls GetConsensusMetricsCtx with the bound context
+func (b *contextBoundExplorer) GetConsensusMetrics() (explorer.ConsensusMetrics, error) {
+	return b.exp.GetConsensusMetricsCtx(b.ctx)
+}
+
+// GetEpochMeta calls GetEpochMetaCtx with the bound context
+func (b *contextBoundExplorer) GetEpochMeta(epochNum int64) (explorer.EpochMeta, error) {
+	return b.exp.GetEpochMetaCtx(b.ctx, epochNum)
+}
+
+// GetProducerStats calls GetProducerStatsCtx with the bound context
+func (b *contextBoundExplorer) GetProducerStats(address string) (explorer.ProducerStats, error) {
+	return b.exp.GetProducerStatsCtx(b.ctx, address)
+}
+
+// GetGenesisHash calls GetGenesisHashCtx with the bound context
+func (b *contextBoundExplorer) GetGenesisHash() (string, error) {
+	return b.exp.GetGenesisHashCtx(b.ctx)
+}
+
+// GetExplorerStatus calls GetExplorerStatusCtx with the bound context
+func (b *contextBoundExplorer) GetExplorerStatus() (explorer.ExplorerStatus, error) {
+	return b.exp.GetExplorerStatusCtx(b.ctx)
+}
+
+// GetServerConfig calls GetServerConfigCtx with the bound context
+func (b *contextBoundExplorer) GetServerConfig() (string, error) {
+	return b.exp.GetServerConfigCtx(b.ctx)
+}
+
+// GetCandidateMetrics calls GetCandidateMetricsCtx with the bound context
+func (b *contextBoundExplorer) GetCandidateMetrics() (explorer.CandidateMetrics, error) {
+	return b.exp.GetCandidateMetricsCtx(b.ctx)
+}
+
+// GetCandidateMetricsByHeight calls GetCandidateMetricsByHeightCtx with the bound context
+func (b *contextBoundExplorer) GetCandidateMetricsByHeight(h int64) (explorer.CandidateMetrics, error) {
+	return b.exp.GetCandidateMetricsByHeightCtx(b.ctx, h)
+}
+
+// GetCandidates calls GetCandidatesCtx with the bound context
+func (b *contextBoundExplorer) GetCandidates(height int64) ([]explorer.Candidate, error) {
+	return b.exp.GetCandidatesCtx(b.ctx, height)
+}
+
+// SendTransfer calls SendTransferCtx with the bound context
+func (b *contextBoundExplorer) SendTransfer(request explorer.SendTransferRequest) (explorer.SendTransferResponse, error) {
+	return b.exp.SendTransferCtx(b.ctx, request)
+}
+
+// SendVote calls SendVoteCtx with the bound context
+func (b *contextBoundExplorer) SendVote(request explorer.SendVoteRequest) (explorer.SendVoteResponse, error) {
+	return b.exp.SendVoteCtx(b.ctx, request)
+}
+
+// SendSmartContract calls SendSmartContractCtx with the bound context
+func (b *contextBoundExplorer) SendSmartContract(request explorer.Execution) (explorer.SendSmartContractResponse, error) {
+	return b.exp.SendSmartContractCtx(b.ctx, request)
+}
+
+// SendAction calls SendActionCtx with the bound context
+func (b *contextBoundExplorer) SendAction(rawAction string) (string, error) {
+	return b.exp.SendActionCtx(b.ctx, rawAction)
+}
+
+// GetPeers calls GetPeersCtx with the bound context
+func (b *contextBoundExplorer) GetPeers() (explorer.GetPeersResponse, error) {
+	return b.exp.GetPeersCtx(b.ctx)
+}
+
+// GetReceiptByExecutionID calls GetReceiptByExecutionIDCtx with the bound context
+func (b *contextBoundExplorer) GetReceiptByExecutionID(id string) (explorer.Receipt, error) {
+	return b.exp.GetReceiptByExecutionIDCtx(b.ctx, id)
+}
+
+// GetReceiptByActionID calls GetReceiptByActionIDCtx with the bound context
+func (b *contextBoundExplorer) GetReceiptByActionID(id string) (explorer.Receipt, error) {
+	return b.exp.GetReceiptByActionIDCtx(b.ctx, id)
+}
+
+// GetLogsByBlockRange calls GetLogsByBlockRangeCtx with the bound context
+func (b *contextBoundExplorer) GetLogsByBlockRange(fromHeight int64, toHeight int64, contract string, topics []string) ([]explorer.Log, error) {
+	return b.exp.GetLogsByBlockRangeCtx(b.ctx, fromHeight, toHeight, contract, topics)
+}
+
+// ReadExecutionState calls ReadExecutionStateCtx with the bound context
+func (b *contextBoundExplorer) ReadExecutionState(request explorer.Execution) (string, error) {
+	return b.exp.ReadExecutionStateCtx(b.ctx, request)
+}
+
+// EstimateGas calls EstimateGasCtx with the bound context
+func (b *contextBoundExplorer) EstimateGas(request explorer.ExecutionRequest) (explorer.GasEstimate, error) {
+	return b.exp.EstimateGasCtx(b.ctx, request)
+}
+
+// CallContract calls CallContractCtx with the bound context
+func (b *contextBoundExplorer) CallContract(request explorer.ExecutionRequest) (explorer.CallResult, error) {
+	return b.exp.CallContractCtx(b.ctx, request)
+}
+
+// EstimateGasPending calls EstimateGasPendingCtx with the bound context
+func (b *contextBoundExplorer) EstimateGasPending(request explorer.ExecutionRequest) (explorer.GasEstimate, error) {
+	return b.exp.EstimateGasPendingCtx(b.ctx, request)
+}
+
+// CallContractPending calls CallContractPendingCtx with the bound context
+func (b *contextBoundExplorer) CallContractPending(request explorer.ExecutionRequest) (explorer.CallResult, error) {
+	return b.exp.CallContractPendingCtx(b.ctx, request)
+}
+
+// Multicall calls MulticallCtx with the bound context
+func (b *contextBoundExplorer) Multicall(requests []explorer.Request) ([]explorer.Response, error) {
+	return b.exp.MulticallCtx(b.ctx, requests)
+}
+
+// GetBlockOrActionByHash calls GetBlockOrActionByHashCtx with the bound context
+func (b *contextBoundExplorer) GetBlockOrActionByHash(hashStr string) (explorer.GetBlkOrActResponse, error) {
+	return b.exp.GetBlockOrActionByHashCtx(b.ctx, hashStr)
+}
+
+// GetActionByID calls GetActionByIDCtx with the bound context
+func (b *contextBoundExplorer) GetActionByID(actionID string) (explorer.Action, error) {
+	return b.exp.GetActionByIDCtx(b.ctx, actionID)
+}
+
+// GetBlockchainHeightCtx is GetBlockchainHeight cancelled by ctx
+func (exp *Service) GetBlockchainHeightCtx(ctx context.Context) (int64, error) {
+	return exp.cancelledBy(ctx).GetBlockchainHeight()
+}
+
+// GetAddressBalanceCtx is GetAddressBalance cancelled by ctx
+func (exp *Service) GetAddressBalanceCtx(ctx context.Context, address string) (int64, error) {
+	return exp.cancelledBy(ctx).GetAddressBalance(address)
+}
+
+// GetAddressBalanceAtHeightCtx is GetAddressBalanceAtHeight cancelled by ctx
+func (exp *Service) GetAddressBalanceAtHeightCtx(ctx context.Context, address string, height int64) (int64, error) {
+	return exp.cancelledBy(ctx).GetAddressBalanceAtHeight(address, height)
+}
+
+// GetAddressDetailsCtx is GetAddressDetails cancelled by ctx
+func (exp *Service) GetAddressDetailsCtx(ctx context.Context, address string) (explorer.AddressDetails, error) {
+	return exp.cancelledBy(ctx).GetAddressDetails(address)
+}
+
+// GetAddressNonceCtx is GetAddressNonce cancelled by ctx
+func (exp *Service) GetAddressNonceCtx(ctx context.Context, address string) (int64, error) {
+	return exp.cancelledBy(ctx).GetAddressNonce(address)
+}
+
+// GetAddressStateCtx is GetAddressState cancelled by ctx
+func (exp *Service) GetAddressStateCtx(ctx context.Context, address string) (explorer.AccountState, error) {
+	return exp.cancelledBy(ctx).GetAddressState(address)
+}
+
+// GetLastTransfersByRangeCtx is GetLastTransfersByRange cancelled by ctx
+func (exp *Service) GetLastTransfersByRangeCtx(ctx context.Context, startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]explorer.Transfer, error) {
+	return exp.cancelledBy(ctx).GetLastTransfersByRange(startBlockHeight, offset, limit, showCoinBase)
+}
+
+// GetLastTransfersByRangeV2Ctx is GetLastTransfersByRangeV2 cancelled by ctx
+func (exp *Service) GetLastTransfersByRangeV2Ctx(ctx context.Context, startBlockHeight int64, offset int64, limit int64, showCoinBase bool) (explorer.TransferPage, error) {
+	return exp.cancelledBy(ctx).GetLastTransfersByRangeV2(startBlockHeight, offset, limit, showCoinBase)
+}
+
+// GetTransferByIDCtx is GetTransferByID cancelled by ctx
+func (exp *Service) GetTransferByIDCtx(ctx context.Context, transferID string) (explorer.Transfer, error) {
+	return exp.cancelledBy(ctx).GetTransferByID(transferID)
+}
+
+// GetTransferProofCtx is GetTransferProof cancelled by ctx
+func (exp *Service) GetTransferProofCtx(ctx context.Context, transferID string) (explorer.MerkleProof, error) {
+	return exp.cancelledBy(ctx).GetTransferProof(transferID)
+}
+
+// GetTransfersByAddressCtx is GetTransfersByAddress cancelled by ctx
+func (exp *Service) GetTransfersByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	return exp.cancelledBy(ctx).GetTransfersByAddress(address, offset, limit)
+}
+
+// GetUnconfirmedTransfersByAddressCtx is GetUnconfirmedTransfersByAddress cancelled by ctx
+func (exp *Service) GetUnconfirmedTransfersByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	return exp.cancelledBy(ctx).GetUnconfirmedTransfersByAddress(address, offset, limit)
+}
+
+// GetTransfersByBlockIDCtx is GetTransfersByBlockID cancelled by ctx
+func (exp *Service) GetTransfersByBlockIDCtx(ctx context.Context, blkID string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	return exp.cancelledBy(ctx).GetTransfersByBlockID(blkID, offset, limit)
+}
+
+// GetTransfersByAmountRangeCtx is GetTransfersByAmountRange cancelled by ctx
+func (exp *Service) GetTransfersByAmountRangeCtx(ctx context.Context, minAmount int64, maxAmount int64, offset int64, limit int64) ([]explorer.Transfer, error) {
+	return exp.cancelledBy(ctx).GetTransfersByAmountRange(minAmount, maxAmount, offset, limit)
+}
+
+// GetLastVotesByRangeCtx is GetLastVotesByRange cancelled by ctx
+func (exp *Service) GetLastVotesByRangeCtx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) ([]explorer.Vote, error) {
+	return exp.cancelledBy(ctx).GetLastVotesByRange(startBlockHeight, offset, limit)
+}
+
+// GetLastVotesByRangeV2Ctx is GetLastVotesByRangeV2 cancelled by ctx
+func (exp *Service) GetLastVotesByRangeV2Ctx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) (explorer.VotePage, error) {
+	return exp.cancelledBy(ctx).GetLastVotesByRangeV2(startBlockHeight, offset, limit)
+}
+
+// GetVoteByIDCtx is GetVoteByID cancelled by ctx
+func (exp *Service) GetVoteByIDCtx(ctx context.Context, voteID string) (explorer.Vote, error) {
+	return exp.cancelledBy(ctx).GetVoteByID(voteID)
+}
+
+// GetVotesByAddressCtx is GetVotesByAddress cancelled by ctx
+func (exp *Service) GetVotesByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	return exp.cancelledBy(ctx).GetVotesByAddress(address, offset, limit)
+}
+
+// GetVotesFromAddressCtx is GetVotesFromAddress cancelled by ctx
+func (exp *Service) GetVotesFromAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	return exp.cancelledBy(ctx).GetVotesFromAddress(address, offset, limit)
+}
+
+// GetVotesToAddressCtx is GetVotesToAddress cancelled by ctx
+func (exp *Service) GetVotesToAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	return exp.cancelledBy(ctx).GetVotesToAddress(address, offset, limit)
+}
+
+// GetUnconfirmedVotesByAddressCtx is GetUnconfirmedVotesByAddress cancelled by ctx
+func (exp *Service) GetUnconfirmedVotesByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	return exp.cancelledBy(ctx).GetUnconfirmedVotesByAddress(address, offset, limit)
+}
+
+// GetVotesByBlockIDCtx is GetVotesByBlockID cancelled by ctx
+func (exp *Service) GetVotesByBlockIDCtx(ctx context.Context, blkID string, offset int64, limit int64) ([]explorer.Vote, error) {
+	return exp.cancelledBy(ctx).GetVotesByBlockID(blkID, offset, limit)
+}
+
+// GetLastExecutionsByRangeCtx is GetLastExecutionsByRange cancelled by ctx
+func (exp *Service) GetLastExecutionsByRangeCtx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) ([]explorer.Execution, error) {
+	return exp.cancelledBy(ctx).GetLastExecutionsByRange(startBlockHeight, offset, limit)
+}
+
+// GetLastExecutionsByRangeV2Ctx is GetLastExecutionsByRangeV2 cancelled by ctx
+func (exp *Service) GetLastExecutionsByRangeV2Ctx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) (explorer.ExecutionPage, error) {
+	return exp.cancelledBy(ctx).GetLastExecutionsByRangeV2(startBlockHeight, offset, limit)
+}
+
+// GetExecutionByIDCtx is GetExecutionByID cancelled by ctx
+func (exp *Service) GetExecutionByIDCtx(ctx context.Context, executionID string) (explorer.Execution, error) {
+	return exp.cancelledBy(ctx).GetExecutionByID(executionID)
+}
+
+// GetExecutionsByAddressCtx is GetExecutionsByAddress cancelled by ctx
+func (exp *Service) GetExecutionsByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	return exp.cancelledBy(ctx).GetExecutionsByAddress(address, offset, limit)
+}
+
+// GetUnconfirmedExecutionsByAddressCtx is GetUnconfirmedExecutionsByAddress cancelled by ctx
+func (exp *Service) GetUnconfirmedExecutionsByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	return exp.cancelledBy(ctx).GetUnconfirmedExecutionsByAddress(address, offset, limit)
+}
+
+// GetPendingActionsByAddressCtx is GetPendingActionsByAddress cancelled by ctx
+func (exp *Service) GetPendingActionsByAddressCtx(ctx context.Context, address string, offset int64, limit int64) (explorer.PendingActions, error) {
+	return exp.cancelledBy(ctx).GetPendingActionsByAddress(address, offset, limit)
+}
+
+// GetExecutionsByBlockIDCtx is GetExecutionsByBlockID cancelled by ctx
+func (exp *Service) GetExecutionsByBlockIDCtx(ctx context.Context, blkID string, offset int64, limit int64) ([]explorer.Execution, error) {
+	return exp.cancelledBy(ctx).GetExecutionsByBlockID(blkID, offset, limit)
+}
+
+// GetLastBlocksByRangeCtx is GetLastBlocksByRange cancelled by ctx
+func (exp *Service) GetLastBlocksByRangeCtx(ctx context.Context, offset int64, limit int64) ([]explorer.Block, error) {
+	return exp.cancelledBy(ctx).GetLastBlocksByRange(offset, limit)
+}
+
+// GetBlockByIDCtx is GetBlockByID cancelled by ctx
+func (exp *Service) GetBlockByIDCtx(ctx context.Context, blkID string) (explorer.Block, error) {
+	return exp.cancelledBy(ctx).GetBlockByID(blkID)
+}
+
+// GetBlockByHeightCtx is GetBlockByHeight cancelled by ctx
+func (exp *Service) GetBlockByHeightCtx(ctx context.Context, height int64) (explorer.Block, error) {
+	return exp.cancelledBy(ctx).GetBlockByHeight(height)
+}
+
+// GetCoinStatisticCtx is GetCoinStatistic cancelled by ctx
+func (exp *Service) GetCoinStatisticCtx(ctx context.Context) (explorer.CoinStatistic, error) {
+	return exp.cancelledBy(ctx).GetCoinStatistic()
+}
+
+// GetFeeStatisticCtx is GetFeeStatistic cancelled by ctx
+func (exp *Service) GetFeeStatisticCtx(ctx context.Context, blockCount int64) (explorer.FeeStatistic, error) {
+	return exp.cancelledBy(ctx).GetFeeStatistic(blockCount)
+}
+
+// GetConsensusMetricsCtx is GetConsensusMetrics cancelled by ctx
+func (exp *Service) GetConsensusMetricsCtx(ctx context.Context) (explorer.ConsensusMetrics, error) {
+	return exp.cancelledBy(ctx).GetConsensusMetrics()
+}
+
+// GetEpochMetaCtx is GetEpochMeta cancelled by ctx
+func (exp *Service) GetEpochMetaCtx(ctx context.Context, epochNum int64) (explorer.EpochMeta, error) {
+	return exp.cancelledBy(ctx).GetEpochMeta(epochNum)
+}
+
+// GetProducerStatsCtx is GetProducerStats cancelled by ctx
+func (exp *Service) GetProducerStatsCtx(ctx context.Context, address string) (explorer.ProducerStats, error) {
+	return exp.cancelledBy(ctx).GetProducerStats(address)
+}
+
+// GetGenesisHashCtx is GetGenesisHash cancelled by ctx
+func (exp *Service) GetGenesisHashCtx(ctx context.Context) (string, error) {
+	return exp.cancelledBy(ctx).GetGenesisHash()
+}
+
+// GetExplorerStatusCtx is GetExplorerStatus cancelled by ctx
+func (exp *Service) GetExplorerStatusCtx(ctx context.Context) (explorer.ExplorerStatus, error) {
+	return exp.cancelledBy(ctx).GetExplorerStatus()
+}
+
+// GetServerConfigCtx is GetServerConfig cancelled by ctx
+func (exp *Service) GetServerConfigCtx(ctx context.Context) (string, error) {
+	return exp.cancelledBy(ctx).GetServerConfig()
+}
+
+// GetCandidateMetricsCtx is GetCandidateMetrics cancelled by ctx
+func (exp *Service) GetCandidateMetricsCtx(ctx context.Context) (explorer.CandidateMetrics, error) {
+	return exp.cancelledBy(ctx).GetCandidateMetrics()
+}
+
+// GetCandidateMetricsByHeightCtx is GetCandidateMetricsByHeight cancelled by ctx
+func (exp *Service) GetCandidateMetricsByHeightCtx(ctx context.Context, h int64) (explorer.CandidateMetrics, error) {
+	return exp.cancelledBy(ctx).GetCandidateMetricsByHeight(h)
+}
+
+// GetCandidatesCtx is GetCandidates cancelled by ctx
+func (exp *Service) GetCandidatesCtx(ctx context.Context, height int64) ([]explorer.Candidate, error) {
+	return exp.cancelledBy(ctx).GetCandidates(height)
+}
+
+// SendTransferCtx is SendTransfer, or returns ctx.Err() if ctx is done before submitting. The submission isn't
+// cancelled by ctx, so that the real result is returned rather than an error making the client retry and submit it
+// twice
+func (exp *Service) SendTransferCtx(ctx context.Context, request explorer.SendTransferRequest) (explorer.SendTransferResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.SendTransferResponse{}, err
+	}
+	return exp.SendTransfer(request)
+}
+
+// SendVoteCtx is SendVote, or returns ctx.Err() if ctx is done before submitting. The submission isn't cancelled by
+// ctx, so that the real result is returned rather than an error making the client retry and submit it twice
+func (exp *Service) SendVoteCtx(ctx context.Context, request explorer.SendVoteRequest) (explorer.SendVoteResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.SendVoteResponse{}, err
+	}
+	return exp.SendVote(request)
+}
+
+// SendSmartContractCtx is SendSmartContract, or returns ctx.Err() if ctx is done before submitting. The submission
+// isn't cancelled by ctx, so that the real result is returned rather than an error making the client retry and submit
+// it twice
+func (exp *Service) SendSmartContractCtx(ctx context.Context, request explorer.Execution) (explorer.SendSmartContractResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.SendSmartContractResponse{}, err
+	}
+	return exp.SendSmartContract(request)
+}
+
+// SendActionCtx is SendAction, or returns ctx.Err() if ctx is done before submitting. The submission isn't cancelled by
+// ctx, so that the real result is returned rather than an error making the client retry and submit it twice
+func (exp *Service) SendActionCtx(ctx context.Context, rawAction string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return exp.SendAction(rawAction)
+}
+
+// GetPeersCtx is GetPeers cancelled by ctx
+func (exp *Service) GetPeersCtx(ctx context.Context) (explorer.GetPeersResponse, error) {
+	return exp.cancelledBy(ctx).GetPeers()
+}
+
+// GetReceiptByExecutionIDCtx is GetReceiptByExecutionID cancelled by ctx
+func (exp *Service) GetReceiptByExecutionIDCtx(ctx context.Context, id string) (explorer.Receipt, error) {
+	return exp.cancelledBy(ctx).GetReceiptByExecutionID(id)
+}
+
+// GetReceiptByActionIDCtx is GetReceiptByActionID cancelled by ctx
+func (exp *Service) GetReceiptByActionIDCtx(ctx context.Context, id string) (explorer.Receipt, error) {
+	return exp.cancelledBy(ctx).GetReceiptByActionID(id)
+}
+
+// GetLogsByBlockRangeCtx is GetLogsByBlockRange cancelled by ctx
+func (exp *Service) GetLogsByBlockRangeCtx(ctx context.Context, fromHeight int64, toHeight int64, contract string, topics []string) ([]explorer.Log, error) {
+	return exp.cancelledBy(ctx).GetLogsByBlockRange(fromHeight, toHeight, contract, topics)
+}
+
+// ReadExecutionStateCtx is ReadExecutionState cancelled by ctx
+func (exp *Service) ReadExecutionStateCtx(ctx context.Context, request explorer.Execution) (string, error) {
+	return exp.cancelledBy(ctx).ReadExecutionState(request)
+}
+
+// EstimateGasCtx is EstimateGas cancelled by ctx
+func (exp *Service) EstimateGasCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.GasEstimate, error) {
+	return exp.cancelledBy(ctx).EstimateGas(request)
+}
+
+// CallContractCtx is CallContract cancelled by ctx
+func (exp *Service) CallContractCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.CallResult, error) {
+	return exp.cancelledBy(ctx).CallContract(request)
+}
+
+// EstimateGasPendingCtx is EstimateGasPending cancelled by ctx
+func (exp *Service) EstimateGasPendingCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.GasEstimate, error) {
+	return exp.cancelledBy(ctx).EstimateGasPending(request)
+}
+
+// CallContractPendingCtx is CallContractPending cancelled by ctx
+func (exp *Service) CallContractPendingCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.CallResult, error) {
+	return exp.cancelledBy(ctx).CallContractPending(request)
+}
+
+// MulticallCtx is Multicall whose requests are cancelled by ctx
+func (exp *Service) MulticallCtx(ctx context.Context, requests []explorer.Request) ([]explorer.Response, error) {
+	return exp.cancelledBy(ctx).Multicall(requests)
+}
+
+// GetBlockOrActionByHashCtx is GetBlockOrActionByHash cancelled by ctx
+func (exp *Service) GetBlockOrActionByHashCtx(ctx context.Context, hashStr string) (explorer.GetBlkOrActResponse, error) {
+	return exp.cancelledBy(ctx).GetBlockOrActionByHash(hashStr)
+}
+
+// GetActionByIDCtx is GetActionByID cancelled by ctx
+func (exp *Service) GetActionByIDCtx(ctx context.Context, actionID string) (explorer.Action, error) {
+	return exp.cancelledBy(ctx).GetActionByID(actionID)
+}
+
+// GetBlockchainHeightCtx is GetBlockchainHeight, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetBlockchainHeightCtx(ctx context.Context) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return exp.GetBlockchainHeight()
+}
+
+// GetAddressBalanceCtx is GetAddressBalance, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetAddressBalanceCtx(ctx context.Context, address string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return exp.GetAddressBalance(address)
+}
+
+// GetAddressBalanceAtHeightCtx is GetAddressBalanceAtHeight, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetAddressBalanceAtHeightCtx(ctx context.Context, address string, height int64) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return exp.GetAddressBalanceAtHeight(address, height)
+}
+
+// GetAddressDetailsCtx is GetAddressDetails, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetAddressDetailsCtx(ctx context.Context, address string) (explorer.AddressDetails, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.AddressDetails{}, err
+	}
+	return exp.GetAddressDetails(address)
+}
+
+// GetAddressNonceCtx is GetAddressNonce, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetAddressNonceCtx(ctx context.Context, address string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return exp.GetAddressNonce(address)
+}
+
+// GetAddressStateCtx is GetAddressState, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetAddressStateCtx(ctx context.Context, address string) (explorer.AccountState, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.AccountState{}, err
+	}
+	return exp.GetAddressState(address)
+}
+
+// GetLastTransfersByRangeCtx is GetLastTransfersByRange, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetLastTransfersByRangeCtx(ctx context.Context, startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]explorer.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetLastTransfersByRange(startBlockHeight, offset, limit, showCoinBase)
+}
+
+// GetLastTransfersByRangeV2Ctx is GetLastTransfersByRangeV2, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetLastTransfersByRangeV2Ctx(ctx context.Context, startBlockHeight int64, offset int64, limit int64, showCoinBase bool) (explorer.TransferPage, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.TransferPage{}, err
+	}
+	return exp.GetLastTransfersByRangeV2(startBlockHeight, offset, limit, showCoinBase)
+}
+
+// GetTransferByIDCtx is GetTransferByID, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetTransferByIDCtx(ctx context.Context, transferID string) (explorer.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.Transfer{}, err
+	}
+	return exp.GetTransferByID(transferID)
+}
+
+// GetTransferProofCtx is GetTransferProof, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetTransferProofCtx(ctx context.Context, transferID string) (explorer.MerkleProof, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.MerkleProof{}, err
+	}
+	return exp.GetTransferProof(transferID)
+}
+
+// GetTransfersByAddressCtx is GetTransfersByAddress, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetTransfersByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetTransfersByAddress(address, offset, limit)
+}
+
+// GetUnconfirmedTransfersByAddressCtx is GetUnconfirmedTransfersByAddress, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetUnconfirmedTransfersByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetUnconfirmedTransfersByAddress(address, offset, limit)
+}
+
+// GetTransfersByBlockIDCtx is GetTransfersByBlockID, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetTransfersByBlockIDCtx(ctx context.Context, blkID string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetTransfersByBlockID(blkID, offset, limit)
+}
+
+// GetTransfersByAmountRangeCtx is GetTransfersByAmountRange, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetTransfersByAmountRangeCtx(ctx context.Context, minAmount int64, maxAmount int64, offset int64, limit int64) ([]explorer.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetTransfersByAmountRange(minAmount, maxAmount, offset, limit)
+}
+
+// GetLastVotesByRangeCtx is GetLastVotesByRange, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetLastVotesByRangeCtx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) ([]explorer.Vote, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetLastVotesByRange(startBlockHeight, offset, limit)
+}
+
+// GetLastVotesByRangeV2Ctx is GetLastVotesByRangeV2, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetLastVotesByRangeV2Ctx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) (explorer.VotePage, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.VotePage{}, err
+	}
+	return exp.GetLastVotesByRangeV2(startBlockHeight, offset, limit)
+}
+
+// GetVoteByIDCtx is GetVoteByID, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetVoteByIDCtx(ctx context.Context, voteID string) (explorer.Vote, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.Vote{}, err
+	}
+	return exp.GetVoteByID(voteID)
+}
+
+// GetVotesByAddressCtx is GetVotesByAddress, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetVotesByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetVotesByAddress(address, offset, limit)
+}
+
+// GetVotesFromAddressCtx is GetVotesFromAddress, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetVotesFromAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetVotesFromAddress(address, offset, limit)
+}
+
+// GetVotesToAddressCtx is GetVotesToAddress, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetVotesToAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetVotesToAddress(address, offset, limit)
+}
+
+// GetUnconfirmedVotesByAddressCtx is GetUnconfirmedVotesByAddress, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetUnconfirmedVotesByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetUnconfirmedVotesByAddress(address, offset, limit)
+}
+
+// GetVotesByBlockIDCtx is GetVotesByBlockID, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetVotesByBlockIDCtx(ctx context.Context, blkID string, offset int64, limit int64) ([]explorer.Vote, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetVotesByBlockID(blkID, offset, limit)
+}
+
+// GetLastExecutionsByRangeCtx is GetLastExecutionsByRange, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetLastExecutionsByRangeCtx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) ([]explorer.Execution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetLastExecutionsByRange(startBlockHeight, offset, limit)
+}
+
+// GetLastExecutionsByRangeV2Ctx is GetLastExecutionsByRangeV2, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetLastExecutionsByRangeV2Ctx(ctx context.Context, startBlockHeight int64, offset int64, limit int64) (explorer.ExecutionPage, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.ExecutionPage{}, err
+	}
+	return exp.GetLastExecutionsByRangeV2(startBlockHeight, offset, limit)
+}
+
+// GetExecutionByIDCtx is GetExecutionByID, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetExecutionByIDCtx(ctx context.Context, executionID string) (explorer.Execution, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.Execution{}, err
+	}
+	return exp.GetExecutionByID(executionID)
+}
+
+// GetExecutionsByAddressCtx is GetExecutionsByAddress, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetExecutionsByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetExecutionsByAddress(address, offset, limit)
+}
+
+// GetUnconfirmedExecutionsByAddressCtx is GetUnconfirmedExecutionsByAddress, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetUnconfirmedExecutionsByAddressCtx(ctx context.Context, address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetUnconfirmedExecutionsByAddress(address, offset, limit)
+}
+
+// GetPendingActionsByAddressCtx is GetPendingActionsByAddress, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetPendingActionsByAddressCtx(ctx context.Context, address string, offset int64, limit int64) (explorer.PendingActions, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.PendingActions{}, err
+	}
+	return exp.GetPendingActionsByAddress(address, offset, limit)
+}
+
+// GetExecutionsByBlockIDCtx is GetExecutionsByBlockID, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetExecutionsByBlockIDCtx(ctx context.Context, blkID string, offset int64, limit int64) ([]explorer.Execution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetExecutionsByBlockID(blkID, offset, limit)
+}
+
+// GetLastBlocksByRangeCtx is GetLastBlocksByRange, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetLastBlocksByRangeCtx(ctx context.Context, offset int64, limit int64) ([]explorer.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetLastBlocksByRange(offset, limit)
+}
+
+// GetBlockByIDCtx is GetBlockByID, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetBlockByIDCtx(ctx context.Context, blkID string) (explorer.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.Block{}, err
+	}
+	return exp.GetBlockByID(blkID)
+}
+
+// GetBlockByHeightCtx is GetBlockByHeight, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetBlockByHeightCtx(ctx context.Context, height int64) (explorer.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.Block{}, err
+	}
+	return exp.GetBlockByHeight(height)
+}
+
+// GetCoinStatisticCtx is GetCoinStatistic, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetCoinStatisticCtx(ctx context.Context) (explorer.CoinStatistic, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.CoinStatistic{}, err
+	}
+	return exp.GetCoinStatistic()
+}
+
+// GetFeeStatisticCtx is GetFeeStatistic, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetFeeStatisticCtx(ctx context.Context, blockCount int64) (explorer.FeeStatistic, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.FeeStatistic{}, err
+	}
+	return exp.GetFeeStatistic(blockCount)
+}
+
+// GetConsensusMetricsCtx is GetConsensusMetrics, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetConsensusMetricsCtx(ctx context.Context) (explorer.ConsensusMetrics, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.ConsensusMetrics{}, err
+	}
+	return exp.GetConsensusMetrics()
+}
+
+// GetEpochMetaCtx is GetEpochMeta, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetEpochMetaCtx(ctx context.Context, epochNum int64) (explorer.EpochMeta, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.EpochMeta{}, err
+	}
+	return exp.GetEpochMeta(epochNum)
+}
+
+// GetProducerStatsCtx is GetProducerStats, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetProducerStatsCtx(ctx context.Context, address string) (explorer.ProducerStats, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.ProducerStats{}, err
+	}
+	return exp.GetProducerStats(address)
+}
+
+// GetGenesisHashCtx is GetGenesisHash, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetGenesisHashCtx(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return exp.GetGenesisHash()
+}
+
+// GetExplorerStatusCtx is GetExplorerStatus, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetExplorerStatusCtx(ctx context.Context) (explorer.ExplorerStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.ExplorerStatus{}, err
+	}
+	return exp.GetExplorerStatus()
+}
+
+// GetServerConfigCtx is GetServerConfig, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetServerConfigCtx(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return exp.GetServerConfig()
+}
+
+// GetCandidateMetricsCtx is GetCandidateMetrics, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetCandidateMetricsCtx(ctx context.Context) (explorer.CandidateMetrics, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.CandidateMetrics{}, err
+	}
+	return exp.GetCandidateMetrics()
+}
+
+// GetCandidateMetricsByHeightCtx is GetCandidateMetricsByHeight, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetCandidateMetricsByHeightCtx(ctx context.Context, h int64) (explorer.CandidateMetrics, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.CandidateMetrics{}, err
+	}
+	return exp.GetCandidateMetricsByHeight(h)
+}
+
+// GetCandidatesCtx is GetCandidates, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetCandidatesCtx(ctx context.Context, height int64) ([]explorer.Candidate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetCandidates(height)
+}
+
+// SendTransferCtx is SendTransfer, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) SendTransferCtx(ctx context.Context, request explorer.SendTransferRequest) (explorer.SendTransferResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.SendTransferResponse{}, err
+	}
+	return exp.SendTransfer(request)
+}
+
+// SendVoteCtx is SendVote, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) SendVoteCtx(ctx context.Context, request explorer.SendVoteRequest) (explorer.SendVoteResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.SendVoteResponse{}, err
+	}
+	return exp.SendVote(request)
+}
+
+// SendSmartContractCtx is SendSmartContract, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) SendSmartContractCtx(ctx context.Context, request explorer.Execution) (explorer.SendSmartContractResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.SendSmartContractResponse{}, err
+	}
+	return exp.SendSmartContract(request)
+}
+
+// SendActionCtx is SendAction, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) SendActionCtx(ctx context.Context, rawAction string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return exp.SendAction(rawAction)
+}
+
+// GetPeersCtx is GetPeers, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetPeersCtx(ctx context.Context) (explorer.GetPeersResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.GetPeersResponse{}, err
+	}
+	return exp.GetPeers()
+}
+
+// GetReceiptByExecutionIDCtx is GetReceiptByExecutionID, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetReceiptByExecutionIDCtx(ctx context.Context, id string) (explorer.Receipt, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.Receipt{}, err
+	}
+	return exp.GetReceiptByExecutionID(id)
+}
+
+// GetReceiptByActionIDCtx is GetReceiptByActionID, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetReceiptByActionIDCtx(ctx context.Context, id string) (explorer.Receipt, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.Receipt{}, err
+	}
+	return exp.GetReceiptByActionID(id)
+}
+
+// GetLogsByBlockRangeCtx is GetLogsByBlockRange, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetLogsByBlockRangeCtx(ctx context.Context, fromHeight int64, toHeight int64, contract string, topics []string) ([]explorer.Log, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return exp.GetLogsByBlockRange(fromHeight, toHeight, contract, topics)
+}
+
+// ReadExecutionStateCtx is ReadExecutionState, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) ReadExecutionStateCtx(ctx context.Context, request explorer.Execution) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return exp.ReadExecutionState(request)
+}
+
+// EstimateGasCtx is EstimateGas, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) EstimateGasCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.GasEstimate, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.GasEstimate{}, err
+	}
+	return exp.EstimateGas(request)
+}
+
+// CallContractCtx is CallContract, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) CallContractCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.CallResult, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.CallResult{}, err
+	}
+	return exp.CallContract(request)
+}
+
+// EstimateGasPendingCtx is EstimateGasPending, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) EstimateGasPendingCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.GasEstimate, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.GasEstimate{}, err
+	}
+	return exp.EstimateGasPending(request)
+}
+
+// CallContractPendingCtx is CallContractPending, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) CallContractPendingCtx(ctx context.Context, request explorer.ExecutionRequest) (explorer.CallResult, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.CallResult{}, err
+	}
+	return exp.CallContractPending(request)
+}
+
+// MulticallCtx is Multicall passing ctx to every request, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) MulticallCtx(ctx context.Context, requests []explorer.Request) ([]explorer.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return multicall(withContext(ctx, exp), requests)
+}
+
+// GetBlockOrActionByHashCtx is GetBlockOrActionByHash, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetBlockOrActionByHashCtx(ctx context.Context, hashStr string) (explorer.GetBlkOrActResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.GetBlkOrActResponse{}, err
+	}
+	return exp.GetBlockOrActionByHash(hashStr)
+}
+
+// GetActionByIDCtx is GetActionByID, or returns ctx.Err() if ctx is done
+func (exp *MockExplorer) GetActionByIDCtx(ctx context.Context, actionID string) (explorer.Action, error) {
+	if err := ctx.Err(); err != nil {
+		return explorer.Action{}, err
+	}
+	return exp.GetActionByID(actionID)
+}
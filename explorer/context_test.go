@@ -0,0 +1,71 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+)
+
+func TestServiceCtx(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	svc := &Service{bc: chain}
+	chain.EXPECT().TipHeight().Return(uint64(10)).Times(2)
+
+	height, err := svc.GetBlockchainHeightCtx(context.Background())
+	require.NoError(err)
+	require.Equal(int64(10), height)
+
+	// the context is passed to the requests of a multicall
+	responses, err := svc.MulticallCtx(context.Background(), []explorer.Request{{Method: "getBlockchainHeight"}})
+	require.NoError(err)
+	require.Equal("10", responses[0].Result)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// the blockchain isn't read once the context is cancelled
+	_, err = svc.GetLastBlocksByRangeCtx(ctx, 5, 1)
+	require.Equal(context.Canceled, err)
+	_, err = svc.MulticallCtx(ctx, []explorer.Request{{Method: "getBlockchainHeight"}})
+	require.Equal(context.Canceled, err)
+	// an action isn't submitted once the context is cancelled
+	_, err = svc.SendActionCtx(ctx, "")
+	require.Equal(context.Canceled, err)
+}
+
+func TestMockExplorerCtx(t *testing.T) {
+	require := require.New(t)
+
+	svc := NewMockExplorer(1)
+	_, err := svc.GetBlockByIDCtx(context.Background(), "")
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = svc.GetBlockByIDCtx(ctx, "")
+	require.Equal(context.Canceled, err)
+	_, err = svc.MulticallCtx(ctx, []explorer.Request{{Method: "getBlockByID", Params: `[""]`}})
+	require.Equal(context.Canceled, err)
+
+	// the methods are called with the bound context
+	bound := withContext(ctx, svc)
+	_, err = bound.GetBlockByID("")
+	require.Equal(context.Canceled, err)
+	_, err = withContext(context.Background(), svc).GetBlockByID("")
+	require.NoError(err)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"net/http"
+	"strings"
+)
+
+// corsWildcard allows requests from any origin
+const corsWildcard = "*"
+
+var (
+	// defaultCORSMethods are the methods allowed if none is configured. JSON-RPC requests are sent by POST.
+	defaultCORSMethods = []string{http.MethodPost}
+	// defaultCORSHeaders are the headers allowed if none is configured
+	defaultCORSHeaders = []string{"Content-Type", APIKeyHeader}
+)
+
+// corsHandler answers the CORS preflight requests and sets the CORS headers of the responses to the allowed origins.
+// Requests from the other origins are served without the CORS headers, so that browsers block them.
+type corsHandler struct {
+	next    http.Handler
+	origins map[string]bool
+	methods string
+	headers string
+}
+
+// withCORS allows the cross-origin requests from the origins. It returns next as is if no origin is allowed, i.e.,
+// same-origin only.
+func withCORS(next http.Handler, origins []string, methods []string, headers []string) http.Handler {
+	if len(origins) == 0 {
+		return next
+	}
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+	h := &corsHandler{
+		next:    next,
+		origins: make(map[string]bool, len(origins)),
+		methods: strings.Join(methods, ", "),
+		headers: strings.Join(headers, ", "),
+	}
+	for _, origin := range origins {
+		h.origins[origin] = true
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *corsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	origin := req.Header.Get("Origin")
+	allowed := origin != "" && (h.origins[corsWildcard] || h.origins[origin])
+	if allowed {
+		if h.origins[corsWildcard] {
+			w.Header().Set("Access-Control-Allow-Origin", corsWildcard)
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+		}
+	}
+	if req.Method != http.MethodOptions || req.Header.Get("Access-Control-Request-Method") == "" {
+		h.next.ServeHTTP(w, req)
+		return
+	}
+	// preflight request
+	if !allowed {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Methods", h.methods)
+	w.Header().Set("Access-Control-Allow-Headers", h.headers)
+	w.WriteHeader(http.StatusNoContent)
+}
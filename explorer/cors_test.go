@@ -0,0 +1,69 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORS(t *testing.T) {
+	require := require.New(t)
+
+	served := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		served++
+	})
+	serve := func(h http.Handler, method string, origin string, preflight bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if preflight {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Same-origin only by default
+	h := withCORS(next, nil, nil, nil)
+	rec := serve(h, http.MethodPost, "http://example.com", false)
+	require.Equal(1, served)
+	require.Empty(rec.Header().Get("Access-Control-Allow-Origin"))
+
+	h = withCORS(next, []string{"http://example.com"}, nil, nil)
+	rec = serve(h, http.MethodPost, "http://example.com", false)
+	require.Equal(2, served)
+	require.Equal("http://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal("Origin", rec.Header().Get("Vary"))
+	rec = serve(h, http.MethodPost, "http://evil.com", false)
+	require.Equal(3, served)
+	require.Empty(rec.Header().Get("Access-Control-Allow-Origin"))
+
+	// Preflight requests are answered without reaching the JSON-RPC server
+	rec = serve(h, http.MethodOptions, "http://example.com", true)
+	require.Equal(3, served)
+	require.Equal(http.StatusNoContent, rec.Code)
+	require.Equal("POST", rec.Header().Get("Access-Control-Allow-Methods"))
+	require.Equal("Content-Type, X-API-Key", rec.Header().Get("Access-Control-Allow-Headers"))
+	rec = serve(h, http.MethodOptions, "http://evil.com", true)
+	require.Equal(3, served)
+	require.Equal(http.StatusForbidden, rec.Code)
+
+	// Any origin is allowed by the wildcard
+	h = withCORS(next, []string{"*"}, []string{"GET", "POST"}, []string{"Content-Type"})
+	rec = serve(h, http.MethodOptions, "http://localhost:3000", true)
+	require.Equal(http.StatusNoContent, rec.Code)
+	require.Equal("*", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal("GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+	require.Equal("Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+}
@@ -7,13 +7,19 @@
 package explorer
 
 import (
+	"context"
 	"encoding/hex"
 	"math/big"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/action"
 	"github.com/iotexproject/iotex-core/config"
@@ -25,6 +31,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
 )
 
 var (
@@ -38,8 +45,56 @@ var (
 	ErrExecution = errors.New("invalid execution")
 	// ErrReceipt indicates the error of receipt
 	ErrReceipt = errors.New("invalid receipt")
+	// ErrAction indicates the error of action
+	ErrAction = errors.New("invalid action")
+	// ErrBlock indicates the error of block
+	ErrBlock = errors.New("invalid block")
+	// ErrInvalidAddress indicates the error of address, which is not in the format described by address.ValidateAddress
+	ErrInvalidAddress = errors.New("invalid address")
 )
 
+// The types of the actions returned by GetActionByID
+const (
+	TransferActionType  = "transfer"
+	VoteActionType      = "vote"
+	ExecutionActionType = "execution"
+)
+
+// The types of the events pushed by SubscribeAddress
+const (
+	// TransferEventType is the event that a transfer sent or received by the address is committed
+	TransferEventType = "transfer"
+	// VoteEventType is the event that a vote cast by the address is committed
+	VoteEventType = "vote"
+	// ConfirmationEventType is the event that an action sent by the address, which was pending in actpool, is committed
+	ConfirmationEventType = "confirmation"
+)
+
+// blockSubscriptionBufferSize is the number of blocks buffered for a slow subscriber
+const blockSubscriptionBufferSize = 16
+
+// addressSubscriptionBufferSize is the number of address events buffered for a slow subscriber
+const addressSubscriptionBufferSize = 64
+
+// addressPendingRefreshInterval is how often an address subscriber takes a snapshot of the pending actions of the
+// address, which tell the confirmations in the committed blocks
+const addressPendingRefreshInterval = time.Second
+
+// maxLogsBlockRange is the maximum number of blocks GetLogsByBlockRange scans in one request
+const maxLogsBlockRange = 1000
+
+// AddressEvent is an event of a subscribed address. One of Transfer, Vote and Execution is set to the committed action
+// which the event is about.
+type AddressEvent struct {
+	Type      string
+	Address   string
+	BlockID   string
+	Height    int64
+	Transfer  *explorer.Transfer
+	Vote      *explorer.Vote
+	Execution *explorer.Execution
+}
+
 var (
 	requestMtc = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -57,11 +112,20 @@ func init() {
 // Service provide api for user to query blockchain data
 type Service struct {
 	bc  blockchain.Blockchain
+	sr  state.StateReader
 	c   consensus.Consensus
 	dp  dispatcher.Dispatcher
 	ap  actpool.ActPool
 	p2p network.Overlay
 	cfg config.Explorer
+	// breaker guards the backend of the JSON-RPC server, or is nil if it is disabled
+	breaker *circuitBreaker
+	// maintenance tells whether the explorer is under maintenance, which is reported by GetExplorerStatus
+	maintenance *maintenanceMode
+	// configDumper returns the JSON of the server config, or is nil if the explorer doesn't run in a server
+	configDumper func() ([]byte, error)
+	// ctx cancels the reads of the blockchain, or is nil if they are not cancellable. It is set by the ...Ctx methods
+	ctx context.Context
 }
 
 // GetBlockchainHeight returns the current blockchain tip height
@@ -72,7 +136,28 @@ func (exp *Service) GetBlockchainHeight() (int64, error) {
 
 // GetAddressBalance returns the balance of an address
 func (exp *Service) GetAddressBalance(address string) (int64, error) {
-	state, err := exp.bc.StateByAddr(address)
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return int64(0), err
+	}
+	state, err := exp.sr.State(address)
+	if err != nil {
+		return int64(0), err
+	}
+	return state.Balance.Int64(), nil
+}
+
+// GetAddressBalanceAtHeight returns the balance of an address as of the block of the given height. It returns an
+// error wrapping state.ErrHistoricalStateUnavailable if the states at that height are not kept
+func (exp *Service) GetAddressBalanceAtHeight(address string, height int64) (int64, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return int64(0), err
+	}
+	if tipHeight := exp.bc.TipHeight(); height < 0 || uint64(height) > tipHeight {
+		return int64(0), errors.Errorf("invalid height %d, the tip height is %d", height, tipHeight)
+	}
+	state, err := exp.sr.StateAtHeight(address, uint64(height))
 	if err != nil {
 		return int64(0), err
 	}
@@ -81,7 +166,11 @@ func (exp *Service) GetAddressBalance(address string) (int64, error) {
 
 // GetAddressDetails returns the properties of an address
 func (exp *Service) GetAddressDetails(address string) (explorer.AddressDetails, error) {
-	state, err := exp.bc.StateByAddr(address)
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return explorer.AddressDetails{}, err
+	}
+	state, err := exp.sr.State(address)
 	if err != nil {
 		return explorer.AddressDetails{}, err
 	}
@@ -100,6 +189,43 @@ func (exp *Service) GetAddressDetails(address string) (explorer.AddressDetails,
 	return details, nil
 }
 
+// GetAddressNonce returns the next nonce to use for an address. It is pending-aware: if the address has actions waiting
+// in the actpool, the nonce following the last contiguous pending one is returned, otherwise the confirmed nonce + 1
+func (exp *Service) GetAddressNonce(address string) (int64, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return int64(0), err
+	}
+	pendingNonce, err := exp.ap.GetPendingNonce(address)
+	if err != nil {
+		return int64(0), err
+	}
+	return int64(pendingNonce), nil
+}
+
+// GetAddressState returns the account state of an address
+func (exp *Service) GetAddressState(address string) (explorer.AccountState, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return explorer.AccountState{}, err
+	}
+	state, err := exp.sr.State(address)
+	if err != nil {
+		return explorer.AccountState{}, err
+	}
+	accountState := explorer.AccountState{
+		Address:     address,
+		Nonce:       int64(state.Nonce),
+		Balance:     state.Balance.Int64(),
+		IsCandidate: state.IsCandidate,
+		Votee:       state.Votee,
+	}
+	if state.VotingWeight != nil {
+		accountState.VotingWeight = state.VotingWeight.Int64()
+	}
+	return accountState, nil
+}
+
 // GetLastTransfersByRange returns transfers in [-(offset+limit-1), -offset] from block
 // with height startBlockHeight
 func (exp *Service) GetLastTransfersByRange(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]explorer.Transfer, error) {
@@ -108,6 +234,9 @@ func (exp *Service) GetLastTransfersByRange(startBlockHeight int64, offset int64
 
 ChainLoop:
 	for height := startBlockHeight; height >= 0; height-- {
+		if err := exp.ctxErr(); err != nil {
+			return []explorer.Transfer{}, err
+		}
 		var blkID string
 		hash, err := exp.bc.GetHashByHeight(uint64(height))
 		if err != nil {
@@ -116,6 +245,10 @@ ChainLoop:
 		blkID = hex.EncodeToString(hash[:])
 
 		blk, err := exp.bc.GetBlockByHeight(uint64(height))
+		if errors.Cause(err) == blockchain.ErrBlockPruned {
+			// the blocks below are pruned as well
+			break
+		}
 		if err != nil {
 			return []explorer.Transfer{}, err
 		}
@@ -149,6 +282,29 @@ ChainLoop:
 	return res, nil
 }
 
+// GetLastTransfersByRangeV2 returns a page of transfers in [-(offset+limit-1), -offset] from block with height
+// startBlockHeight, together with the total number of transfers up to that block
+func (exp *Service) GetLastTransfersByRangeV2(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) (explorer.TransferPage, error) {
+	transfers, err := exp.GetLastTransfersByRange(startBlockHeight, offset, limit, showCoinBase)
+	if err != nil {
+		return explorer.TransferPage{}, err
+	}
+	totalCount, err := exp.countActions(startBlockHeight, func(counts *blockchain.ActionCounts) uint64 {
+		if showCoinBase {
+			return counts.Transfers
+		}
+		return counts.Transfers - counts.CoinbaseTransfers
+	})
+	if err != nil {
+		return explorer.TransferPage{}, err
+	}
+	return explorer.TransferPage{
+		Transfers:  transfers,
+		TotalCount: totalCount,
+		HasMore:    offset+int64(len(transfers)) < totalCount,
+	}, nil
+}
+
 // GetTransferByID returns transfer by transfer id
 func (exp *Service) GetTransferByID(transferID string) (explorer.Transfer, error) {
 	bytes, err := hex.DecodeString(transferID)
@@ -161,8 +317,51 @@ func (exp *Service) GetTransferByID(transferID string) (explorer.Transfer, error
 	return getTransfer(exp.bc, exp.ap, transferHash)
 }
 
+// GetTransferProof returns the merkle proof that a transfer is included in a block
+func (exp *Service) GetTransferProof(transferID string) (explorer.MerkleProof, error) {
+	bytes, err := hex.DecodeString(transferID)
+	if err != nil {
+		return explorer.MerkleProof{}, err
+	}
+	var transferHash hash.Hash32B
+	copy(transferHash[:], bytes)
+
+	blkHash, err := exp.bc.GetBlockHashByTransferHash(transferHash)
+	if err != nil {
+		return explorer.MerkleProof{}, errors.Wrapf(err, "failed to get the block of transfer %s", transferID)
+	}
+	blk, err := exp.bc.GetBlockByHash(blkHash)
+	if err != nil {
+		return explorer.MerkleProof{}, err
+	}
+	index, branch, err := blk.TxProof(transferHash)
+	if err != nil {
+		return explorer.MerkleProof{}, err
+	}
+
+	blkHeaderPb := blk.ConvertToBlockHeaderPb()
+	proof := explorer.MerkleProof{
+		TransferID:  transferID,
+		BlockID:     hex.EncodeToString(blkHash[:]),
+		BlockHeight: int64(blk.Height()),
+		BlockHeader: hex.EncodeToString(blk.ByteStreamHeader()),
+		TxRoot:      hex.EncodeToString(blkHeaderPb.TxRoot),
+		StateRoot:   hex.EncodeToString(blkHeaderPb.StateRoot),
+		Index:       int64(index),
+		Branch:      make([]string, 0, len(branch)),
+	}
+	for _, h := range branch {
+		proof.Branch = append(proof.Branch, hex.EncodeToString(h[:]))
+	}
+	return proof, nil
+}
+
 // GetTransfersByAddress returns all transfers associated with an address
 func (exp *Service) GetTransfersByAddress(address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Transfer{}, err
+	}
 	var res []explorer.Transfer
 	transfersFromAddress, err := exp.bc.GetTransfersFromAddress(address)
 	if err != nil {
@@ -176,6 +375,9 @@ func (exp *Service) GetTransfersByAddress(address string, offset int64, limit in
 
 	transfersFromAddress = append(transfersFromAddress, transfersToAddress...)
 	for i, transferHash := range transfersFromAddress {
+		if err := exp.ctxErr(); err != nil {
+			return []explorer.Transfer{}, err
+		}
 		if int64(i) < offset {
 			continue
 		}
@@ -197,8 +399,12 @@ func (exp *Service) GetTransfersByAddress(address string, offset int64, limit in
 
 // GetUnconfirmedTransfersByAddress returns all unconfirmed transfers in actpool associated with an address
 func (exp *Service) GetUnconfirmedTransfersByAddress(address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Transfer{}, err
+	}
 	res := make([]explorer.Transfer, 0)
-	if _, err := exp.bc.StateByAddr(address); err != nil {
+	if _, err := exp.sr.State(address); err != nil {
 		return []explorer.Transfer{}, err
 	}
 
@@ -266,6 +472,66 @@ func (exp *Service) GetTransfersByBlockID(blkID string, offset int64, limit int6
 	return res, nil
 }
 
+// GetTransfersByAmountRange returns non-coinbase transfers whose amount is within [minAmount, maxAmount], from the
+// latest block to the genesis block
+func (exp *Service) GetTransfersByAmountRange(minAmount int64, maxAmount int64, offset int64, limit int64) ([]explorer.Transfer, error) {
+	if minAmount > maxAmount {
+		return []explorer.Transfer{}, errors.Wrapf(ErrTransfer, "min amount %d is larger than max amount %d", minAmount, maxAmount)
+	}
+	var res []explorer.Transfer
+	min := big.NewInt(minAmount)
+	max := big.NewInt(maxAmount)
+	transferCount := int64(0)
+
+ChainLoop:
+	for height := int64(exp.bc.TipHeight()); height >= 0; height-- {
+		if err := exp.ctxErr(); err != nil {
+			return []explorer.Transfer{}, err
+		}
+		hash, err := exp.bc.GetHashByHeight(uint64(height))
+		if err != nil {
+			return []explorer.Transfer{}, err
+		}
+		blkID := hex.EncodeToString(hash[:])
+
+		blk, err := exp.bc.GetBlockByHeight(uint64(height))
+		if errors.Cause(err) == blockchain.ErrBlockPruned {
+			// the blocks below are pruned as well
+			break
+		}
+		if err != nil {
+			return []explorer.Transfer{}, err
+		}
+
+		for i := len(blk.Transfers) - 1; i >= 0; i-- {
+			transfer := blk.Transfers[i]
+			if transfer.IsCoinbase() || transfer.Amount() == nil ||
+				transfer.Amount().Cmp(min) < 0 || transfer.Amount().Cmp(max) > 0 {
+				continue
+			}
+
+			transferCount++
+			if transferCount <= offset {
+				continue
+			}
+
+			if int64(len(res)) >= limit {
+				break ChainLoop
+			}
+
+			explorerTransfer, err := convertTsfToExplorerTsf(transfer, false)
+			if err != nil {
+				return []explorer.Transfer{}, errors.Wrapf(err, "failed to convert transfer %v to explorer's JSON transfer", transfer)
+			}
+			explorerTransfer.Timestamp = int64(blk.ConvertToBlockHeaderPb().Timestamp)
+			explorerTransfer.BlockID = blkID
+			res = append(res, explorerTransfer)
+		}
+	}
+
+	return res, nil
+}
+
 // GetLastVotesByRange returns votes in [-(offset+limit-1), -offset] from block
 // with height startBlockHeight
 func (exp *Service) GetLastVotesByRange(startBlockHeight int64, offset int64, limit int64) ([]explorer.Vote, error) {
@@ -274,6 +540,9 @@ func (exp *Service) GetLastVotesByRange(startBlockHeight int64, offset int64, li
 
 ChainLoop:
 	for height := startBlockHeight; height >= 0; height-- {
+		if err := exp.ctxErr(); err != nil {
+			return []explorer.Vote{}, err
+		}
 		hash, err := exp.bc.GetHashByHeight(uint64(height))
 		if err != nil {
 			return []explorer.Vote{}, err
@@ -281,6 +550,10 @@ ChainLoop:
 		blkID := hex.EncodeToString(hash[:])
 
 		blk, err := exp.bc.GetBlockByHeight(uint64(height))
+		if errors.Cause(err) == blockchain.ErrBlockPruned {
+			// the blocks below are pruned as well
+			break
+		}
 		if err != nil {
 			return []explorer.Vote{}, err
 		}
@@ -309,6 +582,26 @@ ChainLoop:
 	return res, nil
 }
 
+// GetLastVotesByRangeV2 returns a page of votes in [-(offset+limit-1), -offset] from block with height
+// startBlockHeight, together with the total number of votes up to that block
+func (exp *Service) GetLastVotesByRangeV2(startBlockHeight int64, offset int64, limit int64) (explorer.VotePage, error) {
+	votes, err := exp.GetLastVotesByRange(startBlockHeight, offset, limit)
+	if err != nil {
+		return explorer.VotePage{}, err
+	}
+	totalCount, err := exp.countActions(startBlockHeight, func(counts *blockchain.ActionCounts) uint64 {
+		return counts.Votes
+	})
+	if err != nil {
+		return explorer.VotePage{}, err
+	}
+	return explorer.VotePage{
+		Votes:      votes,
+		TotalCount: totalCount,
+		HasMore:    offset+int64(len(votes)) < totalCount,
+	}, nil
+}
+
 // GetVoteByID returns vote by vote id
 func (exp *Service) GetVoteByID(voteID string) (explorer.Vote, error) {
 	bytes, err := hex.DecodeString(voteID)
@@ -323,7 +616,10 @@ func (exp *Service) GetVoteByID(voteID string) (explorer.Vote, error) {
 
 // GetVotesByAddress returns all votes associated with an address
 func (exp *Service) GetVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
-	var res []explorer.Vote
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
 	votesFromAddress, err := exp.bc.GetVotesFromAddress(address)
 	if err != nil {
 		return []explorer.Vote{}, err
@@ -335,7 +631,41 @@ func (exp *Service) GetVotesByAddress(address string, offset int64, limit int64)
 	}
 
 	votesFromAddress = append(votesFromAddress, votesToAddress...)
-	for i, voteHash := range votesFromAddress {
+	return exp.getVotes(votesFromAddress, offset, limit)
+}
+
+// GetVotesFromAddress returns the votes cast by an address
+func (exp *Service) GetVotesFromAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
+	votesFromAddress, err := exp.bc.GetVotesFromAddress(address)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
+	return exp.getVotes(votesFromAddress, offset, limit)
+}
+
+// GetVotesToAddress returns the votes received by an address
+func (exp *Service) GetVotesToAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
+	votesToAddress, err := exp.bc.GetVotesToAddress(address)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
+	return exp.getVotes(votesToAddress, offset, limit)
+}
+
+func (exp *Service) getVotes(voteHashes []hash.Hash32B, offset int64, limit int64) ([]explorer.Vote, error) {
+	var res []explorer.Vote
+	for i, voteHash := range voteHashes {
+		if err := exp.ctxErr(); err != nil {
+			return []explorer.Vote{}, err
+		}
 		if int64(i) < offset {
 			continue
 		}
@@ -357,8 +687,12 @@ func (exp *Service) GetVotesByAddress(address string, offset int64, limit int64)
 
 // GetUnconfirmedVotesByAddress returns all unconfirmed votes in actpool associated with an address
 func (exp *Service) GetUnconfirmedVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
 	res := make([]explorer.Vote, 0)
-	if _, err := exp.bc.StateByAddr(address); err != nil {
+	if _, err := exp.sr.State(address); err != nil {
 		return []explorer.Vote{}, err
 	}
 
@@ -433,6 +767,9 @@ func (exp *Service) GetLastExecutionsByRange(startBlockHeight int64, offset int6
 
 ChainLoop:
 	for height := startBlockHeight; height >= 0; height-- {
+		if err := exp.ctxErr(); err != nil {
+			return []explorer.Execution{}, err
+		}
 		hash, err := exp.bc.GetHashByHeight(uint64(height))
 		if err != nil {
 			return []explorer.Execution{}, err
@@ -440,6 +777,10 @@ ChainLoop:
 		blkID := hex.EncodeToString(hash[:])
 
 		blk, err := exp.bc.GetBlockByHeight(uint64(height))
+		if errors.Cause(err) == blockchain.ErrBlockPruned {
+			// the blocks below are pruned as well
+			break
+		}
 		if err != nil {
 			return []explorer.Execution{}, err
 		}
@@ -468,6 +809,26 @@ ChainLoop:
 	return res, nil
 }
 
+// GetLastExecutionsByRangeV2 returns a page of executions in [-(offset+limit-1), -offset] from block with height
+// startBlockHeight, together with the total number of executions up to that block
+func (exp *Service) GetLastExecutionsByRangeV2(startBlockHeight int64, offset int64, limit int64) (explorer.ExecutionPage, error) {
+	executions, err := exp.GetLastExecutionsByRange(startBlockHeight, offset, limit)
+	if err != nil {
+		return explorer.ExecutionPage{}, err
+	}
+	totalCount, err := exp.countActions(startBlockHeight, func(counts *blockchain.ActionCounts) uint64 {
+		return counts.Executions
+	})
+	if err != nil {
+		return explorer.ExecutionPage{}, err
+	}
+	return explorer.ExecutionPage{
+		Executions: executions,
+		TotalCount: totalCount,
+		HasMore:    offset+int64(len(executions)) < totalCount,
+	}, nil
+}
+
 // GetExecutionByID returns execution by execution id
 func (exp *Service) GetExecutionByID(executionID string) (explorer.Execution, error) {
 	bytes, err := hex.DecodeString(executionID)
@@ -482,6 +843,10 @@ func (exp *Service) GetExecutionByID(executionID string) (explorer.Execution, er
 
 // GetExecutionsByAddress returns all executions associated with an address
 func (exp *Service) GetExecutionsByAddress(address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Execution{}, err
+	}
 	var res []explorer.Execution
 	executionsFromAddress, err := exp.bc.GetExecutionsFromAddress(address)
 	if err != nil {
@@ -495,6 +860,9 @@ func (exp *Service) GetExecutionsByAddress(address string, offset int64, limit i
 
 	executionsFromAddress = append(executionsFromAddress, executionsToAddress...)
 	for i, executionHash := range executionsFromAddress {
+		if err := exp.ctxErr(); err != nil {
+			return []explorer.Execution{}, err
+		}
 		if int64(i) < offset {
 			continue
 		}
@@ -516,8 +884,12 @@ func (exp *Service) GetExecutionsByAddress(address string, offset int64, limit i
 
 // GetUnconfirmedExecutionsByAddress returns all unconfirmed executions in actpool associated with an address
 func (exp *Service) GetUnconfirmedExecutionsByAddress(address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Execution{}, err
+	}
 	res := make([]explorer.Execution, 0)
-	if _, err := exp.bc.StateByAddr(address); err != nil {
+	if _, err := exp.sr.State(address); err != nil {
 		return []explorer.Execution{}, err
 	}
 
@@ -549,6 +921,63 @@ func (exp *Service) GetUnconfirmedExecutionsByAddress(address string, offset int
 	return res, nil
 }
 
+// GetPendingActionsByAddress returns unconfirmed transfers, votes and executions in actpool associated with an address.
+// The offset and limit apply to the actions of all types, which are ordered by nonce.
+func (exp *Service) GetPendingActionsByAddress(address string, offset int64, limit int64) (explorer.PendingActions, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return explorer.PendingActions{}, err
+	}
+	res := explorer.PendingActions{
+		Transfers:  make([]explorer.Transfer, 0),
+		Votes:      make([]explorer.Vote, 0),
+		Executions: make([]explorer.Execution, 0),
+	}
+	if _, err := exp.sr.State(address); err != nil {
+		return explorer.PendingActions{}, err
+	}
+
+	acts := exp.ap.GetUnconfirmedActs(address)
+	for i, act := range acts {
+		if int64(i) < offset {
+			continue
+		}
+
+		if int64(i)-offset >= limit {
+			break
+		}
+
+		switch {
+		case act.GetTransfer() != nil:
+			transfer := &action.Transfer{}
+			transfer.ConvertFromActionPb(act)
+			explorerTransfer, err := convertTsfToExplorerTsf(transfer, true)
+			if err != nil {
+				return explorer.PendingActions{}, errors.Wrapf(err, "failed to convert transfer %v to explorer's JSON transfer", transfer)
+			}
+			res.Transfers = append(res.Transfers, explorerTransfer)
+		case act.GetVote() != nil:
+			vote := &action.Vote{}
+			vote.ConvertFromActionPb(act)
+			explorerVote, err := convertVoteToExplorerVote(vote, true)
+			if err != nil {
+				return explorer.PendingActions{}, errors.Wrapf(err, "failed to convert vote %v to explorer's JSON vote", vote)
+			}
+			res.Votes = append(res.Votes, explorerVote)
+		case act.GetExecution() != nil:
+			execution := &action.Execution{}
+			execution.ConvertFromActionPb(act)
+			explorerExecution, err := convertExecutionToExplorerExecution(execution, true)
+			if err != nil {
+				return explorer.PendingActions{}, errors.Wrapf(err, "failed to convert execution %v to explorer's JSON execution", execution)
+			}
+			res.Executions = append(res.Executions, explorerExecution)
+		}
+	}
+
+	return res, nil
+}
+
 // GetExecutionsByBlockID returns executions in a block
 func (exp *Service) GetExecutionsByBlockID(blkID string, offset int64, limit int64) ([]explorer.Execution, error) {
 	var res []explorer.Execution
@@ -601,45 +1030,116 @@ func (exp *Service) GetReceiptByExecutionID(id string) (explorer.Receipt, error)
 	return convertReceiptToExplorerReceipt(receipt)
 }
 
+// GetReceiptByActionID gets receipt with corresponding action id. Only executions produce receipts at the moment.
+func (exp *Service) GetReceiptByActionID(id string) (explorer.Receipt, error) {
+	bytes, err := hex.DecodeString(id)
+	if err != nil {
+		return explorer.Receipt{}, err
+	}
+	var actionHash hash.Hash32B
+	copy(actionHash[:], bytes)
+	receipt, err := exp.bc.GetReceiptByExecutionHash(actionHash)
+	if err != nil {
+		return explorer.Receipt{}, err
+	}
+
+	return convertReceiptToExplorerReceipt(receipt)
+}
+
+// GetLogsByBlockRange returns the logs emitted in the blocks of heights [fromHeight, toHeight] by the contract, or by
+// any contract if it is empty, and matching the topics. Like eth_getLogs, the topics are matched by position, and an
+// empty topic matches any topic at its position. toHeight is capped at the tip height.
+func (exp *Service) GetLogsByBlockRange(
+	fromHeight int64,
+	toHeight int64,
+	contract string,
+	topics []string,
+) ([]explorer.Log, error) {
+	if contract != "" {
+		var err error
+		if contract, err = normalizeAddress(contract); err != nil {
+			return []explorer.Log{}, err
+		}
+	}
+	if tipHeight := int64(exp.bc.TipHeight()); toHeight > tipHeight {
+		toHeight = tipHeight
+	}
+	if fromHeight < 0 || fromHeight > toHeight {
+		return []explorer.Log{}, errors.Errorf("invalid block range [%d, %d]", fromHeight, toHeight)
+	}
+	if toHeight-fromHeight >= maxLogsBlockRange {
+		return []explorer.Log{}, errors.Errorf(
+			"block range [%d, %d] exceeds the limit of %d blocks",
+			fromHeight,
+			toHeight,
+			maxLogsBlockRange,
+		)
+	}
+	filter := make([]*hash.Hash32B, len(topics))
+	for i, topic := range topics {
+		if topic == "" {
+			continue
+		}
+		b, err := hex.DecodeString(topic)
+		if err != nil || len(b) != hash.HashSize {
+			return []explorer.Log{}, errors.Errorf("invalid topic %s", topic)
+		}
+		var h hash.Hash32B
+		copy(h[:], b)
+		filter[i] = &h
+	}
+
+	logs := []explorer.Log{}
+	for height := fromHeight; height <= toHeight; height++ {
+		if err := exp.ctxErr(); err != nil {
+			return []explorer.Log{}, err
+		}
+		blk, err := exp.bc.GetBlockByHeight(uint64(height))
+		if errors.Cause(err) == blockchain.ErrBlockPruned {
+			// the logs of the pruned blocks are not available
+			continue
+		}
+		if err != nil {
+			return []explorer.Log{}, errors.Wrapf(err, "failed to get block %d", height)
+		}
+		for _, execution := range blk.Executions {
+			receipt, err := exp.bc.GetReceiptByExecutionHash(execution.Hash())
+			if err != nil {
+				return []explorer.Log{}, errors.Wrapf(err, "failed to get receipt of execution %x", execution.Hash())
+			}
+			for _, log := range receipt.Logs {
+				if matchLog(log, contract, filter) {
+					logs = append(logs, convertLogToExplorerLog(log))
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
 // GetLastBlocksByRange get block with height [offset-limit+1, offset]
 func (exp *Service) GetLastBlocksByRange(offset int64, limit int64) ([]explorer.Block, error) {
 	var res []explorer.Block
 
 	for height := offset; height >= 0 && int64(len(res)) < limit; height-- {
+		if err := exp.ctxErr(); err != nil {
+			return []explorer.Block{}, err
+		}
 		blk, err := exp.bc.GetBlockByHeight(uint64(height))
+		if errors.Cause(err) == blockchain.ErrBlockPruned {
+			// the blocks below are pruned as well
+			break
+		}
 		if err != nil {
 			return []explorer.Block{}, err
 		}
 
-		blockHeaderPb := blk.ConvertToBlockHeaderPb()
 		hash, err := exp.bc.GetHashByHeight(uint64(height))
 		if err != nil {
 			return []explorer.Block{}, err
 		}
 
-		totalAmount := int64(0)
-		totalSize := uint32(0)
-		for _, transfer := range blk.Transfers {
-			totalAmount += transfer.Amount().Int64()
-			totalSize += transfer.TotalSize()
-		}
-
-		explorerBlock := explorer.Block{
-			ID:         hex.EncodeToString(hash[:]),
-			Height:     int64(blockHeaderPb.Height),
-			Timestamp:  int64(blockHeaderPb.Timestamp),
-			Transfers:  int64(len(blk.Transfers)),
-			Votes:      int64(len(blk.Votes)),
-			Executions: int64(len(blk.Executions)),
-			Amount:     totalAmount,
-			Size:       int64(totalSize),
-			GenerateBy: explorer.BlockGenerator{
-				Name:    "",
-				Address: keypair.EncodePublicKey(blk.Header.Pubkey),
-			},
-		}
-
-		res = append(res, explorerBlock)
+		res = append(res, exp.toExplorerBlock(blk, hex.EncodeToString(hash[:])))
 	}
 
 	return res, nil
@@ -659,31 +1159,27 @@ func (exp *Service) GetBlockByID(blkID string) (explorer.Block, error) {
 		return explorer.Block{}, err
 	}
 
-	blkHeaderPb := blk.ConvertToBlockHeaderPb()
+	return exp.toExplorerBlock(blk, blkID), nil
+}
 
-	totalAmount := int64(0)
-	totalSize := uint32(0)
-	for _, transfer := range blk.Transfers {
-		totalAmount += transfer.Amount().Int64()
-		totalSize += transfer.TotalSize()
+// GetBlockByHeight returns block at the given height
+func (exp *Service) GetBlockByHeight(height int64) (explorer.Block, error) {
+	tipHeight := exp.bc.TipHeight()
+	if height < 0 || uint64(height) > tipHeight {
+		return explorer.Block{}, errors.Wrapf(
+			ErrBlock,
+			"height %d is out of the range of the blockchain with tip height %d",
+			height,
+			tipHeight,
+		)
 	}
-
-	explorerBlock := explorer.Block{
-		ID:         blkID,
-		Height:     int64(blkHeaderPb.Height),
-		Timestamp:  int64(blkHeaderPb.Timestamp),
-		Transfers:  int64(len(blk.Transfers)),
-		Votes:      int64(len(blk.Votes)),
-		Executions: int64(len(blk.Executions)),
-		Amount:     totalAmount,
-		Size:       int64(totalSize),
-		GenerateBy: explorer.BlockGenerator{
-			Name:    "",
-			Address: keypair.EncodePublicKey(blk.Header.Pubkey),
-		},
+	blk, err := exp.bc.GetBlockByHeight(uint64(height))
+	if err != nil {
+		return explorer.Block{}, err
 	}
+	hash := blk.HashBlock()
 
-	return explorerBlock, nil
+	return exp.toExplorerBlock(blk, hex.EncodeToString(hash[:])), nil
 }
 
 // GetCoinStatistic returns stats in blockchain
@@ -736,17 +1232,95 @@ func (exp *Service) GetCoinStatistic() (explorer.CoinStatistic, error) {
 	}
 	aps := actionNumber / timeDuration
 
+	// the balance still held by the genesis creator has not been distributed yet, so it is not in circulation. Voting
+	// doesn't lock any balance, hence there is no staked balance to subtract
+	lockedSupply, err := exp.sr.Balance(blockchain.Gen.CreatorAddr(exp.bc.ChainID()))
+	if err != nil {
+		return stat, errors.Wrap(err, "failed to get the balance of the genesis creator")
+	}
+	accumulatedReward, err := exp.sr.AccumulatedBlockReward(tipHeight)
+	if err != nil {
+		return stat, errors.Wrap(err, "failed to get the accumulated block reward")
+	}
+	// the block rewards are minted on top of the genesis supply
+	totalSupply := new(big.Int).SetUint64(blockchain.Gen.TotalSupply)
+	totalSupply.Add(totalSupply, accumulatedReward)
+
 	explorerCoinStats := explorer.CoinStatistic{
-		Height:     int64(tipHeight),
-		Supply:     int64(blockchain.Gen.TotalSupply),
-		Transfers:  int64(totalTransfers),
-		Votes:      int64(totalVotes),
-		Executions: int64(totalExecutions),
-		Aps:        aps,
+		Height:            int64(tipHeight),
+		Supply:            totalSupply.Int64(),
+		TotalSupply:       totalSupply.Int64(),
+		CirculatingSupply: new(big.Int).Sub(totalSupply, lockedSupply).Int64(),
+		BlockReward:       int64(blockchain.Gen.BlockReward),
+		AccumulatedReward: accumulatedReward.Int64(),
+		Transfers:         int64(totalTransfers),
+		Votes:             int64(totalVotes),
+		Executions:        int64(totalExecutions),
+		Aps:               aps,
 	}
 	return explorerCoinStats, nil
 }
 
+// GetFeeStatistic returns the average, median and 95th percentile fee of the transfers and executions in the last
+// blockCount blocks
+func (exp *Service) GetFeeStatistic(blockCount int64) (explorer.FeeStatistic, error) {
+	if blockCount <= 0 {
+		return explorer.FeeStatistic{}, errors.Wrapf(ErrInternalServer, "block count is %d", blockCount)
+	}
+	tipHeight := exp.bc.TipHeight()
+	// avoid genesis block
+	if uint64(blockCount) > tipHeight {
+		blockCount = int64(tipHeight)
+	}
+
+	var fees []*big.Int
+	for height := tipHeight; height > tipHeight-uint64(blockCount); height-- {
+		if err := exp.ctxErr(); err != nil {
+			return explorer.FeeStatistic{}, err
+		}
+		blk, err := exp.bc.GetBlockByHeight(height)
+		if errors.Cause(err) == blockchain.ErrBlockPruned {
+			// the blocks below are pruned as well, so only the available ones are counted
+			blockCount = int64(tipHeight - height)
+			break
+		}
+		if err != nil {
+			return explorer.FeeStatistic{}, err
+		}
+		for _, transfer := range blk.Transfers {
+			if transfer.IsCoinbase() {
+				continue
+			}
+			gas, err := transfer.IntrinsicGas()
+			if err != nil {
+				return explorer.FeeStatistic{}, errors.Wrapf(err, "failed to get intrinsic gas of transfer %v", transfer)
+			}
+			fees = append(fees, actionFee(transfer.GasPrice(), gas))
+		}
+		for _, execution := range blk.Executions {
+			receipt, err := exp.bc.GetReceiptByExecutionHash(execution.Hash())
+			if err != nil {
+				return explorer.FeeStatistic{}, errors.Wrapf(err, "failed to get receipt of execution %v", execution)
+			}
+			fees = append(fees, actionFee(execution.GasPrice(), receipt.GasConsumed))
+		}
+	}
+
+	stat := explorer.FeeStatistic{BlockCount: blockCount}
+	if len(fees) == 0 {
+		return stat, nil
+	}
+	sort.Slice(fees, func(i, j int) bool { return fees[i].Cmp(fees[j]) < 0 })
+	total := big.NewInt(0)
+	for _, fee := range fees {
+		total.Add(total, fee)
+	}
+	stat.AverageFee = total.Div(total, big.NewInt(int64(len(fees)))).Int64()
+	stat.MedianFee = feePercentile(fees, 50).Int64()
+	stat.P95Fee = feePercentile(fees, 95).Int64()
+	return stat, nil
+}
+
 // GetConsensusMetrics returns the latest consensus metrics
 func (exp *Service) GetConsensusMetrics() (explorer.ConsensusMetrics, error) {
 	cm, err := exp.c.Metrics()
@@ -766,9 +1340,93 @@ func (exp *Service) GetConsensusMetrics() (explorer.ConsensusMetrics, error) {
 		LatestDelegates:     dStrs,
 		LatestBlockProducer: bpStr,
 		Candidates:          cStrs,
+		Round:               int64(cm.Round),
+		Phase:               cm.Phase,
+		RoundStartTime:      cm.RoundStartTime.Unix(),
 	}, nil
 }
 
+// GetEpochMeta returns the heights, the delegates in the order of producing blocks and the numbers of blocks they
+// produced of an epoch
+func (exp *Service) GetEpochMeta(epochNum int64) (explorer.EpochMeta, error) {
+	if epochNum <= 0 {
+		return explorer.EpochMeta{}, errors.New("epoch number should be greater than 0")
+	}
+	meta, err := exp.c.EpochMeta(uint64(epochNum))
+	if err != nil {
+		return explorer.EpochMeta{}, errors.Wrapf(err, "failed to get the metadata of epoch %d", epochNum)
+	}
+	delegates := make([]explorer.EpochDelegate, 0, len(meta.Delegates))
+	for _, d := range meta.Delegates {
+		delegates = append(delegates, explorer.EpochDelegate{
+			Address:            d,
+			ProducedBlockCount: int64(meta.ProducedBlocks[d]),
+		})
+	}
+	return explorer.EpochMeta{
+		EpochNum:    int64(meta.Num),
+		StartHeight: int64(meta.StartHeight),
+		EndHeight:   int64(meta.EndHeight),
+		Delegates:   delegates,
+	}, nil
+}
+
+// GetProducerStats returns the numbers of blocks expected, produced and missed by a block producer in the current epoch.
+// They are all 0 if the address is not a delegate of the epoch.
+func (exp *Service) GetProducerStats(address string) (explorer.ProducerStats, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return explorer.ProducerStats{}, err
+	}
+	cm, err := exp.c.Metrics()
+	if err != nil {
+		return explorer.ProducerStats{}, errors.Wrap(err, "failed to get the current epoch")
+	}
+	meta, err := exp.c.EpochMeta(cm.LatestEpoch)
+	if err != nil {
+		return explorer.ProducerStats{}, errors.Wrapf(err, "failed to get the metadata of epoch %d", cm.LatestEpoch)
+	}
+	expected := meta.ExpectedBlocks[address]
+	produced := meta.ProducedBlocks[address]
+	var missed uint64
+	if expected > produced {
+		missed = expected - produced
+	}
+	return explorer.ProducerStats{
+		Address:        address,
+		EpochNum:       int64(meta.Num),
+		ExpectedBlocks: int64(expected),
+		ProducedBlocks: int64(produced),
+		MissedBlocks:   int64(missed),
+	}, nil
+}
+
+// GetGenesisHash returns the hash of the genesis block and the consensus config, which the peers must agree on
+func (exp *Service) GetGenesisHash() (string, error) {
+	genesisHash := exp.p2p.GenesisHash()
+	return hex.EncodeToString(genesisHash[:]), nil
+}
+
+// GetExplorerStatus returns the state of the circuit breaker guarding the backend, and whether the explorer is under
+// maintenance
+func (exp *Service) GetExplorerStatus() (explorer.ExplorerStatus, error) {
+	status := exp.breaker.status()
+	status.Maintenance, status.MaintenanceMessage = exp.maintenance.get()
+	return status, nil
+}
+
+// GetServerConfig returns the effective config of the server in JSON, with the secrets redacted
+func (exp *Service) GetServerConfig() (string, error) {
+	if exp.configDumper == nil {
+		return "", errors.New("server config is unavailable")
+	}
+	cfg, err := exp.configDumper()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to dump server config")
+	}
+	return string(cfg), nil
+}
+
 // GetCandidateMetrics returns the latest delegates metrics
 func (exp *Service) GetCandidateMetrics() (explorer.CandidateMetrics, error) {
 	cm, err := exp.c.Metrics()
@@ -781,7 +1439,7 @@ func (exp *Service) GetCandidateMetrics() (explorer.CandidateMetrics, error) {
 	for _, d := range cm.LatestDelegates {
 		delegateSet[d] = true
 	}
-	allCandidates, err := exp.bc.CandidatesByHeight(cm.LatestHeight)
+	allCandidates, err := exp.sr.CandidatesByHeight(cm.LatestHeight)
 	if err != nil {
 		return explorer.CandidateMetrics{}, errors.Wrapf(err,
 			"Failed to get the candidate metrics")
@@ -816,7 +1474,7 @@ func (exp *Service) GetCandidateMetricsByHeight(h int64) (explorer.CandidateMetr
 	if h < 0 {
 		return explorer.CandidateMetrics{}, errors.New("Invalid height")
 	}
-	allCandidates, err := exp.bc.CandidatesByHeight(uint64(h))
+	allCandidates, err := exp.sr.CandidatesByHeight(uint64(h))
 	if err != nil {
 		return explorer.CandidateMetrics{}, errors.Wrapf(err,
 			"Failed to get the candidate metrics")
@@ -842,6 +1500,43 @@ func (exp *Service) GetCandidateMetricsByHeight(h int64) (explorer.CandidateMetr
 	}, nil
 }
 
+// GetCandidates returns the candidates at the given height. IsDelegate tells whether the candidate is one of the
+// delegates of the latest epoch.
+func (exp *Service) GetCandidates(height int64) ([]explorer.Candidate, error) {
+	if height < 0 {
+		return nil, errors.New("Invalid height")
+	}
+	cm, err := exp.c.Metrics()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the delegates")
+	}
+	delegateSet := make(map[string]bool, len(cm.LatestDelegates))
+	for _, d := range cm.LatestDelegates {
+		delegateSet[d] = true
+	}
+	allCandidates, err := exp.sr.CandidatesByHeight(uint64(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the candidates at height %d", height)
+	}
+	candidates := make([]explorer.Candidate, 0, len(allCandidates))
+	for _, c := range allCandidates {
+		pubKey, err := keypair.BytesToPubKeyString(c.PublicKey[:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid candidate pub key")
+		}
+		candidates = append(candidates, explorer.Candidate{
+			Address:          c.Address,
+			PubKey:           pubKey,
+			TotalVote:        c.Votes.Int64(),
+			CreationHeight:   int64(c.CreationHeight),
+			LastUpdateHeight: int64(c.LastUpdateHeight),
+			IsDelegate:       delegateSet[c.Address],
+			IsProducer:       cm.LatestBlockProducer == c.Address,
+		})
+	}
+	return candidates, nil
+}
+
 // SendTransfer sends a transfer
 func (exp *Service) SendTransfer(tsfJSON explorer.SendTransferRequest) (resp explorer.SendTransferResponse, err error) {
 	logger.Debug().Msg("receive send transfer request")
@@ -893,15 +1588,20 @@ func (exp *Service) SendTransfer(tsfJSON explorer.SendTransferRequest) (resp exp
 		GasPrice:  big.NewInt(tsfJSON.GasPrice).Bytes(),
 		Signature: signature,
 	}
+	tsf := &action.Transfer{}
+	tsf.ConvertFromActionPb(actPb)
+	if err := exp.bc.VerifyActionSignature(tsf); err != nil {
+		return explorer.SendTransferResponse{}, errors.Wrap(ErrTransfer, err.Error())
+	}
+	// send to actpool via dispatcher
+	if err := exp.dp.HandleBroadcast(exp.bc.ChainID(), actPb, nil); err != nil {
+		return explorer.SendTransferResponse{}, err
+	}
 	// broadcast to the network
 	if err = exp.p2p.Broadcast(exp.bc.ChainID(), actPb); err != nil {
 		return explorer.SendTransferResponse{}, err
 	}
-	// send to actpool via dispatcher
-	exp.dp.HandleBroadcast(exp.bc.ChainID(), actPb, nil)
 
-	tsf := &action.Transfer{}
-	tsf.ConvertFromActionPb(actPb)
 	h := tsf.Hash()
 	return explorer.SendTransferResponse{Hash: hex.EncodeToString(h[:])}, nil
 }
@@ -945,7 +1645,9 @@ func (exp *Service) SendVote(voteJSON explorer.SendVoteRequest) (resp explorer.S
 		return explorer.SendVoteResponse{}, err
 	}
 	// send to actpool via dispatcher
-	exp.dp.HandleBroadcast(exp.bc.ChainID(), actPb, nil)
+	if err := exp.dp.HandleBroadcast(exp.bc.ChainID(), actPb, nil); err != nil {
+		return explorer.SendVoteResponse{}, err
+	}
 
 	v := &action.Vote{}
 	v.ConvertFromActionPb(actPb)
@@ -956,9 +1658,12 @@ func (exp *Service) SendVote(voteJSON explorer.SendVoteRequest) (resp explorer.S
 // GetPeers return a list of node peers and itself's network addsress info.
 func (exp *Service) GetPeers() (explorer.GetPeersResponse, error) {
 	var peers []explorer.Node
-	for _, p := range exp.p2p.GetPeers() {
+	for _, p := range exp.p2p.Peers() {
 		peers = append(peers, explorer.Node{
-			Address: p.String(),
+			Address:   p.Addr,
+			Direction: string(p.Direction),
+			LastSeen:  p.LastSeen.Unix(),
+			Height:    int64(p.Height),
 		})
 	}
 	return explorer.GetPeersResponse{
@@ -1012,7 +1717,9 @@ func (exp *Service) SendSmartContract(execution explorer.Execution) (resp explor
 		return explorer.SendSmartContractResponse{}, err
 	}
 	// send to actpool via dispatcher
-	exp.dp.HandleBroadcast(exp.bc.ChainID(), actPb, nil)
+	if err := exp.dp.HandleBroadcast(exp.bc.ChainID(), actPb, nil); err != nil {
+		return explorer.SendSmartContractResponse{}, err
+	}
 
 	sc := &action.Execution{}
 	sc.ConvertFromActionPb(actPb)
@@ -1020,6 +1727,59 @@ func (exp *Service) SendSmartContract(execution explorer.Execution) (resp explor
 	return explorer.SendSmartContractResponse{Hash: hex.EncodeToString(h[:])}, nil
 }
 
+// SendAction sends a signed action, which is the hex string of a serialized ActionPb, and returns the action hash
+func (exp *Service) SendAction(rawAction string) (actHash string, err error) {
+	logger.Debug().Msg("receive send action request")
+
+	defer func() {
+		succeed := "true"
+		if err != nil {
+			succeed = "false"
+		}
+		requestMtc.WithLabelValues("SendAction", succeed).Inc()
+	}()
+
+	actBytes, err := hex.DecodeString(rawAction)
+	if err != nil {
+		return "", errors.Wrap(ErrAction, err.Error())
+	}
+	actPb := &pb.ActionPb{}
+	if err := proto.Unmarshal(actBytes, actPb); err != nil {
+		return "", errors.Wrap(ErrAction, err.Error())
+	}
+	var act action.Action
+	switch {
+	case actPb.GetTransfer() != nil:
+		tsf := &action.Transfer{}
+		tsf.ConvertFromActionPb(actPb)
+		act = tsf
+	case actPb.GetVote() != nil:
+		vote := &action.Vote{}
+		vote.ConvertFromActionPb(actPb)
+		act = vote
+	case actPb.GetExecution() != nil:
+		execution := &action.Execution{}
+		execution.ConvertFromActionPb(actPb)
+		act = execution
+	default:
+		return "", errors.Wrap(ErrAction, "unsupported action type")
+	}
+	if err := exp.bc.VerifyActionSignature(act); err != nil {
+		return "", errors.Wrap(ErrAction, err.Error())
+	}
+	// send to actpool via dispatcher
+	if err := exp.dp.HandleBroadcast(exp.bc.ChainID(), actPb, nil); err != nil {
+		return "", err
+	}
+	// broadcast to the network
+	if err := exp.p2p.Broadcast(exp.bc.ChainID(), actPb); err != nil {
+		return "", err
+	}
+
+	h := act.Hash()
+	return hex.EncodeToString(h[:]), nil
+}
+
 // ReadExecutionState reads the state in a contract address specified by the slot
 func (exp *Service) ReadExecutionState(execution explorer.Execution) (string, error) {
 	logger.Debug().Msg("receive read smart contract request")
@@ -1058,6 +1818,62 @@ func (exp *Service) ReadExecutionState(execution explorer.Execution) (string, er
 	return hex.EncodeToString(res), nil
 }
 
+// EstimateGas returns the gas consumed by the execution, which is simulated against the current state without being
+// committed. It returns an error if the execution fails
+func (exp *Service) EstimateGas(request explorer.ExecutionRequest) (explorer.GasEstimate, error) {
+	return exp.estimateGas(request, false)
+}
+
+// CallContract returns the result of calling the contract, which is simulated against the current state without
+// being committed
+func (exp *Service) CallContract(request explorer.ExecutionRequest) (explorer.CallResult, error) {
+	return exp.callContract(request, false)
+}
+
+// EstimateGasPending returns the gas consumed by the execution like EstimateGas, but simulated after the actions of
+// the executor pending in the action pool
+func (exp *Service) EstimateGasPending(request explorer.ExecutionRequest) (explorer.GasEstimate, error) {
+	return exp.estimateGas(request, true)
+}
+
+// CallContractPending returns the result of calling the contract like CallContract, but simulated after the actions
+// of the executor pending in the action pool
+func (exp *Service) CallContractPending(request explorer.ExecutionRequest) (explorer.CallResult, error) {
+	return exp.callContract(request, true)
+}
+
+func (exp *Service) estimateGas(request explorer.ExecutionRequest, pending bool) (explorer.GasEstimate, error) {
+	receipt, err := exp.simulateExecution(request, pending)
+	if err != nil {
+		return explorer.GasEstimate{}, err
+	}
+	if receipt.Status != blockchain.SuccessStatus {
+		return explorer.GasEstimate{}, errors.Wrapf(ErrExecution, "execution failed after consuming %d gas",
+			receipt.GasConsumed)
+	}
+	return explorer.GasEstimate{Gas: int64(receipt.GasConsumed)}, nil
+}
+
+func (exp *Service) callContract(request explorer.ExecutionRequest, pending bool) (explorer.CallResult, error) {
+	receipt, err := exp.simulateExecution(request, pending)
+	if err != nil {
+		return explorer.CallResult{}, err
+	}
+	return explorer.CallResult{
+		ReturnValue: hex.EncodeToString(receipt.ReturnValue),
+		Status:      int64(receipt.Status),
+		GasConsumed: int64(receipt.GasConsumed),
+	}, nil
+}
+
+// Multicall calls a list of read methods in one request, whose params and results are JSON encoded
+func (exp *Service) Multicall(requests []explorer.Request) ([]explorer.Response, error) {
+	if err := exp.ctxErr(); err != nil {
+		return nil, err
+	}
+	return multicall(exp, requests)
+}
+
 // GetBlockOrActionByHash get block or action by a hash
 func (exp *Service) GetBlockOrActionByHash(hashStr string) (explorer.GetBlkOrActResponse, error) {
 	if blk, err := exp.GetBlockByID(hashStr); err == nil {
@@ -1079,6 +1895,70 @@ func (exp *Service) GetBlockOrActionByHash(hashStr string) (explorer.GetBlkOrAct
 	return explorer.GetBlkOrActResponse{}, nil
 }
 
+// GetActionByID returns the transfer, vote or execution with the given id, together with its type
+func (exp *Service) GetActionByID(actionID string) (explorer.Action, error) {
+	bytes, err := hex.DecodeString(actionID)
+	if err != nil {
+		return explorer.Action{}, errors.Wrapf(ErrAction, "invalid action id %s: %v", actionID, err)
+	}
+	var actHash hash.Hash32B
+	copy(actHash[:], bytes)
+
+	actType, err := exp.getActionType(actHash)
+	if err != nil {
+		return explorer.Action{}, errors.Wrapf(err, "cannot find action %s", actionID)
+	}
+	switch actType {
+	case TransferActionType:
+		tsf, err := getTransfer(exp.bc, exp.ap, actHash)
+		if err != nil {
+			return explorer.Action{}, err
+		}
+		return explorer.Action{Type: actType, Transfer: &tsf}, nil
+	case VoteActionType:
+		vote, err := getVote(exp.bc, exp.ap, actHash)
+		if err != nil {
+			return explorer.Action{}, err
+		}
+		return explorer.Action{Type: actType, Vote: &vote}, nil
+	default:
+		exe, err := getExecution(exp.bc, exp.ap, actHash)
+		if err != nil {
+			return explorer.Action{}, err
+		}
+		return explorer.Action{Type: actType, Execution: &exe}, nil
+	}
+}
+
+// getActionType resolves the type of the action with the given hash from the indexes of the blockchain, or from the
+// action itself if it is still pending in actpool
+func (exp *Service) getActionType(actHash hash.Hash32B) (string, error) {
+	if _, err := exp.bc.GetTransferByTransferHash(actHash); err == nil {
+		return TransferActionType, nil
+	}
+	if _, err := exp.bc.GetVoteByVoteHash(actHash); err == nil {
+		return VoteActionType, nil
+	}
+	if _, err := exp.bc.GetExecutionByExecutionHash(actHash); err == nil {
+		return ExecutionActionType, nil
+	}
+
+	act, err := exp.ap.GetActionByHash(actHash)
+	if err != nil {
+		return "", errors.Wrap(ErrAction, err.Error())
+	}
+	switch {
+	case act.GetTransfer() != nil:
+		return TransferActionType, nil
+	case act.GetVote() != nil:
+		return VoteActionType, nil
+	case act.GetExecution() != nil:
+		return ExecutionActionType, nil
+	default:
+		return "", errors.Wrap(ErrAction, "unsupported action type")
+	}
+}
+
 // getTransfer takes in a blockchain and transferHash and returns an Explorer Transfer
 func getTransfer(bc blockchain.Blockchain, ap actpool.ActPool, transferHash hash.Hash32B) (explorer.Transfer, error) {
 	explorerTransfer := explorer.Transfer{}
@@ -1181,6 +2061,365 @@ func getExecution(bc blockchain.Blockchain, ap actpool.ActPool, executionHash ha
 	return explorerExecution, nil
 }
 
+// countActions returns the number of actions picked by count in the blocks from genesis up to startBlockHeight, which
+// is read from the index of the action counts rather than the blocks
+func (exp *Service) countActions(startBlockHeight int64, count func(*blockchain.ActionCounts) uint64) (int64, error) {
+	if startBlockHeight < 0 {
+		return 0, nil
+	}
+	counts, err := exp.bc.GetActionCounts(uint64(startBlockHeight))
+	if err != nil {
+		return 0, err
+	}
+	return int64(count(counts)), nil
+}
+
+// SubscribeBlocks returns a channel which receives every newly committed block, and the function to cancel the
+// subscription. It is not a part of the JSON-RPC API, which doesn't support streaming.
+func (exp *Service) SubscribeBlocks() (<-chan explorer.Block, func(), error) {
+	sub := &blockSubscriber{
+		blocks:                make(chan explorer.Block, blockSubscriptionBufferSize),
+		finalityConfirmations: exp.cfg.FinalityConfirmations,
+	}
+	if err := exp.bc.AddSubscriber(sub); err != nil {
+		return nil, nil, err
+	}
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			if err := exp.bc.RemoveSubscriber(sub); err != nil {
+				logger.Error().Err(err).Msg("failed to remove the block subscriber")
+			}
+			// No more block will be sent once the subscriber is removed
+			close(sub.blocks)
+		})
+	}
+	return sub.blocks, cancel, nil
+}
+
+// blockSubscriber forwards the committed blocks to a subscription channel
+type blockSubscriber struct {
+	blocks                chan explorer.Block
+	finalityConfirmations uint64
+}
+
+// HandleBlock sends the block to the channel, or drops it if the subscriber falls behind. The block is the new tip,
+// which has no confirmation yet.
+func (s *blockSubscriber) HandleBlock(blk *blockchain.Block) error {
+	hash := blk.HashBlock()
+	explorerBlk := convertBlockToExplorerBlock(blk, hex.EncodeToString(hash[:]))
+	explorerBlk.ConfirmationCount, explorerBlk.Finalized = blockFinality(blk.Height(), blk.Height(),
+		s.finalityConfirmations)
+	select {
+	case s.blocks <- explorerBlk:
+	default:
+		logger.Warn().Uint64("height", blk.Height()).Msg("block subscriber is too slow, drop the block")
+	}
+	return nil
+}
+
+// SubscribeAddress returns a channel which receives the events of the address in every newly committed block, and the
+// function to cancel the subscription. Like SubscribeBlocks, it is not a part of the JSON-RPC API.
+func (exp *Service) SubscribeAddress(addr string) (<-chan AddressEvent, func(), error) {
+	addr, err := normalizeAddress(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	sub := &addressSubscriber{
+		address: addr,
+		ap:      exp.ap,
+		events:  make(chan AddressEvent, addressSubscriptionBufferSize),
+	}
+	sub.refreshPending()
+	if err := exp.bc.AddSubscriber(sub); err != nil {
+		return nil, nil, err
+	}
+	done := make(chan struct{})
+	go sub.keepPendingFresh(done)
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			if err := exp.bc.RemoveSubscriber(sub); err != nil {
+				logger.Error().Err(err).Msg("failed to remove the address subscriber")
+			}
+			// No more event will be sent once the subscriber is removed
+			close(sub.events)
+		})
+	}
+	return sub.events, cancel, nil
+}
+
+// addressSubscriber forwards the events of an address in the committed blocks to a subscription channel
+type addressSubscriber struct {
+	address string
+	ap      actpool.ActPool
+	events  chan AddressEvent
+	// pending is the snapshot of the hashes of the actions sent by the address which are pending in actpool. It is
+	// taken outside HandleBlock, which runs under the chain's lock, so that the actpool isn't called back from there
+	pendingMutex sync.RWMutex
+	pending      map[hash.Hash32B]bool
+}
+
+// HandleBlock sends the events of the address in the block to the channel, or drops them if the subscriber falls
+// behind
+func (s *addressSubscriber) HandleBlock(blk *blockchain.Block) error {
+	blkHash := blk.HashBlock()
+	blkID := hex.EncodeToString(blkHash[:])
+	timestamp := int64(blk.ConvertToBlockHeaderPb().Timestamp)
+	s.pendingMutex.RLock()
+	pending := s.pending
+	s.pendingMutex.RUnlock()
+	var events []AddressEvent
+	newEvent := func(eventType string) AddressEvent {
+		return AddressEvent{Type: eventType, Address: s.address, BlockID: blkID, Height: int64(blk.Height())}
+	}
+	for _, tsf := range blk.Transfers {
+		if tsf.Sender() != s.address && tsf.Recipient() != s.address {
+			continue
+		}
+		explorerTsf, err := convertTsfToExplorerTsf(tsf, false)
+		if err != nil {
+			return err
+		}
+		explorerTsf.Timestamp = timestamp
+		explorerTsf.BlockID = blkID
+		event := newEvent(TransferEventType)
+		event.Transfer = &explorerTsf
+		events = append(events, event)
+		if pending[tsf.Hash()] {
+			event.Type = ConfirmationEventType
+			events = append(events, event)
+		}
+	}
+	for _, vote := range blk.Votes {
+		if vote.Voter() != s.address {
+			continue
+		}
+		explorerVote, err := convertVoteToExplorerVote(vote, false)
+		if err != nil {
+			return err
+		}
+		explorerVote.Timestamp = timestamp
+		explorerVote.BlockID = blkID
+		event := newEvent(VoteEventType)
+		event.Vote = &explorerVote
+		events = append(events, event)
+		if pending[vote.Hash()] {
+			event.Type = ConfirmationEventType
+			events = append(events, event)
+		}
+	}
+	// only the confirmations of executions are pushed
+	for _, execution := range blk.Executions {
+		if execution.Executor() != s.address || !pending[execution.Hash()] {
+			continue
+		}
+		explorerExecution, err := convertExecutionToExplorerExecution(execution, false)
+		if err != nil {
+			return err
+		}
+		explorerExecution.Timestamp = timestamp
+		explorerExecution.BlockID = blkID
+		event := newEvent(ConfirmationEventType)
+		event.Execution = &explorerExecution
+		events = append(events, event)
+	}
+	for _, event := range events {
+		select {
+		case s.events <- event:
+		default:
+			logger.Warn().
+				Uint64("height", blk.Height()).
+				Str("address", s.address).
+				Msg("address subscriber is too slow, drop the event")
+		}
+	}
+	return nil
+}
+
+// keepPendingFresh refreshes the snapshot of the pending actions periodically until done is closed
+func (s *addressSubscriber) keepPendingFresh(done <-chan struct{}) {
+	ticker := time.NewTicker(addressPendingRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			s.refreshPending()
+		}
+	}
+}
+
+// refreshPending takes a snapshot of the hashes of the actions sent by the address which are pending in actpool. The
+// actions committed in a block are removed from actpool after the block is handled, so the ones pending when the
+// snapshot is taken are confirmed by the block. An action added and committed within addressPendingRefreshInterval may
+// miss its confirmation event though. It must not be called under the chain's lock.
+func (s *addressSubscriber) refreshPending() {
+	pending := make(map[hash.Hash32B]bool)
+	defer func() {
+		s.pendingMutex.Lock()
+		s.pending = pending
+		s.pendingMutex.Unlock()
+	}()
+	if s.ap == nil {
+		return
+	}
+	for _, act := range s.ap.GetUnconfirmedActs(s.address) {
+		switch {
+		case act.GetTransfer() != nil:
+			tsf := &action.Transfer{}
+			tsf.ConvertFromActionPb(act)
+			pending[tsf.Hash()] = true
+		case act.GetVote() != nil:
+			vote := &action.Vote{}
+			vote.ConvertFromActionPb(act)
+			pending[vote.Hash()] = true
+		case act.GetExecution() != nil:
+			execution := &action.Execution{}
+			execution.ConvertFromActionPb(act)
+			pending[execution.Hash()] = true
+		}
+	}
+}
+
+// simulateExecution runs the requested execution against the current state with the executor's next nonce. The gas
+// limit defaults to the block gas limit if it isn't given, and an empty contract address means deploying a contract
+func (exp *Service) simulateExecution(request explorer.ExecutionRequest, pending bool) (*blockchain.Receipt, error) {
+	if request.Amount < 0 || request.GasLimit < 0 || request.GasPrice < 0 {
+		return nil, errors.Wrap(ErrExecution, "amount, gas limit and gas price cannot be negative")
+	}
+	executor, err := normalizeAddress(request.Executor)
+	if err != nil {
+		return nil, err
+	}
+	request.Executor = executor
+	if request.Contract != "" {
+		contract, err := normalizeAddress(request.Contract)
+		if err != nil {
+			return nil, err
+		}
+		request.Contract = contract
+	}
+	data, err := hex.DecodeString(request.Data)
+	if err != nil {
+		return nil, errors.Wrap(ErrExecution, err.Error())
+	}
+	nonce, err := exp.sr.Nonce(request.Executor)
+	if err != nil && errors.Cause(err) != state.ErrAccountNotExist {
+		return nil, errors.Wrapf(err, "failed to get the nonce of executor %s", request.Executor)
+	}
+	var pendingActs []action.Action
+	if pending {
+		// only the actions filling the nonces right after the confirmed one are applied, the ones after a gap
+		// cannot be put into a block yet
+		for _, act := range exp.ap.PendingActionMap()[request.Executor] {
+			if act.Nonce() != nonce+1 {
+				break
+			}
+			pendingActs = append(pendingActs, act)
+			nonce++
+		}
+	}
+	gasLimit := uint64(request.GasLimit)
+	if gasLimit == 0 {
+		gasLimit = action.GasLimit
+	}
+	execution, err := action.NewExecution(
+		request.Executor,
+		request.Contract,
+		nonce+1,
+		big.NewInt(request.Amount),
+		gasLimit,
+		big.NewInt(request.GasPrice),
+		data,
+	)
+	if err != nil {
+		return nil, errors.Wrap(ErrExecution, err.Error())
+	}
+	if pending {
+		return exp.bc.SimulateExecutionOnPending(execution, pendingActs)
+	}
+	return exp.bc.SimulateExecution(execution)
+}
+
+// toExplorerBlock converts the block, whose finality is evaluated against the current tip
+func (exp *Service) toExplorerBlock(blk *blockchain.Block, blkID string) explorer.Block {
+	explorerBlk := convertBlockToExplorerBlock(blk, blkID)
+	explorerBlk.ConfirmationCount, explorerBlk.Finalized = blockFinality(blk.Height(), exp.bc.TipHeight(),
+		exp.cfg.FinalityConfirmations)
+	return explorerBlk
+}
+
+// normalizeAddress validates the address passed to the explorer and returns it in the form stored on the blockchain
+func normalizeAddress(addr string) (string, error) {
+	normalized, err := address.NormalizeAddress(addr)
+	if err != nil {
+		return "", errors.Wrapf(ErrInvalidAddress, "%s: %v", addr, err)
+	}
+	return normalized, nil
+}
+
+// blockFinality returns the number of blocks committed on top of the block, and whether the block is finalized, i.e.,
+// it has at least finalityConfirmations confirmations
+func blockFinality(height uint64, tipHeight uint64, finalityConfirmations uint64) (int64, bool) {
+	var confirmations uint64
+	if tipHeight > height {
+		confirmations = tipHeight - height
+	}
+	return int64(confirmations), confirmations >= finalityConfirmations
+}
+
+func convertBlockToExplorerBlock(blk *blockchain.Block, blkID string) explorer.Block {
+	blkHeaderPb := blk.ConvertToBlockHeaderPb()
+
+	totalAmount := int64(0)
+	totalSize := uint32(0)
+	rewardAddress := ""
+	for _, transfer := range blk.Transfers {
+		totalAmount += transfer.Amount().Int64()
+		totalSize += transfer.TotalSize()
+		if transfer.IsCoinbase() {
+			rewardAddress = transfer.Recipient()
+		}
+	}
+
+	return explorer.Block{
+		ID:         blkID,
+		Height:     int64(blkHeaderPb.Height),
+		Timestamp:  int64(blkHeaderPb.Timestamp),
+		Transfers:  int64(len(blk.Transfers)),
+		Votes:      int64(len(blk.Votes)),
+		Executions: int64(len(blk.Executions)),
+		Amount:     totalAmount,
+		Size:       int64(totalSize),
+		GenerateBy: explorer.BlockGenerator{
+			Name:    "",
+			Address: keypair.EncodePublicKey(blk.Header.Pubkey),
+		},
+		ProducerPubKey: hex.EncodeToString(blkHeaderPb.Pubkey),
+		Signature:      hex.EncodeToString(blkHeaderPb.Signature),
+		RewardAddress:  rewardAddress,
+	}
+}
+
+func actionFee(gasPrice *big.Int, gas uint64) *big.Int {
+	if gasPrice == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas))
+}
+
+// feePercentile returns the p-th percentile of the sorted fees using the nearest-rank method
+func feePercentile(fees []*big.Int, p int) *big.Int {
+	rank := (p*len(fees) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return fees[rank-1]
+}
+
 func convertTsfToExplorerTsf(transfer *action.Transfer, isPending bool) (explorer.Transfer, error) {
 	if transfer == nil {
 		return explorer.Transfer{}, errors.Wrap(ErrTransfer, "transfer cannot be nil")
@@ -1247,25 +2486,46 @@ func convertExecutionToExplorerExecution(execution *action.Execution, isPending
 	return explorerExecution, nil
 }
 
+// matchLog returns true if the log is emitted by the contract, or contract is empty, and every topic of the filter is
+// either nil or the same as the topic of the log at its position
+func matchLog(log *blockchain.Log, contract string, filter []*hash.Hash32B) bool {
+	if contract != "" && log.Address != contract {
+		return false
+	}
+	if len(filter) > len(log.Topics) {
+		return false
+	}
+	for i, topic := range filter {
+		if topic != nil && *topic != log.Topics[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func convertLogToExplorerLog(log *blockchain.Log) explorer.Log {
+	topics := []string{}
+	for _, topic := range log.Topics {
+		topics = append(topics, hex.EncodeToString(topic[:]))
+	}
+	return explorer.Log{
+		Address:     log.Address,
+		Topics:      topics,
+		Data:        hex.EncodeToString(log.Data),
+		BlockNumber: int64(log.BlockNumber),
+		TxnHash:     hex.EncodeToString(log.TxnHash[:]),
+		BlockHash:   hex.EncodeToString(log.BlockHash[:]),
+		Index:       int64(log.Index),
+	}
+}
+
 func convertReceiptToExplorerReceipt(receipt *blockchain.Receipt) (explorer.Receipt, error) {
 	if receipt == nil {
 		return explorer.Receipt{}, errors.Wrap(ErrReceipt, "receipt cannot be nil")
 	}
 	logs := []explorer.Log{}
 	for _, log := range receipt.Logs {
-		topics := []string{}
-		for _, topic := range log.Topics {
-			topics = append(topics, hex.EncodeToString(topic[:]))
-		}
-		logs = append(logs, explorer.Log{
-			Address:     log.Address,
-			Topics:      topics,
-			Data:        hex.EncodeToString(log.Data),
-			BlockNumber: int64(log.BlockNumber),
-			TxnHash:     hex.EncodeToString(log.TxnHash[:]),
-			BlockHash:   hex.EncodeToString(log.BlockHash[:]),
-			Index:       int64(log.Index),
-		})
+		logs = append(logs, convertLogToExplorerLog(log))
 	}
 
 	return explorer.Receipt{
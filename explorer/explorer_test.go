@@ -11,10 +11,13 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
-	"net"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,14 +27,21 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/action"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/network"
 	"github.com/iotexproject/iotex-core/network/node"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	pb "github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
 	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
 	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+	"github.com/iotexproject/iotex-core/test/mock/mock_state"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -166,6 +176,7 @@ func TestExplorerApi(t *testing.T) {
 
 	svc := Service{
 		bc: bc,
+		sr: sf,
 		ap: ap,
 		cfg: config.Explorer{
 			TpsWindow:               10,
@@ -193,6 +204,22 @@ func TestExplorerApi(t *testing.T) {
 	require.Nil(err)
 	require.Equal(1, len(votes))
 
+	votes, err = svc.GetVotesFromAddress(ta.Addrinfo["charlie"].RawAddress, 0, 10)
+	require.Nil(err)
+	require.Equal(2, len(votes))
+	for _, vote := range votes {
+		require.Equal(ta.Addrinfo["charlie"].RawAddress, vote.Voter)
+	}
+
+	votes, err = svc.GetVotesToAddress(ta.Addrinfo["charlie"].RawAddress, 0, 10)
+	require.Nil(err)
+	require.Equal(1, len(votes))
+	require.Equal(ta.Addrinfo["alfa"].RawAddress, votes[0].Voter)
+
+	votes, err = svc.GetVotesFromAddress(ta.Addrinfo["delta"].RawAddress, 0, 10)
+	require.Nil(err)
+	require.Equal(0, len(votes))
+
 	executions, err := svc.GetExecutionsByAddress(ta.Addrinfo["charlie"].RawAddress, 0, 10)
 	require.Nil(err)
 	require.Equal(2, len(executions))
@@ -229,9 +256,49 @@ func TestExplorerApi(t *testing.T) {
 	require.Equal(1, len(executions))
 	require.Nil(err)
 
+	transfers, err = svc.GetTransfersByAmountRange(10, 10, 0, 10)
+	require.Nil(err)
+	require.Equal(1, len(transfers))
+	require.Equal(int64(10), transfers[0].Amount)
+	transfers, err = svc.GetTransfersByAmountRange(1, 10, 0, 10)
+	require.Nil(err)
+	require.Equal(5, len(transfers))
+	transfers, err = svc.GetTransfersByAmountRange(1, 1, 1, 2)
+	require.Nil(err)
+	require.Equal(2, len(transfers))
+	transfers, err = svc.GetTransfersByAmountRange(2, 9, 0, 10)
+	require.Nil(err)
+	require.Equal(0, len(transfers))
+	_, err = svc.GetTransfersByAmountRange(10, 1, 0, 10)
+	require.Error(err)
+
+	votePage, err := svc.GetLastVotesByRangeV2(3, 0, 10)
+	require.Nil(err)
+	require.Equal(10, len(votePage.Votes))
+	require.Equal(int64(22), votePage.TotalCount)
+	require.True(votePage.HasMore)
+	votePage, err = svc.GetLastVotesByRangeV2(3, 20, 10)
+	require.Nil(err)
+	require.Equal(2, len(votePage.Votes))
+	require.False(votePage.HasMore)
+	executionPage, err := svc.GetLastExecutionsByRangeV2(3, 0, 50)
+	require.Nil(err)
+	require.Equal(1, len(executionPage.Executions))
+	require.Equal(int64(1), executionPage.TotalCount)
+	require.False(executionPage.HasMore)
+	transferPage, err := svc.GetLastTransfersByRangeV2(4, 1, 3, true)
+	require.Nil(err)
+	require.Equal(3, len(transferPage.Transfers))
+	require.True(transferPage.HasMore)
+
 	blks, getBlkErr := svc.GetLastBlocksByRange(3, 4)
 	require.Nil(getBlkErr)
 	require.Equal(4, len(blks))
+	// blocks are finalized once committed by default
+	for i, blk := range blks {
+		require.Equal(int64(i+1), blk.ConfirmationCount)
+		require.True(blk.Finalized)
+	}
 
 	transfers, err = svc.GetTransfersByBlockID(blks[2].ID, 0, 10)
 	require.Nil(err)
@@ -271,6 +338,13 @@ func TestExplorerApi(t *testing.T) {
 	_, err = svc.GetTransferByID("")
 	require.Error(err)
 
+	proof, err := svc.GetTransferProof(transfers[0].ID)
+	require.Nil(err)
+	require.Equal(transfers[0].BlockID, proof.BlockID)
+	requireValidMerkleProof(require, proof)
+	_, err = svc.GetTransferProof("")
+	require.Error(err)
+
 	vote, err := svc.GetVoteByID(votes[0].ID)
 	require.Nil(err)
 	require.Equal(votes[0].Nonce, vote.Nonce)
@@ -305,13 +379,36 @@ func TestExplorerApi(t *testing.T) {
 	require.Equal(int64(0), blk.Votes)
 	require.Equal(int64(0), blk.Executions)
 	require.Equal(int64(1), blk.Transfers)
+	// the block's authorship could be verified by its producer's public key and signature
+	producerPubKey, err := keypair.DecodePublicKey(blk.ProducerPubKey)
+	require.NoError(err)
+	blkSig, err := hex.DecodeString(blk.Signature)
+	require.NoError(err)
+	blkHash, err := hex.DecodeString(blk.ID)
+	require.NoError(err)
+	require.True(crypto.EC283.Verify(producerPubKey, blkHash, blkSig))
+	// the block reward is credited to the producer by default
+	require.Equal(ta.Addrinfo["producer"].RawAddress, blk.RewardAddress)
 
 	_, err = svc.GetBlockByID("")
 	require.Error(err)
 
+	blk, err = svc.GetBlockByHeight(blks[0].Height)
+	require.Nil(err)
+	require.Equal(blks[0], blk)
+
+	_, err = svc.GetBlockByHeight(5)
+	require.Equal(ErrBlock, errors.Cause(err))
+
 	stats, err := svc.GetCoinStatistic()
 	require.Nil(err)
-	require.Equal(int64(blockchain.Gen.TotalSupply), stats.Supply)
+	require.Equal(int64(blockchain.Gen.TotalSupply)+stats.AccumulatedReward, stats.Supply)
+	require.Equal(stats.Supply, stats.TotalSupply)
+	creatorBalance, err := bc.Balance(blockchain.Gen.CreatorAddr(cfg.Chain.ID))
+	require.Nil(err)
+	require.Equal(stats.TotalSupply-creatorBalance.Int64(), stats.CirculatingSupply)
+	require.Equal(int64(0), stats.BlockReward)
+	require.Equal(int64(0), stats.AccumulatedReward)
 	require.Equal(int64(4), stats.Height)
 	require.Equal(int64(32), stats.Transfers)
 	require.Equal(int64(24), stats.Votes)
@@ -325,7 +422,7 @@ func TestExplorerApi(t *testing.T) {
 
 	// error
 	_, err = svc.GetAddressBalance("")
-	require.Error(err)
+	require.Equal(ErrInvalidAddress, errors.Cause(err))
 
 	// success
 	addressDetails, err := svc.GetAddressDetails(ta.Addrinfo["charlie"].RawAddress)
@@ -335,10 +432,27 @@ func TestExplorerApi(t *testing.T) {
 	require.Equal(int64(9), addressDetails.PendingNonce)
 	require.Equal(ta.Addrinfo["charlie"].RawAddress, addressDetails.Address)
 
+	nonce, err := svc.GetAddressNonce(ta.Addrinfo["charlie"].RawAddress)
+	require.Nil(err)
+	require.Equal(int64(9), nonce)
+
 	// error
 	_, err = svc.GetAddressDetails("")
 	require.Error(err)
 
+	// success
+	accountState, err := svc.GetAddressState(ta.Addrinfo["charlie"].RawAddress)
+	require.Nil(err)
+	require.Equal(ta.Addrinfo["charlie"].RawAddress, accountState.Address)
+	require.Equal(int64(6), accountState.Balance)
+	require.Equal(int64(8), accountState.Nonce)
+	require.False(accountState.IsCandidate)
+	require.Equal(ta.Addrinfo["alfa"].RawAddress, accountState.Votee)
+
+	// error
+	_, err = svc.GetAddressState("")
+	require.Error(err)
+
 	tip, err := svc.GetBlockchainHeight()
 	require.Nil(err)
 	require.Equal(4, int(tip))
@@ -346,6 +460,11 @@ func TestExplorerApi(t *testing.T) {
 	err = addActsToActPool(ap)
 	require.Nil(err)
 
+	// the pending actions with nonce 2 to 5 are taken into account
+	nonce, err = svc.GetAddressNonce(ta.Addrinfo["producer"].RawAddress)
+	require.Nil(err)
+	require.Equal(int64(6), nonce)
+
 	// success
 	transfers, err = svc.GetUnconfirmedTransfersByAddress(ta.Addrinfo["producer"].RawAddress, 0, 3)
 	require.Nil(err)
@@ -371,6 +490,19 @@ func TestExplorerApi(t *testing.T) {
 	require.Nil(err)
 	require.Equal(0, len(executions))
 
+	pendingActions, err := svc.GetPendingActionsByAddress(ta.Addrinfo["producer"].RawAddress, 0, 10)
+	require.Nil(err)
+	require.Equal(2, len(pendingActions.Transfers))
+	require.Equal(1, len(pendingActions.Votes))
+	require.Equal(1, len(pendingActions.Executions))
+	pendingActions, err = svc.GetPendingActionsByAddress(ta.Addrinfo["producer"].RawAddress, 1, 2)
+	require.Nil(err)
+	require.Equal(1, len(pendingActions.Transfers))
+	require.Equal(int64(4), pendingActions.Transfers[0].Nonce)
+	require.Equal(1, len(pendingActions.Votes))
+	require.Equal(int64(3), pendingActions.Votes[0].Nonce)
+	require.Equal(0, len(pendingActions.Executions))
+
 	// error
 	_, err = svc.GetUnconfirmedTransfersByAddress("", 0, 3)
 	require.Error(err)
@@ -378,6 +510,10 @@ func TestExplorerApi(t *testing.T) {
 	require.Error(err)
 	_, err = svc.GetUnconfirmedExecutionsByAddress("", 0, 3)
 	require.Error(err)
+	_, err = svc.GetPendingActionsByAddress("", 0, 3)
+	require.Error(err)
+	_, err = svc.GetTransfersByAddress("io1", 0, 3)
+	require.Equal(ErrInvalidAddress, errors.Cause(err))
 
 	// test GetBlockOrActionByHash
 	res, err := svc.GetBlockOrActionByHash("")
@@ -418,6 +554,78 @@ func TestExplorerApi(t *testing.T) {
 	require.Nil(res.Transfer)
 	require.Nil(res.Vote)
 	require.Equal(&executions[0], res.Execution)
+
+	// test GetActionByID
+	_, err = svc.GetActionByID(blks[0].ID)
+	require.Equal(ErrAction, errors.Cause(err))
+
+	act, err := svc.GetActionByID(transfers[0].ID)
+	require.NoError(err)
+	require.Equal(TransferActionType, act.Type)
+	require.Equal(&transfers[0], act.Transfer)
+	require.Nil(act.Vote)
+	require.Nil(act.Execution)
+
+	act, err = svc.GetActionByID(votes[0].ID)
+	require.NoError(err)
+	require.Equal(VoteActionType, act.Type)
+	require.Equal(&votes[0], act.Vote)
+
+	act, err = svc.GetActionByID(executions[0].ID)
+	require.NoError(err)
+	require.Equal(ExecutionActionType, act.Type)
+	require.Equal(&executions[0], act.Execution)
+}
+
+func TestService_GetPendingActionByID(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	svc := Service{bc: chain, ap: ap}
+
+	a := ta.Addrinfo["alfa"].RawAddress
+	b := ta.Addrinfo["bravo"].RawAddress
+	vote, err := action.NewVote(1, a, b, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	execution, err := action.NewExecution(a, b, 2, big.NewInt(0), uint64(100000), big.NewInt(0), []byte{1})
+	require.NoError(err)
+
+	// the actions aren't in any block yet
+	chain.EXPECT().GetTransferByTransferHash(gomock.Any()).Return(nil, errors.New("not found")).AnyTimes()
+	chain.EXPECT().GetVoteByVoteHash(gomock.Any()).Return(nil, errors.New("not found")).AnyTimes()
+	chain.EXPECT().GetExecutionByExecutionHash(gomock.Any()).Return(nil, errors.New("not found")).AnyTimes()
+	ap.EXPECT().GetActionByHash(vote.Hash()).Return(vote.ConvertToActionPb(), nil).AnyTimes()
+	ap.EXPECT().GetActionByHash(execution.Hash()).Return(execution.ConvertToActionPb(), nil).AnyTimes()
+	ap.EXPECT().GetActionByHash(gomock.Any()).Return(nil, errors.New("not found")).AnyTimes()
+
+	voteHash := vote.Hash()
+	act, err := svc.GetActionByID(hex.EncodeToString(voteHash[:]))
+	require.NoError(err)
+	require.Equal(VoteActionType, act.Type)
+	require.Nil(act.Transfer)
+	require.Nil(act.Execution)
+	require.NotNil(act.Vote)
+	require.Equal(hex.EncodeToString(voteHash[:]), act.Vote.ID)
+	require.True(act.Vote.IsPending)
+
+	executionHash := execution.Hash()
+	act, err = svc.GetActionByID(hex.EncodeToString(executionHash[:]))
+	require.NoError(err)
+	require.Equal(ExecutionActionType, act.Type)
+	require.Nil(act.Transfer)
+	require.Nil(act.Vote)
+	require.NotNil(act.Execution)
+	require.Equal(hex.EncodeToString(executionHash[:]), act.Execution.ID)
+	require.True(act.Execution.IsPending)
+
+	_, err = svc.GetActionByID(hex.EncodeToString(hash.ZeroHash32B[:]))
+	require.Equal(ErrAction, errors.Cause(err))
+	_, err = svc.GetActionByID("invalid")
+	require.Equal(ErrAction, errors.Cause(err))
 }
 
 func TestService_StateByAddr(t *testing.T) {
@@ -434,15 +642,19 @@ func TestService_StateByAddr(t *testing.T) {
 		Votee:        "456",
 	}
 
-	mBc := mock_blockchain.NewMockBlockchain(ctrl)
-	mBc.EXPECT().StateByAddr("123").Times(1).Return(&s, nil)
+	addr := ta.Addrinfo["alfa"].RawAddress
+	sr := mock_state.NewMockStateReader(ctrl)
+	sr.EXPECT().State(addr).Times(1).Return(&s, nil)
+	svc := Service{sr: sr}
 
-	state, err := mBc.StateByAddr("123")
+	// the address is normalized before reading the state
+	state, err := svc.GetAddressState(" " + strings.ToUpper(addr))
 	require.Nil(err)
-	require.Equal(big.NewInt(46), state.Balance)
-	require.Equal(uint64(0), state.Nonce)
+	require.Equal(addr, state.Address)
+	require.Equal(int64(46), state.Balance)
+	require.Equal(int64(0), state.Nonce)
 	require.Equal(false, state.IsCandidate)
-	require.Equal(big.NewInt(100), state.VotingWeight)
+	require.Equal(int64(100), state.VotingWeight)
 	require.Equal("456", state.Votee)
 }
 
@@ -465,6 +677,9 @@ func TestService_GetConsensusMetrics(t *testing.T) {
 		LatestDelegates:     candidates[:4],
 		LatestBlockProducer: candidates[3],
 		Candidates:          candidates,
+		Round:               2,
+		Phase:               "S_ACCEPT_PROPOSE",
+		RoundStartTime:      time.Unix(1000, 0),
 	}, nil)
 
 	svc := Service{c: c}
@@ -473,6 +688,9 @@ func TestService_GetConsensusMetrics(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, m)
 	require.Equal(t, int64(1), m.LatestEpoch)
+	require.Equal(t, int64(2), m.Round)
+	require.Equal(t, "S_ACCEPT_PROPOSE", m.Phase)
+	require.Equal(t, int64(1000), m.RoundStartTime)
 	require.Equal(
 		t,
 		[]string{
@@ -499,6 +717,122 @@ func TestService_GetConsensusMetrics(t *testing.T) {
 	)
 }
 
+func TestService_GetEpochMeta(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	delegates := []string{
+		"io1qyqsyqcy6nm58gjd2wr035wz5eyd5uq47zyqpng3gxe7nh",
+		"io1qyqsyqcy6m6hkqkj3f4w4eflm2gzydmvc0mumm7kgax4l3",
+	}
+	c := mock_consensus.NewMockConsensus(ctrl)
+	c.EXPECT().EpochMeta(uint64(3)).Return(scheme.EpochMeta{
+		Num:            3,
+		StartHeight:    5,
+		EndHeight:      6,
+		Delegates:      delegates,
+		ProducedBlocks: map[string]uint64{delegates[0]: 1, delegates[1]: 0},
+	}, nil)
+	c.EXPECT().EpochMeta(uint64(4)).Return(scheme.EpochMeta{}, errors.New("error"))
+
+	svc := Service{c: c}
+	meta, err := svc.GetEpochMeta(3)
+	require.Nil(err)
+	require.Equal(explorer.EpochMeta{
+		EpochNum:    3,
+		StartHeight: 5,
+		EndHeight:   6,
+		Delegates: []explorer.EpochDelegate{
+			{Address: delegates[0], ProducedBlockCount: 1},
+			{Address: delegates[1], ProducedBlockCount: 0},
+		},
+	}, meta)
+
+	_, err = svc.GetEpochMeta(4)
+	require.Error(err)
+	_, err = svc.GetEpochMeta(0)
+	require.Error(err)
+}
+
+func TestService_GetAddressBalanceAtHeight(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	addr := ta.Addrinfo["alfa"].RawAddress
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+	sr := mock_state.NewMockStateReader(ctrl)
+	sr.EXPECT().StateAtHeight(addr, uint64(5)).Return(&state.State{Balance: big.NewInt(46)}, nil).Times(1)
+	sr.EXPECT().StateAtHeight(addr, uint64(1)).Return(nil, state.ErrHistoricalStateUnavailable).Times(1)
+	svc := Service{bc: chain, sr: sr}
+
+	balance, err := svc.GetAddressBalanceAtHeight(addr, 5)
+	require.Nil(err)
+	require.Equal(int64(46), balance)
+
+	_, err = svc.GetAddressBalanceAtHeight(addr, 1)
+	require.Equal(state.ErrHistoricalStateUnavailable, errors.Cause(err))
+	_, err = svc.GetAddressBalanceAtHeight(addr, 11)
+	require.Error(err)
+	_, err = svc.GetAddressBalanceAtHeight(addr, -1)
+	require.Error(err)
+	_, err = svc.GetAddressBalanceAtHeight("io1", 5)
+	require.Equal(ErrInvalidAddress, errors.Cause(err))
+}
+
+func TestService_GetProducerStats(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	delegates := []string{
+		"io1qyqsyqcy6nm58gjd2wr035wz5eyd5uq47zyqpng3gxe7nh",
+		"io1qyqsyqcy6m6hkqkj3f4w4eflm2gzydmvc0mumm7kgax4l3",
+	}
+	c := mock_consensus.NewMockConsensus(ctrl)
+	c.EXPECT().Metrics().Return(scheme.ConsensusMetrics{LatestEpoch: 3}, nil).Times(2)
+	c.EXPECT().EpochMeta(uint64(3)).Return(scheme.EpochMeta{
+		Num:            3,
+		Delegates:      delegates,
+		ExpectedBlocks: map[string]uint64{delegates[0]: 3, delegates[1]: 2},
+		ProducedBlocks: map[string]uint64{delegates[0]: 1, delegates[1]: 2},
+	}, nil).Times(2)
+
+	svc := Service{c: c}
+	stats, err := svc.GetProducerStats(delegates[0])
+	require.Nil(err)
+	require.Equal(explorer.ProducerStats{
+		Address:        delegates[0],
+		EpochNum:       3,
+		ExpectedBlocks: 3,
+		ProducedBlocks: 1,
+		MissedBlocks:   2,
+	}, stats)
+
+	// not a delegate of the epoch
+	stats, err = svc.GetProducerStats("io1qyqsyqcyyu9pfazcx0wglp35h2h4fm0hl8p8z2u35vkcwc")
+	require.Nil(err)
+	require.Equal(int64(0), stats.ExpectedBlocks)
+	require.Equal(int64(0), stats.MissedBlocks)
+}
+
+func TestService_GetGenesisHash(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genesisHash := byteutil.BytesTo32B(hash.Hash256b([]byte("genesis")))
+	p2p := mock_network.NewMockOverlay(ctrl)
+	p2p.EXPECT().GenesisHash().Return(genesisHash).Times(1)
+
+	svc := Service{p2p: p2p}
+	h, err := svc.GetGenesisHash()
+	require.Nil(err)
+	require.Equal(hex.EncodeToString(genesisHash[:]), h)
+}
+
 func TestService_SendTransfer(t *testing.T) {
 	require := require.New(t)
 
@@ -515,23 +849,37 @@ func TestService_SendTransfer(t *testing.T) {
 	require.Equal("", response.Hash)
 	require.NotNil(err)
 
-	chain.EXPECT().ChainID().Return(uint32(1)).Times(2)
-	mDp.EXPECT().HandleBroadcast(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
-	p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Times(1)
-
+	tsf, err := action.NewTransfer(1, big.NewInt(1), ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["alfa"].RawAddress, []byte{}, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	require.NoError(action.Sign(tsf, ta.Addrinfo["producer"].PrivateKey))
 	r := explorer.SendTransferRequest{
-		Version:      0x1,
+		Version:      int64(tsf.Version()),
 		Nonce:        1,
-		Sender:       senderRawAddr,
-		Recipient:    recipientRawAddr,
+		Sender:       ta.Addrinfo["producer"].RawAddress,
+		Recipient:    ta.Addrinfo["alfa"].RawAddress,
 		Amount:       1,
-		SenderPubKey: senderPubKey,
+		SenderPubKey: keypair.EncodePublicKey(tsf.SenderPublicKey()),
 		Signature:    "",
 		Payload:      "",
+		GasLimit:     100000,
+		GasPrice:     10,
 	}
+
+	// the transfer with an invalid signature is rejected
+	chain.EXPECT().VerifyActionSignature(gomock.Any()).DoAndReturn(action.Verify).Times(2)
+	response, err = svc.SendTransfer(r)
+	require.Equal("", response.Hash)
+	require.Equal(ErrTransfer, errors.Cause(err))
+
+	chain.EXPECT().ChainID().Return(uint32(1)).Times(2)
+	mDp.EXPECT().HandleBroadcast(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+	p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Times(1)
+
+	r.Signature = hex.EncodeToString(tsf.Signature())
 	response, err = svc.SendTransfer(r)
-	require.NotNil(response.Hash)
 	require.Nil(err)
+	h := tsf.Hash()
+	require.Equal(hex.EncodeToString(h[:]), response.Hash)
 }
 
 func TestService_SendVote(t *testing.T) {
@@ -595,6 +943,164 @@ func TestService_SendSmartContract(t *testing.T) {
 	require.Nil(err)
 }
 
+func TestService_SendAction(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	mDp := mock_dispatcher.NewMockDispatcher(ctrl)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	svc := Service{bc: chain, dp: mDp, p2p: p2p}
+
+	_, err := svc.SendAction("invalid hex")
+	require.Equal(ErrAction, errors.Cause(err))
+
+	vote, err := action.NewVote(1, ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["producer"].RawAddress, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	// the action without signature is rejected
+	chain.EXPECT().VerifyActionSignature(gomock.Any()).DoAndReturn(action.Verify).Times(2)
+	vote.SetVoterPublicKey(ta.Addrinfo["producer"].PublicKey)
+	raw, err := proto.Marshal(vote.ConvertToActionPb())
+	require.NoError(err)
+	_, err = svc.SendAction(hex.EncodeToString(raw))
+	require.Equal(ErrAction, errors.Cause(err))
+
+	chain.EXPECT().ChainID().Return(uint32(1)).Times(2)
+	mDp.EXPECT().HandleBroadcast(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+	p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Times(1)
+
+	require.NoError(action.Sign(vote, ta.Addrinfo["producer"].PrivateKey))
+	raw, err = proto.Marshal(vote.ConvertToActionPb())
+	require.NoError(err)
+	actHash, err := svc.SendAction(hex.EncodeToString(raw))
+	require.NoError(err)
+	h := vote.Hash()
+	require.Equal(hex.EncodeToString(h[:]), actHash)
+}
+
+func TestService_SubscribeBlocks(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	svc := Service{bc: chain}
+
+	var sub blockchain.BlockCreationSubscriber
+	chain.EXPECT().AddSubscriber(gomock.Any()).DoAndReturn(func(s blockchain.BlockCreationSubscriber) error {
+		sub = s
+		return nil
+	}).Times(1)
+	blocks, cancel, err := svc.SubscribeBlocks()
+	require.NoError(err)
+
+	blk := blockchain.NewBlock(1, 10, hash.ZeroHash32B, testutil.TimestampNow(), nil, nil, nil)
+	require.NoError(sub.HandleBlock(blk))
+	h := blk.HashBlock()
+	explorerBlk := <-blocks
+	require.Equal(hex.EncodeToString(h[:]), explorerBlk.ID)
+	require.Equal(int64(10), explorerBlk.Height)
+
+	// the channel is closed after the subscription is cancelled
+	chain.EXPECT().RemoveSubscriber(sub).Return(nil).Times(1)
+	cancel()
+	cancel()
+	_, ok := <-blocks
+	require.False(ok)
+}
+
+func TestService_SubscribeAddress(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	svc := Service{bc: chain, ap: ap}
+
+	_, _, err := svc.SubscribeAddress("invalid")
+	require.Equal(ErrInvalidAddress, errors.Cause(err))
+
+	a := ta.Addrinfo["alfa"].RawAddress
+	b := ta.Addrinfo["bravo"].RawAddress
+	sent, err := action.NewTransfer(1, big.NewInt(10), a, b, nil, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	received, err := action.NewTransfer(1, big.NewInt(20), b, a, nil, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	vote, err := action.NewVote(2, a, a, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	execution, err := action.NewExecution(a, b, 3, big.NewInt(0), uint64(100000), big.NewInt(0), nil)
+	require.NoError(err)
+	otherExecution, err := action.NewExecution(b, a, 2, big.NewInt(0), uint64(100000), big.NewInt(0), nil)
+	require.NoError(err)
+	// the vote isn't pending in actpool, e.g., it's in a block synced from others. The pending actions are read when
+	// subscribing and refreshed periodically, but never while handling the block
+	ap.EXPECT().GetUnconfirmedActs(a).
+		Return([]*pb.ActionPb{sent.ConvertToActionPb(), execution.ConvertToActionPb()}).
+		MinTimes(1)
+	var sub blockchain.BlockCreationSubscriber
+	chain.EXPECT().AddSubscriber(gomock.Any()).DoAndReturn(func(s blockchain.BlockCreationSubscriber) error {
+		sub = s
+		return nil
+	}).Times(1)
+	events, cancel, err := svc.SubscribeAddress(a)
+	require.NoError(err)
+	blk := blockchain.NewBlock(
+		1,
+		10,
+		hash.ZeroHash32B,
+		testutil.TimestampNow(),
+		[]*action.Transfer{sent, received},
+		[]*action.Vote{vote},
+		[]*action.Execution{execution, otherExecution},
+	)
+	require.NoError(sub.HandleBlock(blk))
+	blkHash := blk.HashBlock()
+
+	for _, expected := range []struct {
+		eventType string
+		actHash   hash.Hash32B
+	}{
+		{TransferEventType, sent.Hash()},
+		{ConfirmationEventType, sent.Hash()},
+		{TransferEventType, received.Hash()},
+		{VoteEventType, vote.Hash()},
+		{ConfirmationEventType, execution.Hash()},
+	} {
+		event := <-events
+		require.Equal(expected.eventType, event.Type)
+		require.Equal(a, event.Address)
+		require.Equal(hex.EncodeToString(blkHash[:]), event.BlockID)
+		require.Equal(int64(10), event.Height)
+		var id string
+		switch {
+		case event.Transfer != nil:
+			id = event.Transfer.ID
+		case event.Vote != nil:
+			id = event.Vote.ID
+		default:
+			id = event.Execution.ID
+		}
+		require.Equal(hex.EncodeToString(expected.actHash[:]), id)
+	}
+	select {
+	case event := <-events:
+		require.Fail("unexpected event", "%+v", event)
+	default:
+	}
+
+	// the channel is closed after the subscription is cancelled
+	chain.EXPECT().RemoveSubscriber(sub).Return(nil).Times(1)
+	cancel()
+	cancel()
+	_, ok := <-events
+	require.False(ok)
+}
+
 func TestServiceGetPeers(t *testing.T) {
 	require := require.New(t)
 
@@ -605,10 +1111,11 @@ func TestServiceGetPeers(t *testing.T) {
 	p2p := mock_network.NewMockOverlay(ctrl)
 	svc := Service{dp: mDp, p2p: p2p}
 
-	p2p.EXPECT().GetPeers().Return([]net.Addr{
-		&node.Node{Addr: "127.0.0.1:10002"},
-		&node.Node{Addr: "127.0.0.1:10003"},
-		&node.Node{Addr: "127.0.0.1:10004"},
+	lastSeen := time.Unix(1538000000, 0)
+	p2p.EXPECT().Peers().Return([]network.PeerInfo{
+		{Addr: "127.0.0.1:10002", Direction: network.Outbound, LastSeen: lastSeen},
+		{Addr: "127.0.0.1:10003", Direction: network.Inbound, LastSeen: lastSeen, Height: 10},
+		{Addr: "127.0.0.1:10004", Direction: network.Outbound, LastSeen: lastSeen},
 	})
 	p2p.EXPECT().Self().Return(&node.Node{Addr: "127.0.0.1:10001"})
 
@@ -617,6 +1124,9 @@ func TestServiceGetPeers(t *testing.T) {
 	require.Equal("127.0.0.1:10001", response.Self.Address)
 	require.Len(response.Peers, 3)
 	require.Equal("127.0.0.1:10003", response.Peers[1].Address)
+	require.Equal("inbound", response.Peers[1].Direction)
+	require.Equal(lastSeen.Unix(), response.Peers[1].LastSeen)
+	require.Equal(int64(10), response.Peers[1].Height)
 }
 
 func TestTransferPayloadBytesLimit(t *testing.T) {
@@ -662,8 +1172,8 @@ func TestExplorerCandidateMetrics(t *testing.T) {
 		LatestBlockProducer: candidates[3],
 		Candidates:          candidates,
 	}, nil)
-	bc := mock_blockchain.NewMockBlockchain(ctrl)
-	bc.EXPECT().CandidatesByHeight(gomock.Any()).Return([]*state.Candidate{
+	sr := mock_state.NewMockStateReader(ctrl)
+	sr.EXPECT().CandidatesByHeight(gomock.Any()).Return([]*state.Candidate{
 		{Address: candidates[0], Votes: big.NewInt(0)},
 		{Address: candidates[1], Votes: big.NewInt(0)},
 		{Address: candidates[2], Votes: big.NewInt(0)},
@@ -673,7 +1183,7 @@ func TestExplorerCandidateMetrics(t *testing.T) {
 		{Address: candidates[6], Votes: big.NewInt(0)},
 	}, nil)
 
-	svc := Service{c: c, bc: bc}
+	svc := Service{c: c, sr: sr}
 
 	metrics, err := svc.GetCandidateMetrics()
 	require.NoError(err)
@@ -682,6 +1192,47 @@ func TestExplorerCandidateMetrics(t *testing.T) {
 	require.True(1 == metrics.LatestEpoch)
 }
 
+func TestExplorerGetCandidates(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	candidates := []string{
+		"io1qyqsyqcy6nm58gjd2wr035wz5eyd5uq47zyqpng3gxe7nh",
+		"io1qyqsyqcy6m6hkqkj3f4w4eflm2gzydmvc0mumm7kgax4l3",
+		"io1qyqsyqcyyu9pfazcx0wglp35h2h4fm0hl8p8z2u35vkcwc",
+	}
+	c := mock_consensus.NewMockConsensus(ctrl)
+	c.EXPECT().Metrics().Return(scheme.ConsensusMetrics{
+		LatestEpoch:         1,
+		LatestDelegates:     candidates[:2],
+		LatestBlockProducer: candidates[1],
+		Candidates:          candidates,
+	}, nil)
+	sr := mock_state.NewMockStateReader(ctrl)
+	sr.EXPECT().CandidatesByHeight(uint64(10)).Return([]*state.Candidate{
+		{Address: candidates[0], Votes: big.NewInt(30)},
+		{Address: candidates[1], Votes: big.NewInt(20)},
+		{Address: candidates[2], Votes: big.NewInt(10)},
+	}, nil)
+
+	svc := Service{c: c, sr: sr}
+
+	_, err := svc.GetCandidates(-1)
+	require.Error(err)
+
+	res, err := svc.GetCandidates(10)
+	require.NoError(err)
+	require.Equal(3, len(res))
+	require.Equal(candidates[0], res[0].Address)
+	require.Equal(int64(30), res[0].TotalVote)
+	require.True(res[0].IsDelegate)
+	require.False(res[0].IsProducer)
+	require.True(res[1].IsDelegate)
+	require.True(res[1].IsProducer)
+	require.False(res[2].IsDelegate)
+}
+
 func TestExplorerGetReceiptByExecutionID(t *testing.T) {
 	require := require.New(t)
 	cfg := config.Default
@@ -713,6 +1264,7 @@ func TestExplorerGetReceiptByExecutionID(t *testing.T) {
 
 	svc := Service{
 		bc: bc,
+		sr: sf,
 		cfg: config.Explorer{
 			TpsWindow:               10,
 			MaxTransferPayloadBytes: 1024,
@@ -734,4 +1286,394 @@ func TestExplorerGetReceiptByExecutionID(t *testing.T) {
 	receipt, err := svc.GetReceiptByExecutionID(eHashStr)
 	require.NoError(err)
 	require.Equal(eHashStr, receipt.Hash)
+
+	receipt, err = svc.GetReceiptByActionID(eHashStr)
+	require.NoError(err)
+	require.Equal(eHashStr, receipt.Hash)
+	_, err = svc.GetReceiptByActionID("xyz")
+	require.Error(err)
+}
+
+func TestService_GetLogsByBlockRange(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := ta.Addrinfo["alfa"].RawAddress
+	b := ta.Addrinfo["bravo"].RawAddress
+	execution1, err := action.NewExecution(a, b, 1, big.NewInt(0), uint64(100000), big.NewInt(0), nil)
+	require.NoError(err)
+	execution2, err := action.NewExecution(b, a, 1, big.NewInt(0), uint64(100000), big.NewInt(0), nil)
+	require.NoError(err)
+	blk1 := blockchain.NewBlock(1, 1, hash.ZeroHash32B, testutil.TimestampNow(), nil, nil,
+		[]*action.Execution{execution1, execution2})
+	blk2 := blockchain.NewBlock(1, 2, blk1.HashBlock(), testutil.TimestampNow(), nil, nil, nil)
+
+	topic1 := byteutil.BytesTo32B(hash.Hash256b([]byte("Transfer")))
+	topic2 := byteutil.BytesTo32B(hash.Hash256b([]byte("Approval")))
+	topic3 := byteutil.BytesTo32B(hash.Hash256b([]byte("alfa")))
+	log1 := &blockchain.Log{Address: b, Topics: []hash.Hash32B{topic1, topic3}, BlockNumber: 1, Index: 0}
+	log2 := &blockchain.Log{Address: b, Topics: []hash.Hash32B{topic2}, BlockNumber: 1, Index: 1}
+	log3 := &blockchain.Log{Address: a, Topics: []hash.Hash32B{topic1}, BlockNumber: 1, Index: 2}
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().Return(uint64(2)).AnyTimes()
+	chain.EXPECT().GetBlockByHeight(uint64(1)).Return(blk1, nil).AnyTimes()
+	chain.EXPECT().GetBlockByHeight(uint64(2)).Return(blk2, nil).AnyTimes()
+	chain.EXPECT().GetReceiptByExecutionHash(execution1.Hash()).
+		Return(&blockchain.Receipt{Logs: []*blockchain.Log{log1, log2}}, nil).AnyTimes()
+	chain.EXPECT().GetReceiptByExecutionHash(execution2.Hash()).
+		Return(&blockchain.Receipt{Logs: []*blockchain.Log{log3}}, nil).AnyTimes()
+	svc := Service{bc: chain}
+
+	hexHash := func(h hash.Hash32B) string { return hex.EncodeToString(h[:]) }
+	indexes := func(logs []explorer.Log) []int64 {
+		var res []int64
+		for _, log := range logs {
+			res = append(res, log.Index)
+		}
+		return res
+	}
+
+	logs, err := svc.GetLogsByBlockRange(0, 10, "", nil)
+	require.NoError(err)
+	require.Equal([]int64{0, 1, 2}, indexes(logs))
+	require.Equal(convertLogToExplorerLog(log1), logs[0])
+
+	logs, err = svc.GetLogsByBlockRange(1, 1, b, nil)
+	require.NoError(err)
+	require.Equal([]int64{0, 1}, indexes(logs))
+
+	logs, err = svc.GetLogsByBlockRange(1, 2, "", []string{hexHash(topic1)})
+	require.NoError(err)
+	require.Equal([]int64{0, 2}, indexes(logs))
+
+	// an empty topic matches any topic at its position, but the log must have a topic there
+	logs, err = svc.GetLogsByBlockRange(1, 2, "", []string{"", hexHash(topic3)})
+	require.NoError(err)
+	require.Equal([]int64{0}, indexes(logs))
+
+	logs, err = svc.GetLogsByBlockRange(2, 2, "", nil)
+	require.NoError(err)
+	require.Empty(logs)
+
+	_, err = svc.GetLogsByBlockRange(2, 1, "", nil)
+	require.Error(err)
+	_, err = svc.GetLogsByBlockRange(-1, 1, "", nil)
+	require.Error(err)
+	_, err = svc.GetLogsByBlockRange(1, 2, "", []string{"xyz"})
+	require.Error(err)
+	_, err = svc.GetLogsByBlockRange(1, 2, "io1contract", nil)
+	require.Equal(ErrInvalidAddress, errors.Cause(err))
+}
+
+func TestService_PrunedBlocks(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// block 1 is pruned, blocks 2 and 3 each have a transfer
+	var blks []*blockchain.Block
+	prevHash := hash.ZeroHash32B
+	for height := uint64(2); height <= 3; height++ {
+		tsf, err := action.NewTransfer(height, big.NewInt(1), ta.Addrinfo["alfa"].RawAddress,
+			ta.Addrinfo["bravo"].RawAddress, []byte{}, uint64(100000), big.NewInt(10))
+		require.NoError(err)
+		blk := blockchain.NewBlock(1, height, prevHash, testutil.TimestampNow(), []*action.Transfer{tsf}, nil, nil)
+		prevHash = blk.HashBlock()
+		blks = append(blks, blk)
+	}
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().Return(uint64(3)).AnyTimes()
+	chain.EXPECT().GetHashByHeight(gomock.Any()).Return(hash.ZeroHash32B, nil).AnyTimes()
+	chain.EXPECT().GetBlockByHeight(uint64(1)).
+		Return(nil, errors.Wrapf(blockchain.ErrBlockPruned, "block %d", 1)).AnyTimes()
+	chain.EXPECT().GetBlockByHeight(uint64(2)).Return(blks[0], nil).AnyTimes()
+	chain.EXPECT().GetBlockByHeight(uint64(3)).Return(blks[1], nil).AnyTimes()
+	svc := Service{bc: chain}
+
+	explorerBlks, err := svc.GetLastBlocksByRange(3, 10)
+	require.NoError(err)
+	require.Equal(2, len(explorerBlks))
+	require.Equal(int64(3), explorerBlks[0].Height)
+	require.Equal(int64(2), explorerBlks[1].Height)
+
+	tsfs, err := svc.GetLastTransfersByRange(3, 0, 10, false)
+	require.NoError(err)
+	require.Equal(2, len(tsfs))
+
+	votes, err := svc.GetLastVotesByRange(3, 0, 10)
+	require.NoError(err)
+	require.Empty(votes)
+
+	executions, err := svc.GetLastExecutionsByRange(3, 0, 10)
+	require.NoError(err)
+	require.Empty(executions)
+
+	logs, err := svc.GetLogsByBlockRange(1, 3, "", nil)
+	require.NoError(err)
+	require.Empty(logs)
+
+	// only the blocks that are not pruned are counted
+	stat, err := svc.GetFeeStatistic(3)
+	require.NoError(err)
+	require.Equal(int64(2), stat.BlockCount)
+	require.Equal(int64(10*action.TransferBaseIntrinsicGas), stat.AverageFee)
+}
+
+func TestExplorerGetFeeStatistic(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.Chain.TrieDBPath = testTriePath
+	cfg.Chain.ChainDBPath = testDBPath
+	cfg.Explorer.Enabled = true
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	sf, err := state.NewFactory(&cfg, state.InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	_, err = sf.LoadOrCreateState(ta.Addrinfo["producer"].RawAddress, blockchain.Gen.TotalSupply)
+	require.NoError(err)
+	// Disable block reward to make bookkeeping easier
+	blockchain.Gen.BlockReward = uint64(0)
+
+	// create chain
+	ctx := context.Background()
+	bc := blockchain.NewBlockchain(&cfg, blockchain.PrecreatedStateFactoryOption(sf), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(ctx))
+	require.NotNil(bc)
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	svc := Service{
+		bc: bc,
+		sr: sf,
+		cfg: config.Explorer{
+			TpsWindow:               10,
+			MaxTransferPayloadBytes: 1024,
+		},
+	}
+
+	_, err = svc.GetFeeStatistic(0)
+	require.Equal(ErrInternalServer, errors.Cause(err))
+
+	// Add block 1 with an execution
+	execution, err := action.NewExecution(
+		ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["delta"].RawAddress, 1, big.NewInt(0), uint64(100000), big.NewInt(10), []byte{1})
+	require.NoError(err)
+	require.NoError(action.Sign(execution, ta.Addrinfo["producer"].PrivateKey))
+	blk, err := bc.MintNewBlock(nil, nil, []*action.Execution{execution}, ta.Addrinfo["producer"], "")
+	require.NoError(err)
+	require.NoError(bc.CommitBlock(blk))
+
+	// Add block 2 with transfers of gas price 10, 20 and 30
+	var tsfs []*action.Transfer
+	for i := 1; i <= 3; i++ {
+		tsf, err := action.NewTransfer(uint64(i+1), big.NewInt(1), ta.Addrinfo["producer"].RawAddress,
+			ta.Addrinfo["charlie"].RawAddress, []byte{}, uint64(100000), big.NewInt(int64(10*i)))
+		require.NoError(err)
+		require.NoError(action.Sign(tsf, ta.Addrinfo["producer"].PrivateKey))
+		tsfs = append(tsfs, tsf)
+	}
+	blk, err = bc.MintNewBlock(tsfs, nil, nil, ta.Addrinfo["producer"], "")
+	require.NoError(err)
+	require.NoError(bc.CommitBlock(blk))
+
+	gas := int64(action.TransferBaseIntrinsicGas)
+	stat, err := svc.GetFeeStatistic(1)
+	require.NoError(err)
+	require.Equal(int64(1), stat.BlockCount)
+	require.Equal(20*gas, stat.AverageFee)
+	require.Equal(20*gas, stat.MedianFee)
+	require.Equal(30*gas, stat.P95Fee)
+
+	receipt, err := bc.GetReceiptByExecutionHash(execution.Hash())
+	require.NoError(err)
+	executionFee := 10 * int64(receipt.GasConsumed)
+	fees := []int64{10 * gas, 20 * gas, 30 * gas, executionFee}
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+
+	// block count is capped by the tip height
+	stat, err = svc.GetFeeStatistic(10)
+	require.NoError(err)
+	require.Equal(int64(2), stat.BlockCount)
+	require.Equal((60*gas+executionFee)/4, stat.AverageFee)
+	require.Equal(fees[1], stat.MedianFee)
+	require.Equal(fees[3], stat.P95Fee)
+}
+
+func TestExplorerSimulateExecution(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.Explorer.Enabled = true
+
+	sf, err := state.NewFactory(&cfg, state.InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	producer := ta.Addrinfo["producer"]
+	_, err = sf.LoadOrCreateState(producer.RawAddress, blockchain.Gen.TotalSupply)
+	require.NoError(err)
+	// Disable block reward to make bookkeeping easier
+	blockchain.Gen.BlockReward = uint64(0)
+
+	// create chain
+	ctx := context.Background()
+	bc := blockchain.NewBlockchain(&cfg, blockchain.PrecreatedStateFactoryOption(sf), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(ctx))
+	require.NotNil(bc)
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	svc := Service{bc: bc, sr: sf}
+
+	// the contract stores a number by set(uint256) and returns it by get()
+	code := "608060405234801561001057600080fd5b5060df8061001f6000396000f3006080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806360fe47b114604e5780636d4ce63c146078575b600080fd5b348015605957600080fd5b5060766004803603810190808035906020019092919050505060a0565b005b348015608357600080fd5b50608a60aa565b6040518082815260200191505060405180910390f35b8060008190555050565b600080549050905600a165627a7a7230582002faabbefbbda99b20217cf33cb8ab8100caf1542bf1f48117d72e2c59139aea0029"
+	root := sf.RootHash()
+	estimate, err := svc.EstimateGas(explorer.ExecutionRequest{Executor: producer.RawAddress, Data: code})
+	require.NoError(err)
+	require.True(estimate.Gas > 0)
+	_, err = svc.EstimateGas(explorer.ExecutionRequest{Executor: producer.RawAddress, GasLimit: 10, Data: code})
+	require.Error(err)
+	_, err = svc.EstimateGas(explorer.ExecutionRequest{Executor: producer.RawAddress, Data: "xyz"})
+	require.Error(err)
+	_, err = svc.EstimateGas(explorer.ExecutionRequest{Executor: "invalid", Data: code})
+	require.Equal(ErrInvalidAddress, errors.Cause(err))
+	_, err = svc.CallContractPending(explorer.ExecutionRequest{Executor: producer.RawAddress, Contract: "invalid"})
+	require.Equal(ErrInvalidAddress, errors.Cause(err))
+	// nothing is committed by the simulation
+	require.Equal(root, sf.RootHash())
+	nonce, err := sf.Nonce(producer.RawAddress)
+	require.NoError(err)
+	require.Equal(uint64(0), nonce)
+
+	data, err := hex.DecodeString(code)
+	require.NoError(err)
+	execution, err := action.NewExecution(
+		producer.RawAddress, action.EmptyAddress, 1, big.NewInt(0), uint64(100000), big.NewInt(0), data)
+	require.NoError(err)
+	require.NoError(action.Sign(execution, producer.PrivateKey))
+	blk, err := bc.MintNewBlock(nil, nil, []*action.Execution{execution}, producer, "")
+	require.NoError(err)
+	require.Nil(bc.CommitBlock(blk))
+	receipt, err := bc.GetReceiptByExecutionHash(execution.Hash())
+	require.NoError(err)
+
+	set := explorer.ExecutionRequest{
+		Executor: producer.RawAddress,
+		Contract: receipt.ContractAddress,
+		Data:     "60fe47b10000000000000000000000000000000000000000000000000000000000000005",
+	}
+	res, err := svc.CallContract(set)
+	require.NoError(err)
+	require.Equal(int64(blockchain.SuccessStatus), res.Status)
+	require.True(res.GasConsumed > 0)
+	estimate, err = svc.EstimateGas(set)
+	require.NoError(err)
+	require.Equal(res.GasConsumed, estimate.Gas)
+
+	// the number set in the simulation isn't stored
+	res, err = svc.CallContract(explorer.ExecutionRequest{
+		Executor: producer.RawAddress,
+		Contract: receipt.ContractAddress,
+		Data:     "6d4ce63c",
+	})
+	require.NoError(err)
+	require.Equal(int64(blockchain.SuccessStatus), res.Status)
+	require.Equal(hex.EncodeToString(make([]byte, 32)), res.ReturnValue)
+
+	// the pending actions of the executor are applied before the simulation
+	ap, err := actpool.NewActPool(bc, cfg.ActPool)
+	require.NoError(err)
+	svc.ap = ap
+	setData, err := hex.DecodeString(set.Data)
+	require.NoError(err)
+	pendingSet, err := action.NewExecution(
+		producer.RawAddress, receipt.ContractAddress, 2, big.NewInt(0), uint64(100000), big.NewInt(0), setData)
+	require.NoError(err)
+	require.NoError(action.Sign(pendingSet, producer.PrivateKey))
+	require.NoError(ap.AddExecution(pendingSet))
+	get := explorer.ExecutionRequest{
+		Executor: producer.RawAddress,
+		Contract: receipt.ContractAddress,
+		Data:     "6d4ce63c",
+	}
+	res, err = svc.CallContractPending(get)
+	require.NoError(err)
+	require.Equal(int64(blockchain.SuccessStatus), res.Status)
+	require.Equal(fmt.Sprintf("%064x", 5), res.ReturnValue)
+	estimate, err = svc.EstimateGasPending(get)
+	require.NoError(err)
+	require.Equal(res.GasConsumed, estimate.Gas)
+	res, err = svc.CallContract(get)
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(make([]byte, 32)), res.ReturnValue)
+
+	// a pending transfer of all the balance leaves nothing to pay for the execution
+	balance, err := sf.Balance(producer.RawAddress)
+	require.NoError(err)
+	tsf, err := action.NewTransfer(3, balance, producer.RawAddress, ta.Addrinfo["alfa"].RawAddress, []byte{},
+		uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(action.Sign(tsf, producer.PrivateKey))
+	require.NoError(ap.AddTsf(tsf))
+	paid := explorer.ExecutionRequest{Executor: producer.RawAddress, Amount: 1}
+	res, err = svc.CallContract(paid)
+	require.NoError(err)
+	require.Equal(int64(blockchain.SuccessStatus), res.Status)
+	res, err = svc.CallContractPending(paid)
+	require.NoError(err)
+	require.Equal(int64(blockchain.FailureStatus), res.Status)
+	_, err = svc.EstimateGasPending(paid)
+	require.Error(err)
+	// nothing is committed by the simulation
+	newBalance, err := sf.Balance(producer.RawAddress)
+	require.NoError(err)
+	require.Equal(balance, newBalance)
+}
+
+func TestBlockFinality(t *testing.T) {
+	require := require.New(t)
+
+	for _, test := range []struct {
+		height                uint64
+		tipHeight             uint64
+		finalityConfirmations uint64
+		confirmations         int64
+		finalized             bool
+	}{
+		{10, 10, 0, 0, true},
+		{10, 10, 1, 0, false},
+		{8, 10, 3, 2, false},
+		{7, 10, 3, 3, true},
+		{0, 10, 3, 10, true},
+		{11, 10, 0, 0, true},
+	} {
+		confirmations, finalized := blockFinality(test.height, test.tipHeight, test.finalityConfirmations)
+		require.Equal(test.confirmations, confirmations)
+		require.Equal(test.finalized, finalized)
+	}
+}
+
+func TestService_GetServerConfig(t *testing.T) {
+	require := require.New(t)
+
+	svc := Service{}
+	_, err := svc.GetServerConfig()
+	require.Error(err)
+
+	svc.configDumper = func() ([]byte, error) { return []byte(`{"NodeType":"delegate"}`), nil }
+	cfg, err := svc.GetServerConfig()
+	require.NoError(err)
+	require.Equal(`{"NodeType":"delegate"}`, cfg)
+
+	svc.configDumper = func() ([]byte, error) { return nil, errors.New("error") }
+	_, err = svc.GetServerConfig()
+	require.Error(err)
 }
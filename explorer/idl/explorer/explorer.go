@@ -9,16 +9,27 @@ import (
 )
 
 const BarristerVersion string = "0.1.6"
-const BarristerChecksum string = "97e0f2efe11dee3190b33a6c0cb7fda8"
-const BarristerDateGenerated int64 = 1538418246052000000
+const BarristerChecksum string = "a60486576d707794cdb2fcd1871c225b"
+const BarristerDateGenerated int64 = 1792116018745000000
 
 type CoinStatistic struct {
-	Height     int64 `json:"height"`
-	Supply     int64 `json:"supply"`
-	Transfers  int64 `json:"transfers"`
-	Votes      int64 `json:"votes"`
-	Executions int64 `json:"executions"`
-	Aps        int64 `json:"aps"`
+	Height            int64 `json:"height"`
+	Supply            int64 `json:"supply"`
+	TotalSupply       int64 `json:"totalSupply"`
+	CirculatingSupply int64 `json:"circulatingSupply"`
+	BlockReward       int64 `json:"blockReward"`
+	AccumulatedReward int64 `json:"accumulatedReward"`
+	Transfers         int64 `json:"transfers"`
+	Votes             int64 `json:"votes"`
+	Executions        int64 `json:"executions"`
+	Aps               int64 `json:"aps"`
+}
+
+type FeeStatistic struct {
+	BlockCount int64 `json:"blockCount"`
+	AverageFee int64 `json:"averageFee"`
+	MedianFee  int64 `json:"medianFee"`
+	P95Fee     int64 `json:"p95Fee"`
 }
 
 type BlockGenerator struct {
@@ -27,16 +38,32 @@ type BlockGenerator struct {
 }
 
 type Block struct {
-	ID         string         `json:"ID"`
-	Height     int64          `json:"height"`
-	Timestamp  int64          `json:"timestamp"`
-	Transfers  int64          `json:"transfers"`
-	Votes      int64          `json:"votes"`
-	Executions int64          `json:"executions"`
-	GenerateBy BlockGenerator `json:"generateBy"`
-	Amount     int64          `json:"amount"`
-	Forged     int64          `json:"forged"`
-	Size       int64          `json:"size"`
+	ID                string         `json:"ID"`
+	Height            int64          `json:"height"`
+	Timestamp         int64          `json:"timestamp"`
+	Transfers         int64          `json:"transfers"`
+	Votes             int64          `json:"votes"`
+	Executions        int64          `json:"executions"`
+	GenerateBy        BlockGenerator `json:"generateBy"`
+	Amount            int64          `json:"amount"`
+	Forged            int64          `json:"forged"`
+	Size              int64          `json:"size"`
+	Finalized         bool           `json:"finalized"`
+	ConfirmationCount int64          `json:"confirmationCount"`
+	ProducerPubKey    string         `json:"producerPubKey"`
+	Signature         string         `json:"signature"`
+	RewardAddress     string         `json:"rewardAddress"`
+}
+
+type MerkleProof struct {
+	TransferID  string   `json:"transferID"`
+	BlockID     string   `json:"blockID"`
+	BlockHeight int64    `json:"blockHeight"`
+	BlockHeader string   `json:"blockHeader"`
+	TxRoot      string   `json:"txRoot"`
+	StateRoot   string   `json:"stateRoot"`
+	Index       int64    `json:"index"`
+	Branch      []string `json:"branch"`
 }
 
 type Transfer struct {
@@ -113,6 +140,30 @@ type Vote struct {
 	IsPending   bool   `json:"isPending"`
 }
 
+type TransferPage struct {
+	Transfers  []Transfer `json:"transfers"`
+	TotalCount int64      `json:"totalCount"`
+	HasMore    bool       `json:"hasMore"`
+}
+
+type VotePage struct {
+	Votes      []Vote `json:"votes"`
+	TotalCount int64  `json:"totalCount"`
+	HasMore    bool   `json:"hasMore"`
+}
+
+type ExecutionPage struct {
+	Executions []Execution `json:"executions"`
+	TotalCount int64       `json:"totalCount"`
+	HasMore    bool        `json:"hasMore"`
+}
+
+type PendingActions struct {
+	Transfers  []Transfer  `json:"transfers"`
+	Votes      []Vote      `json:"votes"`
+	Executions []Execution `json:"executions"`
+}
+
 type AddressDetails struct {
 	Address      string `json:"address"`
 	TotalBalance int64  `json:"totalBalance"`
@@ -121,6 +172,15 @@ type AddressDetails struct {
 	IsCandidate  bool   `json:"isCandidate"`
 }
 
+type AccountState struct {
+	Address      string `json:"address"`
+	Nonce        int64  `json:"nonce"`
+	Balance      int64  `json:"balance"`
+	IsCandidate  bool   `json:"isCandidate"`
+	Votee        string `json:"votee"`
+	VotingWeight int64  `json:"votingWeight"`
+}
+
 type Candidate struct {
 	Address          string `json:"address"`
 	PubKey           string `json:"pubKey"`
@@ -142,6 +202,29 @@ type ConsensusMetrics struct {
 	LatestDelegates     []string `json:"latestDelegates"`
 	LatestBlockProducer string   `json:"latestBlockProducer"`
 	Candidates          []string `json:"candidates"`
+	Round               int64    `json:"round"`
+	Phase               string   `json:"phase"`
+	RoundStartTime      int64    `json:"roundStartTime"`
+}
+
+type EpochDelegate struct {
+	Address            string `json:"address"`
+	ProducedBlockCount int64  `json:"producedBlockCount"`
+}
+
+type EpochMeta struct {
+	EpochNum    int64           `json:"epochNum"`
+	StartHeight int64           `json:"startHeight"`
+	EndHeight   int64           `json:"endHeight"`
+	Delegates   []EpochDelegate `json:"delegates"`
+}
+
+type ProducerStats struct {
+	Address        string `json:"address"`
+	EpochNum       int64  `json:"epochNum"`
+	ExpectedBlocks int64  `json:"expectedBlocks"`
+	ProducedBlocks int64  `json:"producedBlocks"`
+	MissedBlocks   int64  `json:"missedBlocks"`
 }
 
 type SendTransferRequest struct {
@@ -178,7 +261,10 @@ type SendVoteResponse struct {
 }
 
 type Node struct {
-	Address string `json:"address"`
+	Address   string `json:"address"`
+	Direction string `json:"direction"`
+	LastSeen  int64  `json:"lastSeen"`
+	Height    int64  `json:"height"`
 }
 
 type GetPeersResponse struct {
@@ -197,38 +283,109 @@ type GetBlkOrActResponse struct {
 	Execution *Execution `json:"execution,omitempty"`
 }
 
+type Action struct {
+	Type      string     `json:"type"`
+	Transfer  *Transfer  `json:"transfer,omitempty"`
+	Vote      *Vote      `json:"vote,omitempty"`
+	Execution *Execution `json:"execution,omitempty"`
+}
+
+type ExecutionRequest struct {
+	Executor string `json:"executor"`
+	Contract string `json:"contract"`
+	Amount   int64  `json:"amount"`
+	GasLimit int64  `json:"gasLimit"`
+	GasPrice int64  `json:"gasPrice"`
+	Data     string `json:"data"`
+}
+
+type GasEstimate struct {
+	Gas int64 `json:"gas"`
+}
+
+type CallResult struct {
+	ReturnValue string `json:"returnValue"`
+	Status      int64  `json:"status"`
+	GasConsumed int64  `json:"gasConsumed"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	Params string `json:"params"`
+}
+
+type ExplorerStatus struct {
+	Degraded            bool   `json:"degraded"`
+	ConsecutiveFailures int64  `json:"consecutiveFailures"`
+	Maintenance         bool   `json:"maintenance"`
+	MaintenanceMessage  string `json:"maintenanceMessage"`
+}
+
+type Response struct {
+	Result string `json:"result"`
+	Error  string `json:"error"`
+}
+
 type Explorer interface {
 	GetBlockchainHeight() (int64, error)
 	GetAddressBalance(address string) (int64, error)
+	GetAddressBalanceAtHeight(address string, height int64) (int64, error)
 	GetAddressDetails(address string) (AddressDetails, error)
+	GetAddressNonce(address string) (int64, error)
+	GetAddressState(address string) (AccountState, error)
 	GetLastTransfersByRange(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]Transfer, error)
+	GetLastTransfersByRangeV2(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) (TransferPage, error)
 	GetTransferByID(transferID string) (Transfer, error)
+	GetTransferProof(transferID string) (MerkleProof, error)
 	GetTransfersByAddress(address string, offset int64, limit int64) ([]Transfer, error)
 	GetUnconfirmedTransfersByAddress(address string, offset int64, limit int64) ([]Transfer, error)
 	GetTransfersByBlockID(blkID string, offset int64, limit int64) ([]Transfer, error)
+	GetTransfersByAmountRange(minAmount int64, maxAmount int64, offset int64, limit int64) ([]Transfer, error)
 	GetLastVotesByRange(startBlockHeight int64, offset int64, limit int64) ([]Vote, error)
+	GetLastVotesByRangeV2(startBlockHeight int64, offset int64, limit int64) (VotePage, error)
 	GetVoteByID(voteID string) (Vote, error)
 	GetVotesByAddress(address string, offset int64, limit int64) ([]Vote, error)
+	GetVotesFromAddress(address string, offset int64, limit int64) ([]Vote, error)
+	GetVotesToAddress(address string, offset int64, limit int64) ([]Vote, error)
 	GetUnconfirmedVotesByAddress(address string, offset int64, limit int64) ([]Vote, error)
 	GetVotesByBlockID(blkID string, offset int64, limit int64) ([]Vote, error)
 	GetLastExecutionsByRange(startBlockHeight int64, offset int64, limit int64) ([]Execution, error)
+	GetLastExecutionsByRangeV2(startBlockHeight int64, offset int64, limit int64) (ExecutionPage, error)
 	GetExecutionByID(executionID string) (Execution, error)
 	GetExecutionsByAddress(address string, offset int64, limit int64) ([]Execution, error)
 	GetUnconfirmedExecutionsByAddress(address string, offset int64, limit int64) ([]Execution, error)
+	GetPendingActionsByAddress(address string, offset int64, limit int64) (PendingActions, error)
 	GetExecutionsByBlockID(blkID string, offset int64, limit int64) ([]Execution, error)
 	GetLastBlocksByRange(offset int64, limit int64) ([]Block, error)
 	GetBlockByID(blkID string) (Block, error)
+	GetBlockByHeight(height int64) (Block, error)
 	GetCoinStatistic() (CoinStatistic, error)
+	GetFeeStatistic(blockCount int64) (FeeStatistic, error)
 	GetConsensusMetrics() (ConsensusMetrics, error)
+	GetEpochMeta(epochNum int64) (EpochMeta, error)
+	GetProducerStats(address string) (ProducerStats, error)
+	GetGenesisHash() (string, error)
+	GetExplorerStatus() (ExplorerStatus, error)
+	GetServerConfig() (string, error)
 	GetCandidateMetrics() (CandidateMetrics, error)
 	GetCandidateMetricsByHeight(h int64) (CandidateMetrics, error)
+	GetCandidates(height int64) ([]Candidate, error)
 	SendTransfer(request SendTransferRequest) (SendTransferResponse, error)
 	SendVote(request SendVoteRequest) (SendVoteResponse, error)
 	SendSmartContract(request Execution) (SendSmartContractResponse, error)
+	SendAction(rawAction string) (string, error)
 	GetPeers() (GetPeersResponse, error)
 	GetReceiptByExecutionID(id string) (Receipt, error)
+	GetReceiptByActionID(id string) (Receipt, error)
+	GetLogsByBlockRange(fromHeight int64, toHeight int64, contract string, topics []string) ([]Log, error)
 	ReadExecutionState(request Execution) (string, error)
+	EstimateGas(request ExecutionRequest) (GasEstimate, error)
+	CallContract(request ExecutionRequest) (CallResult, error)
+	EstimateGasPending(request ExecutionRequest) (GasEstimate, error)
+	CallContractPending(request ExecutionRequest) (CallResult, error)
+	Multicall(requests []Request) ([]Response, error)
 	GetBlockOrActionByHash(hashStr string) (GetBlkOrActResponse, error)
+	GetActionByID(actionID string) (Action, error)
 }
 
 func NewExplorerProxy(c barrister.Client) Explorer {
@@ -276,6 +433,24 @@ func (_p ExplorerProxy) GetAddressBalance(address string) (int64, error) {
 	return int64(0), _err
 }
 
+func (_p ExplorerProxy) GetAddressBalanceAtHeight(address string, height int64) (int64, error) {
+	_res, _err := _p.client.Call("Explorer.getAddressBalanceAtHeight", address, height)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getAddressBalanceAtHeight").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(int64(0)), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(int64)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getAddressBalanceAtHeight returned invalid type: %v", _t)
+			return int64(0), &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return int64(0), _err
+}
+
 func (_p ExplorerProxy) GetAddressDetails(address string) (AddressDetails, error) {
 	_res, _err := _p.client.Call("Explorer.getAddressDetails", address)
 	if _err == nil {
@@ -294,6 +469,42 @@ func (_p ExplorerProxy) GetAddressDetails(address string) (AddressDetails, error
 	return AddressDetails{}, _err
 }
 
+func (_p ExplorerProxy) GetAddressNonce(address string) (int64, error) {
+	_res, _err := _p.client.Call("Explorer.getAddressNonce", address)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getAddressNonce").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(int64(0)), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(int64)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getAddressNonce returned invalid type: %v", _t)
+			return int64(0), &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return int64(0), _err
+}
+
+func (_p ExplorerProxy) GetAddressState(address string) (AccountState, error) {
+	_res, _err := _p.client.Call("Explorer.getAddressState", address)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getAddressState").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(AccountState{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(AccountState)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getAddressState returned invalid type: %v", _t)
+			return AccountState{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return AccountState{}, _err
+}
+
 func (_p ExplorerProxy) GetLastTransfersByRange(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]Transfer, error) {
 	_res, _err := _p.client.Call("Explorer.getLastTransfersByRange", startBlockHeight, offset, limit, showCoinBase)
 	if _err == nil {
@@ -312,6 +523,24 @@ func (_p ExplorerProxy) GetLastTransfersByRange(startBlockHeight int64, offset i
 	return []Transfer{}, _err
 }
 
+func (_p ExplorerProxy) GetLastTransfersByRangeV2(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) (TransferPage, error) {
+	_res, _err := _p.client.Call("Explorer.getLastTransfersByRangeV2", startBlockHeight, offset, limit, showCoinBase)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getLastTransfersByRangeV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(TransferPage{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(TransferPage)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getLastTransfersByRangeV2 returned invalid type: %v", _t)
+			return TransferPage{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return TransferPage{}, _err
+}
+
 func (_p ExplorerProxy) GetTransferByID(transferID string) (Transfer, error) {
 	_res, _err := _p.client.Call("Explorer.getTransferByID", transferID)
 	if _err == nil {
@@ -330,6 +559,24 @@ func (_p ExplorerProxy) GetTransferByID(transferID string) (Transfer, error) {
 	return Transfer{}, _err
 }
 
+func (_p ExplorerProxy) GetTransferProof(transferID string) (MerkleProof, error) {
+	_res, _err := _p.client.Call("Explorer.getTransferProof", transferID)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getTransferProof").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(MerkleProof{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(MerkleProof)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getTransferProof returned invalid type: %v", _t)
+			return MerkleProof{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return MerkleProof{}, _err
+}
+
 func (_p ExplorerProxy) GetTransfersByAddress(address string, offset int64, limit int64) ([]Transfer, error) {
 	_res, _err := _p.client.Call("Explorer.getTransfersByAddress", address, offset, limit)
 	if _err == nil {
@@ -384,6 +631,24 @@ func (_p ExplorerProxy) GetTransfersByBlockID(blkID string, offset int64, limit
 	return []Transfer{}, _err
 }
 
+func (_p ExplorerProxy) GetTransfersByAmountRange(minAmount int64, maxAmount int64, offset int64, limit int64) ([]Transfer, error) {
+	_res, _err := _p.client.Call("Explorer.getTransfersByAmountRange", minAmount, maxAmount, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getTransfersByAmountRange").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]Transfer{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]Transfer)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getTransfersByAmountRange returned invalid type: %v", _t)
+			return []Transfer{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []Transfer{}, _err
+}
+
 func (_p ExplorerProxy) GetLastVotesByRange(startBlockHeight int64, offset int64, limit int64) ([]Vote, error) {
 	_res, _err := _p.client.Call("Explorer.getLastVotesByRange", startBlockHeight, offset, limit)
 	if _err == nil {
@@ -402,6 +667,24 @@ func (_p ExplorerProxy) GetLastVotesByRange(startBlockHeight int64, offset int64
 	return []Vote{}, _err
 }
 
+func (_p ExplorerProxy) GetLastVotesByRangeV2(startBlockHeight int64, offset int64, limit int64) (VotePage, error) {
+	_res, _err := _p.client.Call("Explorer.getLastVotesByRangeV2", startBlockHeight, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getLastVotesByRangeV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(VotePage{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(VotePage)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getLastVotesByRangeV2 returned invalid type: %v", _t)
+			return VotePage{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return VotePage{}, _err
+}
+
 func (_p ExplorerProxy) GetVoteByID(voteID string) (Vote, error) {
 	_res, _err := _p.client.Call("Explorer.getVoteByID", voteID)
 	if _err == nil {
@@ -438,6 +721,42 @@ func (_p ExplorerProxy) GetVotesByAddress(address string, offset int64, limit in
 	return []Vote{}, _err
 }
 
+func (_p ExplorerProxy) GetVotesFromAddress(address string, offset int64, limit int64) ([]Vote, error) {
+	_res, _err := _p.client.Call("Explorer.getVotesFromAddress", address, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getVotesFromAddress").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]Vote{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]Vote)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getVotesFromAddress returned invalid type: %v", _t)
+			return []Vote{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []Vote{}, _err
+}
+
+func (_p ExplorerProxy) GetVotesToAddress(address string, offset int64, limit int64) ([]Vote, error) {
+	_res, _err := _p.client.Call("Explorer.getVotesToAddress", address, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getVotesToAddress").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]Vote{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]Vote)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getVotesToAddress returned invalid type: %v", _t)
+			return []Vote{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []Vote{}, _err
+}
+
 func (_p ExplorerProxy) GetUnconfirmedVotesByAddress(address string, offset int64, limit int64) ([]Vote, error) {
 	_res, _err := _p.client.Call("Explorer.getUnconfirmedVotesByAddress", address, offset, limit)
 	if _err == nil {
@@ -492,6 +811,24 @@ func (_p ExplorerProxy) GetLastExecutionsByRange(startBlockHeight int64, offset
 	return []Execution{}, _err
 }
 
+func (_p ExplorerProxy) GetLastExecutionsByRangeV2(startBlockHeight int64, offset int64, limit int64) (ExecutionPage, error) {
+	_res, _err := _p.client.Call("Explorer.getLastExecutionsByRangeV2", startBlockHeight, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getLastExecutionsByRangeV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(ExecutionPage{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(ExecutionPage)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getLastExecutionsByRangeV2 returned invalid type: %v", _t)
+			return ExecutionPage{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return ExecutionPage{}, _err
+}
+
 func (_p ExplorerProxy) GetExecutionByID(executionID string) (Execution, error) {
 	_res, _err := _p.client.Call("Explorer.getExecutionByID", executionID)
 	if _err == nil {
@@ -546,6 +883,24 @@ func (_p ExplorerProxy) GetUnconfirmedExecutionsByAddress(address string, offset
 	return []Execution{}, _err
 }
 
+func (_p ExplorerProxy) GetPendingActionsByAddress(address string, offset int64, limit int64) (PendingActions, error) {
+	_res, _err := _p.client.Call("Explorer.getPendingActionsByAddress", address, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getPendingActionsByAddress").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(PendingActions{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(PendingActions)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getPendingActionsByAddress returned invalid type: %v", _t)
+			return PendingActions{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return PendingActions{}, _err
+}
+
 func (_p ExplorerProxy) GetExecutionsByBlockID(blkID string, offset int64, limit int64) ([]Execution, error) {
 	_res, _err := _p.client.Call("Explorer.getExecutionsByBlockID", blkID, offset, limit)
 	if _err == nil {
@@ -600,6 +955,24 @@ func (_p ExplorerProxy) GetBlockByID(blkID string) (Block, error) {
 	return Block{}, _err
 }
 
+func (_p ExplorerProxy) GetBlockByHeight(height int64) (Block, error) {
+	_res, _err := _p.client.Call("Explorer.getBlockByHeight", height)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getBlockByHeight").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(Block{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(Block)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getBlockByHeight returned invalid type: %v", _t)
+			return Block{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return Block{}, _err
+}
+
 func (_p ExplorerProxy) GetCoinStatistic() (CoinStatistic, error) {
 	_res, _err := _p.client.Call("Explorer.getCoinStatistic")
 	if _err == nil {
@@ -618,6 +991,24 @@ func (_p ExplorerProxy) GetCoinStatistic() (CoinStatistic, error) {
 	return CoinStatistic{}, _err
 }
 
+func (_p ExplorerProxy) GetFeeStatistic(blockCount int64) (FeeStatistic, error) {
+	_res, _err := _p.client.Call("Explorer.getFeeStatistic", blockCount)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getFeeStatistic").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(FeeStatistic{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(FeeStatistic)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getFeeStatistic returned invalid type: %v", _t)
+			return FeeStatistic{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return FeeStatistic{}, _err
+}
+
 func (_p ExplorerProxy) GetConsensusMetrics() (ConsensusMetrics, error) {
 	_res, _err := _p.client.Call("Explorer.getConsensusMetrics")
 	if _err == nil {
@@ -636,108 +1027,234 @@ func (_p ExplorerProxy) GetConsensusMetrics() (ConsensusMetrics, error) {
 	return ConsensusMetrics{}, _err
 }
 
-func (_p ExplorerProxy) GetCandidateMetrics() (CandidateMetrics, error) {
-	_res, _err := _p.client.Call("Explorer.getCandidateMetrics")
+func (_p ExplorerProxy) GetEpochMeta(epochNum int64) (EpochMeta, error) {
+	_res, _err := _p.client.Call("Explorer.getEpochMeta", epochNum)
 	if _err == nil {
-		_retType := _p.idl.Method("Explorer.getCandidateMetrics").Returns
-		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(CandidateMetrics{}), _res, "")
+		_retType := _p.idl.Method("Explorer.getEpochMeta").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(EpochMeta{}), _res, "")
 	}
 	if _err == nil {
-		_cast, _ok := _res.(CandidateMetrics)
+		_cast, _ok := _res.(EpochMeta)
 		if !_ok {
 			_t := reflect.TypeOf(_res)
-			_msg := fmt.Sprintf("Explorer.getCandidateMetrics returned invalid type: %v", _t)
-			return CandidateMetrics{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+			_msg := fmt.Sprintf("Explorer.getEpochMeta returned invalid type: %v", _t)
+			return EpochMeta{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
 		}
 		return _cast, nil
 	}
-	return CandidateMetrics{}, _err
+	return EpochMeta{}, _err
 }
 
-func (_p ExplorerProxy) GetCandidateMetricsByHeight(h int64) (CandidateMetrics, error) {
-	_res, _err := _p.client.Call("Explorer.getCandidateMetricsByHeight", h)
+func (_p ExplorerProxy) GetProducerStats(address string) (ProducerStats, error) {
+	_res, _err := _p.client.Call("Explorer.getProducerStats", address)
 	if _err == nil {
-		_retType := _p.idl.Method("Explorer.getCandidateMetricsByHeight").Returns
-		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(CandidateMetrics{}), _res, "")
+		_retType := _p.idl.Method("Explorer.getProducerStats").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(ProducerStats{}), _res, "")
 	}
 	if _err == nil {
-		_cast, _ok := _res.(CandidateMetrics)
+		_cast, _ok := _res.(ProducerStats)
 		if !_ok {
 			_t := reflect.TypeOf(_res)
-			_msg := fmt.Sprintf("Explorer.getCandidateMetricsByHeight returned invalid type: %v", _t)
-			return CandidateMetrics{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+			_msg := fmt.Sprintf("Explorer.getProducerStats returned invalid type: %v", _t)
+			return ProducerStats{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
 		}
 		return _cast, nil
 	}
-	return CandidateMetrics{}, _err
+	return ProducerStats{}, _err
 }
 
-func (_p ExplorerProxy) SendTransfer(request SendTransferRequest) (SendTransferResponse, error) {
-	_res, _err := _p.client.Call("Explorer.sendTransfer", request)
+func (_p ExplorerProxy) GetGenesisHash() (string, error) {
+	_res, _err := _p.client.Call("Explorer.getGenesisHash")
 	if _err == nil {
-		_retType := _p.idl.Method("Explorer.sendTransfer").Returns
-		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(SendTransferResponse{}), _res, "")
+		_retType := _p.idl.Method("Explorer.getGenesisHash").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(""), _res, "")
 	}
 	if _err == nil {
-		_cast, _ok := _res.(SendTransferResponse)
+		_cast, _ok := _res.(string)
 		if !_ok {
 			_t := reflect.TypeOf(_res)
-			_msg := fmt.Sprintf("Explorer.sendTransfer returned invalid type: %v", _t)
-			return SendTransferResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+			_msg := fmt.Sprintf("Explorer.getGenesisHash returned invalid type: %v", _t)
+			return "", &barrister.JsonRpcError{Code: -32000, Message: _msg}
 		}
 		return _cast, nil
 	}
-	return SendTransferResponse{}, _err
+	return "", _err
 }
 
-func (_p ExplorerProxy) SendVote(request SendVoteRequest) (SendVoteResponse, error) {
-	_res, _err := _p.client.Call("Explorer.sendVote", request)
+func (_p ExplorerProxy) GetExplorerStatus() (ExplorerStatus, error) {
+	_res, _err := _p.client.Call("Explorer.getExplorerStatus")
 	if _err == nil {
-		_retType := _p.idl.Method("Explorer.sendVote").Returns
-		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(SendVoteResponse{}), _res, "")
+		_retType := _p.idl.Method("Explorer.getExplorerStatus").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(ExplorerStatus{}), _res, "")
 	}
 	if _err == nil {
-		_cast, _ok := _res.(SendVoteResponse)
+		_cast, _ok := _res.(ExplorerStatus)
 		if !_ok {
 			_t := reflect.TypeOf(_res)
-			_msg := fmt.Sprintf("Explorer.sendVote returned invalid type: %v", _t)
-			return SendVoteResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+			_msg := fmt.Sprintf("Explorer.getExplorerStatus returned invalid type: %v", _t)
+			return ExplorerStatus{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
 		}
 		return _cast, nil
 	}
-	return SendVoteResponse{}, _err
+	return ExplorerStatus{}, _err
 }
 
-func (_p ExplorerProxy) SendSmartContract(request Execution) (SendSmartContractResponse, error) {
-	_res, _err := _p.client.Call("Explorer.sendSmartContract", request)
+func (_p ExplorerProxy) GetServerConfig() (string, error) {
+	_res, _err := _p.client.Call("Explorer.getServerConfig")
 	if _err == nil {
-		_retType := _p.idl.Method("Explorer.sendSmartContract").Returns
-		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(SendSmartContractResponse{}), _res, "")
+		_retType := _p.idl.Method("Explorer.getServerConfig").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(""), _res, "")
 	}
 	if _err == nil {
-		_cast, _ok := _res.(SendSmartContractResponse)
+		_cast, _ok := _res.(string)
 		if !_ok {
 			_t := reflect.TypeOf(_res)
-			_msg := fmt.Sprintf("Explorer.sendSmartContract returned invalid type: %v", _t)
-			return SendSmartContractResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+			_msg := fmt.Sprintf("Explorer.getServerConfig returned invalid type: %v", _t)
+			return "", &barrister.JsonRpcError{Code: -32000, Message: _msg}
 		}
 		return _cast, nil
 	}
-	return SendSmartContractResponse{}, _err
+	return "", _err
 }
 
-func (_p ExplorerProxy) GetPeers() (GetPeersResponse, error) {
-	_res, _err := _p.client.Call("Explorer.getPeers")
+func (_p ExplorerProxy) GetCandidateMetrics() (CandidateMetrics, error) {
+	_res, _err := _p.client.Call("Explorer.getCandidateMetrics")
 	if _err == nil {
-		_retType := _p.idl.Method("Explorer.getPeers").Returns
-		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(GetPeersResponse{}), _res, "")
+		_retType := _p.idl.Method("Explorer.getCandidateMetrics").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(CandidateMetrics{}), _res, "")
 	}
 	if _err == nil {
-		_cast, _ok := _res.(GetPeersResponse)
+		_cast, _ok := _res.(CandidateMetrics)
 		if !_ok {
 			_t := reflect.TypeOf(_res)
-			_msg := fmt.Sprintf("Explorer.getPeers returned invalid type: %v", _t)
-			return GetPeersResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+			_msg := fmt.Sprintf("Explorer.getCandidateMetrics returned invalid type: %v", _t)
+			return CandidateMetrics{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return CandidateMetrics{}, _err
+}
+
+func (_p ExplorerProxy) GetCandidateMetricsByHeight(h int64) (CandidateMetrics, error) {
+	_res, _err := _p.client.Call("Explorer.getCandidateMetricsByHeight", h)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getCandidateMetricsByHeight").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(CandidateMetrics{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(CandidateMetrics)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getCandidateMetricsByHeight returned invalid type: %v", _t)
+			return CandidateMetrics{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return CandidateMetrics{}, _err
+}
+
+func (_p ExplorerProxy) GetCandidates(height int64) ([]Candidate, error) {
+	_res, _err := _p.client.Call("Explorer.getCandidates", height)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getCandidates").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]Candidate{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]Candidate)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getCandidates returned invalid type: %v", _t)
+			return []Candidate{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []Candidate{}, _err
+}
+
+func (_p ExplorerProxy) SendTransfer(request SendTransferRequest) (SendTransferResponse, error) {
+	_res, _err := _p.client.Call("Explorer.sendTransfer", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.sendTransfer").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(SendTransferResponse{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(SendTransferResponse)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.sendTransfer returned invalid type: %v", _t)
+			return SendTransferResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return SendTransferResponse{}, _err
+}
+
+func (_p ExplorerProxy) SendVote(request SendVoteRequest) (SendVoteResponse, error) {
+	_res, _err := _p.client.Call("Explorer.sendVote", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.sendVote").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(SendVoteResponse{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(SendVoteResponse)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.sendVote returned invalid type: %v", _t)
+			return SendVoteResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return SendVoteResponse{}, _err
+}
+
+func (_p ExplorerProxy) SendSmartContract(request Execution) (SendSmartContractResponse, error) {
+	_res, _err := _p.client.Call("Explorer.sendSmartContract", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.sendSmartContract").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(SendSmartContractResponse{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(SendSmartContractResponse)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.sendSmartContract returned invalid type: %v", _t)
+			return SendSmartContractResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return SendSmartContractResponse{}, _err
+}
+
+func (_p ExplorerProxy) SendAction(rawAction string) (string, error) {
+	_res, _err := _p.client.Call("Explorer.sendAction", rawAction)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.sendAction").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(""), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(string)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.sendAction returned invalid type: %v", _t)
+			return "", &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return "", _err
+}
+
+func (_p ExplorerProxy) GetPeers() (GetPeersResponse, error) {
+	_res, _err := _p.client.Call("Explorer.getPeers")
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getPeers").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(GetPeersResponse{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(GetPeersResponse)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getPeers returned invalid type: %v", _t)
+			return GetPeersResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
 		}
 		return _cast, nil
 	}
@@ -762,6 +1279,42 @@ func (_p ExplorerProxy) GetReceiptByExecutionID(id string) (Receipt, error) {
 	return Receipt{}, _err
 }
 
+func (_p ExplorerProxy) GetReceiptByActionID(id string) (Receipt, error) {
+	_res, _err := _p.client.Call("Explorer.getReceiptByActionID", id)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getReceiptByActionID").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(Receipt{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(Receipt)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getReceiptByActionID returned invalid type: %v", _t)
+			return Receipt{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return Receipt{}, _err
+}
+
+func (_p ExplorerProxy) GetLogsByBlockRange(fromHeight int64, toHeight int64, contract string, topics []string) ([]Log, error) {
+	_res, _err := _p.client.Call("Explorer.getLogsByBlockRange", fromHeight, toHeight, contract, topics)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getLogsByBlockRange").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]Log{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]Log)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getLogsByBlockRange returned invalid type: %v", _t)
+			return []Log{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []Log{}, _err
+}
+
 func (_p ExplorerProxy) ReadExecutionState(request Execution) (string, error) {
 	_res, _err := _p.client.Call("Explorer.readExecutionState", request)
 	if _err == nil {
@@ -780,6 +1333,96 @@ func (_p ExplorerProxy) ReadExecutionState(request Execution) (string, error) {
 	return "", _err
 }
 
+func (_p ExplorerProxy) EstimateGas(request ExecutionRequest) (GasEstimate, error) {
+	_res, _err := _p.client.Call("Explorer.estimateGas", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.estimateGas").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(GasEstimate{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(GasEstimate)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.estimateGas returned invalid type: %v", _t)
+			return GasEstimate{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return GasEstimate{}, _err
+}
+
+func (_p ExplorerProxy) CallContract(request ExecutionRequest) (CallResult, error) {
+	_res, _err := _p.client.Call("Explorer.callContract", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.callContract").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(CallResult{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(CallResult)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.callContract returned invalid type: %v", _t)
+			return CallResult{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return CallResult{}, _err
+}
+
+func (_p ExplorerProxy) EstimateGasPending(request ExecutionRequest) (GasEstimate, error) {
+	_res, _err := _p.client.Call("Explorer.estimateGasPending", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.estimateGasPending").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(GasEstimate{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(GasEstimate)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.estimateGasPending returned invalid type: %v", _t)
+			return GasEstimate{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return GasEstimate{}, _err
+}
+
+func (_p ExplorerProxy) CallContractPending(request ExecutionRequest) (CallResult, error) {
+	_res, _err := _p.client.Call("Explorer.callContractPending", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.callContractPending").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(CallResult{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(CallResult)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.callContractPending returned invalid type: %v", _t)
+			return CallResult{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return CallResult{}, _err
+}
+
+func (_p ExplorerProxy) Multicall(requests []Request) ([]Response, error) {
+	_res, _err := _p.client.Call("Explorer.multicall", requests)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.multicall").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]Response{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]Response)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.multicall returned invalid type: %v", _t)
+			return []Response{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []Response{}, _err
+}
+
 func (_p ExplorerProxy) GetBlockOrActionByHash(hashStr string) (GetBlkOrActResponse, error) {
 	_res, _err := _p.client.Call("Explorer.getBlockOrActionByHash", hashStr)
 	if _err == nil {
@@ -798,6 +1441,24 @@ func (_p ExplorerProxy) GetBlockOrActionByHash(hashStr string) (GetBlkOrActRespo
 	return GetBlkOrActResponse{}, _err
 }
 
+func (_p ExplorerProxy) GetActionByID(actionID string) (Action, error) {
+	_res, _err := _p.client.Call("Explorer.getActionByID", actionID)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getActionByID").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(Action{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(Action)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getActionByID returned invalid type: %v", _t)
+			return Action{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return Action{}, _err
+}
+
 func NewJSONServer(idl *barrister.Idl, forceASCII bool, explorer Explorer) barrister.Server {
 	return NewServer(idl, &barrister.JsonSerializer{forceASCII}, explorer)
 }
@@ -882,6 +1543,34 @@ var IdlJsonRaw = `[
                 "is_array": false,
                 "comment": ""
             },
+            {
+                "name": "totalSupply",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "circulatingSupply",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockReward",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "accumulatedReward",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
             {
                 "name": "transfers",
                 "type": "int",
@@ -919,21 +1608,35 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "BlockGenerator",
+        "name": "FeeStatistic",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "name",
-                "type": "string",
+                "name": "blockCount",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "address",
-                "type": "string",
+                "name": "averageFee",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "medianFee",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "p95Fee",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -947,29 +1650,57 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "Block",
+        "name": "BlockGenerator",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "ID",
+                "name": "name",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "height",
-                "type": "int",
+                "name": "address",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
-            },
-            {
-                "name": "timestamp",
-                "type": "int",
-                "optional": false,
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Block",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "ID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "height",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "timestamp",
+                "type": "int",
+                "optional": false,
                 "is_array": false,
                 "comment": ""
             },
@@ -1021,6 +1752,111 @@ var IdlJsonRaw = `[
                 "optional": false,
                 "is_array": false,
                 "comment": ""
+            },
+            {
+                "name": "finalized",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "confirmationCount",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "producerPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": "hex encoded public key of the block producer"
+            },
+            {
+                "name": "signature",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": "hex encoded signature of the block hash by the block producer"
+            },
+            {
+                "name": "rewardAddress",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": "address credited with the block reward, i.e., the recipient of the coinbase transfer, which could be other than\nthe block producer's"
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "MerkleProof",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "transferID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockHeader",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": "hex encoded block header, whose hash is the block ID"
+            },
+            {
+                "name": "txRoot",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": "hex encoded merkle root of the actions in the block"
+            },
+            {
+                "name": "stateRoot",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": "hex encoded root of the state trie after the block"
+            },
+            {
+                "name": "index",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": "index of the transfer among the leaves of the merkle tree"
+            },
+            {
+                "name": "branch",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": "hex encoded sibling hashes from the transfer up to the merkle root"
             }
         ],
         "values": null,
@@ -1507,41 +2343,27 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "AddressDetails",
+        "name": "TransferPage",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "address",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "totalBalance",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "nonce",
-                "type": "int",
+                "name": "transfers",
+                "type": "Transfer",
                 "optional": false,
-                "is_array": false,
+                "is_array": true,
                 "comment": ""
             },
             {
-                "name": "pendingNonce",
+                "name": "totalCount",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "isCandidate",
+                "name": "hasMore",
                 "type": "bool",
                 "optional": false,
                 "is_array": false,
@@ -1556,55 +2378,62 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "Candidate",
+        "name": "VotePage",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "address",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "pubKey",
-                "type": "string",
+                "name": "votes",
+                "type": "Vote",
                 "optional": false,
-                "is_array": false,
+                "is_array": true,
                 "comment": ""
             },
             {
-                "name": "totalVote",
+                "name": "totalCount",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "creationHeight",
-                "type": "int",
+                "name": "hasMore",
+                "type": "bool",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
-            },
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "ExecutionPage",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
             {
-                "name": "lastUpdateHeight",
-                "type": "int",
+                "name": "executions",
+                "type": "Execution",
                 "optional": false,
-                "is_array": false,
+                "is_array": true,
                 "comment": ""
             },
             {
-                "name": "isDelegate",
-                "type": "bool",
+                "name": "totalCount",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "isProducer",
+                "name": "hasMore",
                 "type": "bool",
                 "optional": false,
                 "is_array": false,
@@ -1619,30 +2448,30 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "CandidateMetrics",
+        "name": "PendingActions",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "candidates",
-                "type": "Candidate",
+                "name": "transfers",
+                "type": "Transfer",
                 "optional": false,
                 "is_array": true,
                 "comment": ""
             },
             {
-                "name": "latestEpoch",
-                "type": "int",
+                "name": "votes",
+                "type": "Vote",
                 "optional": false,
-                "is_array": false,
+                "is_array": true,
                 "comment": ""
             },
             {
-                "name": "latestHeight",
-                "type": "int",
+                "name": "executions",
+                "type": "Execution",
                 "optional": false,
-                "is_array": false,
+                "is_array": true,
                 "comment": ""
             }
         ],
@@ -1654,37 +2483,44 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "ConsensusMetrics",
+        "name": "AddressDetails",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "latestEpoch",
+                "name": "address",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "totalBalance",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "latestDelegates",
-                "type": "string",
+                "name": "nonce",
+                "type": "int",
                 "optional": false,
-                "is_array": true,
+                "is_array": false,
                 "comment": ""
             },
             {
-                "name": "latestBlockProducer",
-                "type": "string",
+                "name": "pendingNonce",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "candidates",
-                "type": "string",
+                "name": "isCandidate",
+                "type": "bool",
                 "optional": false,
-                "is_array": true,
+                "is_array": false,
                 "comment": ""
             }
         ],
@@ -1696,14 +2532,14 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "SendTransferRequest",
+        "name": "AccountState",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "version",
-                "type": "int",
+                "name": "address",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -1716,63 +2552,91 @@ var IdlJsonRaw = `[
                 "comment": ""
             },
             {
-                "name": "sender",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "recipient",
-                "type": "string",
+                "name": "balance",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "amount",
-                "type": "int",
+                "name": "isCandidate",
+                "type": "bool",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "senderPubKey",
+                "name": "votee",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "signature",
-                "type": "string",
+                "name": "votingWeight",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
-            },
-            {
-                "name": "payload",
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Candidate",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "address",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "gasLimit",
+                "name": "pubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "totalVote",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "gasPrice",
+                "name": "creationHeight",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "isCoinbase",
+                "name": "lastUpdateHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isDelegate",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isProducer",
                 "type": "bool",
                 "optional": false,
                 "is_array": false,
@@ -1787,14 +2651,28 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "SendTransferResponse",
+        "name": "CandidateMetrics",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "hash",
-                "type": "string",
+                "name": "candidates",
+                "type": "Candidate",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "latestEpoch",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "latestHeight",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -1808,66 +2686,87 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "SendVoteRequest",
+        "name": "ConsensusMetrics",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "version",
+                "name": "latestEpoch",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "nonce",
-                "type": "int",
+                "name": "latestDelegates",
+                "type": "string",
                 "optional": false,
-                "is_array": false,
+                "is_array": true,
                 "comment": ""
             },
             {
-                "name": "voter",
+                "name": "latestBlockProducer",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "votee",
+                "name": "candidates",
                 "type": "string",
                 "optional": false,
-                "is_array": false,
+                "is_array": true,
                 "comment": ""
             },
             {
-                "name": "voterPubKey",
-                "type": "string",
+                "name": "round",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "gasLimit",
-                "type": "int",
+                "name": "phase",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "gasPrice",
+                "name": "roundStartTime",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
-            },
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "EpochDelegate",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
             {
-                "name": "signature",
+                "name": "address",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
+            },
+            {
+                "name": "producedBlockCount",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": "number of blocks produced by the delegate in the epoch up to the tip of the chain"
             }
         ],
         "values": null,
@@ -1878,17 +2777,38 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "SendVoteResponse",
+        "name": "EpochMeta",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "hash",
-                "type": "string",
+                "name": "epochNum",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "startHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "endHeight",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
+            },
+            {
+                "name": "delegates",
+                "type": "EpochDelegate",
+                "optional": false,
+                "is_array": true,
+                "comment": "delegates in the order of producing blocks"
             }
         ],
         "values": null,
@@ -1899,7 +2819,7 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "Node",
+        "name": "ProducerStats",
         "comment": "",
         "value": "",
         "extends": "",
@@ -1910,6 +2830,34 @@ var IdlJsonRaw = `[
                 "optional": false,
                 "is_array": false,
                 "comment": ""
+            },
+            {
+                "name": "epochNum",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "expectedBlocks",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": "number of blocks the producer is scheduled to produce in the current epoch up to the tip of the chain"
+            },
+            {
+                "name": "producedBlocks",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "missedBlocks",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
             }
         ],
         "values": null,
@@ -1920,23 +2868,86 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "GetPeersResponse",
+        "name": "SendTransferRequest",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "Self",
-                "type": "Node",
+                "name": "version",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "Peers",
-                "type": "Node",
+                "name": "nonce",
+                "type": "int",
                 "optional": false,
-                "is_array": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "sender",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "recipient",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "amount",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "senderPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "signature",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "payload",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasLimit",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasPrice",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isCoinbase",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
                 "comment": ""
             }
         ],
@@ -1948,7 +2959,7 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "SendSmartContractResponse",
+        "name": "SendTransferResponse",
         "comment": "",
         "value": "",
         "extends": "",
@@ -1969,36 +2980,64 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "GetBlkOrActResponse",
+        "name": "SendVoteRequest",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "block",
-                "type": "Block",
-                "optional": true,
+                "name": "version",
+                "type": "int",
+                "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "transfer",
-                "type": "Transfer",
-                "optional": true,
+                "name": "nonce",
+                "type": "int",
+                "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "vote",
-                "type": "Vote",
-                "optional": true,
+                "name": "voter",
+                "type": "string",
+                "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "execution",
-                "type": "Execution",
-                "optional": true,
+                "name": "votee",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "voterPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasLimit",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasPrice",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "signature",
+                "type": "string",
+                "optional": false,
                 "is_array": false,
                 "comment": ""
             }
@@ -2010,33 +3049,1049 @@ var IdlJsonRaw = `[
         "checksum": ""
     },
     {
-        "type": "interface",
-        "name": "Explorer",
+        "type": "struct",
+        "name": "SendVoteResponse",
         "comment": "",
         "value": "",
         "extends": "",
-        "fields": null,
+        "fields": [
+            {
+                "name": "hash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
         "values": null,
-        "functions": [
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Node",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
             {
-                "name": "getBlockchainHeight",
-                "comment": "get the blockchain tip height",
-                "params": [],
-                "returns": {
-                    "name": "",
-                    "type": "int",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
+                "name": "address",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
             },
             {
-                "name": "getAddressBalance",
-                "comment": "get the balance of an address",
-                "params": [
+                "name": "direction",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "lastSeen",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "height",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "GetPeersResponse",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "Self",
+                "type": "Node",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "Peers",
+                "type": "Node",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "SendSmartContractResponse",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "hash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "GetBlkOrActResponse",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "block",
+                "type": "Block",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "transfer",
+                "type": "Transfer",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "vote",
+                "type": "Vote",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "execution",
+                "type": "Execution",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Action",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "type",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "transfer",
+                "type": "Transfer",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "vote",
+                "type": "Vote",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "execution",
+                "type": "Execution",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "ExecutionRequest",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "executor",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "contract",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "amount",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasLimit",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasPrice",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "data",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "GasEstimate",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "gas",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "CallResult",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "returnValue",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "status",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasConsumed",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Request",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "method",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "params",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "ExplorerStatus",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "degraded",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": "whether the backend is failing, when the read methods are served with the last known results if any"
+            },
+            {
+                "name": "consecutiveFailures",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": "number of the consecutive failures of the backend"
+            },
+            {
+                "name": "maintenance",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": "whether the explorer is under maintenance, when the other methods reply with an error"
+            },
+            {
+                "name": "maintenanceMessage",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": "message about the maintenance, e.g., when it's expected to end"
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Response",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "result",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "error",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "interface",
+        "name": "Explorer",
+        "comment": "The addresses taken by the methods are Bech32 encoded, prefixed by \"io\" on mainnet and \"it\" on testnet, in either\nlowercase or uppercase. An address in any other format is rejected with an \"invalid address\" error.",
+        "value": "",
+        "extends": "",
+        "fields": null,
+        "values": null,
+        "functions": [
+            {
+                "name": "getBlockchainHeight",
+                "comment": "get the blockchain tip height",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "int",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressBalance",
+                "comment": "get the balance of an address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "int",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressBalanceAtHeight",
+                "comment": "get the balance of an address as of the block of the given height",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "height",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "int",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressDetails",
+                "comment": "get the address detail of an iotex address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "AddressDetails",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressNonce",
+                "comment": "get the next nonce to use for an iotex address, taking the pending actions in actpool into account",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "int",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressState",
+                "comment": "get the account state of an iotex address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "AccountState",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getLastTransfersByRange",
+                "comment": "get list of transfers by start block height, transfer offset and limit",
+                "params": [
+                    {
+                        "name": "startBlockHeight",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "showCoinBase",
+                        "type": "bool",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getLastTransfersByRangeV2",
+                "comment": "get a page of transfers by start block height, transfer offset and limit, together with the total count",
+                "params": [
+                    {
+                        "name": "startBlockHeight",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "showCoinBase",
+                        "type": "bool",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "TransferPage",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransferByID",
+                "comment": "get transfers from transaction id",
+                "params": [
+                    {
+                        "name": "transferID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransferProof",
+                "comment": "get the merkle proof that a transfer is included in a block",
+                "params": [
+                    {
+                        "name": "transferID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "MerkleProof",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransfersByAddress",
+                "comment": "get list of transfers belonging to an address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getUnconfirmedTransfersByAddress",
+                "comment": "get list of unconfirmed transfers in actpool belonging to an address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransfersByBlockID",
+                "comment": "get all transfers in a block",
+                "params": [
+                    {
+                        "name": "blkID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransfersByAmountRange",
+                "comment": "get list of transfers whose amount is within [minAmount, maxAmount]",
+                "params": [
+                    {
+                        "name": "minAmount",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "maxAmount",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getLastVotesByRange",
+                "comment": "get list of votes by start block height, vote offset and limit",
+                "params": [
+                    {
+                        "name": "startBlockHeight",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getLastVotesByRangeV2",
+                "comment": "get a page of votes by start block height, vote offset and limit, together with the total count",
+                "params": [
+                    {
+                        "name": "startBlockHeight",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "VotePage",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getVoteByID",
+                "comment": "get vote from vote id",
+                "params": [
+                    {
+                        "name": "voteID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getVotesByAddress",
+                "comment": "get list of votes belonging to an address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getVotesFromAddress",
+                "comment": "get list of votes cast by an address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getVotesToAddress",
+                "comment": "get list of votes received by an address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getUnconfirmedVotesByAddress",
+                "comment": "get list of unconfirmed votes in actpool belonging to an address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getVotesByBlockID",
+                "comment": "get all votes in a block",
+                "params": [
+                    {
+                        "name": "blkID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
                     {
-                        "name": "address",
-                        "type": "string",
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2044,19 +4099,33 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "int",
+                    "type": "Vote",
                     "optional": false,
-                    "is_array": false,
+                    "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getAddressDetails",
-                "comment": "get the address detail of an iotex address",
+                "name": "getLastExecutionsByRange",
+                "comment": "get list of executions by start block height, execution offset and limit",
                 "params": [
                     {
-                        "name": "address",
-                        "type": "string",
+                        "name": "startBlockHeight",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2064,15 +4133,15 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "AddressDetails",
+                    "type": "Execution",
                     "optional": false,
-                    "is_array": false,
+                    "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getLastTransfersByRange",
-                "comment": "get list of transfers by start block height, transfer offset and limit",
+                "name": "getLastExecutionsByRangeV2",
+                "comment": "get a page of executions by start block height, execution offset and limit, together with the total count",
                 "params": [
                     {
                         "name": "startBlockHeight",
@@ -2094,29 +4163,22 @@ var IdlJsonRaw = `[
                         "optional": false,
                         "is_array": false,
                         "comment": ""
-                    },
-                    {
-                        "name": "showCoinBase",
-                        "type": "bool",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
                     }
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Transfer",
+                    "type": "ExecutionPage",
                     "optional": false,
-                    "is_array": true,
+                    "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getTransferByID",
-                "comment": "get transfers from transaction id",
+                "name": "getExecutionByID",
+                "comment": "get execution from execution id",
                 "params": [
                     {
-                        "name": "transferID",
+                        "name": "executionID",
                         "type": "string",
                         "optional": false,
                         "is_array": false,
@@ -2125,15 +4187,15 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Transfer",
+                    "type": "Execution",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getTransfersByAddress",
-                "comment": "get list of transfers belonging to an address",
+                "name": "getExecutionsByAddress",
+                "comment": "get list of executions belonging to an address",
                 "params": [
                     {
                         "name": "address",
@@ -2159,15 +4221,15 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Transfer",
+                    "type": "Execution",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getUnconfirmedTransfersByAddress",
-                "comment": "get list of unconfirmed transfers in actpool belonging to an address",
+                "name": "getUnconfirmedExecutionsByAddress",
+                "comment": "get list of unconfirmed executions in actpool belonging to an address",
                 "params": [
                     {
                         "name": "address",
@@ -2193,18 +4255,18 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Transfer",
+                    "type": "Execution",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getTransfersByBlockID",
-                "comment": "get all transfers in a block",
+                "name": "getPendingActionsByAddress",
+                "comment": "get list of unconfirmed actions of all types in actpool belonging to an address",
                 "params": [
                     {
-                        "name": "blkID",
+                        "name": "address",
                         "type": "string",
                         "optional": false,
                         "is_array": false,
@@ -2227,23 +4289,50 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Transfer",
+                    "type": "PendingActions",
                     "optional": false,
-                    "is_array": true,
+                    "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getLastVotesByRange",
-                "comment": "get list of votes by start block height, vote offset and limit",
+                "name": "getExecutionsByBlockID",
+                "comment": "get all executions in a block",
                 "params": [
                     {
-                        "name": "startBlockHeight",
+                        "name": "blkID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
                         "type": "int",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
                     },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Execution",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getLastBlocksByRange",
+                "comment": "get list of blocks by block id offset and limit",
+                "params": [
                     {
                         "name": "offset",
                         "type": "int",
@@ -2261,18 +4350,18 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Vote",
+                    "type": "Block",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getVoteByID",
-                "comment": "get vote from vote id",
+                "name": "getBlockByID",
+                "comment": "get block by block id",
                 "params": [
                     {
-                        "name": "voteID",
+                        "name": "blkID",
                         "type": "string",
                         "optional": false,
                         "is_array": false,
@@ -2281,32 +4370,82 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Vote",
+                    "type": "Block",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getVotesByAddress",
-                "comment": "get list of votes belonging to an address",
+                "name": "getBlockByHeight",
+                "comment": "get block by height",
                 "params": [
                     {
-                        "name": "address",
-                        "type": "string",
+                        "name": "height",
+                        "type": "int",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
-                    },
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Block",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getCoinStatistic",
+                "comment": "get statistic of iotx",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "CoinStatistic",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getFeeStatistic",
+                "comment": "get average, median and 95th percentile fee of the actions in the last blockCount blocks",
+                "params": [
                     {
-                        "name": "offset",
+                        "name": "blockCount",
                         "type": "int",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
-                    },
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "FeeStatistic",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getConsensusMetrics",
+                "comment": "get consensus metrics",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "ConsensusMetrics",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getEpochMeta",
+                "comment": "get the heights and the delegate schedule of an epoch",
+                "params": [
                     {
-                        "name": "limit",
+                        "name": "epochNum",
                         "type": "int",
                         "optional": false,
                         "is_array": false,
@@ -2315,15 +4454,15 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Vote",
+                    "type": "EpochMeta",
                     "optional": false,
-                    "is_array": true,
+                    "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getUnconfirmedVotesByAddress",
-                "comment": "get list of unconfirmed votes in actpool belonging to an address",
+                "name": "getProducerStats",
+                "comment": "get the numbers of blocks expected, produced and missed by a block producer in the current epoch",
                 "params": [
                     {
                         "name": "address",
@@ -2331,16 +4470,90 @@ var IdlJsonRaw = `[
                         "optional": false,
                         "is_array": false,
                         "comment": ""
-                    },
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "ProducerStats",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getGenesisHash",
+                "comment": "get the hash of the genesis block and the consensus config, which is the same for all the nodes of a chain",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "string",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getExplorerStatus",
+                "comment": "get the status of the explorer, which is degraded while its backend is failing, and is the only method served\nwhile the explorer is under maintenance",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "ExplorerStatus",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getServerConfig",
+                "comment": "get the effective config of the server in JSON with the secrets redacted, which requires an API key",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "string",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getCandidateMetrics",
+                "comment": "get candidates metrics",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "CandidateMetrics",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getCandidateMetricsByHeight",
+                "comment": "get candidates metrics at given height",
+                "params": [
                     {
-                        "name": "offset",
+                        "name": "h",
                         "type": "int",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
-                    },
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "CandidateMetrics",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getCandidates",
+                "comment": "get candidates at given height",
+                "params": [
                     {
-                        "name": "limit",
+                        "name": "height",
                         "type": "int",
                         "optional": false,
                         "is_array": false,
@@ -2349,33 +4562,19 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Vote",
+                    "type": "Candidate",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getVotesByBlockID",
-                "comment": "get all votes in a block",
+                "name": "sendTransfer",
+                "comment": "send transfer",
                 "params": [
                     {
-                        "name": "blkID",
-                        "type": "string",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "offset",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "limit",
-                        "type": "int",
+                        "name": "request",
+                        "type": "SendTransferRequest",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2383,33 +4582,19 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Vote",
+                    "type": "SendTransferResponse",
                     "optional": false,
-                    "is_array": true,
+                    "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getLastExecutionsByRange",
-                "comment": "get list of executions by start block height, execution offset and limit",
+                "name": "sendVote",
+                "comment": "send vote",
                 "params": [
                     {
-                        "name": "startBlockHeight",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "offset",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "limit",
-                        "type": "int",
+                        "name": "request",
+                        "type": "SendVoteRequest",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2417,19 +4602,19 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Execution",
+                    "type": "SendVoteResponse",
                     "optional": false,
-                    "is_array": true,
+                    "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getExecutionByID",
-                "comment": "get execution from execution id",
+                "name": "sendSmartContract",
+                "comment": "sendSmartContract",
                 "params": [
                     {
-                        "name": "executionID",
-                        "type": "string",
+                        "name": "request",
+                        "type": "Execution",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2437,148 +4622,132 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Execution",
+                    "type": "SendSmartContractResponse",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getExecutionsByAddress",
-                "comment": "get list of executions belonging to an address",
+                "name": "sendAction",
+                "comment": "send a signed action, which is the hex string of a serialized ActionPb",
                 "params": [
                     {
-                        "name": "address",
+                        "name": "rawAction",
                         "type": "string",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
-                    },
-                    {
-                        "name": "offset",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "limit",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
                     }
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Execution",
+                    "type": "string",
                     "optional": false,
-                    "is_array": true,
+                    "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getUnconfirmedExecutionsByAddress",
-                "comment": "get list of unconfirmed executions in actpool belonging to an address",
+                "name": "getPeers",
+                "comment": "get list of peers",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "GetPeersResponse",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getReceiptByExecutionID",
+                "comment": "get receipt by execution id",
                 "params": [
                     {
-                        "name": "address",
+                        "name": "id",
                         "type": "string",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
-                    },
-                    {
-                        "name": "offset",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "limit",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
                     }
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Execution",
+                    "type": "Receipt",
                     "optional": false,
-                    "is_array": true,
+                    "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getExecutionsByBlockID",
-                "comment": "get all executions in a block",
+                "name": "getReceiptByActionID",
+                "comment": "get receipt by action id",
                 "params": [
                     {
-                        "name": "blkID",
+                        "name": "id",
                         "type": "string",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
-                    },
-                    {
-                        "name": "offset",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "limit",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
                     }
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Execution",
+                    "type": "Receipt",
                     "optional": false,
-                    "is_array": true,
+                    "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getLastBlocksByRange",
-                "comment": "get list of blocks by block id offset and limit",
+                "name": "getLogsByBlockRange",
+                "comment": "get the logs emitted in the blocks of heights [fromHeight, toHeight] by the contract, or any contract if it is\nempty, whose topics match the given ones by position, where an empty topic matches any topic",
                 "params": [
                     {
-                        "name": "offset",
+                        "name": "fromHeight",
                         "type": "int",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
                     },
                     {
-                        "name": "limit",
+                        "name": "toHeight",
                         "type": "int",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
+                    },
+                    {
+                        "name": "contract",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "topics",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": true,
+                        "comment": ""
                     }
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Block",
+                    "type": "Log",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getBlockByID",
-                "comment": "get block by block id",
+                "name": "readExecutionState",
+                "comment": "read execution state",
                 "params": [
                     {
-                        "name": "blkID",
-                        "type": "string",
+                        "name": "request",
+                        "type": "Execution",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2586,55 +4755,19 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Block",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
-            },
-            {
-                "name": "getCoinStatistic",
-                "comment": "get statistic of iotx",
-                "params": [],
-                "returns": {
-                    "name": "",
-                    "type": "CoinStatistic",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
-            },
-            {
-                "name": "getConsensusMetrics",
-                "comment": "get consensus metrics",
-                "params": [],
-                "returns": {
-                    "name": "",
-                    "type": "ConsensusMetrics",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
-            },
-            {
-                "name": "getCandidateMetrics",
-                "comment": "get candidates metrics",
-                "params": [],
-                "returns": {
-                    "name": "",
-                    "type": "CandidateMetrics",
+                    "type": "string",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getCandidateMetricsByHeight",
-                "comment": "get candidates metrics at given height",
+                "name": "estimateGas",
+                "comment": "estimate the gas consumed by an execution, which is simulated against the current state without being committed",
                 "params": [
                     {
-                        "name": "h",
-                        "type": "int",
+                        "name": "request",
+                        "type": "ExecutionRequest",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2642,19 +4775,19 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "CandidateMetrics",
+                    "type": "GasEstimate",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "sendTransfer",
-                "comment": "send transfer",
+                "name": "callContract",
+                "comment": "call a contract, which is simulated against the current state without being committed",
                 "params": [
                     {
                         "name": "request",
-                        "type": "SendTransferRequest",
+                        "type": "ExecutionRequest",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2662,19 +4795,19 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "SendTransferResponse",
+                    "type": "CallResult",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "sendVote",
-                "comment": "send vote",
+                "name": "estimateGasPending",
+                "comment": "estimate the gas consumed by an execution like estimateGas, but after the actions of the executor pending in the\naction pool, so that the nonce and balance reflect the queued actions",
                 "params": [
                     {
                         "name": "request",
-                        "type": "SendVoteRequest",
+                        "type": "ExecutionRequest",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2682,19 +4815,19 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "SendVoteResponse",
+                    "type": "GasEstimate",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "sendSmartContract",
-                "comment": "sendSmartContract",
+                "name": "callContractPending",
+                "comment": "call a contract like callContract, but after the actions of the executor pending in the action pool",
                 "params": [
                     {
                         "name": "request",
-                        "type": "Execution",
+                        "type": "ExecutionRequest",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2702,51 +4835,39 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "SendSmartContractResponse",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
-            },
-            {
-                "name": "getPeers",
-                "comment": "get list of peers",
-                "params": [],
-                "returns": {
-                    "name": "",
-                    "type": "GetPeersResponse",
+                    "type": "CallResult",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getReceiptByExecutionID",
-                "comment": "get receipt by execution id",
+                "name": "multicall",
+                "comment": "call a list of read methods in one request, where the params and results are JSON encoded",
                 "params": [
                     {
-                        "name": "id",
-                        "type": "string",
+                        "name": "requests",
+                        "type": "Request",
                         "optional": false,
-                        "is_array": false,
+                        "is_array": true,
                         "comment": ""
                     }
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Receipt",
+                    "type": "Response",
                     "optional": false,
-                    "is_array": false,
+                    "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "readExecutionState",
-                "comment": "read execution state",
+                "name": "getBlockOrActionByHash",
+                "comment": "get block or action by a hash",
                 "params": [
                     {
-                        "name": "request",
-                        "type": "Execution",
+                        "name": "hashStr",
+                        "type": "string",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2754,18 +4875,18 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "string",
+                    "type": "GetBlkOrActResponse",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getBlockOrActionByHash",
-                "comment": "get block or action by a hash",
+                "name": "getActionByID",
+                "comment": "get action of any type by action id",
                 "params": [
                     {
-                        "name": "hashStr",
+                        "name": "actionID",
                         "type": "string",
                         "optional": false,
                         "is_array": false,
@@ -2774,7 +4895,7 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "GetBlkOrActResponse",
+                    "type": "Action",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
@@ -2795,7 +4916,7 @@ var IdlJsonRaw = `[
         "values": null,
         "functions": null,
         "barrister_version": "0.1.6",
-        "date_generated": 1538418246052,
-        "checksum": "97e0f2efe11dee3190b33a6c0cb7fda8"
+        "date_generated": 1792116018745,
+        "checksum": "a60486576d707794cdb2fcd1871c225b"
     }
 ]`
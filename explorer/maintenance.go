@@ -0,0 +1,80 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"sync"
+
+	"github.com/coopernurse/barrister-go"
+	"github.com/pkg/errors"
+)
+
+// ErrMaintenance indicates the explorer is under maintenance, when the methods other than getExplorerStatus are
+// rejected
+var ErrMaintenance = errors.New("explorer is under maintenance")
+
+// maintenanceCode is the JSON-RPC error code of the requests rejected by maintenanceFilter
+const maintenanceCode = -32004
+
+// statusMethod is the method reporting the status of the explorer, which is served even under maintenance
+const statusMethod = "Explorer.getExplorerStatus"
+
+// maintenanceMode tells whether the explorer is under maintenance. It is safe for concurrent access.
+type maintenanceMode struct {
+	mutex   sync.RWMutex
+	on      bool
+	message string
+}
+
+// set turns the maintenance mode on or off
+func (m *maintenanceMode) set(on bool, message string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.on = on
+	m.message = message
+}
+
+// get returns whether the explorer is under maintenance and the message about it
+func (m *maintenanceMode) get() (bool, string) {
+	if m == nil {
+		return false, ""
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.on, m.message
+}
+
+// maintenanceFilter rejects the requests of the methods other than getExplorerStatus while under maintenance
+type maintenanceFilter struct {
+	mode *maintenanceMode
+}
+
+// PreInvoke rejects the request with ErrMaintenance if the explorer is under maintenance
+func (f maintenanceFilter) PreInvoke(r *barrister.RequestResponse) bool {
+	on, message := f.mode.get()
+	if !on || r.Method == statusMethod {
+		return true
+	}
+	r.Err = &barrister.JsonRpcError{Code: maintenanceCode, Message: ErrMaintenance.Error(), Data: message}
+	return false
+}
+
+// PostInvoke does nothing
+func (f maintenanceFilter) PostInvoke(r *barrister.RequestResponse) bool {
+	return true
+}
+
+// IsMaintenance returns true if the error is due to the explorer being under maintenance, either returned by the
+// explorer server or by a proxy of it
+func IsMaintenance(err error) bool {
+	err = errors.Cause(err)
+	if err == ErrMaintenance {
+		return true
+	}
+	rpcErr, ok := err.(*barrister.JsonRpcError)
+	return ok && rpcErr.Code == maintenanceCode
+}
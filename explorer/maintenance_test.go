@@ -0,0 +1,86 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/coopernurse/barrister-go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func TestMaintenanceFilter(t *testing.T) {
+	require := require.New(t)
+
+	f := maintenanceFilter{mode: &maintenanceMode{}}
+	svc := Service{maintenance: f.mode}
+	request := func(method string) *barrister.RequestResponse {
+		return &barrister.RequestResponse{Method: method}
+	}
+
+	require.True(f.PreInvoke(request("Explorer.getBlockchainHeight")))
+	status, err := svc.GetExplorerStatus()
+	require.NoError(err)
+	require.False(status.Maintenance)
+
+	f.mode.set(true, "upgrading until 10:00 UTC")
+	for _, method := range []string{"Explorer.getBlockchainHeight", "Explorer.sendTransfer", "Explorer.multicall"} {
+		r := request(method)
+		require.False(f.PreInvoke(r))
+		rpcErr, ok := r.Err.(*barrister.JsonRpcError)
+		require.True(ok)
+		require.Equal(maintenanceCode, rpcErr.Code)
+		require.Equal("upgrading until 10:00 UTC", rpcErr.Data)
+		require.True(IsMaintenance(r.Err))
+	}
+	// the status is still served
+	require.True(f.PreInvoke(request(statusMethod)))
+	status, err = svc.GetExplorerStatus()
+	require.NoError(err)
+	require.True(status.Maintenance)
+	require.Equal("upgrading until 10:00 UTC", status.MaintenanceMessage)
+
+	f.mode.set(false, "")
+	require.True(f.PreInvoke(request("Explorer.getBlockchainHeight")))
+
+	require.True(IsMaintenance(errors.Wrap(ErrMaintenance, "wrapped")))
+	require.False(IsMaintenance(errors.New("error")))
+	require.False(IsMaintenance(&barrister.JsonRpcError{Code: serviceUnavailableCode}))
+}
+
+func TestServerMaintenance(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.Explorer
+	cfg.Port = 0
+	svr := NewTestSever(cfg)
+	require.NoError(svr.Start(context.Background()))
+	defer func() {
+		require.NoError(svr.Stop(context.Background()))
+	}()
+	proxy := NewExplorerProxy(fmt.Sprintf("http://127.0.0.1:%d", svr.Port()))
+
+	svr.SetMaintenance(true, "upgrading")
+	_, err := proxy.GetBlockchainHeight()
+	require.True(IsMaintenance(err))
+	status, err := proxy.GetExplorerStatus()
+	require.NoError(err)
+	require.True(status.Maintenance)
+	require.Equal("upgrading", status.MaintenanceMessage)
+
+	svr.SetMaintenance(false, "")
+	_, err = proxy.GetBlockchainHeight()
+	require.NoError(err)
+	status, err = proxy.GetExplorerStatus()
+	require.NoError(err)
+	require.False(status.Maintenance)
+}
@@ -7,57 +7,200 @@
 package explorer
 
 import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
 	"math/rand"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 )
 
+// mockBlockInterval is the interval of the random blocks emitted by MockExplorer.SubscribeBlocks
+var mockBlockInterval = 3 * time.Second
+
+// mockCoinbaseRatio means one in every mockCoinbaseRatio fake transfers is a coinbase transfer
+const mockCoinbaseRatio = 5
+
+// mockFinalityConfirmations is the number of confirmations finalizing the fake blocks
+const mockFinalityConfirmations = 3
+
 // MockExplorer return an explorer for test purpose
 type MockExplorer struct {
+	rnd  *rand.Rand
+	once sync.Once
+	// maintenance tells whether the test server is under maintenance, which is reported by GetExplorerStatus
+	maintenance *maintenanceMode
+}
+
+// NewMockExplorer creates a MockExplorer whose fake data is determined by the seed
+func NewMockExplorer(seed int64) *MockExplorer {
+	return &MockExplorer{rnd: newLockedRand(seed)}
 }
 
 // GetBlockchainHeight returns the blockchain height
 func (exp *MockExplorer) GetBlockchainHeight() (int64, error) {
-	return randInt64(), nil
+	return exp.randInt64(), nil
 }
 
 // GetAddressBalance returns the balance of an address
 func (exp *MockExplorer) GetAddressBalance(address string) (int64, error) {
-	return randInt64(), nil
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return int64(0), err
+	}
+	return exp.randInt64(), nil
+}
+
+// GetAddressBalanceAtHeight returns a fake balance of an address at the given height
+func (exp *MockExplorer) GetAddressBalanceAtHeight(address string, height int64) (int64, error) {
+	if _, err := normalizeAddress(address); err != nil {
+		return int64(0), err
+	}
+	if height < 0 {
+		return int64(0), errors.Errorf("invalid height %d", height)
+	}
+	return exp.randInt64(), nil
 }
 
 // GetAddressDetails returns the properties of an address
 func (exp *MockExplorer) GetAddressDetails(address string) (explorer.AddressDetails, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return explorer.AddressDetails{}, err
+	}
 	return explorer.AddressDetails{
 		Address:      address,
-		TotalBalance: randInt64(),
+		TotalBalance: exp.randInt64(),
 	}, nil
 }
 
+// GetAddressNonce returns the next nonce to use for an address
+func (exp *MockExplorer) GetAddressNonce(address string) (int64, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return int64(0), err
+	}
+	return exp.randInt64(), nil
+}
+
+// GetAddressState returns the account state of an address
+func (exp *MockExplorer) GetAddressState(address string) (explorer.AccountState, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return explorer.AccountState{}, err
+	}
+	return exp.randAccountState(address), nil
+}
+
 // GetLastTransfersByRange return transfers in [-(offset+limit-1), -offset] from block
 // with height startBlockHeight
 func (exp *MockExplorer) GetLastTransfersByRange(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]explorer.Transfer, error) {
 	var txs []explorer.Transfer
-	for i := int64(0); i < limit; i++ {
-		txs = append(txs, randTransaction())
+	// Nonce carries the position of the transfer so that consecutive pages don't overlap
+	for pos := offset; int64(len(txs)) < limit; pos++ {
+		tx := exp.randTransaction()
+		tx.Nonce = pos
+		// tag some transfers as coinbase so that the showCoinBase toggle is observable
+		if exp.rng().Intn(mockCoinbaseRatio) == 0 {
+			tx.IsCoinbase = true
+			tx.Fee = 0
+		}
+		if tx.IsCoinbase && !showCoinBase {
+			continue
+		}
+		txs = append(txs, tx)
 	}
 	return txs, nil
 }
 
+// GetLastTransfersByRangeV2 returns a page of transfers in [-(offset+limit-1), -offset] from block
+// with height startBlockHeight, together with a fabricated total count
+func (exp *MockExplorer) GetLastTransfersByRangeV2(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) (explorer.TransferPage, error) {
+	transfers, err := exp.GetLastTransfersByRange(startBlockHeight, offset, limit, showCoinBase)
+	if err != nil {
+		return explorer.TransferPage{}, err
+	}
+	totalCount := exp.randTotalCount(offset, limit)
+	return explorer.TransferPage{
+		Transfers:  transfers,
+		TotalCount: totalCount,
+		HasMore:    offset+int64(len(transfers)) < totalCount,
+	}, nil
+}
+
 // GetTransferByID returns transfer by transfer id
 func (exp *MockExplorer) GetTransferByID(transferID string) (explorer.Transfer, error) {
-	return randTransaction(), nil
+	return exp.randTransaction(), nil
+}
+
+// GetTransferProof returns a random merkle proof of the transfer. The branch proves the transfer against the tx root,
+// and the block ID is the hash of the block header, but the header is random bytes.
+func (exp *MockExplorer) GetTransferProof(transferID string) (explorer.MerkleProof, error) {
+	bytes, err := hex.DecodeString(transferID)
+	if err != nil {
+		return explorer.MerkleProof{}, err
+	}
+	var transferHash hash.Hash32B
+	copy(transferHash[:], bytes)
+
+	leaves := make([]hash.Hash32B, exp.rng().Intn(16)+1)
+	index := exp.rng().Intn(len(leaves))
+	for i := range leaves {
+		leaves[i] = exp.randHash32B()
+	}
+	leaves[index] = transferHash
+	mk := crypto.NewMerkleTree(leaves)
+	txRoot := mk.HashTree()
+	branch, err := mk.Proof(index)
+	if err != nil {
+		return explorer.MerkleProof{}, err
+	}
+
+	var header []byte
+	for i := 0; i < 4; i++ {
+		h := exp.randHash32B()
+		header = append(header, h[:]...)
+	}
+	blkHash := blake2b.Sum256(header)
+	proof := explorer.MerkleProof{
+		TransferID:  transferID,
+		BlockID:     hex.EncodeToString(blkHash[:]),
+		BlockHeight: exp.randInt64(),
+		BlockHeader: hex.EncodeToString(header),
+		TxRoot:      hex.EncodeToString(txRoot[:]),
+		StateRoot:   exp.randHash(),
+		Index:       int64(index),
+		Branch:      make([]string, 0, len(branch)),
+	}
+	for _, h := range branch {
+		proof.Branch = append(proof.Branch, hex.EncodeToString(h[:]))
+	}
+	return proof, nil
 }
 
 // GetTransfersByAddress returns all transfers associate with an address
 func (exp *MockExplorer) GetTransfersByAddress(address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Transfer{}, err
+	}
 	return exp.GetLastTransfersByRange(0, offset, limit, true)
 }
 
 // GetUnconfirmedTransfersByAddress returns all unconfirmed transfers in actpool associated with an address
 func (exp *MockExplorer) GetUnconfirmedTransfersByAddress(address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Transfer{}, err
+	}
 	return exp.GetLastTransfersByRange(0, offset, limit, true)
 }
 
@@ -66,28 +209,91 @@ func (exp *MockExplorer) GetTransfersByBlockID(blockID string, offset int64, lim
 	return exp.GetLastTransfersByRange(0, offset, limit, true)
 }
 
+// GetTransfersByAmountRange returns transfers whose amount is within [minAmount, maxAmount]
+func (exp *MockExplorer) GetTransfersByAmountRange(minAmount int64, maxAmount int64, offset int64, limit int64) ([]explorer.Transfer, error) {
+	if minAmount > maxAmount {
+		return []explorer.Transfer{}, errors.Wrapf(ErrTransfer, "min amount %d is larger than max amount %d", minAmount, maxAmount)
+	}
+	var txs []explorer.Transfer
+	for i := int64(0); i < limit; i++ {
+		tx := exp.randTransaction()
+		tx.Amount = exp.randAmountInRange(minAmount, maxAmount)
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
 // GetLastVotesByRange return votes in [-(offset+limit-1), -offset] from block
 // with height startBlockHeight
 func (exp *MockExplorer) GetLastVotesByRange(startBlockHeight int64, offset int64, limit int64) ([]explorer.Vote, error) {
 	var votes []explorer.Vote
 	for i := int64(0); i < limit; i++ {
-		votes = append(votes, randVote())
+		votes = append(votes, exp.randVote())
 	}
 	return votes, nil
 }
 
+// GetLastVotesByRangeV2 returns a page of votes in [-(offset+limit-1), -offset] from block
+// with height startBlockHeight, together with a fabricated total count
+func (exp *MockExplorer) GetLastVotesByRangeV2(startBlockHeight int64, offset int64, limit int64) (explorer.VotePage, error) {
+	votes, err := exp.GetLastVotesByRange(startBlockHeight, offset, limit)
+	if err != nil {
+		return explorer.VotePage{}, err
+	}
+	totalCount := exp.randTotalCount(offset, limit)
+	return explorer.VotePage{
+		Votes:      votes,
+		TotalCount: totalCount,
+		HasMore:    offset+int64(len(votes)) < totalCount,
+	}, nil
+}
+
 // GetVoteByID returns vote by vote id
 func (exp *MockExplorer) GetVoteByID(voteID string) (explorer.Vote, error) {
-	return randVote(), nil
+	return exp.randVote(), nil
 }
 
 // GetVotesByAddress returns all votes associate with an address
 func (exp *MockExplorer) GetVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
 	return exp.GetLastVotesByRange(0, offset, limit)
 }
 
+// GetVotesFromAddress returns the votes cast by an address
+func (exp *MockExplorer) GetVotesFromAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
+	votes, err := exp.GetLastVotesByRange(0, offset, limit)
+	for i := range votes {
+		votes[i].Voter = address
+	}
+	return votes, err
+}
+
+// GetVotesToAddress returns the votes received by an address
+func (exp *MockExplorer) GetVotesToAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
+	votes, err := exp.GetLastVotesByRange(0, offset, limit)
+	for i := range votes {
+		votes[i].Votee = address
+	}
+	return votes, err
+}
+
 // GetUnconfirmedVotesByAddress returns all unconfirmed votes in actpool associated with an address
 func (exp *MockExplorer) GetUnconfirmedVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
 	return exp.GetLastVotesByRange(0, offset, limit)
 }
 
@@ -101,31 +307,134 @@ func (exp *MockExplorer) GetReceiptByExecutionID(id string) (explorer.Receipt, e
 	return explorer.Receipt{}, nil
 }
 
+// GetReceiptByActionID gets receipt with corresponding action id
+func (exp *MockExplorer) GetReceiptByActionID(id string) (explorer.Receipt, error) {
+	receipt := exp.randReceipt()
+	receipt.Hash = id
+	return receipt, nil
+}
+
+// GetLogsByBlockRange returns fake logs in the block range, which are emitted by the contract and match the topics
+func (exp *MockExplorer) GetLogsByBlockRange(
+	fromHeight int64,
+	toHeight int64,
+	contract string,
+	topics []string,
+) ([]explorer.Log, error) {
+	if contract != "" {
+		var err error
+		if contract, err = normalizeAddress(contract); err != nil {
+			return []explorer.Log{}, err
+		}
+	}
+	if fromHeight < 0 || fromHeight > toHeight {
+		return []explorer.Log{}, errors.Errorf("invalid block range [%d, %d]", fromHeight, toHeight)
+	}
+	logs := []explorer.Log{}
+	numLogs := exp.rng().Int63n(5)
+	for i := int64(0); i < numLogs; i++ {
+		address := contract
+		if address == "" {
+			address = exp.randString()
+		}
+		logTopics := make([]string, len(topics), len(topics)+1)
+		for j, topic := range topics {
+			if topic == "" {
+				topic = exp.randHash()
+			}
+			logTopics[j] = topic
+		}
+		logTopics = append(logTopics, exp.randHash())
+		logs = append(logs, explorer.Log{
+			Address:     address,
+			Topics:      logTopics,
+			Data:        exp.randString(),
+			BlockNumber: fromHeight + exp.rng().Int63n(toHeight-fromHeight+1),
+			TxnHash:     exp.randHash(),
+			BlockHash:   exp.randHash(),
+			Index:       i,
+		})
+	}
+	return logs, nil
+}
+
 // GetLastExecutionsByRange return executions in [-(offset+limit-1), -offset] from block
 // with height startBlockHeight
 func (exp *MockExplorer) GetLastExecutionsByRange(startBlockHeight int64, offset int64, limit int64) ([]explorer.Execution, error) {
 	var executions []explorer.Execution
 	for i := int64(0); i < limit; i++ {
-		executions = append(executions, randExecution())
+		executions = append(executions, exp.randExecution())
 	}
 	return executions, nil
 }
 
+// GetLastExecutionsByRangeV2 returns a page of executions in [-(offset+limit-1), -offset] from block
+// with height startBlockHeight, together with a fabricated total count
+func (exp *MockExplorer) GetLastExecutionsByRangeV2(startBlockHeight int64, offset int64, limit int64) (explorer.ExecutionPage, error) {
+	executions, err := exp.GetLastExecutionsByRange(startBlockHeight, offset, limit)
+	if err != nil {
+		return explorer.ExecutionPage{}, err
+	}
+	totalCount := exp.randTotalCount(offset, limit)
+	return explorer.ExecutionPage{
+		Executions: executions,
+		TotalCount: totalCount,
+		HasMore:    offset+int64(len(executions)) < totalCount,
+	}, nil
+}
+
 // GetExecutionByID returns execution by execution id
 func (exp *MockExplorer) GetExecutionByID(executionID string) (explorer.Execution, error) {
-	return randExecution(), nil
+	return exp.randExecution(), nil
 }
 
 // GetExecutionsByAddress returns all executions associate with an address
 func (exp *MockExplorer) GetExecutionsByAddress(address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Execution{}, err
+	}
 	return exp.GetLastExecutionsByRange(0, offset, limit)
 }
 
 // GetUnconfirmedExecutionsByAddress returns all unconfirmed executions in actpool associated with an address
 func (exp *MockExplorer) GetUnconfirmedExecutionsByAddress(address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return []explorer.Execution{}, err
+	}
 	return exp.GetLastExecutionsByRange(0, offset, limit)
 }
 
+// GetPendingActionsByAddress returns unconfirmed actions of all types in actpool associated with an address
+func (exp *MockExplorer) GetPendingActionsByAddress(address string, offset int64, limit int64) (explorer.PendingActions, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return explorer.PendingActions{}, err
+	}
+	var res explorer.PendingActions
+	for i := int64(0); i < limit; i++ {
+		switch exp.randInt64() % 3 {
+		case 0:
+			transfer := exp.randTransaction()
+			transfer.Sender = address
+			transfer.IsPending = true
+			res.Transfers = append(res.Transfers, transfer)
+		case 1:
+			vote := exp.randVote()
+			vote.Voter = address
+			vote.IsPending = true
+			res.Votes = append(res.Votes, vote)
+		default:
+			execution := exp.randExecution()
+			execution.Executor = address
+			execution.IsPending = true
+			res.Executions = append(res.Executions, execution)
+		}
+	}
+	return res, nil
+}
+
 // GetExecutionsByBlockID returns executions in a block
 func (exp *MockExplorer) GetExecutionsByBlockID(blkID string, offset int64, limit int64) ([]explorer.Execution, error) {
 	return exp.GetLastExecutionsByRange(0, offset, limit)
@@ -135,46 +444,132 @@ func (exp *MockExplorer) GetExecutionsByBlockID(blkID string, offset int64, limi
 func (exp *MockExplorer) GetLastBlocksByRange(offset int64, limit int64) ([]explorer.Block, error) {
 	var blks []explorer.Block
 	for i := int64(0); i < limit; i++ {
-		blks = append(blks, randBlock())
+		blks = append(blks, exp.randBlock())
 	}
 	return blks, nil
 }
 
 // GetBlockByID returns block by block id
 func (exp *MockExplorer) GetBlockByID(blkID string) (explorer.Block, error) {
-	return randBlock(), nil
+	return exp.randBlock(), nil
+}
+
+// GetBlockByHeight returns a random block at the given height
+func (exp *MockExplorer) GetBlockByHeight(height int64) (explorer.Block, error) {
+	blk := exp.randBlock()
+	blk.Height = height
+	return blk, nil
 }
 
 // GetCoinStatistic returns stats in blockchain
 func (exp *MockExplorer) GetCoinStatistic() (explorer.CoinStatistic, error) {
+	totalSupply := exp.randInt64()
 	return explorer.CoinStatistic{
-		Height: randInt64(),
-		Supply: randInt64(),
+		Height:            exp.randInt64(),
+		Supply:            totalSupply,
+		TotalSupply:       totalSupply,
+		CirculatingSupply: exp.rng().Int63n(totalSupply + 1),
+		BlockReward:       exp.randInt64(),
+		AccumulatedReward: exp.randInt64(),
+	}, nil
+}
+
+// GetFeeStatistic returns the fake fee statistic
+func (exp *MockExplorer) GetFeeStatistic(blockCount int64) (explorer.FeeStatistic, error) {
+	if blockCount <= 0 {
+		return explorer.FeeStatistic{}, errors.Wrapf(ErrInternalServer, "block count is %d", blockCount)
+	}
+	median := exp.randInt64()
+	return explorer.FeeStatistic{
+		BlockCount: blockCount,
+		AverageFee: exp.randInt64(),
+		MedianFee:  median,
+		P95Fee:     median + exp.randInt64(),
 	}, nil
 }
 
 // GetConsensusMetrics returns the fake consensus metrics
 func (exp *MockExplorer) GetConsensusMetrics() (explorer.ConsensusMetrics, error) {
 	delegates := []string{
-		randString(),
-		randString(),
-		randString(),
-		randString(),
+		exp.randString(),
+		exp.randString(),
+		exp.randString(),
+		exp.randString(),
 	}
+	phases := []string{"S_INIT_PROPOSE", "S_ACCEPT_PROPOSE", "S_ACCEPT_PROPOSAL_ENDROSE", "S_ACCEPT_COMMIT_ENDORSE"}
 	return explorer.ConsensusMetrics{
-		LatestEpoch:         randInt64(),
+		LatestEpoch:         exp.randInt64(),
 		LatestDelegates:     delegates,
 		LatestBlockProducer: delegates[0],
+		Round:               exp.rng().Int63n(5),
+		Phase:               phases[exp.rng().Intn(len(phases))],
+		RoundStartTime:      time.Now().Unix() - exp.rng().Int63n(10),
+	}, nil
+}
+
+// GetEpochMeta returns the fake metadata of an epoch, whose produced block counts add up to no more than the epoch's
+// height range
+func (exp *MockExplorer) GetEpochMeta(epochNum int64) (explorer.EpochMeta, error) {
+	if epochNum <= 0 {
+		return explorer.EpochMeta{}, errors.New("epoch number should be greater than 0")
+	}
+	const numDelegates = 4
+	delegates := make([]explorer.EpochDelegate, numDelegates)
+	for i := range delegates {
+		delegates[i] = explorer.EpochDelegate{
+			Address:            exp.randString(),
+			ProducedBlockCount: exp.rng().Int63n(2),
+		}
+	}
+	return explorer.EpochMeta{
+		EpochNum:    epochNum,
+		StartHeight: numDelegates*(epochNum-1) + 1,
+		EndHeight:   numDelegates * epochNum,
+		Delegates:   delegates,
 	}, nil
 }
 
+// GetProducerStats returns the fake block production stats of a producer
+func (exp *MockExplorer) GetProducerStats(address string) (explorer.ProducerStats, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return explorer.ProducerStats{}, err
+	}
+	expected := exp.rng().Int63n(10)
+	produced := expected - exp.rng().Int63n(expected+1)
+	return explorer.ProducerStats{
+		Address:        address,
+		EpochNum:       exp.randInt64(),
+		ExpectedBlocks: expected,
+		ProducedBlocks: produced,
+		MissedBlocks:   expected - produced,
+	}, nil
+}
+
+// GetGenesisHash returns a fake genesis hash
+func (exp *MockExplorer) GetGenesisHash() (string, error) {
+	return exp.randHash(), nil
+}
+
+// GetServerConfig returns an empty config
+func (exp *MockExplorer) GetServerConfig() (string, error) {
+	return "{}", nil
+}
+
+// GetExplorerStatus returns the status of a healthy explorer, which could be under maintenance
+func (exp *MockExplorer) GetExplorerStatus() (explorer.ExplorerStatus, error) {
+	var status explorer.ExplorerStatus
+	status.Maintenance, status.MaintenanceMessage = exp.maintenance.get()
+	return status, nil
+}
+
 // GetCandidateMetrics returns the fake delegates metrics
 func (exp *MockExplorer) GetCandidateMetrics() (explorer.CandidateMetrics, error) {
 	candidate := explorer.Candidate{
-		Address:          randString(),
-		TotalVote:        randInt64(),
-		CreationHeight:   randInt64(),
-		LastUpdateHeight: randInt64(),
+		Address:          exp.randString(),
+		TotalVote:        exp.randInt64(),
+		CreationHeight:   exp.randInt64(),
+		LastUpdateHeight: exp.randInt64(),
 		IsDelegate:       false,
 		IsProducer:       false,
 	}
@@ -186,10 +581,10 @@ func (exp *MockExplorer) GetCandidateMetrics() (explorer.CandidateMetrics, error
 // GetCandidateMetricsByHeight returns the fake delegates metrics
 func (exp *MockExplorer) GetCandidateMetricsByHeight(h int64) (explorer.CandidateMetrics, error) {
 	candidate := explorer.Candidate{
-		Address:          randString(),
-		TotalVote:        randInt64(),
-		CreationHeight:   randInt64(),
-		LastUpdateHeight: randInt64(),
+		Address:          exp.randString(),
+		TotalVote:        exp.randInt64(),
+		CreationHeight:   exp.randInt64(),
+		LastUpdateHeight: exp.randInt64(),
 		IsDelegate:       false,
 		IsProducer:       false,
 	}
@@ -198,9 +593,26 @@ func (exp *MockExplorer) GetCandidateMetricsByHeight(h int64) (explorer.Candidat
 	}, nil
 }
 
-// SendTransfer sends a fake transfer
+// GetCandidates returns several random candidates
+func (exp *MockExplorer) GetCandidates(height int64) ([]explorer.Candidate, error) {
+	candidates := make([]explorer.Candidate, 0, 5)
+	for i := 0; i < 5; i++ {
+		candidates = append(candidates, explorer.Candidate{
+			Address:          exp.randString(),
+			PubKey:           exp.randString(),
+			TotalVote:        exp.randInt64(),
+			CreationHeight:   exp.randInt64(),
+			LastUpdateHeight: height,
+			IsDelegate:       i < 3,
+			IsProducer:       i == 0,
+		})
+	}
+	return candidates, nil
+}
+
+// SendTransfer sends a fake transfer and returns a random hash
 func (exp *MockExplorer) SendTransfer(request explorer.SendTransferRequest) (explorer.SendTransferResponse, error) {
-	return explorer.SendTransferResponse{}, nil
+	return explorer.SendTransferResponse{Hash: exp.randHash()}, nil
 }
 
 // SendVote sends a fake vote
@@ -218,77 +630,335 @@ func (exp *MockExplorer) SendSmartContract(request explorer.Execution) (explorer
 	return explorer.SendSmartContractResponse{}, nil
 }
 
+// SendAction ignores the action and returns a random hash
+func (exp *MockExplorer) SendAction(rawAction string) (string, error) {
+	return exp.randHash(), nil
+}
+
 // ReadExecutionState sends a smart contract
 func (exp *MockExplorer) ReadExecutionState(request explorer.Execution) (string, error) {
 	return "100", nil
 }
 
+// EstimateGas returns a random gas estimate
+func (exp *MockExplorer) EstimateGas(request explorer.ExecutionRequest) (explorer.GasEstimate, error) {
+	return explorer.GasEstimate{Gas: exp.randInt64()}, nil
+}
+
+// CallContract returns a random call result
+func (exp *MockExplorer) CallContract(request explorer.ExecutionRequest) (explorer.CallResult, error) {
+	return explorer.CallResult{
+		ReturnValue: exp.randHash(),
+		Status:      1,
+		GasConsumed: exp.randInt64(),
+	}, nil
+}
+
+// EstimateGasPending returns a random gas estimate
+func (exp *MockExplorer) EstimateGasPending(request explorer.ExecutionRequest) (explorer.GasEstimate, error) {
+	return explorer.GasEstimate{Gas: exp.randInt64()}, nil
+}
+
+// CallContractPending returns a random call result
+func (exp *MockExplorer) CallContractPending(request explorer.ExecutionRequest) (explorer.CallResult, error) {
+	return explorer.CallResult{
+		ReturnValue: exp.randHash(),
+		Status:      1,
+		GasConsumed: exp.randInt64(),
+	}, nil
+}
+
+// Multicall dispatches the requests to the mock methods
+func (exp *MockExplorer) Multicall(requests []explorer.Request) ([]explorer.Response, error) {
+	return multicall(exp, requests)
+}
+
 // GetBlockOrActionByHash get block or action by a hash
 func (exp *MockExplorer) GetBlockOrActionByHash(hash string) (explorer.GetBlkOrActResponse, error) {
 	return explorer.GetBlkOrActResponse{}, nil
 }
 
-func randInt64() int64 {
-	rand.Seed(time.Now().UnixNano())
+// SubscribeBlocks emits a random block every few seconds until it is cancelled
+func (exp *MockExplorer) SubscribeBlocks() (<-chan explorer.Block, func(), error) {
+	blocks := make(chan explorer.Block)
+	done := make(chan struct{})
+	go func() {
+		defer close(blocks)
+		ticker := time.NewTicker(mockBlockInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				select {
+				case blocks <- exp.randBlock():
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return blocks, func() { once.Do(func() { close(done) }) }, nil
+}
+
+// SubscribeAddress emits a random event of the address every few seconds until it is cancelled
+func (exp *MockExplorer) SubscribeAddress(address string) (<-chan AddressEvent, func(), error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	events := make(chan AddressEvent)
+	done := make(chan struct{})
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(mockBlockInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				select {
+				case events <- exp.randAddressEvent(address):
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return events, func() { once.Do(func() { close(done) }) }, nil
+}
+
+// GetActionByID returns an action of a random type with the given id
+func (exp *MockExplorer) GetActionByID(actionID string) (explorer.Action, error) {
+	switch exp.randInt64() % 3 {
+	case 0:
+		tsf := exp.randTransaction()
+		tsf.ID = actionID
+		return explorer.Action{Type: TransferActionType, Transfer: &tsf}, nil
+	case 1:
+		vote := exp.randVote()
+		vote.ID = actionID
+		return explorer.Action{Type: VoteActionType, Vote: &vote}, nil
+	default:
+		exe := exp.randExecution()
+		exe.ID = actionID
+		return explorer.Action{Type: ExecutionActionType, Execution: &exe}, nil
+	}
+}
+
+func (exp *MockExplorer) rng() *rand.Rand {
+	exp.once.Do(func() {
+		if exp.rnd == nil {
+			exp.rnd = newLockedRand(time.Now().UnixNano())
+		}
+	})
+	return exp.rnd
+}
+
+func (exp *MockExplorer) randInt64() int64 {
 	amount := int64(0)
 	for amount == int64(0) {
-		amount = int64(rand.Intn(100000000))
+		amount = exp.rng().Int63n(100000000)
 	}
 	return amount
 }
 
-func randString() string {
-	return strconv.FormatInt(randInt64(), 10)
+func (exp *MockExplorer) randHash() string {
+	h := exp.randHash32B()
+	return hex.EncodeToString(h[:])
+}
+
+func (exp *MockExplorer) randHash32B() hash.Hash32B {
+	var h hash.Hash32B
+	for i := 0; i < len(h); i += 8 {
+		binary.LittleEndian.PutUint64(h[i:], exp.rng().Uint64())
+	}
+	return h
+}
+
+func (exp *MockExplorer) randString() string {
+	return strconv.FormatInt(exp.randInt64(), 10)
+}
+
+// randTotalCount fabricates a total count that covers at least the requested page
+func (exp *MockExplorer) randTotalCount(offset int64, limit int64) int64 {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	// saturate instead of overflowing when the page reaches past math.MaxInt64
+	if limit > math.MaxInt64-offset {
+		return math.MaxInt64
+	}
+	total := offset + limit
+	extra := limit
+	if headroom := math.MaxInt64 - total; extra > headroom {
+		extra = headroom
+	}
+	return total + exp.rng().Int63n(extra+1)
+}
+
+func (exp *MockExplorer) randAccountState(address string) explorer.AccountState {
+	isCandidate := exp.randInt64()%2 == 0
+	votee := exp.randString()
+	if isCandidate {
+		votee = address
+	}
+	return explorer.AccountState{
+		Address:      address,
+		Nonce:        exp.randInt64(),
+		Balance:      exp.randInt64(),
+		IsCandidate:  isCandidate,
+		Votee:        votee,
+		VotingWeight: exp.randInt64(),
+	}
 }
 
-func randTransaction() explorer.Transfer {
+func (exp *MockExplorer) randAmountInRange(minAmount int64, maxAmount int64) int64 {
+	span := maxAmount - minAmount
+	if span < 0 || span == math.MaxInt64 {
+		// the range is too wide to be represented in int64, so fall back to its middle
+		return minAmount/2 + maxAmount/2
+	}
+	return minAmount + exp.rng().Int63n(span+1)
+}
+
+func (exp *MockExplorer) randTransaction() explorer.Transfer {
 	return explorer.Transfer{
-		ID:        randString(),
-		Sender:    randString(),
-		Recipient: randString(),
-		Amount:    randInt64(),
+		ID:        exp.randString(),
+		Sender:    exp.randString(),
+		Recipient: exp.randString(),
+		Amount:    exp.randInt64(),
 		Fee:       12,
-		Timestamp: randInt64(),
-		BlockID:   randString(),
+		Timestamp: exp.randInt64(),
+		BlockID:   exp.randString(),
 	}
 }
 
-func randVote() explorer.Vote {
+func (exp *MockExplorer) randVote() explorer.Vote {
 	return explorer.Vote{
-		ID:        randString(),
-		Timestamp: randInt64(),
-		BlockID:   randString(),
-		Nonce:     randInt64(),
-		Voter:     randString(),
-		Votee:     randString(),
+		ID:        exp.randString(),
+		Timestamp: exp.randInt64(),
+		BlockID:   exp.randString(),
+		Nonce:     exp.randInt64(),
+		Voter:     exp.randString(),
+		Votee:     exp.randString(),
 	}
 }
 
-func randExecution() explorer.Execution {
+// randAddressEvent fabricates a transfer sent or received by the address, a vote cast by the address, or the
+// confirmation of an action sent by the address
+func (exp *MockExplorer) randAddressEvent(address string) AddressEvent {
+	event := AddressEvent{Address: address, BlockID: exp.randHash(), Height: exp.randInt64()}
+	switch exp.randInt64() % 4 {
+	case 0:
+		tsf := exp.randTransaction()
+		tsf.Sender = address
+		event.Type, event.Transfer = TransferEventType, &tsf
+	case 1:
+		tsf := exp.randTransaction()
+		tsf.Recipient = address
+		event.Type, event.Transfer = TransferEventType, &tsf
+	case 2:
+		vote := exp.randVote()
+		vote.Voter = address
+		event.Type, event.Vote = VoteEventType, &vote
+	default:
+		execution := exp.randExecution()
+		execution.Executor = address
+		event.Type, event.Execution = ConfirmationEventType, &execution
+	}
+	return event
+}
+
+func (exp *MockExplorer) randExecution() explorer.Execution {
 	return explorer.Execution{
-		ID:        randString(),
-		Timestamp: randInt64(),
-		BlockID:   randString(),
-		Nonce:     randInt64(),
-		Executor:  randString(),
-		Contract:  randString(),
-		Amount:    randInt64(),
-		GasLimit:  randInt64(),
-		GasPrice:  randInt64(),
+		ID:        exp.randString(),
+		Timestamp: exp.randInt64(),
+		BlockID:   exp.randString(),
+		Nonce:     exp.randInt64(),
+		Executor:  exp.randString(),
+		Contract:  exp.randString(),
+		Amount:    exp.randInt64(),
+		GasLimit:  exp.randInt64(),
+		GasPrice:  exp.randInt64(),
+		Data:      exp.randString(),
+	}
+}
+
+func (exp *MockExplorer) randReceipt() explorer.Receipt {
+	var logs []explorer.Log
+	numLogs := exp.randInt64()%3 + 1
+	for i := int64(0); i < numLogs; i++ {
+		logs = append(logs, explorer.Log{
+			Address:     exp.randString(),
+			Topics:      []string{exp.randString(), exp.randString()},
+			Data:        exp.randString(),
+			BlockNumber: exp.randInt64(),
+			TxnHash:     exp.randString(),
+			BlockHash:   exp.randString(),
+			Index:       i,
+		})
+	}
+	return explorer.Receipt{
+		ReturnValue:     exp.randString(),
+		Status:          exp.randInt64() % 2,
+		Hash:            exp.randString(),
+		GasConsumed:     exp.randInt64(),
+		ContractAddress: exp.randString(),
+		Logs:            logs,
 	}
 }
 
-func randBlock() explorer.Block {
+func (exp *MockExplorer) randBlock() explorer.Block {
+	confirmations, finalized := blockFinality(0, uint64(exp.rng().Int63n(2*mockFinalityConfirmations)),
+		mockFinalityConfirmations)
 	return explorer.Block{
-		ID:        randString(),
-		Height:    randInt64(),
-		Timestamp: randInt64(),
-		Transfers: randInt64(),
+		ID:         exp.randString(),
+		Height:     exp.randInt64(),
+		Timestamp:  exp.randInt64(),
+		Transfers:  exp.randInt64(),
+		Votes:      exp.randInt64(),
+		Executions: exp.randInt64(),
 		GenerateBy: explorer.BlockGenerator{
-			Name:    randString(),
-			Address: randString(),
+			Name:    exp.randString(),
+			Address: exp.randString(),
 		},
-		Amount: randInt64(),
-		Forged: randInt64(),
+		Amount:            exp.randInt64(),
+		Forged:            exp.randInt64(),
+		Size:              exp.randInt64(),
+		Finalized:         finalized,
+		ConfirmationCount: confirmations,
+		ProducerPubKey:    exp.randString(),
+		Signature:         exp.randString(),
+		RewardAddress:     exp.randString(),
 	}
 }
+
+// lockedSource is a rand.Source safe for concurrent use, because the explorer serves requests concurrently
+type lockedSource struct {
+	mutex sync.Mutex
+	src   rand.Source
+}
+
+func newLockedRand(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed)})
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.src.Seed(seed)
+}
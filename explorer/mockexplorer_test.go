@@ -7,45 +7,155 @@
 package explorer
 
 import (
+	"encoding/hex"
+	"math"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
 )
 
+func TestMockExplorerSubscribeBlocks(t *testing.T) {
+	require := require.New(t)
+
+	interval := mockBlockInterval
+	mockBlockInterval = 10 * time.Millisecond
+	defer func() { mockBlockInterval = interval }()
+
+	svc := MockExplorer{}
+	blocks, cancel, err := svc.SubscribeBlocks()
+	require.Nil(err)
+	blk := <-blocks
+	require.NotEmpty(blk.ID)
+
+	cancel()
+	for range blocks {
+	}
+}
+
+func TestMockExplorerSubscribeAddress(t *testing.T) {
+	require := require.New(t)
+
+	interval := mockBlockInterval
+	mockBlockInterval = 10 * time.Millisecond
+	defer func() { mockBlockInterval = interval }()
+
+	addr := ta.Addrinfo["alfa"].RawAddress
+	svc := MockExplorer{}
+	_, _, err := svc.SubscribeAddress(addr)
+	require.Equal(ErrInvalidAddress, errors.Cause(err))
+	events, cancel, err := svc.SubscribeAddress(addr)
+	require.Nil(err)
+	for i := 0; i < 10; i++ {
+		event := <-events
+		require.Equal(addr, event.Address)
+		switch {
+		case event.Transfer != nil:
+			require.Equal(TransferEventType, event.Type)
+			require.True(event.Transfer.Sender == addr || event.Transfer.Recipient == addr)
+		case event.Vote != nil:
+			require.Equal(VoteEventType, event.Type)
+			require.Equal(addr, event.Vote.Voter)
+		default:
+			require.Equal(ConfirmationEventType, event.Type)
+			require.Equal(addr, event.Execution.Executor)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
 func TestMockExplorerApi(t *testing.T) {
 	require := require.New(t)
 
+	addr := ta.Addrinfo["alfa"].RawAddress
 	svc := MockExplorer{}
 
 	_, err := svc.GetBlockchainHeight()
 	require.Nil(err)
 
-	_, err = svc.GetAddressBalance("")
+	_, err = svc.GetAddressBalance(addr)
+	require.Nil(err)
+
+	_, err = svc.GetAddressBalanceAtHeight(addr, 10)
+	require.Nil(err)
+	_, err = svc.GetAddressBalanceAtHeight(addr, -1)
+	require.Error(err)
+
+	_, err = svc.GetAddressDetails(addr)
 	require.Nil(err)
 
-	_, err = svc.GetAddressDetails("")
+	_, err = svc.GetAddressNonce(addr)
 	require.Nil(err)
 
+	_, err = svc.GetAddressNonce("")
+	require.Equal(ErrInvalidAddress, errors.Cause(err))
+
+	accountState, err := svc.GetAddressState(addr)
+	require.Nil(err)
+	require.Equal(addr, accountState.Address)
+
 	_, err = svc.GetLastTransfersByRange(0, 0, 10, true)
 	require.Nil(err)
 
+	transferPage, err := svc.GetLastTransfersByRangeV2(0, 0, 10, true)
+	require.Nil(err)
+	require.Equal(10, len(transferPage.Transfers))
+	require.True(transferPage.TotalCount >= 10)
+
 	_, err = svc.GetTransferByID("")
 	require.Nil(err)
 
-	_, err = svc.GetTransfersByAddress("", 0, 10)
+	_, err = svc.GetTransfersByAddress(addr, 0, 10)
 	require.Nil(err)
 
 	_, err = svc.GetTransfersByBlockID("", 0, 10)
 	require.Nil(err)
 
+	transfers, err := svc.GetTransfersByAmountRange(100, 200, 0, 10)
+	require.Nil(err)
+	require.Equal(10, len(transfers))
+	for _, transfer := range transfers {
+		require.True(transfer.Amount >= 100 && transfer.Amount <= 200)
+	}
+	transfers, err = svc.GetTransfersByAmountRange(100, 100, 0, 10)
+	require.Nil(err)
+	for _, transfer := range transfers {
+		require.Equal(int64(100), transfer.Amount)
+	}
+	_, err = svc.GetTransfersByAmountRange(200, 100, 0, 10)
+	require.Error(err)
+
 	_, err = svc.GetLastVotesByRange(0, 0, 10)
 	require.Nil(err)
 
+	votePage, err := svc.GetLastVotesByRangeV2(0, 0, 10)
+	require.Nil(err)
+	require.Equal(10, len(votePage.Votes))
+	require.True(votePage.TotalCount >= 10)
+
 	_, err = svc.GetVoteByID("")
 	require.Nil(err)
 
-	_, err = svc.GetVotesByAddress("", 0, 10)
+	_, err = svc.GetVotesByAddress(addr, 0, 10)
+	require.Nil(err)
+
+	votes, err := svc.GetVotesFromAddress(addr, 0, 10)
+	require.Nil(err)
+	require.Equal(addr, votes[0].Voter)
+
+	votes, err = svc.GetVotesToAddress(addr, 0, 10)
 	require.Nil(err)
+	require.Equal(addr, votes[0].Votee)
 
 	_, err = svc.GetVotesByBlockID("", 0, 10)
 	require.Nil(err)
@@ -53,42 +163,273 @@ func TestMockExplorerApi(t *testing.T) {
 	_, err = svc.GetLastExecutionsByRange(0, 0, 10)
 	require.Nil(err)
 
+	executionPage, err := svc.GetLastExecutionsByRangeV2(0, 0, 10)
+	require.Nil(err)
+	require.Equal(10, len(executionPage.Executions))
+	require.True(executionPage.TotalCount >= 10)
+
 	_, err = svc.GetExecutionByID("")
 	require.Nil(err)
 
-	_, err = svc.GetExecutionsByAddress("", 0, 10)
+	_, err = svc.GetExecutionsByAddress(addr, 0, 10)
 	require.Nil(err)
 
 	_, err = svc.GetExecutionsByBlockID("", 0, 10)
 	require.Nil(err)
 
+	pendingActions, err := svc.GetPendingActionsByAddress(addr, 0, 10)
+	require.Nil(err)
+	require.Equal(10, len(pendingActions.Transfers)+len(pendingActions.Votes)+len(pendingActions.Executions))
+
 	_, err = svc.GetLastBlocksByRange(0, 10)
 	require.Nil(err)
 
 	_, err = svc.GetBlockByID("")
 	require.Nil(err)
 
-	_, err = svc.GetCoinStatistic()
+	blk, err := svc.GetBlockByHeight(10)
 	require.Nil(err)
+	require.Equal(int64(10), blk.Height)
+	require.NotZero(blk.Votes)
+	require.NotZero(blk.Executions)
+
+	coinStat, err := svc.GetCoinStatistic()
+	require.Nil(err)
+	require.Equal(coinStat.Supply, coinStat.TotalSupply)
+	require.True(coinStat.CirculatingSupply <= coinStat.TotalSupply)
+
+	feeStat, err := svc.GetFeeStatistic(10)
+	require.Nil(err)
+	require.Equal(int64(10), feeStat.BlockCount)
+	require.True(feeStat.MedianFee <= feeStat.P95Fee)
+	_, err = svc.GetFeeStatistic(0)
+	require.Error(err)
 
 	_, err = svc.GetConsensusMetrics()
 	require.Nil(err)
 
+	act, err := svc.GetActionByID("abc")
+	require.Nil(err)
+	switch act.Type {
+	case TransferActionType:
+		require.Equal("abc", act.Transfer.ID)
+	case VoteActionType:
+		require.Equal("abc", act.Vote.ID)
+	default:
+		require.Equal(ExecutionActionType, act.Type)
+		require.Equal("abc", act.Execution.ID)
+	}
+
+	candidates, err := svc.GetCandidates(10)
+	require.Nil(err)
+	require.NotEmpty(candidates)
+
 	_, err = svc.GetPeers()
 	require.Nil(err)
 
-	randInt64 := randInt64()
+	sendTransferRes, err := svc.SendTransfer(explorer.SendTransferRequest{})
+	require.Nil(err)
+	require.Len(sendTransferRes.Hash, 64)
+
+	actHash, err := svc.SendAction("")
+	require.Nil(err)
+	require.Len(actHash, 64)
+
+	receipt, err := svc.GetReceiptByActionID("abc")
+	require.Nil(err)
+	require.Equal("abc", receipt.Hash)
+	require.NotEmpty(receipt.Logs)
+
+	randInt64 := svc.randInt64()
 	require.NotNil(randInt64)
 
-	randString := randString()
+	randString := svc.randString()
 	require.NotNil(randString)
 
-	randTransaction := randTransaction()
+	randTransaction := svc.randTransaction()
 	require.NotNil(randTransaction)
 
-	randVote := randVote()
+	randVote := svc.randVote()
 	require.NotNil(randVote)
 
-	randBlock := randBlock()
+	randExecution := svc.randExecution()
+	require.NotNil(randExecution)
+	require.NotEmpty(randExecution.Data)
+
+	randBlock := svc.randBlock()
 	require.NotNil(randBlock)
 }
+
+func TestMockExplorerDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	svc1 := NewMockExplorer(42)
+	svc2 := NewMockExplorer(42)
+
+	blk1, err := svc1.GetBlockByID("")
+	require.Nil(err)
+	blk2, err := svc2.GetBlockByID("")
+	require.Nil(err)
+	require.Equal(blk1, blk2)
+
+	transfers1, err := svc1.GetLastTransfersByRange(0, 0, 10, true)
+	require.Nil(err)
+	transfers2, err := svc2.GetLastTransfersByRange(0, 0, 10, true)
+	require.Nil(err)
+	require.Equal(transfers1, transfers2)
+
+	svc3 := NewMockExplorer(43)
+	blk3, err := svc3.GetBlockByID("")
+	require.Nil(err)
+	require.NotEqual(blk1.ID, blk3.ID)
+}
+
+func TestMockExplorerTransfersByRange(t *testing.T) {
+	require := require.New(t)
+
+	svc := NewMockExplorer(1)
+
+	transfers, err := svc.GetLastTransfersByRange(0, 0, 100, false)
+	require.Nil(err)
+	require.Equal(100, len(transfers))
+	for _, transfer := range transfers {
+		require.False(transfer.IsCoinbase)
+	}
+
+	transfers, err = svc.GetLastTransfersByRange(0, 0, 100, true)
+	require.Nil(err)
+	require.Equal(100, len(transfers))
+	hasCoinbase := false
+	for i, transfer := range transfers {
+		require.Equal(int64(i), transfer.Nonce)
+		hasCoinbase = hasCoinbase || transfer.IsCoinbase
+	}
+	require.True(hasCoinbase)
+
+	transfers, err = svc.GetLastTransfersByRange(0, 20, 10, true)
+	require.Nil(err)
+	require.Equal(int64(20), transfers[0].Nonce)
+	require.Equal(int64(29), transfers[9].Nonce)
+}
+
+func TestMockExplorerRandTotalCount(t *testing.T) {
+	require := require.New(t)
+
+	svc := NewMockExplorer(1)
+	for i := 0; i < 100; i++ {
+		totalCount := svc.randTotalCount(20, 10)
+		require.True(totalCount >= 30 && totalCount <= 40)
+	}
+	require.Equal(int64(0), svc.randTotalCount(-1, -1))
+	require.Equal(int64(math.MaxInt64), svc.randTotalCount(0, math.MaxInt64))
+	require.Equal(int64(math.MaxInt64), svc.randTotalCount(math.MaxInt64, 1))
+	require.Equal(int64(math.MaxInt64), svc.randTotalCount(math.MaxInt64-5, 5))
+	totalCount := svc.randTotalCount(math.MaxInt64-15, 10)
+	require.True(totalCount >= math.MaxInt64-5)
+}
+
+func TestMockExplorerTransferProof(t *testing.T) {
+	require := require.New(t)
+
+	svc := NewMockExplorer(1)
+	transferID := svc.randHash()
+	proof, err := svc.GetTransferProof(transferID)
+	require.Nil(err)
+	require.Equal(transferID, proof.TransferID)
+	requireValidMerkleProof(require, proof)
+
+	_, err = svc.GetTransferProof("invalid")
+	require.Error(err)
+}
+
+// requireValidMerkleProof checks that the proof's branch proves the transfer against the tx root, and the block ID is
+// the hash of the block header
+func requireValidMerkleProof(require *require.Assertions, proof explorer.MerkleProof) {
+	decodeHash := func(s string) hash.Hash32B {
+		b, err := hex.DecodeString(s)
+		require.Nil(err)
+		require.Len(b, len(hash.ZeroHash32B))
+		var h hash.Hash32B
+		copy(h[:], b)
+		return h
+	}
+	header, err := hex.DecodeString(proof.BlockHeader)
+	require.Nil(err)
+	require.Equal(decodeHash(proof.BlockID), hash.Hash32B(blake2b.Sum256(header)))
+	branch := make([]hash.Hash32B, 0, len(proof.Branch))
+	for _, h := range proof.Branch {
+		branch = append(branch, decodeHash(h))
+	}
+	require.True(crypto.VerifyMerkleProof(
+		decodeHash(proof.TransferID),
+		int(proof.Index),
+		branch,
+		decodeHash(proof.TxRoot),
+	))
+	decodeHash(proof.StateRoot)
+}
+
+func TestMockExplorerEpochMeta(t *testing.T) {
+	require := require.New(t)
+
+	svc := NewMockExplorer(1)
+	meta, err := svc.GetEpochMeta(2)
+	require.Nil(err)
+	require.Equal(int64(2), meta.EpochNum)
+	require.True(meta.StartHeight <= meta.EndHeight)
+	produced := int64(0)
+	for _, d := range meta.Delegates {
+		produced += d.ProducedBlockCount
+	}
+	require.True(produced <= meta.EndHeight-meta.StartHeight+1)
+
+	_, err = svc.GetEpochMeta(0)
+	require.Error(err)
+}
+
+func TestMockExplorerProducerStats(t *testing.T) {
+	require := require.New(t)
+
+	addr := ta.Addrinfo["producer"].RawAddress
+	svc := NewMockExplorer(1)
+	for i := 0; i < 10; i++ {
+		stats, err := svc.GetProducerStats(addr)
+		require.Nil(err)
+		require.Equal(addr, stats.Address)
+		require.True(stats.ProducedBlocks >= 0)
+		require.Equal(stats.ExpectedBlocks, stats.ProducedBlocks+stats.MissedBlocks)
+	}
+}
+
+func TestMockExplorerGenesisHash(t *testing.T) {
+	require := require.New(t)
+
+	svc := NewMockExplorer(1)
+	h, err := svc.GetGenesisHash()
+	require.Nil(err)
+	b, err := hex.DecodeString(h)
+	require.Nil(err)
+	require.Equal(hash.HashSize, len(b))
+}
+
+func TestMockExplorerLogsByBlockRange(t *testing.T) {
+	require := require.New(t)
+
+	contract := ta.Addrinfo["alfa"].RawAddress
+	svc := NewMockExplorer(1)
+	topic := svc.randHash()
+	for i := 0; i < 10; i++ {
+		logs, err := svc.GetLogsByBlockRange(5, 10, contract, []string{"", topic})
+		require.Nil(err)
+		for _, log := range logs {
+			require.Equal(contract, log.Address)
+			require.True(len(log.Topics) >= 2)
+			require.Equal(topic, log.Topics[1])
+			require.True(log.BlockNumber >= 5 && log.BlockNumber <= 10)
+		}
+	}
+	_, err := svc.GetLogsByBlockRange(10, 5, "", nil)
+	require.Error(err)
+	_, err = svc.GetLogsByBlockRange(5, 10, "io1contract", nil)
+	require.Equal(ErrInvalidAddress, errors.Cause(err))
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+)
+
+// MaxMulticallRequests is the max number of requests in one multicall
+const MaxMulticallRequests = 100
+
+var explorerType = reflect.TypeOf((*explorer.Explorer)(nil)).Elem()
+
+// multicall calls the read methods of the explorer in order. The error of a request is returned in its response
+// without failing the other requests.
+func multicall(exp explorer.Explorer, requests []explorer.Request) ([]explorer.Response, error) {
+	if len(requests) > MaxMulticallRequests {
+		return nil, errors.Errorf("%d requests exceed the multicall limit %d", len(requests), MaxMulticallRequests)
+	}
+	responses := make([]explorer.Response, len(requests))
+	for i, request := range requests {
+		result, err := callMethod(exp, request)
+		if err != nil {
+			responses[i].Error = err.Error()
+			continue
+		}
+		responses[i].Result = result
+	}
+	return responses, nil
+}
+
+// callMethod calls the read method named as in the IDL, e.g., getBlockByID, with the params in a JSON array, and
+// returns the JSON encoded result
+func callMethod(exp explorer.Explorer, request explorer.Request) (string, error) {
+	name := request.Method
+	if name == "" || name == "multicall" || writeMethods["Explorer."+name] || adminMethods["Explorer."+name] {
+		return "", errors.Errorf("method %s cannot be multicalled", name)
+	}
+	method, ok := explorerType.MethodByName(strings.ToUpper(name[:1]) + name[1:])
+	if !ok {
+		return "", errors.Errorf("unknown method %s", name)
+	}
+	var params []json.RawMessage
+	if request.Params != "" {
+		if err := json.Unmarshal([]byte(request.Params), &params); err != nil {
+			return "", errors.Wrapf(err, "params of method %s are not a JSON array", name)
+		}
+	}
+	if len(params) != method.Type.NumIn() {
+		return "", errors.Errorf("method %s expects %d params but gets %d", name, method.Type.NumIn(), len(params))
+	}
+	args := make([]reflect.Value, len(params))
+	for i, param := range params {
+		arg := reflect.New(method.Type.In(i))
+		if err := json.Unmarshal(param, arg.Interface()); err != nil {
+			return "", errors.Wrapf(err, "invalid param %d of method %s", i, name)
+		}
+		args[i] = arg.Elem()
+	}
+	// every method of the explorer returns a result and an error
+	out := reflect.ValueOf(exp).MethodByName(method.Name).Call(args)
+	if err, _ := out[1].Interface().(error); err != nil {
+		return "", err
+	}
+	result, err := json.Marshal(out[0].Interface())
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal the result of method %s", name)
+	}
+	return string(result), nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+)
+
+func TestMulticall(t *testing.T) {
+	require := require.New(t)
+
+	svc1 := NewMockExplorer(42)
+	svc2 := NewMockExplorer(42)
+
+	blk, err := svc1.GetBlockByID("")
+	require.Nil(err)
+	transfers, err := svc1.GetLastTransfersByRange(0, 0, 10, true)
+	require.Nil(err)
+
+	responses, err := svc2.Multicall([]explorer.Request{
+		{Method: "getBlockByID", Params: `[""]`},
+		{Method: "getLastTransfersByRange", Params: `[0, 0, 10, true]`},
+		{Method: "getBlockchainHeight"},
+		{Method: "sendAction", Params: `["00"]`},
+		{Method: "multicall", Params: `[[]]`},
+		{Method: "getNothing"},
+		{Method: "getBlockByID"},
+		{Method: "getBlockByID", Params: `[1]`},
+		{Method: "getBlockByID", Params: `""`},
+	})
+	require.Nil(err)
+	require.Equal(9, len(responses))
+
+	var mBlk explorer.Block
+	require.Equal("", responses[0].Error)
+	require.Nil(json.Unmarshal([]byte(responses[0].Result), &mBlk))
+	require.Equal(blk, mBlk)
+	var mTransfers []explorer.Transfer
+	require.Equal("", responses[1].Error)
+	require.Nil(json.Unmarshal([]byte(responses[1].Result), &mTransfers))
+	require.Equal(transfers, mTransfers)
+	var height int64
+	require.Equal("", responses[2].Error)
+	require.Nil(json.Unmarshal([]byte(responses[2].Result), &height))
+	// write methods, unknown methods and invalid params are rejected
+	for _, response := range responses[3:] {
+		require.Equal("", response.Result)
+		require.NotEqual("", response.Error)
+	}
+
+	_, err = svc2.Multicall(make([]explorer.Request, MaxMulticallRequests+1))
+	require.Error(err)
+}
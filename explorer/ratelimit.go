@@ -0,0 +1,179 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coopernurse/barrister-go"
+	"github.com/facebookgo/clock"
+
+	"github.com/iotexproject/iotex-core/logger"
+)
+
+// tooManyRequestsCode is the JSON-RPC error code of the requests rejected by rateLimitFilter, which is the equivalent
+// of HTTP 429 Too Many Requests
+const tooManyRequestsCode = -32029
+
+// remoteIPHeader carries the IP of the client to the filters, because barrister only passes the HTTP headers to them
+const remoteIPHeader = "X-Explorer-Remote-IP"
+
+// multicallMethod is the method running a batch of read requests
+const multicallMethod = "Explorer.multicall"
+
+// sweepInterval is how often the idle buckets are removed from a rate limiter
+const sweepInterval = time.Minute
+
+// withRemoteIP sets the remote IP header of the requests from the connection's remote address. The value sent by the
+// client is overwritten, so that it cannot be spoofed.
+func withRemoteIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ip, _, err := net.SplitHostPort(req.RemoteAddr)
+		if err != nil {
+			ip = req.RemoteAddr
+		}
+		req.Header.Set(remoteIPHeader, ip)
+		next.ServeHTTP(w, req)
+	})
+}
+
+// tokenBucket is the bucket of an IP, which is refilled continuously and drained by the tokens the requests take
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter limits the requests of each IP by a token bucket. It is safe for concurrent access.
+type rateLimiter struct {
+	rate      float64
+	burst     float64
+	clk       clock.Clock
+	mutex     sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// newRateLimiter creates a rate limiter allowing rate requests per second and bursts of burst requests per IP. It
+// returns nil, i.e., no limit, if rate is not positive. The burst is at least 1.
+func newRateLimiter(rate float64, burst int, clk clock.Clock) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:      rate,
+		burst:     float64(burst),
+		clk:       clk,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: clk.Now(),
+	}
+}
+
+// allow takes a token from the bucket of the IP, and returns false if the bucket is empty
+func (l *rateLimiter) allow(ip string) bool {
+	return l.allowN(ip, 1)
+}
+
+// allowN takes n tokens from the bucket of the IP, and returns false if the bucket has fewer tokens
+func (l *rateLimiter) allowN(ip string, n int) bool {
+	if l == nil {
+		return true
+	}
+	now := l.clk.Now()
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+	bucket, ok := l.buckets[ip]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[ip] = bucket
+	}
+	l.refill(bucket, now)
+	if bucket.tokens < float64(n) {
+		return false
+	}
+	bucket.tokens -= float64(n)
+	return true
+}
+
+func (l *rateLimiter) refill(bucket *tokenBucket, now time.Time) {
+	if elapsed := now.Sub(bucket.last).Seconds(); elapsed > 0 {
+		bucket.tokens += elapsed * l.rate
+		if bucket.tokens > l.burst {
+			bucket.tokens = l.burst
+		}
+	}
+	bucket.last = now
+}
+
+// sweep removes the buckets which have been refilled to full, as they are the same as new ones. It keeps the memory
+// bounded when the requests come from many IPs.
+func (l *rateLimiter) sweep(now time.Time) {
+	for ip, bucket := range l.buckets {
+		l.refill(bucket, now)
+		if bucket.tokens >= l.burst {
+			delete(l.buckets, ip)
+		}
+	}
+	l.lastSweep = now
+}
+
+// rateLimitFilter rejects the requests of an IP exceeding the rate limit. The read and write methods are limited
+// separately.
+type rateLimitFilter struct {
+	read  *rateLimiter
+	write *rateLimiter
+}
+
+// PreInvoke checks the rate limit of the request's IP
+func (f rateLimitFilter) PreInvoke(r *barrister.RequestResponse) bool {
+	limiter := f.read
+	if writeMethods[r.Method] {
+		limiter = f.write
+	}
+	ip := http.Header(r.Headers.Request).Get(remoteIPHeader)
+	if limiter.allowN(ip, requestCost(r)) {
+		return true
+	}
+	logger.Warn().Str("method", r.Method).Str("ip", ip).Msg("Rejecting explorer request exceeding the rate limit")
+	r.Err = &barrister.JsonRpcError{Code: tooManyRequestsCode, Message: "too many requests"}
+	return false
+}
+
+// requestCost returns the number of tokens the request takes, which is the number of requests for a multicall, as it
+// runs each of them, and one otherwise
+func requestCost(r *barrister.RequestResponse) int {
+	if r.Method != multicallMethod {
+		return 1
+	}
+	params, err := json.Marshal(r.Params)
+	if err != nil {
+		return 1
+	}
+	var args []json.RawMessage
+	if err := json.Unmarshal(params, &args); err != nil || len(args) != 1 {
+		return 1
+	}
+	var requests []json.RawMessage
+	if err := json.Unmarshal(args[0], &requests); err != nil || len(requests) < 1 {
+		return 1
+	}
+	return len(requests)
+}
+
+// PostInvoke does nothing
+func (f rateLimitFilter) PostInvoke(r *barrister.RequestResponse) bool {
+	return true
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coopernurse/barrister-go"
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+)
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(newRateLimiter(0, 10, clock.New()))
+	var noLimit *rateLimiter
+	require.True(noLimit.allow("1.1.1.1"))
+
+	clk := clock.NewMock()
+	l := newRateLimiter(2, 3, clk)
+	for i := 0; i < 3; i++ {
+		require.True(l.allow("1.1.1.1"))
+	}
+	require.False(l.allow("1.1.1.1"))
+	// Other IPs have their own buckets
+	require.True(l.allow("2.2.2.2"))
+
+	// The bucket is refilled by 2 tokens per second
+	clk.Add(500 * time.Millisecond)
+	require.True(l.allow("1.1.1.1"))
+	require.False(l.allow("1.1.1.1"))
+	clk.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		require.True(l.allow("1.1.1.1"))
+	}
+	require.False(l.allow("1.1.1.1"))
+
+	// The idle buckets are swept
+	require.Len(l.buckets, 1)
+	require.Contains(l.buckets, "1.1.1.1")
+	clk.Add(sweepInterval)
+	require.True(l.allow("3.3.3.3"))
+	require.Len(l.buckets, 1)
+	require.Contains(l.buckets, "3.3.3.3")
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	l := newRateLimiter(1, 100, clock.NewMock())
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if l.allow("1.1.1.1") {
+					mutex.Lock()
+					allowed++
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 100, allowed)
+}
+
+func TestRateLimitFilter(t *testing.T) {
+	require := require.New(t)
+	clk := clock.NewMock()
+	f := rateLimitFilter{
+		read:  newRateLimiter(1, 2, clk),
+		write: newRateLimiter(1, 1, clk),
+	}
+	request := func(method string, ip string) *barrister.RequestResponse {
+		headers := map[string][]string{http.CanonicalHeaderKey(remoteIPHeader): {ip}}
+		return &barrister.RequestResponse{Method: method, Headers: barrister.Headers{Request: headers}}
+	}
+
+	require.True(f.PreInvoke(request("Explorer.getBlockchainHeight", "1.1.1.1")))
+	require.True(f.PreInvoke(request("Explorer.getBlockchainHeight", "1.1.1.1")))
+	r := request("Explorer.getBlockchainHeight", "1.1.1.1")
+	require.False(f.PreInvoke(r))
+	rpcErr, ok := r.Err.(*barrister.JsonRpcError)
+	require.True(ok)
+	require.Equal(tooManyRequestsCode, rpcErr.Code)
+
+	// Writes are limited independently of reads
+	require.True(f.PreInvoke(request("Explorer.sendTransfer", "1.1.1.1")))
+	require.False(f.PreInvoke(request("Explorer.sendTransfer", "1.1.1.1")))
+
+	// Writes are unlimited if the write rate is not set
+	f.write = newRateLimiter(0, 0, clk)
+	for i := 0; i < 10; i++ {
+		require.True(f.PreInvoke(request("Explorer.sendTransfer", "1.1.1.1")))
+	}
+
+	// A multicall takes a token for each of its requests
+	f.read = newRateLimiter(1, 3, clk)
+	multicall := func(n int) *barrister.RequestResponse {
+		r := request(multicallMethod, "2.2.2.2")
+		requests := make([]explorer.Request, n)
+		for i := range requests {
+			requests[i] = explorer.Request{Method: "getBlockchainHeight"}
+		}
+		r.Params = []interface{}{requests}
+		return r
+	}
+	require.Equal(3, requestCost(multicall(3)))
+	require.Equal(1, requestCost(multicall(0)))
+	require.Equal(1, requestCost(request("Explorer.getBlockchainHeight", "2.2.2.2")))
+	require.True(f.PreInvoke(multicall(2)))
+	require.False(f.PreInvoke(multicall(2)))
+	require.True(f.PreInvoke(multicall(1)))
+	require.False(f.PreInvoke(request("Explorer.getBlockchainHeight", "2.2.2.2")))
+	clk.Add(3 * time.Second)
+	require.False(f.PreInvoke(multicall(4)))
+	require.True(f.PreInvoke(multicall(3)))
+}
+
+func TestWithRemoteIP(t *testing.T) {
+	var ip string
+	handler := withRemoteIP(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ip = req.Header.Get(remoteIPHeader)
+	}))
+	req := httptest.NewRequest("POST", "/", nil)
+	req.RemoteAddr = "1.2.3.4:5678"
+	// The header sent by the client is overwritten
+	req.Header.Set(remoteIPHeader, "5.6.7.8")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "1.2.3.4", ip)
+}
+
+func TestServerRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.Explorer
+	cfg.Port = 0
+	cfg.ReadRateLimit = 0.001
+	cfg.ReadRateBurst = 2
+	svr := NewTestSever(cfg)
+	require.NoError(svr.Start(context.Background()))
+	defer func() {
+		require.NoError(svr.Stop(context.Background()))
+	}()
+
+	proxy := NewExplorerProxy(fmt.Sprintf("http://127.0.0.1:%d", svr.Port()))
+	for i := 0; i < 2; i++ {
+		_, err := proxy.GetBlockchainHeight()
+		require.NoError(err)
+	}
+	_, err := proxy.GetBlockchainHeight()
+	require.Error(err)
+}
@@ -7,11 +7,17 @@
 package explorer
 
 import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/coopernurse/barrister-go"
+	"github.com/facebookgo/clock"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 
@@ -23,60 +29,117 @@ import (
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
+	"github.com/iotexproject/iotex-core/state"
 )
 
 // Server is the container of the explorer service
 type Server struct {
 	cfg     config.Explorer
-	exp     explorer.Explorer
-	jrpcSvr barrister.Server
+	exp     ContextExplorer
 	httpSvr http.Server
 	port    int
+	// certs is the TLS certificate to serve HTTPS, or nil to serve plain HTTP
+	certs *certReloader
+	// breaker guards the backend, or is nil if it is disabled
+	breaker *circuitBreaker
+	// maintenance tells whether the explorer is under maintenance
+	maintenance *maintenanceMode
+	done        chan struct{}
 }
 
 // NewServer instantiates an explorer server
 func NewServer(
 	cfg config.Explorer,
 	chain blockchain.Blockchain,
+	stateReader state.StateReader,
 	consensus consensus.Consensus,
 	dispatcher dispatcher.Dispatcher,
 	actPool actpool.ActPool,
 	p2p network.Overlay,
 ) *Server {
+	if cfg.StateCacheSize > 0 && stateReader != nil {
+		cache := newStateCache(stateReader, cfg.StateCacheSize)
+		if err := chain.AddSubscriber(cache); err != nil {
+			logger.Error().Err(err).Msg("failed to subscribe the state cache to the committed blocks")
+		} else {
+			stateReader = cache
+		}
+	}
+	breaker := newCircuitBreaker(cfg.BreakerFailureThreshold, cfg.BreakerCooldown, clock.New())
+	maintenance := &maintenanceMode{}
 	return &Server{
 		cfg: cfg,
 		exp: &Service{
-			bc:  chain,
-			c:   consensus,
-			dp:  dispatcher,
-			ap:  actPool,
-			p2p: p2p,
-			cfg: cfg,
+			bc:          chain,
+			sr:          stateReader,
+			c:           consensus,
+			dp:          dispatcher,
+			ap:          actPool,
+			p2p:         p2p,
+			cfg:         cfg,
+			breaker:     breaker,
+			maintenance: maintenance,
 		},
+		breaker:     breaker,
+		maintenance: maintenance,
 	}
 }
 
 // NewTestSever instantiates an explorer server with mock handler
 func NewTestSever(cfg config.Explorer) *Server {
+	maintenance := &maintenanceMode{}
+	exp := NewMockExplorer(time.Now().UnixNano())
+	exp.maintenance = maintenance
 	return &Server{
-		cfg: cfg,
-		exp: &MockExplorer{},
+		cfg:         cfg,
+		exp:         exp,
+		maintenance: maintenance,
 	}
 }
 
-// Start starts the explorer server
+// Start starts the explorer server. It serves HTTPS if the TLS certificate and key are configured.
 func (s *Server) Start(_ context.Context) error {
+	if s.cfg.TLSCertPath != "" && s.cfg.TLSKeyPath != "" {
+		certs, err := newCertReloader(s.cfg.TLSCertPath, s.cfg.TLSKeyPath)
+		if err != nil {
+			return err
+		}
+		s.certs = certs
+		s.done = make(chan struct{})
+		s.certs.reloadOnSIGHUP(s.done)
+	}
 	portStr := strconv.Itoa(s.cfg.Port)
 	started := make(chan bool)
 	go func(started chan bool) {
-		idl := barrister.MustParseIdlJson([]byte(explorer.IdlJsonRaw))
-		s.jrpcSvr = explorer.NewJSONServer(idl, true, s.exp)
-		s.jrpcSvr.AddFilter(logFilter{})
-		s.httpSvr = http.Server{Handler: &s.jrpcSvr}
+		filters := []barrister.Filter{logFilter{}, maintenanceFilter{mode: s.maintenance}}
+		if s.cfg.ReadRateLimit > 0 || s.cfg.WriteRateLimit > 0 {
+			filters = append(filters, rateLimitFilter{
+				read:  newRateLimiter(s.cfg.ReadRateLimit, s.cfg.ReadRateBurst, clock.New()),
+				write: newRateLimiter(s.cfg.WriteRateLimit, s.cfg.WriteRateBurst, clock.New()),
+			})
+		}
+		filters = append(filters, newAuthFilter(s.cfg.APIKeys))
+		if s.breaker != nil {
+			filters = append(filters, breakerFilter{breaker: s.breaker})
+		}
+		jrpcSvr := newContextServer(barrister.MustParseIdlJson([]byte(explorer.IdlJsonRaw)), s.exp, filters)
+		handler := withRemoteIP(jrpcSvr)
+		if s.breaker != nil {
+			handler = withDegradedHeader(handler, s.breaker)
+		}
+		// the explorer methods are called with the request's context, which is cancelled once the timeout elapses
+		if s.cfg.RequestTimeout > 0 {
+			handler = withRequestTimeout(handler, s.cfg.RequestTimeout)
+		}
+		handler = withCORS(handler, s.cfg.CORSAllowedOrigins, s.cfg.CORSAllowedMethods, s.cfg.CORSAllowedHeaders)
+		s.httpSvr = http.Server{Handler: handler}
 		listener, err := net.Listen("tcp", ":"+portStr)
 		if err != nil {
 			logger.Panic().Err(err).Msg("error when creating network listener")
 		}
+		if s.certs != nil {
+			listener = tls.NewListener(listener, &tls.Config{GetCertificate: s.certs.getCertificate})
+		}
 		logger.Info().Msgf("Starting Explorer JSON-RPC server on %s", listener.Addr().String())
 		_, port, err := net.SplitHostPort(listener.Addr().String())
 		if err != nil {
@@ -97,6 +160,10 @@ func (s *Server) Start(_ context.Context) error {
 
 // Stop stops the explorer server
 func (s *Server) Stop(ctx context.Context) error {
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
+	}
 	if err := s.httpSvr.Shutdown(ctx); err != nil {
 		return errors.Wrap(err, "error when shutting down explorer http server")
 	}
@@ -108,8 +175,123 @@ func (s *Server) Port() int {
 	return s.port
 }
 
-// Explorer returns explorer interface.
-func (s *Server) Explorer() explorer.Explorer { return s.exp }
+// SetMaintenance turns the maintenance mode on or off. While it's on, all the methods but getExplorerStatus reply with
+// ErrMaintenance, with the message as its data, and getExplorerStatus reports the maintenance and the message.
+func (s *Server) SetMaintenance(on bool, message string) {
+	s.maintenance.set(on, message)
+	logger.Info().Bool("on", on).Str("message", message).Msg("Set explorer maintenance mode")
+}
+
+// SetConfigDumper sets the function returning the JSON of the server config, which is served by the getServerConfig
+// admin method. It should be called before the server is started, and does nothing if the server is nil, i.e., the
+// explorer is disabled.
+func (s *Server) SetConfigDumper(dumper func() ([]byte, error)) {
+	if s == nil {
+		return
+	}
+	if svc, ok := s.exp.(*Service); ok {
+		svc.configDumper = dumper
+	}
+}
+
+// Explorer returns explorer interface. It returns nil if the server is nil, i.e., the explorer is disabled.
+func (s *Server) Explorer() explorer.Explorer {
+	if s == nil {
+		return nil
+	}
+	return s.exp
+}
+
+// maxIdleServers is the max number of idle barrister servers kept by contextServer for the following requests
+const maxIdleServers = 64
+
+// contextServer serves the JSON-RPC requests by barrister servers calling the explorer methods with the request's
+// context. As barrister invokes the explorer methods without a context, every barrister server calls the explorer via
+// a contextBoundExplorer, which is bound to the context of the request being served. A barrister server serves one
+// request at a time, and is kept for the following requests once idle, so it is built only if all are busy
+type contextServer struct {
+	idl     *barrister.Idl
+	exp     ContextExplorer
+	filters []barrister.Filter
+	idle    chan *boundServer
+}
+
+// boundServer is a barrister server calling the explorer bound to the context of the request being served
+type boundServer struct {
+	jrpcSvr barrister.Server
+	exp     *contextBoundExplorer
+}
+
+func newContextServer(idl *barrister.Idl, exp ContextExplorer, filters []barrister.Filter) *contextServer {
+	return &contextServer{
+		idl:     idl,
+		exp:     exp,
+		filters: filters,
+		idle:    make(chan *boundServer, maxIdleServers),
+	}
+}
+
+func (s *contextServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var svr *boundServer
+	select {
+	case svr = <-s.idle:
+	default:
+		svr = &boundServer{exp: &contextBoundExplorer{exp: s.exp}}
+		svr.jrpcSvr = explorer.NewJSONServer(s.idl, true, svr.exp)
+		for _, filter := range s.filters {
+			svr.jrpcSvr.AddFilter(filter)
+		}
+	}
+	svr.exp.ctx = req.Context()
+	svr.jrpcSvr.ServeHTTP(w, req)
+	// the idle server doesn't keep the context of the request
+	svr.exp.ctx = nil
+	select {
+	case s.idle <- svr:
+	default:
+	}
+}
+
+// withRequestTimeout replies 503 Service Unavailable to the requests not served within the timeout, and cancels their
+// context. The requests calling a write method are served without the timeout, since the submission of an action
+// can't be cancelled, and a client getting the timeout would retry and submit the action twice
+func withRequestTimeout(next http.Handler, timeout time.Duration) http.Handler {
+	timeoutHandler := http.TimeoutHandler(next, timeout, "explorer request timed out")
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, "failed to read the request", http.StatusBadRequest)
+			return
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if callsWriteMethod(body) {
+			next.ServeHTTP(w, req)
+			return
+		}
+		timeoutHandler.ServeHTTP(w, req)
+	})
+}
+
+// callsWriteMethod tells whether the JSON-RPC request, or any request in the batch, calls a write method
+func callsWriteMethod(body []byte) bool {
+	type request struct {
+		Method string `json:"method"`
+	}
+	var batch []request
+	if err := json.Unmarshal(body, &batch); err != nil {
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			return false
+		}
+		batch = []request{req}
+	}
+	for _, req := range batch {
+		if writeMethods[req.Method] {
+			return true
+		}
+	}
+	return false
+}
 
 // logFilter example of Filter implementation
 type logFilter struct{}
@@ -125,3 +307,55 @@ func (f logFilter) PostInvoke(r *barrister.RequestResponse) bool {
 	logger.Debug().Msgf("logFilter: PostInvoke of method: %s", r.Method)
 	return true
 }
+
+// APIKeyHeader is the HTTP header carrying the API key of a request
+const APIKeyHeader = "X-API-Key"
+
+// unauthorizedCode is the JSON-RPC error code of the requests rejected by authFilter
+const unauthorizedCode = -32001
+
+// writeMethods are the methods submitting actions to the blockchain
+var writeMethods = map[string]bool{
+	"Explorer.sendTransfer":      true,
+	"Explorer.sendVote":          true,
+	"Explorer.sendSmartContract": true,
+	"Explorer.sendAction":        true,
+}
+
+// adminMethods are the methods exposing the internals of the server, which require an API key even if there is no API
+// key configured, i.e., they are closed then
+var adminMethods = map[string]bool{
+	"Explorer.getServerConfig": true,
+}
+
+// authFilter rejects the requests of the admin methods, and the write methods if any API key is configured, without a
+// valid API key, while keeping the read methods open
+type authFilter struct {
+	keys map[string]bool
+}
+
+func newAuthFilter(keys []string) authFilter {
+	f := authFilter{keys: make(map[string]bool, len(keys))}
+	for _, key := range keys {
+		if key != "" {
+			f.keys[key] = true
+		}
+	}
+	return f
+}
+
+// PreInvoke checks the API key of the admin and write methods
+func (f authFilter) PreInvoke(r *barrister.RequestResponse) bool {
+	guarded := adminMethods[r.Method] || writeMethods[r.Method] && len(f.keys) > 0
+	if !guarded || f.keys[http.Header(r.Headers.Request).Get(APIKeyHeader)] {
+		return true
+	}
+	logger.Warn().Str("method", r.Method).Msg("Rejecting explorer request without a valid API key")
+	r.Err = &barrister.JsonRpcError{Code: unauthorizedCode, Message: "unauthorized: missing or invalid API key"}
+	return false
+}
+
+// PostInvoke does nothing
+func (f authFilter) PostInvoke(r *barrister.RequestResponse) bool {
+	return true
+}
@@ -7,14 +7,29 @@
 package explorer
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
 	"net/http"
+	"os"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/coopernurse/barrister-go"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/testutil"
 )
 
 func TestServer(t *testing.T) {
@@ -33,3 +48,170 @@ func TestServer(t *testing.T) {
 		require.Equal("200 OK", resp.Status)
 	}
 }
+
+type slowExplorer struct {
+	*MockExplorer
+	delay     time.Duration
+	sendDelay time.Duration
+	cancelled chan error
+}
+
+func (exp *slowExplorer) GetBlockchainHeightCtx(ctx context.Context) (int64, error) {
+	select {
+	case <-time.After(exp.delay):
+		return exp.MockExplorer.GetBlockchainHeightCtx(ctx)
+	case <-ctx.Done():
+		exp.cancelled <- ctx.Err()
+		return 0, ctx.Err()
+	}
+}
+
+// SendActionCtx ignores the cancellation of ctx like a submission does
+func (exp *slowExplorer) SendActionCtx(ctx context.Context, rawAction string) (string, error) {
+	time.Sleep(exp.sendDelay)
+	return exp.MockExplorer.SendAction(rawAction)
+}
+
+func TestServerRequestTimeout(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.Explorer
+	cfg.Port = 0
+	cfg.RequestTimeout = 100 * time.Millisecond
+	exp := &slowExplorer{
+		MockExplorer: NewMockExplorer(0),
+		delay:        time.Second,
+		sendDelay:    300 * time.Millisecond,
+		cancelled:    make(chan error, 1),
+	}
+	svr := &Server{
+		cfg: cfg,
+		exp: exp,
+	}
+	require.NoError(svr.Start(context.Background()))
+	defer func() {
+		require.NoError(svr.Stop(context.Background()))
+	}()
+
+	proxy := NewExplorerProxy(fmt.Sprintf("http://127.0.0.1:%d", svr.Port()))
+	_, err := proxy.GetBlockchainHeight()
+	require.Error(err)
+	// the explorer method is called with the request's context, which is cancelled by the timeout
+	select {
+	case err := <-exp.cancelled:
+		require.Equal(context.DeadlineExceeded, err)
+	case <-time.After(exp.delay):
+		require.Fail("the context of the request isn't cancelled")
+	}
+
+	_, err = proxy.GetCoinStatistic()
+	require.NoError(err)
+
+	// a write request isn't timed out, so that its real result is returned
+	hash, err := proxy.SendAction("")
+	require.NoError(err)
+	require.NotEmpty(hash)
+}
+
+func TestCallsWriteMethod(t *testing.T) {
+	require := require.New(t)
+
+	require.True(callsWriteMethod([]byte(`{"jsonrpc":"2.0","method":"Explorer.sendAction","params":[""],"id":"1"}`)))
+	require.True(callsWriteMethod([]byte(`[{"method":"Explorer.getBlockchainHeight"},{"method":"Explorer.sendVote"}]`)))
+	require.False(callsWriteMethod([]byte(`{"method":"Explorer.getBlockchainHeight"}`)))
+	require.False(callsWriteMethod([]byte(`[{"method":"Explorer.getBlockchainHeight"}]`)))
+	require.False(callsWriteMethod([]byte(`not json`)))
+}
+
+func TestNilServerExplorer(t *testing.T) {
+	var svr *Server
+	require.Nil(t, svr.Explorer())
+}
+
+func TestServerTLS(t *testing.T) {
+	require := require.New(t)
+	certPath := "explorer.cert.test"
+	keyPath := "explorer.key.test"
+	testutil.CleanupPath(t, certPath)
+	testutil.CleanupPath(t, keyPath)
+	defer testutil.CleanupPath(t, certPath)
+	defer testutil.CleanupPath(t, keyPath)
+	writeTestCert(t, certPath, keyPath, "cert1")
+
+	cfg := config.Default.Explorer
+	cfg.Port = 0
+	cfg.TLSCertPath = certPath
+	cfg.TLSKeyPath = keyPath
+	svr := NewTestSever(cfg)
+	require.NoError(svr.Start(context.Background()))
+	defer func() {
+		require.NoError(svr.Stop(context.Background()))
+	}()
+
+	addr := fmt.Sprintf("127.0.0.1:%d", svr.Port())
+	servedCN := func() string {
+		conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true})
+		require.NoError(err)
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+	}
+	require.Equal("cert1", servedCN())
+
+	// The certificate is rotated on SIGHUP
+	writeTestCert(t, certPath, keyPath, "cert2")
+	require.NoError(syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return servedCN() == "cert2", nil
+	}))
+}
+
+func writeTestCert(t *testing.T, certPath string, keyPath string, commonName string) {
+	require := require.New(t)
+	sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	tmpl := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &sk.PublicKey, sk)
+	require.NoError(err)
+	skDer, err := x509.MarshalECPrivateKey(sk)
+	require.NoError(err)
+	require.NoError(ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: skDer}), 0600))
+}
+
+func TestAuthFilter(t *testing.T) {
+	require := require.New(t)
+	f := newAuthFilter([]string{"key1", ""})
+	request := func(method string, key string) *barrister.RequestResponse {
+		headers := make(map[string][]string)
+		if key != "" {
+			headers[http.CanonicalHeaderKey(APIKeyHeader)] = []string{key}
+		}
+		return &barrister.RequestResponse{Method: method, Headers: barrister.Headers{Request: headers}}
+	}
+
+	// Read methods are open
+	require.True(f.PreInvoke(request("Explorer.getBlockchainHeight", "")))
+	// Write methods require a valid API key
+	require.True(f.PreInvoke(request("Explorer.sendTransfer", "key1")))
+	for _, key := range []string{"", "key2"} {
+		r := request("Explorer.sendTransfer", key)
+		require.False(f.PreInvoke(r))
+		rpcErr, ok := r.Err.(*barrister.JsonRpcError)
+		require.True(ok)
+		require.Equal(unauthorizedCode, rpcErr.Code)
+	}
+	// Admin methods require a valid API key
+	require.True(f.PreInvoke(request("Explorer.getServerConfig", "key1")))
+	require.False(f.PreInvoke(request("Explorer.getServerConfig", "key2")))
+
+	// Without any API key, write methods are open while admin methods are closed
+	f = newAuthFilter(nil)
+	require.True(f.PreInvoke(request("Explorer.sendTransfer", "")))
+	require.False(f.PreInvoke(request("Explorer.getServerConfig", "")))
+	require.False(f.PreInvoke(request("Explorer.getServerConfig", "key1")))
+}
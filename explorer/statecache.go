@@ -0,0 +1,122 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"container/list"
+	"math/big"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+var stateCacheMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_explorer_state_cache",
+		Help: "Lookups of the explorer's account state cache.",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	prometheus.MustRegister(stateCacheMtc)
+}
+
+// stateCache is a read-through LRU cache of the account states in front of a state reader. Any block could change the
+// states, so the cache is flushed whenever a block is committed, including the blocks replacing the old ones in a
+// reorg. The cached states are shared by the callers, which must not modify them.
+type stateCache struct {
+	state.StateReader
+
+	mutex   sync.Mutex
+	size    int
+	entries *list.List
+	index   map[string]*list.Element
+	// generation is increased on every flush, so that a state read before the flush is not cached after it
+	generation uint64
+}
+
+type stateCacheEntry struct {
+	addr  string
+	state *state.State
+}
+
+func newStateCache(sr state.StateReader, size int) *stateCache {
+	return &stateCache{
+		StateReader: sr,
+		size:        size,
+		entries:     list.New(),
+		index:       make(map[string]*list.Element),
+	}
+}
+
+// State returns the cached state of the address, or reads it from the underlying state reader and caches it
+func (c *stateCache) State(addr string) (*state.State, error) {
+	c.mutex.Lock()
+	if e, ok := c.index[addr]; ok {
+		c.entries.MoveToFront(e)
+		c.mutex.Unlock()
+		stateCacheMtc.WithLabelValues("hit").Inc()
+		return e.Value.(*stateCacheEntry).state, nil
+	}
+	generation := c.generation
+	c.mutex.Unlock()
+	stateCacheMtc.WithLabelValues("miss").Inc()
+
+	s, err := c.StateReader.State(addr)
+	if err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if generation != c.generation {
+		// a block has been committed meanwhile, so the state could be stale
+		return s, nil
+	}
+	if e, ok := c.index[addr]; ok {
+		c.entries.MoveToFront(e)
+		return s, nil
+	}
+	c.index[addr] = c.entries.PushFront(&stateCacheEntry{addr: addr, state: s})
+	if c.entries.Len() > c.size {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.index, oldest.Value.(*stateCacheEntry).addr)
+	}
+	return s, nil
+}
+
+// Balance returns the balance in the cached state of the address
+func (c *stateCache) Balance(addr string) (*big.Int, error) {
+	s, err := c.State(addr)
+	if err != nil {
+		return nil, err
+	}
+	return s.Balance, nil
+}
+
+// Nonce returns the nonce in the cached state of the address
+func (c *stateCache) Nonce(addr string) (uint64, error) {
+	s, err := c.State(addr)
+	if err != nil {
+		return 0, err
+	}
+	return s.Nonce, nil
+}
+
+// HandleBlock flushes the cache when a block is committed
+func (c *stateCache) HandleBlock(_ *blockchain.Block) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries.Init()
+	c.index = make(map[string]*list.Element)
+	c.generation++
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/mock/mock_state"
+)
+
+func TestStateCache(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sr := mock_state.NewMockStateReader(ctrl)
+	cache := newStateCache(sr, 2)
+
+	// the states are read from the state reader only once until the cache is flushed
+	sr.EXPECT().State("io1a").Return(&state.State{Nonce: 1, Balance: big.NewInt(10)}, nil).Times(1)
+	balance, err := cache.Balance("io1a")
+	require.NoError(err)
+	require.Equal(big.NewInt(10), balance)
+	nonce, err := cache.Nonce("io1a")
+	require.NoError(err)
+	require.Equal(uint64(1), nonce)
+
+	require.NoError(cache.HandleBlock(nil))
+	sr.EXPECT().State("io1a").Return(&state.State{Nonce: 2, Balance: big.NewInt(5)}, nil).Times(1)
+	balance, err = cache.Balance("io1a")
+	require.NoError(err)
+	require.Equal(big.NewInt(5), balance)
+	nonce, err = cache.Nonce("io1a")
+	require.NoError(err)
+	require.Equal(uint64(2), nonce)
+
+	// the least recently used state is evicted
+	sr.EXPECT().State("io1b").Return(&state.State{Balance: big.NewInt(20)}, nil).Times(1)
+	sr.EXPECT().State("io1c").Return(&state.State{Balance: big.NewInt(30)}, nil).Times(1)
+	_, err = cache.State("io1b")
+	require.NoError(err)
+	_, err = cache.State("io1a")
+	require.NoError(err)
+	_, err = cache.State("io1c")
+	require.NoError(err)
+	_, err = cache.State("io1a")
+	require.NoError(err)
+	sr.EXPECT().State("io1b").Return(&state.State{Balance: big.NewInt(20)}, nil).Times(1)
+	_, err = cache.State("io1b")
+	require.NoError(err)
+
+	// the errors are not cached
+	sr.EXPECT().State("io1d").Return(nil, state.ErrAccountNotExist).Times(2)
+	_, err = cache.Balance("io1d")
+	require.Equal(state.ErrAccountNotExist, errors.Cause(err))
+	_, err = cache.Nonce("io1d")
+	require.Equal(state.ErrAccountNotExist, errors.Cause(err))
+}
+
+func TestStateCacheFlushedDuringRead(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sr := mock_state.NewMockStateReader(ctrl)
+	cache := newStateCache(sr, 2)
+
+	// a block is committed while reading the state, which could be stale, so it is not cached
+	sr.EXPECT().State("io1a").DoAndReturn(func(string) (*state.State, error) {
+		require.NoError(cache.HandleBlock(nil))
+		return &state.State{Balance: big.NewInt(10)}, nil
+	}).Times(1)
+	balance, err := cache.Balance("io1a")
+	require.NoError(err)
+	require.Equal(big.NewInt(10), balance)
+
+	sr.EXPECT().State("io1a").Return(&state.State{Balance: big.NewInt(5)}, nil).Times(1)
+	balance, err = cache.Balance("io1a")
+	require.NoError(err)
+	require.Equal(big.NewInt(5), balance)
+	balance, err = cache.Balance("io1a")
+	require.NoError(err)
+	require.Equal(big.NewInt(5), balance)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"crypto/tls"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/logger"
+)
+
+// certReloader holds the TLS certificate of the explorer server, which could be reloaded from the cert and key files
+// without restarting the server
+type certReloader struct {
+	certPath string
+	keyPath  string
+	mutex    sync.RWMutex
+	cert     *tls.Certificate
+}
+
+func newCertReloader(certPath string, keyPath string) (*certReloader, error) {
+	r := &certReloader{certPath: certPath, keyPath: keyPath}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload loads the certificate from the cert and key files. The current certificate is kept if it fails.
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		return errors.Wrapf(err, "error when loading TLS certificate %s and key %s", r.certPath, r.keyPath)
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cert = &cert
+	return nil
+}
+
+// getCertificate implements tls.Config.GetCertificate
+func (r *certReloader) getCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.cert, nil
+}
+
+// reloadOnSIGHUP reloads the certificate whenever the process receives SIGHUP, until done is closed
+func (r *certReloader) reloadOnSIGHUP(done <-chan struct{}) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(sigs)
+		for {
+			select {
+			case <-done:
+				return
+			case <-sigs:
+				if err := r.reload(); err != nil {
+					logger.Error().Err(err).Msg("Error when reloading explorer TLS certificate")
+					continue
+				}
+				logger.Info().Str("cert", r.certPath).Msg("Reloaded explorer TLS certificate")
+			}
+		}
+	}()
+}
@@ -15,6 +15,7 @@ package logger
 import (
 	"context"
 	"flag"
+	"fmt"
 	"io"
 	"os"
 
@@ -29,10 +30,20 @@ var (
 	_callLine bool
 )
 
-// logger is initialized with default settings
-var logger = zerolog.New(os.Stderr).Level(zerolog.InfoLevel).With().Timestamp().Logger()
+// Log formats
+const (
+	// JSONFormat writes the logs as JSON objects
+	JSONFormat = "json"
+	// ConsoleFormat writes the logs in a human-readable and colorful format
+	ConsoleFormat = "console"
+)
+
+// logger is initialized with default settings. The level of the loggers is controlled globally by SetLevel, so that it
+// could be adjusted at runtime.
+var logger = zerolog.New(os.Stderr).With().Timestamp().Logger()
 
 func init() {
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	// read config
 	flag.StringVar(&_levelStr, "log-level", zerolog.InfoLevel.String(), "Log level")
 	flag.StringVar(&_pathStr, "log-path", "", "Log path")
@@ -44,36 +55,75 @@ func init() {
 		flag.Parse()
 		level, err := zerolog.ParseLevel(_levelStr)
 		if err == nil {
-			logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).Level(level).With().Timestamp().Logger()
+			zerolog.SetGlobalLevel(level)
+			logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
 		} else {
 			logger.Warn().Err(err).Msg("error when initializing the testing logger, use default one.")
 		}
 	}
 }
 
-// New creates a new zerolog instance.
+// New creates a new zerolog instance from the flags.
 func New() (zerolog.Logger, error) {
-	var l zerolog.Logger
-	level, err := zerolog.ParseLevel(_levelStr)
-	if err != nil {
-		return l, err
+	format := JSONFormat
+	if _colorful {
+		format = ConsoleFormat
 	}
-	if _pathStr == "" {
-		var w io.Writer = os.Stderr
-		if _colorful {
-			w = zerolog.ConsoleWriter{Out: w}
+	return newLogger(_levelStr, format, _pathStr)
+}
+
+// NewWithConfig creates a new zerolog instance logging at the level in the format to the file at path, or stderr if
+// path is empty. The -log-level, -log-path and -log-colorful flags take precedence over the config if they are set.
+func NewWithConfig(level string, format string, path string) (zerolog.Logger, error) {
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "log-level":
+			level = _levelStr
+		case "log-path":
+			path = _pathStr
+		case "log-colorful":
+			if _colorful {
+				format = ConsoleFormat
+			} else {
+				format = JSONFormat
+			}
 		}
-		l = zerolog.New(w).Level(level).With().Timestamp().Logger()
-	} else {
-		if _colorful {
+	})
+	return newLogger(level, format, path)
+}
+
+// SetLevel sets the minimum level of all the loggers, which takes effect immediately
+func SetLevel(level string) error {
+	l, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	zerolog.SetGlobalLevel(l)
+	return nil
+}
+
+func newLogger(level string, format string, path string) (zerolog.Logger, error) {
+	var l zerolog.Logger
+	if format != JSONFormat && format != ConsoleFormat {
+		return l, fmt.Errorf("unknown log format %s", format)
+	}
+	var w io.Writer = os.Stderr
+	if path != "" {
+		if format == ConsoleFormat {
 			Warn().Msg("log config colorful set to true when log output to file.")
 		}
-		file, err := os.Create(_pathStr)
+		file, err := os.Create(path)
 		if err != nil {
 			return l, err
 		}
-		l = zerolog.New(file).Level(level).With().Timestamp().Logger()
+		w = file
+	} else if format == ConsoleFormat {
+		w = zerolog.ConsoleWriter{Out: w}
+	}
+	if err := SetLevel(level); err != nil {
+		return l, err
 	}
+	l = zerolog.New(w).With().Timestamp().Logger()
 	if _callLine {
 		l = l.With().Caller().Logger()
 	}
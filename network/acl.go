@@ -0,0 +1,84 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"net"
+	"time"
+
+	"github.com/iotexproject/iotex-core/logger"
+)
+
+// Ban disconnects the peer at the given address, which is either a host or a host:port address, and refuses to
+// connect with it for the configured ban duration
+func (o *IotxOverlay) Ban(addr string) {
+	o.bans.Store(addr, time.Now().Add(o.Config.BanDuration))
+	o.PM.Peers.Range(func(key, _ interface{}) bool {
+		if matchAddr(addr, key.(string)) {
+			o.PM.RemovePeer(key.(string))
+		}
+		return true
+	})
+	logger.Info().
+		Str("addr", addr).
+		Dur("duration", o.Config.BanDuration).
+		Msg("banned peer")
+}
+
+// Unban lifts the ban of the given address
+func (o *IotxOverlay) Unban(addr string) {
+	o.bans.Delete(addr)
+}
+
+// isAllowed checks the address against the ban list, the deny list and the allow list. When hostOnly is true, only
+// the host part of the address is meaningful, e.g., the source address of an inbound connection, so that it is
+// allowed by any entry on the same host, but only denied by an entry of the host itself.
+func (o *IotxOverlay) isAllowed(addr string, hostOnly bool) bool {
+	banned := false
+	now := time.Now()
+	o.bans.Range(func(key, value interface{}) bool {
+		if now.After(value.(time.Time)) {
+			o.bans.Delete(key)
+			return true
+		}
+		if matchAddr(key.(string), addr) {
+			banned = true
+			return false
+		}
+		return true
+	})
+	if banned {
+		return false
+	}
+	for _, entry := range o.Config.DenyList {
+		if matchAddr(entry, addr) {
+			return false
+		}
+	}
+	if len(o.Config.AllowList) == 0 {
+		return true
+	}
+	for _, entry := range o.Config.AllowList {
+		if matchAddr(entry, addr) || (hostOnly && hostOf(entry) == hostOf(addr)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAddr tells if the address matches the entry, which is either a host or a host:port address
+func matchAddr(entry string, addr string) bool {
+	return entry == addr || entry == hostOf(addr)
+}
+
+func hostOf(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAllowed(t *testing.T) {
+	cfg := LoadTestConfig("", true)
+	o := &IotxOverlay{Config: cfg}
+	assert.True(t, o.isAllowed("127.0.0.1:10001", false))
+
+	cfg.DenyList = []string{"127.0.0.1:10001", "192.168.0.1"}
+	assert.False(t, o.isAllowed("127.0.0.1:10001", false))
+	assert.True(t, o.isAllowed("127.0.0.1:10002", false))
+	assert.False(t, o.isAllowed("192.168.0.1:10001", false))
+	// an entry with port doesn't deny other nodes on the same host
+	assert.True(t, o.isAllowed("127.0.0.1:54321", true))
+	assert.False(t, o.isAllowed("192.168.0.1:54321", true))
+
+	cfg.DenyList = nil
+	cfg.AllowList = []string{"127.0.0.1:10001"}
+	assert.True(t, o.isAllowed("127.0.0.1:10001", false))
+	assert.False(t, o.isAllowed("127.0.0.1:10002", false))
+	assert.False(t, o.isAllowed("192.168.0.1:10001", false))
+	// the inbound connection could come from the allowed node
+	assert.True(t, o.isAllowed("127.0.0.1:54321", true))
+	assert.False(t, o.isAllowed("192.168.0.1:54321", true))
+}
+
+func TestBan(t *testing.T) {
+	cfg := LoadTestConfig("", true)
+	cfg.NumPeersUpperBound = 10
+	cfg.BanDuration = time.Hour
+	o := &IotxOverlay{Config: cfg}
+	o.PM = NewPeerManager(o, 0, cfg.NumPeersUpperBound)
+	o.RPC = NewRPCServer(o)
+
+	o.PM.AddPeer("127.0.0.1:10001")
+	o.PM.AddPeer("127.0.0.1:10002")
+	assert.Equal(t, uint(2), LenSyncMap(o.PM.Peers))
+
+	// the banned peer is disconnected and refused
+	o.Ban("127.0.0.1:10001")
+	_, ok := o.PM.Peers.Load("127.0.0.1:10001")
+	assert.False(t, ok)
+	_, ok = o.PM.Peers.Load("127.0.0.1:10002")
+	assert.True(t, ok)
+	o.PM.AddPeer("127.0.0.1:10001")
+	_, ok = o.PM.Peers.Load("127.0.0.1:10001")
+	assert.False(t, ok)
+
+	o.Unban("127.0.0.1:10001")
+	o.PM.AddPeer("127.0.0.1:10001")
+	_, ok = o.PM.Peers.Load("127.0.0.1:10001")
+	assert.True(t, ok)
+
+	// the ban expires after the ban duration
+	o.bans.Store("127.0.0.1:10003", time.Now().Add(-time.Second))
+	assert.True(t, o.isAllowed("127.0.0.1:10003", false))
+	_, ok = o.bans.Load("127.0.0.1:10003")
+	assert.False(t, ok)
+}
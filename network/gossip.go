@@ -8,6 +8,7 @@ package network
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/routine"
 	"github.com/iotexproject/iotex-core/proto"
@@ -28,6 +30,7 @@ type Gossip struct {
 	MsgLogs     *sync.Map
 	CleanerTask *routine.RecurringTask
 
+	seen      *seenCache
 	lifecycle lifecycle.Lifecycle
 }
 
@@ -36,6 +39,7 @@ func NewGossip(o *IotxOverlay) *Gossip {
 	g := &Gossip{
 		Overlay: o,
 		MsgLogs: &sync.Map{},
+		seen:    newSeenCache(o.Config.GossipSeenCacheSize),
 	}
 	cleaner := NewMsgLogsCleaner(g)
 	g.CleanerTask = routine.NewRecurringTask(cleaner.Clean, o.Config.MsgLogsCleaningInterval)
@@ -60,6 +64,15 @@ func (g *Gossip) OnReceivingMsg(msg *network.BroadcastReq) error {
 	if _, loaded := g.MsgLogs.LoadOrStore(checksumStr, time.Now()); loaded {
 		return nil
 	}
+	// The same action or block could come from multiple peers in different messages, so drop it if it has been
+	// dispatched and relayed
+	if g.seen.Seen(msgKey(msg.MsgType, msg.MsgBody)) {
+		logger.Debug().
+			Uint32("msg-type", msg.MsgType).
+			Str("msg-checksum", checksumStr).
+			Msg("drop the message which has been seen")
+		return nil
+	}
 	// Call dispatch to notify that a new message comes in
 	if err := g.processMsg(msg.ChainId, msg.MsgType, msg.MsgBody); err != nil {
 		return err
@@ -82,6 +95,11 @@ func (g *Gossip) OnReceivingMsg(msg *network.BroadcastReq) error {
 	return nil
 }
 
+// msgKey identifies the content of a message regardless of the checksum claimed by the sender
+func msgKey(msgType uint32, msgBody []byte) string {
+	return strconv.FormatUint(uint64(msgType), 10) + ":" + hex.EncodeToString(hash.Hash256b(msgBody))
+}
+
 func (g *Gossip) processMsg(chainID uint32, msgType uint32, msgBody []byte) error {
 	protoMsg, err := iproto.TypifyProtoMsg(msgType, msgBody)
 	if err != nil {
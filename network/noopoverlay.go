@@ -0,0 +1,67 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/network/node"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// NoopOverlay is the overlay of a standalone node, which neither listens nor connects to any peer. Broadcasting
+// messages does nothing, as there is nobody to receive them.
+type NoopOverlay struct {
+	self        net.Addr
+	genesisHash hash.Hash32B
+}
+
+// NewNoopOverlay creates an instance of NoopOverlay
+func NewNoopOverlay(config *config.Network) *NoopOverlay {
+	return &NoopOverlay{self: node.NewTCPNode(net.JoinHostPort(config.Host, strconv.Itoa(config.Port)))}
+}
+
+// Start does nothing
+func (o *NoopOverlay) Start(_ context.Context) error { return nil }
+
+// Stop does nothing
+func (o *NoopOverlay) Stop(_ context.Context) error { return nil }
+
+// Broadcast does nothing
+func (o *NoopOverlay) Broadcast(chainID uint32, msg proto.Message) error { return nil }
+
+// Tell returns ErrPeerNotFound, as there is no peer
+func (o *NoopOverlay) Tell(chainID uint32, addr net.Addr, msg proto.Message) error {
+	return errors.Wrapf(ErrPeerNotFound, "cannot tell %s in standalone mode", addr)
+}
+
+// Self returns the configured address, which is not listened on
+func (o *NoopOverlay) Self() net.Addr { return o.self }
+
+// GetPeers returns no peer
+func (o *NoopOverlay) GetPeers() []net.Addr { return nil }
+
+// Peers returns no peer
+func (o *NoopOverlay) Peers() []PeerInfo { return nil }
+
+// Ban does nothing
+func (o *NoopOverlay) Ban(string) {}
+
+// Unban does nothing
+func (o *NoopOverlay) Unban(string) {}
+
+// SetGenesisHash sets the genesis hash, which is only reported as there is no peer to check it against
+func (o *NoopOverlay) SetGenesisHash(h hash.Hash32B) { o.genesisHash = h }
+
+// GenesisHash returns the genesis hash
+func (o *NoopOverlay) GenesisHash() hash.Hash32B { return o.genesisHash }
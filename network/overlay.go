@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/hex"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -29,6 +30,12 @@ import (
 // ErrPeerNotFound means the peer is not found
 var ErrPeerNotFound = errors.New("Peer not found")
 
+// ErrPeerNotAllowed means the peer is denied or banned
+var ErrPeerNotAllowed = errors.New("Peer not allowed")
+
+// ErrGenesisMismatch means the peer runs a chain of a different genesis block or consensus config
+var ErrGenesisMismatch = errors.New("Genesis hash mismatch")
+
 // Overlay represents the peer-to-peer network
 type Overlay interface {
 	lifecycle.StartStopper
@@ -36,6 +43,11 @@ type Overlay interface {
 	Tell(uint32, net.Addr, proto.Message) error
 	Self() net.Addr
 	GetPeers() []net.Addr
+	Peers() []PeerInfo
+	Ban(string)
+	Unban(string)
+	SetGenesisHash(hash.Hash32B)
+	GenesisHash() hash.Hash32B
 }
 
 // IotxOverlay is the implementation
@@ -47,7 +59,9 @@ type IotxOverlay struct {
 	Config     *config.Network
 	Dispatcher dispatcher.Dispatcher
 
-	lifecycle lifecycle.Lifecycle
+	lifecycle   lifecycle.Lifecycle
+	bans        sync.Map
+	genesisHash hash.Hash32B
 }
 
 // NewOverlay creates an instance of IotxOverlay
@@ -126,6 +140,7 @@ func (o *IotxOverlay) Broadcast(chainID uint32, msg proto.Message) error {
 	msgChecksum := hash.Hash256b(msgBody)
 	checksumStr := hex.EncodeToString(msgChecksum)
 	o.Gossip.MsgLogs.Store(checksumStr, time.Now())
+	o.Gossip.seen.Seen(msgKey(msgType, msgBody))
 	// Kick off the message
 	if err = o.Gossip.relayMsg(chainID, msgType, msgBody, msgChecksum, o.Config.TTL); err != nil {
 		return errors.Wrap(err, "failed to relay msg when broadcast")
@@ -143,6 +158,16 @@ func (o *IotxOverlay) GetPeers() []net.Addr {
 	return nodes
 }
 
+// Peers returns the metadata of the current neighbors
+func (o *IotxOverlay) Peers() []PeerInfo {
+	var peers []PeerInfo
+	o.PM.Peers.Range(func(_, value interface{}) bool {
+		peers = append(peers, value.(*Peer).Info())
+		return true
+	})
+	return peers
+}
+
 // Tell tells a given node a proto message
 func (o *IotxOverlay) Tell(chainID uint32, node net.Addr, msg proto.Message) error {
 	peer := o.PM.GetOrAddPeer(node.String())
@@ -170,6 +195,13 @@ func (o *IotxOverlay) Tell(chainID uint32, node net.Addr, msg proto.Message) err
 	return nil
 }
 
+// SetGenesisHash sets the genesis hash exchanged with the peers on ping, which must be called before starting the
+// overlay. The peers of a different genesis hash are disconnected.
+func (o *IotxOverlay) SetGenesisHash(h hash.Hash32B) { o.genesisHash = h }
+
+// GenesisHash returns the genesis hash exchanged with the peers on ping
+func (o *IotxOverlay) GenesisHash() hash.Hash32B { return o.genesisHash }
+
 // Self returns the RPC server address to receive messages
 func (o *IotxOverlay) Self() net.Addr {
 	return o.RPC
@@ -80,6 +80,8 @@ type MockDispatcher struct {
 
 func (d *MockDispatcher) AddSubscriber(uint32, dispatcher.Subscriber) {}
 
+func (d *MockDispatcher) RemoveSubscriber(uint32) {}
+
 func (d *MockDispatcher) Start(_ context.Context) error {
 	return nil
 }
@@ -88,10 +90,12 @@ func (d *MockDispatcher) Stop(_ context.Context) error {
 	return nil
 }
 
-func (d *MockDispatcher) HandleBroadcast(uint32, proto.Message, chan bool) {
+func (d *MockDispatcher) HandleBroadcast(uint32, proto.Message, chan bool) error {
+	return nil
 }
 
-func (d *MockDispatcher) HandleTell(uint32, net.Addr, proto.Message, chan bool) {
+func (d *MockDispatcher) HandleTell(uint32, net.Addr, proto.Message, chan bool) error {
+	return nil
 }
 
 type MockDispatcher1 struct {
@@ -101,8 +105,11 @@ type MockDispatcher1 struct {
 
 func (d1 *MockDispatcher1) AddSubscriber(uint32, dispatcher.Subscriber) {}
 
-func (d1 *MockDispatcher1) HandleBroadcast(uint32, proto.Message, chan bool) {
+func (d1 *MockDispatcher1) RemoveSubscriber(uint32) {}
+
+func (d1 *MockDispatcher1) HandleBroadcast(uint32, proto.Message, chan bool) error {
 	d1.Count++
+	return nil
 }
 
 func TestOverlay(t *testing.T) {
@@ -163,7 +170,9 @@ type MockDispatcher2 struct {
 
 func (d2 *MockDispatcher2) AddSubscriber(uint32, dispatcher.Subscriber) {}
 
-func (d2 *MockDispatcher2) HandleTell(chainID uint32, sender net.Addr, message proto.Message, done chan bool) {
+func (d2 *MockDispatcher2) RemoveSubscriber(uint32) {}
+
+func (d2 *MockDispatcher2) HandleTell(chainID uint32, sender net.Addr, message proto.Message, done chan bool) error {
 	// Handle Tx Msg
 	msgType, err := iproto.GetTypeFromProtoMsg(message)
 	/*
@@ -179,6 +188,7 @@ func (d2 *MockDispatcher2) HandleTell(chainID uint32, sender net.Addr, message p
 	assert.Nil(d2.T, err)
 	assert.Equal(d2.T, iproto.MsgActionType, msgType)
 	d2.Count++
+	return nil
 }
 
 func TestTell(t *testing.T) {
@@ -383,12 +393,14 @@ type MockDispatcher3 struct {
 	C chan bool
 }
 
-func (d3 *MockDispatcher3) HandleTell(uint32, net.Addr, proto.Message, chan bool) {
+func (d3 *MockDispatcher3) HandleTell(uint32, net.Addr, proto.Message, chan bool) error {
 	d3.C <- true
+	return nil
 }
 
-func (d3 *MockDispatcher3) HandleBroadcast(uint32, proto.Message, chan bool) {
+func (d3 *MockDispatcher3) HandleBroadcast(uint32, proto.Message, chan bool) error {
 	d3.C <- true
+	return nil
 }
 
 func runBenchmarkOp(tell bool, size int, parallel bool, tls bool, b *testing.B) {
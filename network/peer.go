@@ -7,6 +7,7 @@
 package network
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -42,6 +43,25 @@ func init() {
 	prometheus.MustRegister(cConnMtc)
 }
 
+// Direction indicates which side initiated the connection with a peer
+type Direction string
+
+const (
+	// Outbound means the connection is initiated by the current node
+	Outbound Direction = "outbound"
+	// Inbound means the connection is initiated by the remote node
+	Inbound Direction = "inbound"
+)
+
+// PeerInfo describes a connected peer
+type PeerInfo struct {
+	Addr      string
+	Direction Direction
+	LastSeen  time.Time
+	// Height is the highest block height the peer has sent to the current node
+	Height uint64
+}
+
 // Peer represents a node in the peer-to-peer networks
 type Peer struct {
 	node.Node
@@ -49,6 +69,8 @@ type Peer struct {
 	Conn        *grpc.ClientConn
 	Ctx         context.Context
 	LastResTime time.Time
+	Direction   Direction
+	height      uint64
 }
 
 // NewTCPPeer creates an instance of Peer with tcp transportation
@@ -58,7 +80,7 @@ func NewTCPPeer(addr string) *Peer {
 
 // NewPeer creates an instance of Peer
 func NewPeer(n string, addr string) *Peer {
-	p := &Peer{LastResTime: time.Now()}
+	p := &Peer{LastResTime: time.Now(), Direction: Outbound}
 	p.NetworkType = n
 	p.Addr = addr
 	return p
@@ -158,3 +180,28 @@ func (p *Peer) Tell(req *pb.TellReq) (*pb.TellRes, error) {
 func (p *Peer) updateLastResTime() {
 	p.LastResTime = time.Now()
 }
+
+// Height returns the highest block height the peer has sent to the current node
+func (p *Peer) Height() uint64 {
+	return atomic.LoadUint64(&p.height)
+}
+
+// UpdateHeight records the height of a block received from the peer if it is higher than the known one
+func (p *Peer) UpdateHeight(height uint64) {
+	for {
+		cur := atomic.LoadUint64(&p.height)
+		if height <= cur || atomic.CompareAndSwapUint64(&p.height, cur, height) {
+			return
+		}
+	}
+}
+
+// Info returns the metadata of the peer
+func (p *Peer) Info() PeerInfo {
+	return PeerInfo{
+		Addr:      p.String(),
+		Direction: p.Direction,
+		LastSeen:  p.LastResTime,
+		Height:    p.Height(),
+	}
+}
@@ -35,6 +35,15 @@ func NewPeerManager(o *IotxOverlay, lb uint, ub uint) *PeerManager {
 
 // AddPeer adds a new peer
 func (pm *PeerManager) AddPeer(addr string) {
+	pm.addPeer(addr, Outbound)
+}
+
+// AddInboundPeer adds a new peer which initiated the contact with the current node
+func (pm *PeerManager) AddInboundPeer(addr string) {
+	pm.addPeer(addr, Inbound)
+}
+
+func (pm *PeerManager) addPeer(addr string, direction Direction) {
 	if LenSyncMap(pm.Peers) >= pm.NumPeersUpperBound {
 		logger.Debug().
 			Uint("peers", pm.NumPeersUpperBound).
@@ -47,6 +56,12 @@ func (pm *PeerManager) AddPeer(addr string) {
 			Msg("Node at address is the current node")
 		return
 	}
+	if !pm.Overlay.isAllowed(addr, false) {
+		logger.Debug().
+			Str("dst", addr).
+			Msg("Node at address is not allowed to be the peer")
+		return
+	}
 	_, ok := pm.Peers.Load(addr)
 	if ok {
 		logger.Debug().
@@ -86,6 +101,7 @@ func (pm *PeerManager) AddPeer(addr string) {
 		}
 	}
 	p := NewTCPPeer(addr)
+	p.Direction = direction
 	err := p.Connect(pm.Overlay.Config)
 	if err != nil {
 		logger.Error().
@@ -95,6 +111,7 @@ func (pm *PeerManager) AddPeer(addr string) {
 	pm.Peers.Store(addr, p)
 	logger.Debug().
 		Str("dst", addr).
+		Str("direction", string(direction)).
 		Msg("establish an outgoing connection")
 }
 
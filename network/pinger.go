@@ -7,6 +7,7 @@
 package network
 
 import (
+	"bytes"
 	"math/rand"
 
 	"github.com/iotexproject/iotex-core/logger"
@@ -33,7 +34,8 @@ func (h *Pinger) Ping() {
 				logger.Error().Msg("value is not an instance of Peer")
 				return
 			}
-			pong, err := p.Ping(&pb.Ping{Nonce: n, Addr: h.Overlay.RPC.String()})
+			genesisHash := h.Overlay.GenesisHash()
+			pong, err := p.Ping(&pb.Ping{Nonce: n, Addr: h.Overlay.RPC.String(), GenesisHash: genesisHash[:]})
 			if err != nil {
 				logger.Error().Err(err).Str("dst", p.String()).Msg("error when getting pong")
 				return
@@ -42,6 +44,16 @@ func (h *Pinger) Ping() {
 				logger.Error().Str("dst", p.String()).Msg("nil pong")
 				return
 			}
+			if !bytes.Equal(pong.GenesisHash, genesisHash[:]) {
+				logger.Warn().
+					Str("dst", p.String()).
+					Hex("out-genesis-hash", genesisHash[:]).
+					Hex("in-genesis-hash", pong.GenesisHash).
+					Err(ErrGenesisMismatch).
+					Msg("disconnect the peer running a different chain")
+				h.Overlay.PM.RemovePeer(p.String())
+				return
+			}
 			if pong.AckNonce != n {
 				logger.Error().
 					Str("dst", p.String()).
@@ -27,7 +27,9 @@ type Ping struct {
 	Nonce uint64 `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	// Every one who participates into the network needs to tell others its address
 	// TODO: Seperate it as a standalone protocol
-	Addr                 string   `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Addr string `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	// The hash of the genesis block and the consensus config, which must be the same for the nodes to be peers
+	GenesisHash          []byte   `protobuf:"bytes,3,opt,name=genesis_hash,json=genesisHash,proto3" json:"genesis_hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -71,8 +73,16 @@ func (m *Ping) GetAddr() string {
 	return ""
 }
 
+func (m *Ping) GetGenesisHash() []byte {
+	if m != nil {
+		return m.GenesisHash
+	}
+	return nil
+}
+
 type Pong struct {
 	AckNonce             uint64   `protobuf:"varint,1,opt,name=ack_nonce,json=ackNonce,proto3" json:"ack_nonce,omitempty"`
+	GenesisHash          []byte   `protobuf:"bytes,2,opt,name=genesis_hash,json=genesisHash,proto3" json:"genesis_hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -109,6 +119,13 @@ func (m *Pong) GetAckNonce() uint64 {
 	return 0
 }
 
+func (m *Pong) GetGenesisHash() []byte {
+	if m != nil {
+		return m.GenesisHash
+	}
+	return nil
+}
+
 type GetPeersReq struct {
 	Count                uint32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -594,30 +611,31 @@ var _Peer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("network/proto/rpc.proto", fileDescriptor_rpc_c5f1a61bd6a5b846) }
 
 var fileDescriptor_rpc_c5f1a61bd6a5b846 = []byte{
-	// 399 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xcd, 0x8e, 0xd3, 0x30,
-	0x10, 0xae, 0x89, 0xd3, 0x9f, 0xd9, 0x56, 0x5a, 0x59, 0x0b, 0x84, 0x70, 0x49, 0xbd, 0xd2, 0x2a,
-	0x07, 0xd4, 0x45, 0x70, 0x41, 0xe2, 0x56, 0x0e, 0x88, 0x0b, 0xaa, 0xa2, 0xde, 0xab, 0xd4, 0xb6,
-	0xd2, 0x2a, 0x89, 0x1d, 0x62, 0x57, 0x28, 0x2f, 0xc0, 0xd3, 0xf0, 0x36, 0xbc, 0x10, 0xb2, 0x9b,
-	0x46, 0x29, 0x6a, 0xb8, 0xcd, 0x37, 0xdf, 0x78, 0x3c, 0xdf, 0xf8, 0x33, 0xbc, 0x96, 0xc2, 0xfc,
-	0x54, 0x75, 0xfe, 0x5c, 0xd5, 0xca, 0xa8, 0xe7, 0xba, 0x62, 0x2b, 0x17, 0x91, 0x49, 0x4b, 0xd0,
-	0xf7, 0x80, 0x37, 0x47, 0x99, 0x91, 0x07, 0xf0, 0xa5, 0x92, 0x4c, 0x04, 0x28, 0x42, 0x31, 0x4e,
-	0xce, 0x80, 0x10, 0xc0, 0x29, 0xe7, 0x75, 0xf0, 0x22, 0x42, 0xf1, 0x2c, 0x71, 0x31, 0x7d, 0x04,
-	0xbc, 0x51, 0x32, 0x23, 0x6f, 0x61, 0x96, 0xb2, 0x7c, 0xd7, 0x3f, 0x35, 0x4d, 0x59, 0xfe, 0xdd,
-	0x62, 0xfa, 0x08, 0x77, 0x5f, 0x85, 0xd9, 0x08, 0x51, 0xeb, 0x44, 0xfc, 0xb0, 0xdd, 0x99, 0x3a,
-	0x49, 0xe3, 0xea, 0x16, 0xc9, 0x19, 0xd0, 0x65, 0xbf, 0x48, 0x77, 0x97, 0xa1, 0xc8, 0xeb, 0x2e,
-	0xfb, 0x8d, 0x60, 0xbe, 0xae, 0x55, 0xca, 0x59, 0xaa, 0x8d, 0xed, 0xf4, 0x0a, 0xc6, 0x07, 0x91,
-	0x72, 0x51, 0xb7, 0xad, 0x5a, 0x44, 0xde, 0xc0, 0x94, 0x1d, 0xd2, 0xa3, 0xdc, 0x1d, 0xb9, 0x9b,
-	0x76, 0x91, 0x4c, 0x1c, 0xfe, 0xc6, 0x2d, 0x55, 0xea, 0x6c, 0x67, 0x9a, 0x4a, 0x04, 0xde, 0x99,
-	0x2a, 0x75, 0xb6, 0x6d, 0x2a, 0x71, 0xa1, 0xf6, 0x8a, 0x37, 0x01, 0x8e, 0x50, 0x3c, 0x77, 0xd4,
-	0x5a, 0xf1, 0x86, 0x2c, 0x61, 0x6e, 0x29, 0x76, 0x10, 0x2c, 0xd7, 0xa7, 0x32, 0xf0, 0x1d, 0x7d,
-	0x57, 0xea, 0xec, 0x4b, 0x9b, 0x22, 0xf7, 0xe0, 0x19, 0x53, 0x04, 0xe3, 0x08, 0xc5, 0x7e, 0x62,
-	0x43, 0xfa, 0x74, 0x35, 0xad, 0x1e, 0x9a, 0x96, 0xfe, 0x42, 0x30, 0xd9, 0x8a, 0xa2, 0xf8, 0x9f,
-	0xa2, 0x1b, 0xbb, 0xbf, 0x52, 0xe9, 0x0d, 0xab, 0xc4, 0xc3, 0x2a, 0xfd, 0x2b, 0x95, 0x74, 0x79,
-	0x99, 0x63, 0x70, 0xd6, 0x0f, 0x7f, 0x10, 0x60, 0xfb, 0x46, 0xe4, 0x09, 0x70, 0x65, 0xad, 0xb2,
-	0x58, 0xb5, 0xe6, 0x59, 0x59, 0xe7, 0x84, 0x3d, 0xa8, 0x64, 0x46, 0x47, 0xe4, 0x13, 0x4c, 0xb3,
-	0xf6, 0x59, 0xc9, 0x43, 0x47, 0xf6, 0xec, 0x10, 0xde, 0xca, 0x6a, 0x3a, 0x22, 0x9f, 0x61, 0xb6,
-	0xbf, 0xac, 0x8f, 0xbc, 0xec, 0x8a, 0xfa, 0x06, 0x08, 0x6f, 0xa6, 0xed, 0xe1, 0x77, 0x80, 0x8d,
-	0x28, 0x0a, 0x72, 0xdf, 0x15, 0xb4, 0x1b, 0x0e, 0xff, 0xcd, 0x68, 0x3a, 0xda, 0x8f, 0xdd, 0x3f,
-	0xf8, 0xf8, 0x37, 0x00, 0x00, 0xff, 0xff, 0xbf, 0xf4, 0x7b, 0x4a, 0x22, 0x03, 0x00, 0x00,
+	// 410 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7d, 0x53, 0xcd, 0x4e, 0xc2, 0x40,
+	0x18, 0xb4, 0xb4, 0xe5, 0x67, 0x81, 0x84, 0x6c, 0x50, 0x6b, 0xbd, 0x40, 0x4d, 0x88, 0x07, 0x03,
+	0x89, 0x5e, 0x4c, 0xbc, 0x61, 0xe2, 0xcf, 0xc5, 0x90, 0xca, 0xbd, 0x29, 0xed, 0xa6, 0x6d, 0x80,
+	0x2e, 0x76, 0x97, 0x18, 0x5e, 0xc0, 0xa7, 0xf1, 0x6d, 0x7c, 0x21, 0xbf, 0x5d, 0x96, 0xa6, 0x20,
+	0xf5, 0xb6, 0x33, 0xf3, 0x75, 0x32, 0xb3, 0xfb, 0x15, 0x9d, 0xa7, 0x84, 0x7f, 0xd2, 0x6c, 0x3e,
+	0x5a, 0x65, 0x94, 0xd3, 0x51, 0xb6, 0x0a, 0x86, 0xf2, 0x84, 0x6b, 0x4a, 0x70, 0xde, 0x91, 0x31,
+	0x49, 0xd2, 0x08, 0x77, 0x91, 0x99, 0xd2, 0x34, 0x20, 0x96, 0xd6, 0xd3, 0xae, 0x0d, 0x77, 0x0b,
+	0x30, 0x46, 0x86, 0x1f, 0x86, 0x99, 0x55, 0x01, 0xb2, 0xe1, 0xca, 0x33, 0xee, 0xa3, 0x56, 0x44,
+	0x52, 0xc2, 0x12, 0xe6, 0xc5, 0x3e, 0x8b, 0x2d, 0x1d, 0xb4, 0x96, 0xdb, 0x54, 0xdc, 0x0b, 0x50,
+	0xce, 0x13, 0x98, 0x52, 0x30, 0xbd, 0x44, 0x0d, 0x3f, 0x98, 0x7b, 0x45, 0xe3, 0x3a, 0x10, 0x6f,
+	0xd2, 0xfb, 0xd0, 0xa7, 0xf2, 0xd7, 0xe7, 0x0a, 0x35, 0x9f, 0x09, 0x9f, 0x10, 0x92, 0x31, 0x97,
+	0x7c, 0x88, 0x8c, 0x01, 0x5d, 0xa7, 0x5c, 0x5a, 0xb5, 0xdd, 0x2d, 0x70, 0xfa, 0xc5, 0x21, 0x96,
+	0x47, 0xd6, 0x7a, 0xfa, 0x2e, 0xb2, 0xf3, 0xad, 0xa1, 0xd6, 0x38, 0xa3, 0x7e, 0x18, 0xf8, 0x8c,
+	0x0b, 0xa7, 0x33, 0x54, 0x8d, 0x89, 0x1f, 0x92, 0x4c, 0x59, 0x29, 0x84, 0x2f, 0x50, 0x3d, 0x88,
+	0xfd, 0x24, 0xf5, 0x92, 0x50, 0xe6, 0x69, 0xbb, 0x35, 0x89, 0x5f, 0x43, 0x21, 0x2d, 0x59, 0xe4,
+	0xf1, 0xcd, 0x8a, 0xc8, 0xca, 0x20, 0x01, 0x9e, 0x02, 0xdc, 0x49, 0x33, 0x1a, 0x6e, 0x2c, 0x43,
+	0xb6, 0x10, 0xd2, 0x18, 0xa0, 0x28, 0x29, 0xa4, 0x20, 0x26, 0xc1, 0x9c, 0xad, 0x97, 0x96, 0xb9,
+	0x2d, 0x09, 0xdc, 0xa3, 0xa2, 0x70, 0x07, 0xe9, 0x9c, 0x2f, 0xac, 0x2a, 0x28, 0xa6, 0x2b, 0x8e,
+	0xce, 0x60, 0x2f, 0x2d, 0x2b, 0x4b, 0xeb, 0x7c, 0x69, 0xa8, 0x36, 0x25, 0x8b, 0xc5, 0x7f, 0x8d,
+	0x8e, 0xbd, 0x60, 0xb1, 0xa5, 0x5e, 0xde, 0xd2, 0x28, 0x6f, 0x69, 0xee, 0xb5, 0x84, 0x27, 0x50,
+	0x39, 0x4a, 0xb3, 0xde, 0xfe, 0x68, 0xb0, 0x13, 0xf0, 0x46, 0x78, 0x80, 0x8c, 0x95, 0x58, 0xb8,
+	0xf6, 0x50, 0xad, 0xe0, 0x50, 0xec, 0x9f, 0x5d, 0x80, 0xb0, 0x39, 0xce, 0x09, 0xbe, 0x47, 0xf5,
+	0x48, 0x3d, 0x2b, 0xee, 0xe6, 0x62, 0x61, 0x1d, 0xec, 0x63, 0x2c, 0x83, 0x2f, 0x1f, 0x50, 0x63,
+	0xb6, 0xbb, 0x3e, 0x7c, 0x9a, 0x0f, 0x15, 0x17, 0xc0, 0x3e, 0x4a, 0x8b, 0x8f, 0x6f, 0x90, 0xc1,
+	0xa1, 0x0a, 0xee, 0xe4, 0x03, 0xea, 0x86, 0xed, 0x43, 0x06, 0xa6, 0x67, 0x55, 0xf9, 0x37, 0xdd,
+	0xfd, 0x02, 0x5d, 0x5d, 0x4f, 0x29, 0x68, 0x03, 0x00, 0x00,
 }
@@ -7,6 +7,7 @@
 package network
 
 import (
+	"bytes"
 	"fmt"
 	"net"
 	"strconv"
@@ -82,8 +83,27 @@ func (s *RPCServer) Ping(ctx context.Context, ping *pb.Ping) (*pb.Pong, error) {
 		return nil, fmt.Errorf("sended requests too frequently")
 	}
 	sRequestMtc.WithLabelValues("Ping", "false").Inc()
-	s.Overlay.PM.AddPeer(ping.Addr)
-	return &pb.Pong{AckNonce: ping.Nonce}, nil
+	if !s.isAllowedRequest(ctx, ping.Addr) {
+		return nil, ErrPeerNotAllowed
+	}
+	genesisHash := s.Overlay.GenesisHash()
+	if !bytes.Equal(ping.GenesisHash, genesisHash[:]) {
+		// the peer runs a different chain, so stop talking to it if it has already been a peer. The claimed address is
+		// only trusted for disconnecting when the request comes from its host. The pong still carries the genesis hash
+		// for the peer to disconnect too.
+		logger.Warn().
+			Str("src", ping.Addr).
+			Hex("in-genesis-hash", ping.GenesisHash).
+			Hex("genesis-hash", genesisHash[:]).
+			Err(ErrGenesisMismatch).
+			Msg("reject the peer running a different chain")
+		if s.isFromHost(ctx, ping.Addr) {
+			s.Overlay.PM.RemovePeer(ping.Addr)
+		}
+	} else {
+		s.Overlay.PM.AddInboundPeer(ping.Addr)
+	}
+	return &pb.Pong{AckNonce: ping.Nonce, GenesisHash: genesisHash[:]}, nil
 }
 
 // GetPeers implements the server side RPC logic
@@ -97,6 +117,9 @@ func (s *RPCServer) GetPeers(ctx context.Context, req *pb.GetPeersReq) (*pb.GetP
 		return nil, fmt.Errorf("sended requests too frequently")
 	}
 	sRequestMtc.WithLabelValues("GetPeers", "false").Inc()
+	if !s.isAllowedRequest(ctx, "") {
+		return nil, ErrPeerNotAllowed
+	}
 
 	var addrs []string
 	s.Overlay.PM.Peers.Range(func(key, value interface{}) bool {
@@ -124,6 +147,9 @@ func (s *RPCServer) Broadcast(ctx context.Context, req *pb.BroadcastReq) (*pb.Br
 		return nil, fmt.Errorf("sended requests too frequently")
 	}
 	sRequestMtc.WithLabelValues("Broadcast", "false").Inc()
+	if !s.isAllowedRequest(ctx, "") {
+		return nil, ErrPeerNotAllowed
+	}
 
 	err = s.Overlay.Gossip.OnReceivingMsg(req)
 	if err == nil {
@@ -143,11 +169,20 @@ func (s *RPCServer) Tell(ctx context.Context, req *pb.TellReq) (*pb.TellRes, err
 		return nil, fmt.Errorf("sended requests too frequently")
 	}
 	sRequestMtc.WithLabelValues("Tell", "false").Inc()
+	if !s.isAllowedRequest(ctx, req.Addr) {
+		return nil, ErrPeerNotAllowed
+	}
 
 	protoMsg, err := iproto.TypifyProtoMsg(req.MsgType, req.MsgBody)
 	if err != nil {
 		return nil, err
 	}
+	// Remember the chain height of the sender from the blocks it sends
+	if container, ok := protoMsg.(*iproto.BlockContainer); ok && s.Overlay.PM != nil {
+		if peer, ok := s.Overlay.PM.Peers.Load(req.Addr); ok {
+			peer.(*Peer).UpdateHeight(container.GetBlock().GetHeader().GetHeight())
+		}
+	}
 	if s.Overlay.Dispatcher != nil {
 		s.Overlay.Dispatcher.HandleTell(req.ChainId, node.NewTCPNode(req.Addr), protoMsg, nil)
 	}
@@ -232,6 +267,31 @@ func (s *RPCServer) shouldDropRequest(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
+// isAllowedRequest checks whether both the source of the request and the address it claims are allowed
+func (s *RPCServer) isAllowedRequest(ctx context.Context, addr string) bool {
+	if src, err := s.getClientAddr(ctx); err == nil && !s.Overlay.isAllowed(src, true) {
+		return false
+	}
+	return addr == "" || s.Overlay.isAllowed(addr, false)
+}
+
+// isFromHost checks whether the request comes from the host of the given address
+func (s *RPCServer) isFromHost(ctx context.Context, addr string) bool {
+	src, err := s.getClientAddr(ctx)
+	if err != nil {
+		return false
+	}
+	srcHost, _, err := net.SplitHostPort(src)
+	if err != nil {
+		return false
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	return srcHost == host
+}
+
 func (s *RPCServer) getClientAddr(ctx context.Context) (string, error) {
 	p, ok := peer.FromContext(ctx)
 	if !ok {
@@ -18,6 +18,8 @@ import (
 	"golang.org/x/net/context"
 
 	pb "github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
 )
@@ -26,6 +28,8 @@ func TestRpcPingPong(t *testing.T) {
 	ctx := context.Background()
 	config := LoadTestConfig("", true)
 	o := &IotxOverlay{Config: config}
+	genesisHash := byteutil.BytesTo32B(hash.Hash256b([]byte("genesis")))
+	o.SetGenesisHash(genesisHash)
 	o.PM = NewPeerManager(o, 1, 1)
 	s := NewRPCServer(o)
 	o.RPC = s
@@ -42,14 +46,64 @@ func TestRpcPingPong(t *testing.T) {
 		assert.NoError(t, err)
 	}()
 
-	pong, err := p.Ping(&pb.Ping{Nonce: uint64(4689), Addr: "127.0.0.1:10001"})
+	pong, err := p.Ping(&pb.Ping{Nonce: uint64(4689), Addr: "127.0.0.1:10001", GenesisHash: genesisHash[:]})
 	assert.Nil(t, err)
 	assert.NotNil(t, pong)
 	assert.Equal(t, uint64(4689), pong.AckNonce)
+	assert.Equal(t, genesisHash[:], pong.GenesisHash)
 	value, ok := o.PM.Peers.Load("127.0.0.1:10001")
 	assert.True(t, ok)
 	assert.NotNil(t, value)
 	assert.True(t, "127.0.0.1:10001" == value.(*Peer).String())
+	assert.Equal(t, Inbound, value.(*Peer).Direction)
+}
+
+func TestRpcPingGenesisMismatch(t *testing.T) {
+	ctx := context.Background()
+	config := LoadTestConfig("", true)
+	o := &IotxOverlay{Config: config}
+	o.SetGenesisHash(byteutil.BytesTo32B(hash.Hash256b([]byte("genesis"))))
+	o.PM = NewPeerManager(o, 1, 1)
+	o.PM.Peers.Store("127.0.0.1:10002", NewTCPPeer("127.0.0.1:10002"))
+	o.PM.Peers.Store("10.0.0.1:10003", NewTCPPeer("10.0.0.1:10003"))
+	s := NewRPCServer(o)
+	o.RPC = s
+	err := s.Start(ctx)
+	require.NoError(t, err)
+	p := NewPeer(s.Network(), s.String())
+	err = p.Connect(config)
+	require.NoError(t, err)
+
+	defer func() {
+		err := p.Close()
+		assert.NoError(t, err)
+		err = s.Stop(ctx)
+		assert.NoError(t, err)
+	}()
+
+	// a peer is not disconnected by a ping claiming its address from another host
+	pong, err := p.Ping(&pb.Ping{Nonce: uint64(4689), Addr: "10.0.0.1:10003"})
+	require.NoError(t, err)
+	require.NotNil(t, pong)
+	_, ok := o.PM.Peers.Load("10.0.0.1:10003")
+	assert.True(t, ok)
+
+	// a new node of another chain doesn't become a peer
+	otherHash := hash.Hash256b([]byte("other genesis"))
+	pong, err = p.Ping(&pb.Ping{Nonce: uint64(4689), Addr: "127.0.0.1:10001", GenesisHash: otherHash})
+	require.NoError(t, err)
+	require.NotNil(t, pong)
+	genesisHash := o.GenesisHash()
+	assert.Equal(t, genesisHash[:], pong.GenesisHash)
+	_, ok = o.PM.Peers.Load("127.0.0.1:10001")
+	assert.False(t, ok)
+
+	// an existing peer of another chain, or of an unknown one, is disconnected
+	pong, err = p.Ping(&pb.Ping{Nonce: uint64(4689), Addr: "127.0.0.1:10002"})
+	require.NoError(t, err)
+	require.NotNil(t, pong)
+	_, ok = o.PM.Peers.Load("127.0.0.1:10002")
+	assert.False(t, ok)
 }
 
 func TestGetPeers(t *testing.T) {
@@ -163,6 +217,49 @@ func TestRPCTell(t *testing.T) {
 	assert.Equal(t, iproto.MagicBroadcastMsgHeader, res.Header)
 }
 
+func TestRPCTellPeerHeight(t *testing.T) {
+	ctx := context.Background()
+	mctrl := gomock.NewController(t)
+	dp := mock_dispatcher.NewMockDispatcher(mctrl)
+	dp.EXPECT().HandleTell(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	config := LoadTestConfig("", true)
+	o := &IotxOverlay{Dispatcher: dp, Config: config}
+	o.PM = NewPeerManager(o, 0, 0)
+	o.PM.Peers.Store("127.0.0.1:10001", NewTCPPeer("127.0.0.1:10001"))
+	s := NewRPCServer(o)
+	o.RPC = s
+	err := s.Start(ctx)
+	assert.NoError(t, err)
+	p := NewPeer(s.Network(), s.String())
+	err = p.Connect(config)
+	assert.NoError(t, err)
+
+	defer func() {
+		err := p.Close()
+		assert.NoError(t, err)
+		err = s.Stop(ctx)
+		assert.NoError(t, err)
+		mctrl.Finish()
+	}()
+
+	for _, height := range []uint64{12, 10} {
+		b, _ := proto.Marshal(&iproto.BlockContainer{Block: &iproto.BlockPb{Header: &iproto.BlockHeaderPb{Height: height}}})
+		_, err = p.Tell(&pb.TellReq{Header: iproto.MagicBroadcastMsgHeader,
+			Addr:    "127.0.0.1:10001",
+			MsgType: iproto.MsgBlockSyncDataType,
+			MsgBody: b})
+		assert.NoError(t, err)
+	}
+
+	peers := o.Peers()
+	require.Equal(t, 1, len(peers))
+	assert.Equal(t, "127.0.0.1:10001", peers[0].Addr)
+	assert.Equal(t, Outbound, peers[0].Direction)
+	// the height never goes backward
+	assert.Equal(t, uint64(12), peers[0].Height)
+}
+
 func TestRateLimit(t *testing.T) {
 	ctx := context.Background()
 	mctrl := gomock.NewController(t)
@@ -234,7 +331,7 @@ func TestSecureRpcPingPong(t *testing.T) {
 		assert.NoError(t, err)
 	}()
 
-	pong, err := p.Ping(&pb.Ping{Nonce: uint64(4689), Addr: "127.0.0.1:10001"})
+	pong, err := p.Ping(&pb.Ping{Nonce: uint64(4689), Addr: "127.0.0.1:10001", GenesisHash: hash.ZeroHash32B[:]})
 	assert.Nil(t, err)
 	assert.NotNil(t, pong)
 	assert.Equal(t, uint64(4689), pong.AckNonce)
@@ -272,7 +369,7 @@ func TestKeepaliveParams(t *testing.T) {
 
 	for i := 0; i < 5; i++ {
 		time.Sleep(100 * time.Millisecond)
-		pong, err := p.Ping(&pb.Ping{Nonce: uint64(4689), Addr: "127.0.0.1:10001"})
+		pong, err := p.Ping(&pb.Ping{Nonce: uint64(4689), Addr: "127.0.0.1:10001", GenesisHash: hash.ZeroHash32B[:]})
 		assert.Nil(t, err)
 		assert.NotNil(t, pong)
 		assert.Equal(t, uint64(4689), pong.AckNonce)
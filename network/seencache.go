@@ -0,0 +1,79 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var seenCacheHitRateMtc = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "iotex_network_gossip_seen_cache_hit_rate",
+		Help: "Hit rate of the gossip deduplication cache.",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(seenCacheHitRateMtc)
+}
+
+// seenCache is a bounded LRU set of the messages which have been seen
+type seenCache struct {
+	mutex   sync.Mutex
+	size    int
+	entries *list.List
+	index   map[string]*list.Element
+	hits    uint64
+	lookups uint64
+}
+
+func newSeenCache(size int) *seenCache {
+	return &seenCache{
+		size:    size,
+		entries: list.New(),
+		index:   make(map[string]*list.Element),
+	}
+}
+
+// Seen returns true if the key has been seen before, otherwise it remembers the key and evicts the least recently
+// seen one if the cache is full
+func (c *seenCache) Seen(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.lookups++
+	defer func() { seenCacheHitRateMtc.Set(float64(c.hits) / float64(c.lookups)) }()
+	if e, ok := c.index[key]; ok {
+		c.hits++
+		c.entries.MoveToFront(e)
+		return true
+	}
+	if c.size <= 0 {
+		return false
+	}
+	c.index[key] = c.entries.PushFront(key)
+	if c.entries.Len() > c.size {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.index, oldest.Value.(string))
+	}
+	return false
+}
+
+// HitRate returns the ratio of the lookups of the seen messages
+func (c *seenCache) HitRate() float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.lookups == 0 {
+		return 0
+	}
+	return float64(c.hits) / float64(c.lookups)
+}
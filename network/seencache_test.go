@@ -0,0 +1,62 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
+)
+
+func TestSeenCache(t *testing.T) {
+	c := newSeenCache(2)
+	assert.False(t, c.Seen("a"))
+	assert.False(t, c.Seen("b"))
+	assert.True(t, c.Seen("a"))
+	// b is the least recently seen one, so it is evicted
+	assert.False(t, c.Seen("c"))
+	assert.True(t, c.Seen("a"))
+	assert.False(t, c.Seen("b"))
+	assert.Equal(t, 2.0/6.0, c.HitRate())
+
+	// nothing is remembered with zero size
+	c = newSeenCache(0)
+	assert.False(t, c.Seen("a"))
+	assert.False(t, c.Seen("a"))
+}
+
+func TestGossipDropSeenMsg(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dp := mock_dispatcher.NewMockDispatcher(ctrl)
+	dp.EXPECT().HandleBroadcast(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	cfg := LoadTestConfig("", true)
+	cfg.GossipSeenCacheSize = 16
+	o := &IotxOverlay{Config: cfg}
+	o.PM = NewPeerManager(o, 0, 0)
+	g := NewGossip(o)
+	g.AttachDispatcher(dp)
+
+	b, _ := proto.Marshal(&iproto.ActionPb{Nonce: 1})
+	// the same action relayed by different peers with different checksums is only dispatched once
+	for _, checksum := range [][]byte{{1}, {2}} {
+		assert.NoError(t, g.OnReceivingMsg(&pb.BroadcastReq{
+			MsgType:     iproto.MsgActionType,
+			MsgBody:     b,
+			MsgChecksum: checksum,
+			Ttl:         1,
+		}))
+	}
+}
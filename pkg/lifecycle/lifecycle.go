@@ -9,6 +9,7 @@ package lifecycle
 
 import (
 	"context"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 )
@@ -34,6 +35,31 @@ type StartStopper interface {
 	Stopper
 }
 
+// Phase is a phase that a model goes through in the lifecycle.
+type Phase string
+
+const (
+	// Starting is the phase right before a model starts
+	Starting Phase = "starting"
+	// Started is the phase after a model started successfully
+	Started Phase = "started"
+	// Stopping is the phase right before a model stops
+	Stopping Phase = "stopping"
+	// Stopped is the phase after a model stopped successfully
+	Stopped Phase = "stopped"
+)
+
+// Hook is notified when a named model enters a lifecycle phase.
+type Hook interface {
+	OnPhase(name string, phase Phase)
+}
+
+// NopHook is a Hook which does nothing.
+type NopHook struct{}
+
+// OnPhase does nothing.
+func (NopHook) OnPhase(string, Phase) {}
+
 // Lifecycle manages lifecycle for models. Currently a Lifecycle has two phases: Start and Stop.
 // Currently Lifecycle doesn't support soft dependecy models and multi-err, so all models in Lifecycle require to be succeed on both phases.
 type Lifecycle struct {
@@ -69,3 +95,21 @@ func (lc *Lifecycle) OnStop(ctx context.Context) error {
 	}
 	return g.Wait()
 }
+
+// StopWithTimeout runs stopper's Stop method but gives up waiting for it once timeout elapses or ctx is done, so that a
+// hanging component won't block the rest of the shutdown. A non-positive timeout means only ctx is honored.
+func StopWithTimeout(ctx context.Context, stopper Stopper, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	errChan := make(chan error, 1)
+	go func() { errChan <- stopper.Stop(ctx) }()
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
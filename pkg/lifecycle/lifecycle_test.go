@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -51,3 +52,27 @@ func TestLifecycleWithError(t *testing.T) {
 	assert.Nil(t, lc.OnStart(ctx))
 	assert.EqualError(t, lc.OnStop(ctx), err.Error())
 }
+
+func TestStopWithTimeout(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	ctx := context.Background()
+	m1 := mock_lifecycle.NewMockStartStopper(mctrl)
+	m1.EXPECT().Stop(gomock.Any()).Return(nil).Times(1)
+	assert.Nil(t, StopWithTimeout(ctx, m1, time.Second))
+
+	err := errors.New("error")
+	m2 := mock_lifecycle.NewMockStartStopper(mctrl)
+	m2.EXPECT().Stop(gomock.Any()).Return(err).Times(1)
+	assert.EqualError(t, StopWithTimeout(ctx, m2, time.Second), err.Error())
+
+	block := make(chan struct{})
+	defer close(block)
+	m3 := mock_lifecycle.NewMockStartStopper(mctrl)
+	m3.EXPECT().Stop(gomock.Any()).DoAndReturn(func(_ context.Context) error {
+		<-block
+		return nil
+	}).Times(1)
+	assert.Equal(t, context.DeadlineExceeded, StopWithTimeout(ctx, m3, 10*time.Millisecond))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+)
+
+// pruneThreshold is the number of buckets above which the idle buckets are pruned
+const pruneThreshold = 4096
+
+// Limiter is a token bucket rate limiter keeping a separate bucket for each key. Each bucket is refilled at rate
+// tokens per second and holds at most burst tokens.
+type Limiter struct {
+	mutex   sync.Mutex
+	rate    float64
+	burst   float64
+	clock   clock.Clock
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Option sets Limiter construction parameter.
+type Option func(l *Limiter)
+
+// WithClock is an option to replace the wall clock, which is useful for testing.
+func WithClock(c clock.Clock) Option {
+	return func(l *Limiter) {
+		l.clock = c
+	}
+}
+
+// NewLimiter creates a Limiter allowing rate events per second and bursts of at most burst events for each key
+func NewLimiter(rate float64, burst int, opts ...Option) *Limiter {
+	l := &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		clock:   clock.New(),
+		buckets: make(map[string]*bucket),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Allow reports whether an event of the given key may happen now, and consumes a token if so
+func (l *Limiter) Allow(key string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.clock.Now()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= pruneThreshold {
+			l.prune(now)
+		}
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	tokens := b.tokens + now.Sub(b.last).Seconds()*l.rate
+	if tokens > l.burst {
+		tokens = l.burst
+	}
+	return tokens
+}
+
+// prune removes the buckets which have been refilled to full, as they behave the same as new ones
+func (l *Limiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
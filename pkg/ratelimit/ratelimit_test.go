@@ -0,0 +1,55 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ratelimit
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	require := require.New(t)
+	c := clock.NewMock()
+	l := NewLimiter(2, 3, WithClock(c))
+
+	// burst
+	require.True(l.Allow("a"))
+	require.True(l.Allow("a"))
+	require.True(l.Allow("a"))
+	require.False(l.Allow("a"))
+	// other keys are not affected
+	require.True(l.Allow("b"))
+
+	// refill 2 tokens per second
+	c.Add(500 * time.Millisecond)
+	require.True(l.Allow("a"))
+	require.False(l.Allow("a"))
+	c.Add(10 * time.Second)
+	for i := 0; i < 3; i++ {
+		require.True(l.Allow("a"))
+	}
+	require.False(l.Allow("a"))
+}
+
+func TestLimiterPrune(t *testing.T) {
+	require := require.New(t)
+	c := clock.NewMock()
+	l := NewLimiter(1, 1, WithClock(c))
+	for i := 0; i < pruneThreshold; i++ {
+		require.True(l.Allow(strconv.Itoa(i)))
+	}
+	require.Equal(pruneThreshold, len(l.buckets))
+
+	// the idle buckets are pruned when a new key comes
+	c.Add(time.Second)
+	require.True(l.Allow("new"))
+	require.Equal(1, len(l.buckets))
+}
@@ -8,6 +8,7 @@ package routine
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/facebookgo/clock"
@@ -24,10 +25,13 @@ type RecurringTaskOption interface {
 
 // RecurringTask represents a recurring task
 type RecurringTask struct {
-	t        Task
+	t Task
+	// mutex guards interval and ticker
+	mutex    sync.Mutex
 	interval time.Duration
 	ticker   *clock.Ticker
 	ch       chan interface{}
+	reset    chan struct{}
 	clock    clock.Clock
 }
 
@@ -37,6 +41,7 @@ func NewRecurringTask(t Task, i time.Duration, ops ...RecurringTaskOption) *Recu
 		t:        t,
 		interval: i,
 		ch:       make(chan interface{}, 1),
+		reset:    make(chan struct{}, 1),
 		clock:    clock.New(),
 	}
 	for _, opt := range ops {
@@ -47,7 +52,10 @@ func NewRecurringTask(t Task, i time.Duration, ops ...RecurringTaskOption) *Recu
 
 // Start starts the timer
 func (t *RecurringTask) Start(ctx context.Context) error {
-	t.ticker = t.clock.Ticker(t.interval)
+	t.mutex.Lock()
+	ticker := t.clock.Ticker(t.interval)
+	t.ticker = ticker
+	t.mutex.Unlock()
 	ready := make(chan struct{})
 	go func() {
 		close(ready)
@@ -56,7 +64,13 @@ func (t *RecurringTask) Start(ctx context.Context) error {
 			// TODO (soy) we can not cancel on ctx.Done, seems there is something cause context timeout of recurring task unexpected
 			case <-t.ch:
 				return
-			case <-t.ticker.C:
+			case <-t.reset:
+				t.mutex.Lock()
+				ticker.Stop()
+				ticker = t.clock.Ticker(t.interval)
+				t.ticker = ticker
+				t.mutex.Unlock()
+			case <-ticker.C:
 				t.t()
 			}
 		}
@@ -69,9 +83,33 @@ func (t *RecurringTask) Start(ctx context.Context) error {
 // Stop stops the timer
 func (t *RecurringTask) Stop(_ context.Context) error {
 	// TODO: actually this happens when stop is called before init/start. We should prevent this from happening
+	t.mutex.Lock()
 	if t.ticker != nil {
 		t.ticker.Stop()
 	}
+	t.mutex.Unlock()
 	t.ch <- struct{}{}
 	return nil
 }
+
+// SetInterval changes the interval of the task. If the task is running, the ticker is restarted with the new interval.
+func (t *RecurringTask) SetInterval(i time.Duration) {
+	t.mutex.Lock()
+	t.interval = i
+	started := t.ticker != nil
+	t.mutex.Unlock()
+	if started {
+		select {
+		case t.reset <- struct{}{}:
+		default:
+			// a reset is pending already, which will pick up the latest interval
+		}
+	}
+}
+
+// Interval returns the interval of the task
+func (t *RecurringTask) Interval() time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.interval
+}
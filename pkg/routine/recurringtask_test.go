@@ -38,3 +38,23 @@ func TestRecurringTask(t *testing.T) {
 	ck.Add(600 * time.Millisecond)
 	assert.True(t, h.Count >= 5)
 }
+
+func TestRecurringTaskSetInterval(t *testing.T) {
+	h := &MockHandler{Count: 0}
+	ctx := context.Background()
+	ck := clock.NewMock()
+	task := routine.NewRecurringTask(h.Do, 100*time.Millisecond, routine.WithClock(ck))
+	task.Start(ctx)
+	defer func() {
+		task.Stop(ctx)
+	}()
+
+	task.SetInterval(time.Second)
+	assert.Equal(t, time.Second, task.Interval())
+	// wait for the ticker to be restarted
+	time.Sleep(10 * time.Millisecond)
+	ck.Add(500 * time.Millisecond)
+	assert.Equal(t, uint(0), h.Count)
+	ck.Add(500 * time.Millisecond)
+	assert.Equal(t, uint(1), h.Count)
+}
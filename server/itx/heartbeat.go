@@ -32,6 +32,9 @@ var heartbeatMtc = prometheus.NewGaugeVec(
 	[]string{"status_type", "source"},
 )
 
+// actPoolAlertRatio is the ratio of the actpool size to its capacity above which a warning is logged
+const actPoolAlertRatio = 0.9
+
 func init() {
 	prometheus.MustRegister(heartbeatMtc)
 }
@@ -48,26 +51,30 @@ func NewHeartbeatHandler(s *Server) *HeartbeatHandler {
 
 // Log executes the logging logic
 func (h *HeartbeatHandler) Log() {
-	// Network metrics
-	p2p, ok := h.s.P2P().(*network.IotxOverlay)
-	if !ok {
-		logger.Error().Msg("value is not the instance of IotxOverlay")
-		return
-	}
-	numPeers := network.LenSyncMap(p2p.PM.Peers)
+	// Network metrics, which are all zero in standalone mode
+	numPeers := uint(0)
 	lastOutTime := time.Unix(0, 0)
-	p2p.PM.Peers.Range(func(_, value interface{}) bool {
-		p, ok := value.(*network.Peer)
+	lastInTime := time.Unix(0, 0)
+	if !h.s.cfg.Network.Disabled {
+		p2p, ok := h.s.P2P().(*network.IotxOverlay)
 		if !ok {
-			logger.Error().Msg("value is not the instance of Peer")
-			return true
-		}
-		if p.LastResTime.After(lastOutTime) {
-			lastOutTime = p.LastResTime
+			logger.Error().Msg("value is not the instance of IotxOverlay")
+			return
 		}
-		return true
-	})
-	lastInTime := p2p.RPC.LastReqTime()
+		numPeers = network.LenSyncMap(p2p.PM.Peers)
+		p2p.PM.Peers.Range(func(_, value interface{}) bool {
+			p, ok := value.(*network.Peer)
+			if !ok {
+				logger.Error().Msg("value is not the instance of Peer")
+				return true
+			}
+			if p.LastResTime.After(lastOutTime) {
+				lastOutTime = p.LastResTime
+			}
+			return true
+		})
+		lastInTime = p2p.RPC.LastReqTime()
+	}
 
 	// Dispatcher metrics
 	dp, ok := h.s.Dispatcher().(*dispatcher.IotxDispatcher)
@@ -78,7 +85,7 @@ func (h *HeartbeatHandler) Log() {
 	numDPEvts := len(*dp.EventChan())
 	dpEvtsAudit, err := json.Marshal(dp.EventAudit())
 	if err != nil {
-		logger.Error().Msg("error when serializing the dispatcher event audit map")
+		logger.Error().Err(err).Msg("error when serializing the dispatcher event audit map")
 		return
 	}
 
@@ -93,7 +100,7 @@ func (h *HeartbeatHandler) Log() {
 	heartbeatMtc.WithLabelValues("numPeers", "node").Set(float64(numPeers))
 	heartbeatMtc.WithLabelValues("pendingDispatcherEvents", "node").Set(float64(numDPEvts))
 	// chain service
-	for _, c := range h.s.chainservices {
+	for _, c := range h.s.chainServices() {
 		// Consensus metrics
 		cs, ok := c.Consensus().(*consensus.IotxConsensus)
 		if !ok {
@@ -115,6 +122,7 @@ func (h *HeartbeatHandler) Log() {
 
 		actPoolSize := c.ActionPool().GetSize()
 		actPoolCapacity := c.ActionPool().GetCapacity()
+		actPoolEvictions := c.ActionPool().GetEvictionCount()
 
 		logger.Info().
 			Int("rolldposEvents", numPendingEvts).
@@ -122,14 +130,23 @@ func (h *HeartbeatHandler) Log() {
 			Uint64("blockchainHeight", height).
 			Uint64("actpoolSize", actPoolSize).
 			Uint64("actpoolCapacity", actPoolCapacity).
+			Uint64("actpoolEvictions", actPoolEvictions).
 			Uint32("chainID", c.ChainID()).
 			Msg("chain service status")
+		if actPoolCapacity > 0 && float64(actPoolSize) >= actPoolAlertRatio*float64(actPoolCapacity) {
+			logger.Warn().
+				Uint64("actpoolSize", actPoolSize).
+				Uint64("actpoolCapacity", actPoolCapacity).
+				Uint32("chainID", c.ChainID()).
+				Msg("actpool is near capacity")
+		}
 
 		chainIDStr := strconv.FormatUint(uint64(c.ChainID()), 10)
 		heartbeatMtc.WithLabelValues("pendingRolldposEvents", chainIDStr).Set(float64(numPendingEvts))
 		heartbeatMtc.WithLabelValues("blockchainHeight", chainIDStr).Set(float64(height))
 		heartbeatMtc.WithLabelValues("actpoolSize", chainIDStr).Set(float64(actPoolSize))
 		heartbeatMtc.WithLabelValues("actpoolCapacity", chainIDStr).Set(float64(actPoolCapacity))
+		heartbeatMtc.WithLabelValues("actpoolEvictions", chainIDStr).Set(float64(actPoolEvictions))
 	}
 
 }
@@ -8,111 +8,412 @@ package itx
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
 
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/multierr"
+
+	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/chainservice"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
-
-	"github.com/pkg/errors"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 )
 
 // Server is the iotex server instance containing all components.
 type Server struct {
+	cfg           *config.Config
 	chainservices map[uint32]*chainservice.ChainService
 	p2p           network.Overlay
 	dispatcher    dispatcher.Dispatcher
 	rootChainAPI  explorer.Explorer
+	rootChainID   uint32
+	stopTimeout   time.Duration
+	hook          lifecycle.Hook
+	// registerer is the registry which the metrics of all the components are registered to, and gatherer gathers
+	// them together with the metrics registered by default
+	registerer prometheus.Registerer
+	gatherer   prometheus.Gatherer
+	// consensusFactory builds the consensus schemes of the chains if it's not nil
+	consensusFactory consensus.SchemeFactory
+
+	// mutex guards chainservices, hook and the component flags, so that the chains can be added and removed while the
+	// server is running
+	mutex        sync.RWMutex
+	dispatcherUp bool
+	p2pUp        bool
+}
+
+// Status is the health status of the server. The component flags tell whether the component of the root chain has
+// been started successfully.
+type Status struct {
+	Chain      bool
+	Dispatcher bool
+	Consensus  bool
+	BlockSync  bool
+	P2P        bool
+	Explorer   bool
+	// Height is the tip height of the root chain
+	Height uint64
+	// NumPeers is the number of connected peers
+	NumPeers int
+	// Healthy is true only when all the components are ready
+	Healthy bool
+}
+
+type optionParams struct {
+	dispatcher       dispatcher.Dispatcher
+	registerer       prometheus.Registerer
+	consensusFactory consensus.SchemeFactory
+}
+
+// Option sets Server construction parameter.
+type Option func(ops *optionParams) error
+
+// WithDispatcher is an option to replace the default dispatcher with a custom one.
+func WithDispatcher(dp dispatcher.Dispatcher) Option {
+	return func(ops *optionParams) error {
+		ops.dispatcher = dp
+		return nil
+	}
+}
+
+// WithMetricsRegisterer is an option to register the metrics of the server to the given registerer instead of a new
+// registry, e.g., to isolate the metrics in tests. They are served by MetricsHandler only if the registerer is also a
+// prometheus.Gatherer, e.g., a prometheus.Registry.
+func WithMetricsRegisterer(registerer prometheus.Registerer) Option {
+	return func(ops *optionParams) error {
+		ops.registerer = registerer
+		return nil
+	}
+}
+
+// WithConsensusFactory is an option to build the consensus schemes of the chains by the given factory instead of the
+// one registered for the configured scheme, e.g., to experiment with a consensus engine without registering it.
+func WithConsensusFactory(factory consensus.SchemeFactory) Option {
+	return func(ops *optionParams) error {
+		ops.consensusFactory = factory
+		return nil
+	}
 }
 
 // NewServer creates a new server
 // TODO clean up config, make root config contains network, dispatch and chainservice
-func NewServer(cfg *config.Config) (*Server, error) {
-	return newServer(cfg, false)
+func NewServer(cfg *config.Config, opts ...Option) (*Server, error) {
+	return newServer(cfg, false, opts...)
 }
 
 // NewInMemTestServer creates a test server in memory
-func NewInMemTestServer(cfg *config.Config) (*Server, error) {
-	return newServer(cfg, true)
+func NewInMemTestServer(cfg *config.Config, opts ...Option) (*Server, error) {
+	return newServer(cfg, true, opts...)
 }
 
-func newServer(cfg *config.Config, testing bool) (*Server, error) {
-	// create P2P network and BlockSync
-	p2p := network.NewOverlay(&cfg.Network)
+func newServer(cfg *config.Config, testing bool, opts ...Option) (*Server, error) {
+	var ops optionParams
+	for _, opt := range opts {
+		if err := opt(&ops); err != nil {
+			return nil, err
+		}
+	}
 
-	// create dispatcher instance
-	dispatcher, err := dispatcher.NewDispatcher(cfg)
+	// create P2P network, which does nothing in standalone mode
+	var p2p network.Overlay
+	if cfg.Network.Disabled {
+		p2p = network.NewNoopOverlay(&cfg.Network)
+	} else {
+		p2p = network.NewOverlay(&cfg.Network)
+	}
+	// the peers must run the chain of the same genesis block and consensus config
+	genesisHash, err := blockchain.GenesisHash(cfg)
 	if err != nil {
-		return nil, errors.Wrap(err, "fail to create dispatcher")
+		return nil, errors.Wrap(err, "fail to compute genesis hash")
 	}
-	p2p.AttachDispatcher(dispatcher)
+	p2p.SetGenesisHash(genesisHash)
 
-	chains := make(map[uint32]*chainservice.ChainService)
+	// create dispatcher instance if it's not given, which handles messages synchronously in testing mode
+	dp := ops.dispatcher
+	if dp == nil {
+		var dpOpts []dispatcher.Option
+		if testing {
+			dpOpts = []dispatcher.Option{dispatcher.WithSyncDispatch()}
+		}
+		dp, err = dispatcher.NewDispatcher(cfg, dpOpts...)
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to create dispatcher")
+		}
+	}
+	if overlay, ok := p2p.(*network.IotxOverlay); ok {
+		overlay.AttachDispatcher(dp)
+	}
 
-	var cs *chainservice.ChainService
+	chains := make(map[uint32]*chainservice.ChainService)
 
-	var opts []chainservice.Option
+	var csOpts []chainservice.Option
 	if testing {
-		opts = []chainservice.Option{chainservice.WithTesting()}
+		csOpts = append(csOpts, chainservice.WithTesting())
 	}
-	cs, err = chainservice.New(cfg, p2p, dispatcher, opts...)
+	if ops.consensusFactory != nil {
+		csOpts = append(csOpts, chainservice.WithConsensusFactory(ops.consensusFactory))
+	}
+	cs, err := chainservice.New(cfg, p2p, dp, csOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "fail to create chain service")
 	}
 
 	chains[cs.ChainID()] = cs
-	dispatcher.AddSubscriber(cs.ChainID(), cs)
-	return &Server{
+	dp.AddSubscriber(cs.ChainID(), cs)
+
+	// create the registry shared by all the components if it's not given
+	registerer := ops.registerer
+	if registerer == nil {
+		registerer = prometheus.NewRegistry()
+	}
+	gatherers := prometheus.Gatherers{prometheus.DefaultGatherer}
+	if gatherer, ok := registerer.(prometheus.Gatherer); ok && gatherer != prometheus.DefaultGatherer {
+		gatherers = append(gatherers, gatherer)
+	}
+	if err := registerer.Register(prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Name: "iotex_p2p_peers",
+			Help: "Number of connected peers.",
+		},
+		func() float64 { return float64(len(p2p.GetPeers())) },
+	)); err != nil {
+		return nil, errors.Wrap(err, "fail to register P2P metrics")
+	}
+	if err := registerer.Register(cs.Metrics()); err != nil {
+		return nil, errors.Wrap(err, "fail to register chain service metrics")
+	}
+	svr := &Server{
+		cfg:           cfg,
 		p2p:           p2p,
-		dispatcher:    dispatcher,
+		dispatcher:    dp,
 		rootChainAPI:  cs.Explorer().Explorer(),
+		rootChainID:   cs.ChainID(),
 		chainservices: chains,
-	}, nil
+		stopTimeout:   cfg.System.StopTimeout,
+		hook:          lifecycle.NopHook{},
+		registerer:    registerer,
+		gatherer:      gatherers,
+
+		consensusFactory: ops.consensusFactory,
+	}
+	cs.Explorer().SetConfigDumper(svr.DumpConfig)
+	return svr, nil
+}
+
+// DumpConfig returns the effective config of the server in JSON, after the defaults and overrides are applied, with
+// the secrets redacted
+func (s *Server) DumpConfig() ([]byte, error) {
+	cfg, err := json.MarshalIndent(s.cfg.Redacted(), "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal config")
+	}
+	return cfg, nil
 }
 
-// Start starts the server
+// GenesisHash returns the hash of the genesis block and the consensus config, which the peers must agree on
+func (s *Server) GenesisHash() hash.Hash32B { return s.p2p.GenesisHash() }
+
+// peersPollInterval is how often the number of peers is checked while waiting for them to start consensus
+const peersPollInterval = time.Second
+
+// Start starts the server. If Network.MinPeersToStart is set, it doesn't return until the peers are connected or
+// Network.MinPeersTimeout elapses, and meanwhile the consensus is paused, i.e., the blocks agreed by the others are
+// synced and committed, but the node doesn't propose or endorse blocks, so that it doesn't fork off a stale chain.
 func (s *Server) Start(ctx context.Context) error {
-	for _, cs := range s.chainservices {
+	gated := !s.cfg.Network.Disabled && s.cfg.Network.MinPeersToStart > 0
+	for _, cs := range s.chainServices() {
+		if gated {
+			cs.Consensus().Pause()
+		}
 		if err := cs.Start(ctx); err != nil {
 			return errors.Wrap(err, "error when stopping blockchain")
 		}
+		// re-add the actions which were pending before the last shutdown
+		if err := cs.ActionPool().Recover(); err != nil {
+			return errors.Wrap(err, "error when recovering actpool")
+		}
 	}
-	if err := s.dispatcher.Start(ctx); err != nil {
-		return errors.Wrap(err, "error when starting dispatcher")
+	if err := s.startComponent(ctx, "dispatcher", s.dispatcher); err != nil {
+		return err
 	}
-	if err := s.p2p.Start(ctx); err != nil {
-		return errors.Wrap(err, "error when starting P2P networks")
+	s.mutex.Lock()
+	s.dispatcherUp = true
+	s.mutex.Unlock()
+	if s.cfg.Network.Disabled {
+		return nil
+	}
+	if err := s.startComponent(ctx, "P2P networks", s.p2p); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	s.p2pUp = true
+	s.mutex.Unlock()
+	if !gated {
+		return nil
+	}
+	if err := s.waitForPeers(ctx); err != nil {
+		return err
+	}
+	for _, cs := range s.chainServices() {
+		cs.Consensus().Resume()
 	}
 	return nil
 }
 
-// Stop stops the server
+// waitForPeers blocks until Network.MinPeersToStart peers are connected, or Network.MinPeersTimeout elapses
+func (s *Server) waitForPeers(ctx context.Context) error {
+	minPeers := s.cfg.Network.MinPeersToStart
+	var timeout <-chan time.Time
+	if s.cfg.Network.MinPeersTimeout > 0 {
+		timer := time.NewTimer(s.cfg.Network.MinPeersTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	ticker := time.NewTicker(peersPollInterval)
+	defer ticker.Stop()
+	lastNumPeers := -1
+	for {
+		numPeers := len(s.p2p.GetPeers())
+		if numPeers >= minPeers {
+			logger.Info().Int("peers", numPeers).Msg("Connected to enough peers, starting consensus")
+			return nil
+		}
+		if numPeers != lastNumPeers {
+			logger.Info().
+				Int("peers", numPeers).
+				Int("min-peers", minPeers).
+				Msg("Waiting for peers before starting consensus")
+			lastNumPeers = numPeers
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "interrupted while waiting for peers")
+		case <-timeout:
+			logger.Warn().
+				Int("peers", numPeers).
+				Int("min-peers", minPeers).
+				Dur("timeout", s.cfg.Network.MinPeersTimeout).
+				Msg("Timed out waiting for peers, starting consensus anyway")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops the server. It keeps stopping the remaining components when one of them fails or doesn't stop within
+// the configured timeout, and returns all the errors together.
 func (s *Server) Stop(ctx context.Context) error {
-	if err := s.p2p.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping P2P networks")
+	s.mutex.Lock()
+	s.dispatcherUp = false
+	s.p2pUp = false
+	s.mutex.Unlock()
+	var err error
+	if !s.cfg.Network.Disabled {
+		err = s.stopComponent(ctx, "P2P networks", s.p2p)
 	}
-	if err := s.dispatcher.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping dispatcher")
+	err = multierr.Append(err, s.stopComponent(ctx, "dispatcher", s.dispatcher))
+	for _, cs := range s.chainServices() {
+		if e := cs.Stop(ctx); e != nil {
+			err = multierr.Append(err, errors.Wrap(e, "error when stopping blockchain"))
+		}
+	}
+	return err
+}
+
+// MetricsHandler returns the HTTP handler serving the metrics of all the components in Prometheus format, together with
+// the metrics registered to the default registry
+func (s *Server) MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(s.gatherer, promhttp.HandlerOpts{})
+}
+
+// SetLogLevel changes the minimum level of the logs at runtime, e.g., to debug a running node
+func (s *Server) SetLogLevel(level string) error {
+	return logger.SetLevel(level)
+}
+
+// SetLifecycleHook sets the hook which is notified when a component of the server starts or stops, including the
+// components of all chain services. By default, the hook does nothing.
+func (s *Server) SetLifecycleHook(hook lifecycle.Hook) {
+	if hook == nil {
+		hook = lifecycle.NopHook{}
 	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.hook = hook
 	for _, cs := range s.chainservices {
-		if err := cs.Stop(ctx); err != nil {
-			return errors.Wrap(err, "error when stopping blockchain")
-		}
+		cs.SetLifecycleHook(hook)
+	}
+}
+
+func (s *Server) startComponent(ctx context.Context, name string, starter lifecycle.Starter) error {
+	hook := s.lifecycleHook()
+	hook.OnPhase(name, lifecycle.Starting)
+	if err := starter.Start(ctx); err != nil {
+		return errors.Wrapf(err, "error when starting %s", name)
+	}
+	hook.OnPhase(name, lifecycle.Started)
+	return nil
+}
+
+func (s *Server) stopComponent(ctx context.Context, name string, stopper lifecycle.Stopper) error {
+	hook := s.lifecycleHook()
+	hook.OnPhase(name, lifecycle.Stopping)
+	if err := lifecycle.StopWithTimeout(ctx, stopper, s.stopTimeout); err != nil {
+		return errors.Wrapf(err, "error when stopping %s", name)
 	}
+	hook.OnPhase(name, lifecycle.Stopped)
 	return nil
 }
 
+func (s *Server) lifecycleHook() lifecycle.Hook {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.hook
+}
+
+// Status returns the health status of the server
+func (s *Server) Status() Status {
+	s.mutex.RLock()
+	status := Status{
+		Dispatcher: s.dispatcherUp,
+		P2P:        s.p2pUp,
+	}
+	s.mutex.RUnlock()
+	if cs, ok := s.chainService(s.rootChainID); ok {
+		csStatus := cs.Status()
+		status.Chain = csStatus.Chain
+		status.Consensus = csStatus.Consensus
+		status.BlockSync = csStatus.BlockSync
+		status.Explorer = csStatus.Explorer
+		status.Height = cs.Blockchain().TipHeight()
+	}
+	status.NumPeers = len(s.p2p.GetPeers())
+	// the explorer and the P2P network aren't required to be healthy if they're disabled
+	status.Healthy = status.Chain && status.Dispatcher && status.Consensus && status.BlockSync &&
+		(status.P2P || s.cfg.Network.Disabled) && (status.Explorer || !s.cfg.Explorer.Enabled)
+	return status
+}
+
 // NewChainService creates a new chain service in this server.
 func (s *Server) NewChainService(cfg *config.Config) error {
 	opts := []chainservice.Option{chainservice.WithRootChainAPI(s.rootChainAPI)}
-	cs, err := chainservice.New(cfg, s.p2p, s.dispatcher, opts...)
+	cs, err := chainservice.New(cfg, s.p2p, s.dispatcher, s.withConsensusFactory(opts)...)
 	if err != nil {
 		return err
 	}
-	s.chainservices[cs.ChainID()] = cs
-	s.dispatcher.AddSubscriber(cs.ChainID(), cs)
-	return nil
+	return s.addChainService(cs)
 }
 
 // NewTestingChainService creates a new testing chain service in this server.
@@ -121,18 +422,87 @@ func (s *Server) NewTestingChainService(cfg *config.Config) error {
 		chainservice.WithTesting(),
 		chainservice.WithRootChainAPI(s.rootChainAPI),
 	}
-	cs, err := chainservice.New(cfg, s.p2p, s.dispatcher, opts...)
+	cs, err := chainservice.New(cfg, s.p2p, s.dispatcher, s.withConsensusFactory(opts)...)
+	if err != nil {
+		return err
+	}
+	return s.addChainService(cs)
+}
+
+// AddChain creates a chain service on top of the given blockchain, together with its own actpool, blocksync, consensus
+// and explorer, and registers it to the dispatcher with the blockchain's chain ID. The server config is reused, except
+// that the explorer of the new chain binds to a random port, which can be found by ChainService(id).Explorer().Port().
+func (s *Server) AddChain(chain blockchain.Blockchain) error {
+	if chain == nil {
+		return errors.New("blockchain is nil")
+	}
+	if _, ok := s.chainService(chain.ChainID()); ok {
+		return errors.Errorf("chain %d already exists", chain.ChainID())
+	}
+	cfg := *s.cfg
+	cfg.Chain.ID = chain.ChainID()
+	cfg.Explorer.Port = 0
+	opts := []chainservice.Option{
+		chainservice.WithBlockchain(chain),
+		chainservice.WithRootChainAPI(s.rootChainAPI),
+	}
+	cs, err := chainservice.New(&cfg, s.p2p, s.dispatcher, s.withConsensusFactory(opts)...)
 	if err != nil {
 		return err
 	}
+	return s.addChainService(cs)
+}
+
+// withConsensusFactory appends the option of the server's consensus factory if there is one
+func (s *Server) withConsensusFactory(opts []chainservice.Option) []chainservice.Option {
+	if s.consensusFactory == nil {
+		return opts
+	}
+	return append(opts, chainservice.WithConsensusFactory(s.consensusFactory))
+}
+
+// addChainService registers the chain service's metrics, hooks and message subscription
+func (s *Server) addChainService(cs *chainservice.ChainService) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// the chain may be added concurrently while the chain service is being created
+	if _, ok := s.chainservices[cs.ChainID()]; ok {
+		return errors.Errorf("chain %d already exists", cs.ChainID())
+	}
+	if err := s.registerer.Register(cs.Metrics()); err != nil {
+		return errors.Wrap(err, "fail to register chain service metrics")
+	}
+	cs.SetLifecycleHook(s.hook)
+	cs.Explorer().SetConfigDumper(s.DumpConfig)
 	s.chainservices[cs.ChainID()] = cs
 	s.dispatcher.AddSubscriber(cs.ChainID(), cs)
 	return nil
 }
 
+// RemoveChain stops the chain service with the given chain ID and removes it from the server. The dispatcher stops
+// routing the messages of the chain to the chain service before it's stopped. The root chain cannot be removed.
+func (s *Server) RemoveChain(ctx context.Context, id uint32) error {
+	s.mutex.Lock()
+	cs, ok := s.chainservices[id]
+	if !ok {
+		s.mutex.Unlock()
+		return errors.Errorf("chain %d does not exist", id)
+	}
+	if id == s.rootChainID {
+		s.mutex.Unlock()
+		return errors.New("cannot remove the root chain")
+	}
+	s.dispatcher.RemoveSubscriber(id)
+	s.registerer.Unregister(cs.Metrics())
+	delete(s.chainservices, id)
+	s.mutex.Unlock()
+	// the chain service is stopped without holding the lock, as it may take a while
+	return cs.Stop(ctx)
+}
+
 // StartChainService starts the chain service run in the server.
 func (s *Server) StartChainService(ctx context.Context, id uint32) error {
-	c, ok := s.chainservices[id]
+	c, ok := s.chainService(id)
 	if !ok {
 		return errors.New("Chain ID does not match any existing chains")
 	}
@@ -141,7 +511,7 @@ func (s *Server) StartChainService(ctx context.Context, id uint32) error {
 
 // StopChainService stops the chain service run in the server.
 func (s *Server) StopChainService(ctx context.Context, id uint32) error {
-	c, ok := s.chainservices[id]
+	c, ok := s.chainService(id)
 	if !ok {
 		return errors.New("Chain ID does not match any existing chains")
 	}
@@ -154,9 +524,54 @@ func (s *Server) P2P() network.Overlay {
 }
 
 // ChainService returns the chainservice hold in Server with given id.
-func (s *Server) ChainService(id uint32) *chainservice.ChainService { return s.chainservices[id] }
+func (s *Server) ChainService(id uint32) *chainservice.ChainService {
+	cs, _ := s.chainService(id)
+	return cs
+}
+
+// Blockchain returns the blockchain of the default (root) chain
+func (s *Server) Blockchain() blockchain.Blockchain {
+	return s.BlockchainByID(s.rootChainID)
+}
+
+// Consensus returns the consensus of the default (root) chain
+func (s *Server) Consensus() consensus.Consensus {
+	cs, ok := s.chainService(s.rootChainID)
+	if !ok {
+		return nil
+	}
+	return cs.Consensus()
+}
+
+// BlockchainByID returns the blockchain with the given chain ID, or nil if there's no such chain
+func (s *Server) BlockchainByID(id uint32) blockchain.Blockchain {
+	cs, ok := s.chainService(id)
+	if !ok {
+		return nil
+	}
+	return cs.Blockchain()
+}
 
 // Dispatcher returns the Dispatcher
 func (s *Server) Dispatcher() dispatcher.Dispatcher {
 	return s.dispatcher
 }
+
+// chainService returns the chain service with the given chain ID, and whether it exists
+func (s *Server) chainService(id uint32) (*chainservice.ChainService, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	cs, ok := s.chainservices[id]
+	return cs, ok
+}
+
+// chainServices returns the chain services, which are started or stopped without holding the lock
+func (s *Server) chainServices() []*chainservice.ChainService {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	css := make([]*chainservice.ChainService, 0, len(s.chainservices))
+	for _, cs := range s.chainservices {
+		css = append(css, cs)
+	}
+	return css
+}
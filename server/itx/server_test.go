@@ -0,0 +1,114 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/dispatcher"
+	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
+)
+
+func newTestConfig() config.Config {
+	cfg := config.Default
+	cfg.Consensus.Scheme = config.NOOPScheme
+	cfg.Network.Disabled = true
+	cfg.Explorer.Enabled = false
+	return cfg
+}
+
+func TestServer_AddRemoveChain(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the mock dispatcher records which subscriber the messages of each chain are routed to
+	subscribers := make(map[uint32]dispatcher.Subscriber)
+	dp := mock_dispatcher.NewMockDispatcher(ctrl)
+	dp.EXPECT().AddSubscriber(gomock.Any(), gomock.Any()).Do(func(id uint32, sub dispatcher.Subscriber) {
+		subscribers[id] = sub
+	}).Times(2)
+	dp.EXPECT().RemoveSubscriber(gomock.Any()).Do(func(id uint32) {
+		delete(subscribers, id)
+	}).Times(1)
+
+	cfg := newTestConfig()
+	svr, err := NewInMemTestServer(&cfg, WithDispatcher(dp))
+	require.NoError(err)
+	rootID := cfg.Chain.ID
+	require.True(svr.ChainService(rootID) == subscribers[rootID])
+
+	subCfg := cfg
+	subCfg.Chain.ID = rootID + 1
+	subID := subCfg.Chain.ID
+	chain := blockchain.NewBlockchain(&subCfg, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NotNil(chain)
+
+	// the chains are read concurrently while the chain is added and removed
+	done := make(chan struct{})
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				svr.BlockchainByID(subID)
+				svr.ChainService(rootID)
+			}
+		}
+	}()
+
+	require.NoError(svr.AddChain(chain))
+	require.Error(svr.AddChain(chain))
+	require.Equal(chain, svr.BlockchainByID(subID))
+	require.True(svr.ChainService(subID) == subscribers[subID])
+
+	ctx := context.Background()
+	require.NoError(svr.StartChainService(ctx, subID))
+	require.Error(svr.RemoveChain(ctx, rootID))
+	require.NoError(svr.RemoveChain(ctx, subID))
+	close(done)
+	<-readerDone
+
+	require.Nil(svr.BlockchainByID(subID))
+	require.Nil(svr.ChainService(subID))
+	_, ok := subscribers[subID]
+	require.False(ok)
+	require.Error(svr.RemoveChain(ctx, subID))
+	require.True(svr.ChainService(rootID) == subscribers[rootID])
+}
+
+func TestServer_WithDispatcher(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := newTestConfig()
+	dp := mock_dispatcher.NewMockDispatcher(ctrl)
+	dp.EXPECT().AddSubscriber(cfg.Chain.ID, gomock.Any()).Times(1)
+	dp.EXPECT().Start(gomock.Any()).Return(nil).Times(1)
+	dp.EXPECT().Stop(gomock.Any()).Return(nil).Times(1)
+
+	// the injected dispatcher is used instead of creating a new one
+	svr, err := NewInMemTestServer(&cfg, WithDispatcher(dp))
+	require.NoError(err)
+	require.True(svr.Dispatcher() == dp)
+
+	ctx := context.Background()
+	require.NoError(svr.Start(ctx))
+	require.True(svr.Status().Dispatcher)
+	require.NoError(svr.Stop(ctx))
+	require.False(svr.Status().Dispatcher)
+}
@@ -19,7 +19,6 @@ import (
 	_ "net/http/pprof"
 	"os"
 
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	_ "go.uber.org/automaxprocs"
 
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -104,7 +103,7 @@ func main() {
 
 	if cfg.System.HTTPMetricsPort > 0 {
 		mux := http.NewServeMux()
-		mux.Handle("/metrics", promhttp.Handler())
+		mux.Handle("/metrics", svr.MetricsHandler())
 		port := fmt.Sprintf(":%d", cfg.System.HTTPMetricsPort)
 		go func() {
 			if err := http.ListenAndServe(port, mux); err != nil {
@@ -121,7 +120,7 @@ func initLogger(cfg *config.Config) {
 		logger.Fatal().Err(err).Msg("Failed to get producer address from pub/kri key.")
 		return
 	}
-	l, err := logger.New()
+	l, err := logger.NewWithConfig(cfg.Log.Level, cfg.Log.Format, cfg.Log.Path)
 	if err != nil {
 		logger.Warn().Err(err).Msg("Cannot config logger, use default one.")
 	} else {
@@ -19,17 +19,6 @@ import (
 	"github.com/iotexproject/iotex-core/proto"
 )
 
-var (
-	// ErrCandidate indicates the error of candidate
-	ErrCandidate = errors.New("invalid candidate")
-	// ErrCandidatePb indicates the error of protobuf's candidate message
-	ErrCandidatePb = errors.New("invalid protobuf's candidate message")
-	// ErrCandidateMap indicates the error of candidate map
-	ErrCandidateMap = errors.New("invalid candidate map")
-	// ErrCandidateList indicates the error of candidate list
-	ErrCandidateList = errors.New("invalid candidate list")
-)
-
 // Candidate indicates the structure of a candidate
 type Candidate struct {
 	Address          string
@@ -39,14 +28,25 @@ type Candidate struct {
 	LastUpdateHeight uint64
 }
 
-// CandidateList indicates the list of candidates which is sortable
+// CandidateList indicates the list of candidates which is sortable in descending order of votes, and ascending
+// order of addresses on tie
 type CandidateList []*Candidate
 
 func (l CandidateList) Len() int      { return len(l) }
 func (l CandidateList) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
 func (l CandidateList) Less(i, j int) bool {
-	res := l[i].Votes.Cmp(l[j].Votes)
-	return res == 1
+	if res := l[i].Votes.Cmp(l[j].Votes); res != 0 {
+		return res == 1
+	}
+	return l[i].Address < l[j].Address
+}
+
+func (c *Candidate) clone() *Candidate {
+	cand := *c
+	if c.Votes != nil {
+		cand.Votes = new(big.Int).Set(c.Votes)
+	}
+	return &cand
 }
 
 // candidateToPb converts a candidate to protobuf's candidate message
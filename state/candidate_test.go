@@ -76,3 +76,21 @@ func TestCandidate(t *testing.T) {
 	require.Equal(uint64(2), candidateMap[cand2Hash].Votes.Uint64())
 	require.Equal(uint64(3), candidateMap[cand3Hash].Votes.Uint64())
 }
+
+func TestCandidateListTieBreak(t *testing.T) {
+	require := require.New(t)
+
+	candidates := CandidateList{
+		{Address: "io1c", Votes: big.NewInt(5)},
+		{Address: "io1b", Votes: big.NewInt(10)},
+		{Address: "io1d", Votes: big.NewInt(5)},
+		{Address: "io1a", Votes: big.NewInt(5)},
+	}
+	sort.Sort(candidates)
+
+	addrs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		addrs = append(addrs, candidate.Address)
+	}
+	require.Equal([]string{"io1b", "io1a", "io1c", "io1d"}, addrs)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package state
+
+import (
+	"github.com/pkg/errors"
+)
+
+// The errors returned by the state package are wrapped around the following ones, which callers should compare against
+// errors.Cause(err) instead of matching the error messages
+var (
+	// ErrStateNotExist is the error that the state of the account does not exist
+	ErrStateNotExist = errors.New("state does not exist")
+
+	// ErrAccountNotExist is the error that the account does not exist, which is the same error as ErrStateNotExist
+	ErrAccountNotExist = ErrStateNotExist
+
+	// ErrNotEnoughBalance is the error that the balance is not enough
+	ErrNotEnoughBalance = errors.New("not enough balance")
+
+	// ErrAccountCollision is the error that the account already exists
+	ErrAccountCollision = errors.New("account already exists")
+
+	// ErrFailedToMarshalState is the error that the state marshaling is failed
+	ErrFailedToMarshalState = errors.New("failed to marshal state")
+
+	// ErrFailedToUnmarshalState is the error that the state un-marshaling is failed
+	ErrFailedToUnmarshalState = errors.New("failed to unmarshal state")
+
+	// ErrUnsupportedStateVersion is the error that the version of the serialized state is unknown
+	ErrUnsupportedStateVersion = errors.New("unsupported state version")
+
+	// ErrSnapshotNotExist is the error that the snapshot to revert to does not exist
+	ErrSnapshotNotExist = errors.New("snapshot does not exist")
+
+	// ErrHistoricalStateUnavailable is the error that the states at a past height are not kept
+	ErrHistoricalStateUnavailable = errors.New("historical state unavailable")
+
+	// ErrCandidate indicates the error of candidate
+	ErrCandidate = errors.New("invalid candidate")
+
+	// ErrCandidatePb indicates the error of protobuf's candidate message
+	ErrCandidatePb = errors.New("invalid protobuf's candidate message")
+
+	// ErrCandidateMap indicates the error of candidate map
+	ErrCandidateMap = errors.New("invalid candidate map")
+
+	// ErrCandidateList indicates the error of candidate list
+	ErrCandidateList = errors.New("invalid candidate list")
+)
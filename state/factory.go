@@ -10,6 +10,7 @@ import (
 	"context"
 	"math/big"
 	"sort"
+	"sync"
 
 	"github.com/boltdb/bolt"
 	"github.com/pkg/errors"
@@ -25,45 +26,46 @@ import (
 	"github.com/iotexproject/iotex-core/trie"
 )
 
-var (
-	// ErrNotEnoughBalance is the error that the balance is not enough
-	ErrNotEnoughBalance = errors.New("not enough balance")
-
-	// ErrAccountNotExist is the error that the account does not exist
-	ErrAccountNotExist = errors.New("account does not exist")
-
-	// ErrAccountCollision is the error that the account already exists
-	ErrAccountCollision = errors.New("account already exists")
-
-	// ErrFailedToMarshalState is the error that the state marshaling is failed
-	ErrFailedToMarshalState = errors.New("failed to marshal state")
-
-	// ErrFailedToUnmarshalState is the error that the state un-marshaling is failed
-	ErrFailedToUnmarshalState = errors.New("failed to unmarshal state")
-)
-
 const (
 	// CurrentHeightKey indicates the key of current factory height in underlying DB
 	CurrentHeightKey = "currentHeight"
 	// AccountTrieRootKey indicates the key of accountTrie root hash in underlying DB
 	AccountTrieRootKey = "accountTrieRoot"
+	// PrunedHeightKey indicates the key of the height below which the states have been pruned in underlying DB
+	PrunedHeightKey = "prunedHeight"
 )
 
 type (
+	// StateReader defines an interface for querying states without being able to modify them
+	StateReader interface {
+		Balance(string) (*big.Int, error)
+		Nonce(string) (uint64, error) // Note that nonce starts with 1.
+		State(string) (*State, error)
+		StateAtHeight(string, uint64) (*State, error)
+		CandidatesByHeight(uint64) ([]*Candidate, error)
+		AccumulatedBlockReward(uint64) (*big.Int, error)
+	}
+
 	// Factory defines an interface for managing states
 	Factory interface {
 		lifecycle.StartStopper
+		StateReader
 		// Accounts
 		LoadOrCreateState(string, uint64) (*State, error)
-		Balance(string) (*big.Int, error)
-		Nonce(string) (uint64, error) // Note that nonce starts with 1.
-		State(string) (*State, error)
 		CachedState(string) (*State, error)
 		RootHash() hash.Hash32B
 		Height() (uint64, error)
 		RunActions(uint64, []*action.Transfer, []*action.Vote, []*action.Execution) (hash.Hash32B, error)
+		// Block rewards
+		ApplyBlockReward(string, *big.Int, *big.Int) error
+		// Pruning
+		PruneBelow(uint64) error
+		CollectTrieNodes(context.Context, sync.Locker, int) (int, error)
 		HasRun() bool
 		Commit() error
+		// Snapshots
+		Snapshot() int
+		RevertToSnapshot(int) error
 		// Contracts
 		GetCodeHash(hash.PKHash) (hash.Hash32B, error)
 		GetCode(hash.PKHash) ([]byte, error)
@@ -72,7 +74,6 @@ type (
 		SetContractState(hash.PKHash, hash.Hash32B, hash.Hash32B) error
 		// Candidate pool
 		Candidates() (uint64, []*Candidate)
-		CandidatesByHeight(uint64) ([]*Candidate, error)
 	}
 
 	// factory implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
@@ -90,6 +91,24 @@ type (
 		rootHash       hash.Hash32B             // new root hash after running executions in this block
 		accountTrie    trie.Trie                // global state trie
 		dao            db.CachedKVStore         // the underlying DB for account/contract storage
+		snapshots      []*snapshot              // snapshots taken since the last commit
+		pendingReward  *big.Int                 // block reward minted in this block
+		// voting decay applied to candidates at the beginning of every epoch
+		epochLength uint64
+		votingDecay VotingDecayFunc
+	}
+
+	// snapshot records what RevertToSnapshot needs to roll the factory back
+	snapshot struct {
+		accountTrieRoot    hash.Hash32B
+		height             []byte // factory's height persisted in DB, nil if not persisted yet
+		currentChainHeight uint64
+		run                bool
+		rootHash           hash.Hash32B
+		savedAccount       map[string]*State
+		cachedAccount      map[hash.PKHash]*State
+		cachedCandidates   map[hash.PKHash]*Candidate
+		pendingReward      *big.Int
 	}
 )
 
@@ -155,6 +174,22 @@ func InMemTrieOption() FactoryOption {
 	}
 }
 
+// VotingDecayOption decays the votes on the candidates at the beginning of each epoch of epochLength blocks, according
+// to the number of blocks since each voter's last vote. The votes are tracked per voter in Voters, and the voting
+// weight is recomputed from them by State.ApplyVotingDecay, so the decay doesn't compound over epochs. It overrides
+// the voting decay configured by Chain.VotingDecay, and should be given since genesis, as the votes cast without it
+// aren't tracked per voter
+func VotingDecayOption(epochLength uint64, decayFn VotingDecayFunc) FactoryOption {
+	return func(sf *factory, cfg *config.Config) error {
+		if epochLength == 0 {
+			return errors.New("epoch length cannot be 0")
+		}
+		sf.epochLength = epochLength
+		sf.votingDecay = decayFn
+		return nil
+	}
+}
+
 // NewFactory creates a new state factory
 func NewFactory(cfg *config.Config, opts ...FactoryOption) (Factory, error) {
 	sf := &factory{
@@ -164,6 +199,16 @@ func NewFactory(cfg *config.Config, opts ...FactoryOption) (Factory, error) {
 		savedAccount:       make(map[string]*State),
 		cachedAccount:      make(map[hash.PKHash]*State),
 		cachedContract:     make(map[hash.PKHash]Contract),
+		pendingReward:      big.NewInt(0),
+	}
+	if decay := cfg.Chain.VotingDecay; decay.EpochLength > 0 {
+		sf.epochLength = decay.EpochLength
+		switch decay.Curve {
+		case config.ExponentialVotingDecay:
+			sf.votingDecay = ExponentialVotingDecay(decay.Period, decay.Percent)
+		default:
+			sf.votingDecay = LinearVotingDecay(decay.Period, decay.Percent)
+		}
 	}
 
 	for _, opt := range opts {
@@ -195,7 +240,7 @@ func (sf *factory) LoadOrCreateState(addr string, init uint64) (*State, error) {
 	addrHash := byteutil.BytesTo20B(h)
 	state, err := sf.cachedState(addrHash)
 	switch {
-	case errors.Cause(err) == ErrAccountNotExist:
+	case errors.Cause(err) == ErrStateNotExist:
 		balance := big.NewInt(0)
 		balance.SetUint64(init)
 		state = &State{
@@ -253,6 +298,49 @@ func (sf *factory) State(addr string) (*State, error) {
 	return sf.getState(byteutil.BytesTo20B(pkHash))
 }
 
+// StateAtHeight returns the confirmed state on the chain as of the given height. The state is read from the
+// accountTrie of the root hash recorded on that height, which remains intact in DB since the trie is copy-on-write.
+// ErrHistoricalStateUnavailable is returned if no root hash has been recorded on that height, or it has been pruned
+func (sf *factory) StateAtHeight(addr string, height uint64) (*State, error) {
+	pkHash, err := iotxaddress.GetPubkeyHash(addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting the pubkey hash")
+	}
+	if err := sf.checkPruned(height); err != nil {
+		return nil, err
+	}
+	rootBytes, err := sf.dao.Get(trie.AccountRootKVNameSpace, byteutil.Uint64ToBytes(height))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, bolt.ErrBucketNotFound:
+		return nil, errors.Wrapf(ErrHistoricalStateUnavailable, "no accountTrie's root hash on height %d", height)
+	default:
+		return nil, errors.Wrapf(err, "failed to get accountTrie's root hash on height %d", height)
+	}
+	root := byteutil.BytesTo32B(rootBytes)
+	// the trie shares the DB with accountTrie, so it is not started to avoid starting the DB again
+	tr, err := trie.NewTrieSharedDB(sf.dao, trie.AccountKVNameSpace, root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create accountTrie on root %x", root)
+	}
+	if err := tr.SetRoot(root); err != nil {
+		return nil, errors.Wrapf(
+			ErrHistoricalStateUnavailable,
+			"failed to load accountTrie's root hash on height %d: %v",
+			height,
+			err,
+		)
+	}
+	mstate, err := tr.Get(pkHash)
+	if errors.Cause(err) == trie.ErrNotExist {
+		return nil, errors.Wrapf(ErrStateNotExist, "addrHash = %x, height = %d", pkHash, height)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get state of %x on height %d", pkHash, height)
+	}
+	return bytesToState(mstate)
+}
+
 // CachedState returns the cached state if the address exists in local cache
 func (sf *factory) CachedState(addr string) (*State, error) {
 	h, err := iotxaddress.GetPubkeyHash(addr)
@@ -309,12 +397,15 @@ func (sf *factory) RunActions(
 		}
 	}
 
-	if err := sf.handleTsf(tsf); err != nil {
+	if err := sf.handleTsf(blockHeight, tsf); err != nil {
 		return sf.rootHash, errors.Wrap(err, "failed to handle transfers")
 	}
 	if err := sf.handleVote(blockHeight, vote); err != nil {
 		return sf.rootHash, errors.Wrap(err, "failed to handle votes")
 	}
+	if err := sf.applyVotingDecay(blockHeight); err != nil {
+		return sf.rootHash, errors.Wrap(err, "failed to apply voting decay")
+	}
 
 	// update pending state changes to trie
 	for addr, state := range sf.cachedAccount {
@@ -369,6 +460,10 @@ func (sf *factory) RunActions(
 	if err := sf.dao.Put(trie.AccountKVNameSpace, []byte(AccountTrieRootKey), sf.rootHash[:]); err != nil {
 		return sf.rootHash, errors.Wrap(err, "failed to store accountTrie's root hash")
 	}
+	// Persist accountTrie's root hash on this height, so that the states at this height could be read later
+	if err := sf.dao.Put(trie.AccountRootKVNameSpace, byteutil.Uint64ToBytes(blockHeight), sf.rootHash[:]); err != nil {
+		return sf.rootHash, errors.Wrapf(err, "failed to store accountTrie's root hash on height %d", blockHeight)
+	}
 	// Persist new list of candidates
 	candidates, err := MapToCandidates(sf.cachedCandidates)
 	if err != nil {
@@ -382,6 +477,18 @@ func (sf *factory) RunActions(
 	if err := sf.dao.Put(trie.CandidateKVNameSpace, byteutil.Uint64ToBytes(blockHeight), candidatesBytes); err != nil {
 		return sf.rootHash, errors.Wrapf(err, "failed to store candidates on height %d", blockHeight)
 	}
+	// Persist the block rewards accumulated until this block
+	accumulatedReward := big.NewInt(0)
+	if blockHeight > 0 {
+		if accumulatedReward, err = sf.AccumulatedBlockReward(blockHeight - 1); err != nil {
+			return sf.rootHash, errors.Wrapf(err, "failed to get accumulated block reward on height %d", blockHeight-1)
+		}
+	}
+	accumulatedReward.Add(accumulatedReward, sf.pendingReward)
+	rewardKey := byteutil.Uint64ToBytes(blockHeight)
+	if err := sf.dao.Put(trie.RewardKVNameSpace, rewardKey, accumulatedReward.Bytes()); err != nil {
+		return sf.rootHash, errors.Wrapf(err, "failed to store accumulated block reward on height %d", blockHeight)
+	}
 	// Persist current chain height
 	sf.currentChainHeight = blockHeight
 	if err := sf.dao.Put(trie.AccountKVNameSpace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(blockHeight)); err != nil {
@@ -390,6 +497,147 @@ func (sf *factory) RunActions(
 	return sf.rootHash, nil
 }
 
+// ApplyBlockReward credits the block reward and the fees collected in the block to the producer, and adds the block
+// reward to the accumulated block reward. The fees are paid by the senders rather than minted, so they are not
+// accumulated. It takes effect together with the other changes of the block in RunActions(), so it should be called
+// before RunActions(). The coinbase transfers run by RunActions() are accounted as block reward as well
+func (sf *factory) ApplyBlockReward(producer string, reward *big.Int, fees *big.Int) error {
+	if sf.run {
+		return errors.New("block reward should be applied before running actions")
+	}
+	if reward == nil {
+		reward = big.NewInt(0)
+	}
+	if fees == nil {
+		fees = big.NewInt(0)
+	}
+	if reward.Sign() < 0 || fees.Sign() < 0 {
+		return errors.Errorf("negative block reward %s or fees %s", reward, fees)
+	}
+	if err := sf.creditReward(producer, new(big.Int).Add(reward, fees)); err != nil {
+		return errors.Wrapf(err, "failed to credit block reward to producer %s", producer)
+	}
+	sf.pendingReward.Add(sf.pendingReward, reward)
+	return nil
+}
+
+// AccumulatedBlockReward returns the total block reward credited until the given height, which is zero if no block
+// reward has been recorded. ErrHistoricalStateUnavailable is returned if the height has been pruned
+func (sf *factory) AccumulatedBlockReward(height uint64) (*big.Int, error) {
+	if err := sf.checkPruned(height); err != nil {
+		return nil, err
+	}
+	rewardBytes, err := sf.dao.Get(trie.RewardKVNameSpace, byteutil.Uint64ToBytes(height))
+	switch errors.Cause(err) {
+	case nil:
+		return new(big.Int).SetBytes(rewardBytes), nil
+	case db.ErrNotExist, bolt.ErrBucketNotFound:
+		return big.NewInt(0), nil
+	default:
+		return nil, errors.Wrapf(err, "failed to get accumulated block reward on height %d", height)
+	}
+}
+
+// PruneBelow discards the accountTrie's root hashes, the candidates and the accumulated block rewards recorded on the
+// heights below the given height, except the genesis height, so that the states on those heights are no longer
+// available. The nodes of accountTrie are shared by the heights, hence they are left to CollectTrieNodes(). The
+// deletions are committed right away, or together with the block if RunActions() has been called
+func (sf *factory) PruneBelow(height uint64) error {
+	currentHeight, err := sf.Height()
+	if err != nil {
+		return err
+	}
+	if height > currentHeight {
+		return errors.Errorf("cannot prune states below %d, which is higher than current height %d", height, currentHeight)
+	}
+	prunedHeight, err := sf.prunedHeight()
+	if err != nil {
+		return err
+	}
+	if prunedHeight == 0 {
+		prunedHeight = 1
+	}
+	if height <= prunedHeight {
+		return nil
+	}
+	for h := prunedHeight; h < height; h++ {
+		key := byteutil.Uint64ToBytes(h)
+		for _, ns := range []string{trie.AccountRootKVNameSpace, trie.CandidateKVNameSpace, trie.RewardKVNameSpace} {
+			if err := sf.dao.Delete(ns, key); err != nil {
+				return errors.Wrapf(err, "failed to delete %s on height %d", ns, h)
+			}
+		}
+	}
+	if err := sf.dao.Put(trie.AccountKVNameSpace, []byte(PrunedHeightKey), byteutil.Uint64ToBytes(height)); err != nil {
+		return errors.Wrap(err, "failed to store factory's pruned height")
+	}
+	if sf.run {
+		return nil
+	}
+	return sf.dao.Commit()
+}
+
+// CollectTrieNodes deletes the nodes of accountTrie and the contract storage tries which are no longer reachable from
+// the root hashes recorded on the genesis height and the heights not pruned yet. The reachable nodes are marked
+// without holding the lock, and the unreachable ones are deleted in batches of the given size while holding it, so the
+// lock must keep the factory from committing. The roots committed in the meantime are marked before every batch, hence
+// a node which gets reachable again is never deleted. The contract code is not collected. It returns the number of the
+// deleted nodes
+func (sf *factory) CollectTrieNodes(ctx context.Context, lock sync.Locker, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		return 0, errors.Errorf("invalid batch size %d", batchSize)
+	}
+	m := newTrieMarker(sf.dao.KVStore())
+	// the nodes committed after being listed are never deleted
+	candidates := make(map[string][]hash.Hash32B)
+	for _, ns := range []string{trie.AccountKVNameSpace, trie.ContractKVNameSpace} {
+		if err := m.kv.ForEach(ns, func(key, _ []byte) error {
+			if len(key) == hash.HashSize {
+				if node := byteutil.BytesTo32B(key); node != trie.EmptyRoot {
+					candidates[ns] = append(candidates[ns], node)
+				}
+			}
+			return ctx.Err()
+		}); err != nil {
+			return 0, errors.Wrapf(err, "failed to list the trie nodes in %s", ns)
+		}
+	}
+	prunedHeight, err := m.height(PrunedHeightKey)
+	if err != nil {
+		return 0, err
+	}
+	if prunedHeight == 0 {
+		prunedHeight = 1
+	}
+	// the genesis height is never pruned
+	if err := m.markHeight(0); err != nil {
+		return 0, err
+	}
+	if err := m.markHeights(ctx, prunedHeight); err != nil {
+		return 0, err
+	}
+	var deleted int
+	for ns, nodes := range candidates {
+		for start := 0; start < len(nodes); start += batchSize {
+			if err := ctx.Err(); err != nil {
+				return deleted, err
+			}
+			end := start + batchSize
+			if end > len(nodes) {
+				end = len(nodes)
+			}
+			lock.Lock()
+			n, err := m.sweep(ctx, ns, nodes[start:end])
+			lock.Unlock()
+			deleted += n
+			if err != nil {
+				return deleted, err
+			}
+		}
+	}
+	return deleted, nil
+}
+
 // HasRun return the run status
 func (sf *factory) HasRun() bool {
 	return sf.run
@@ -403,6 +651,82 @@ func (sf *factory) Commit() error {
 	}
 	sf.clearCache()
 	sf.run = false
+	sf.snapshots = nil
+	sf.pendingReward = big.NewInt(0)
+	return nil
+}
+
+// Snapshot records the current state of the factory, and returns the id to revert to it. The snapshots are
+// discarded upon Commit()
+func (sf *factory) Snapshot() int {
+	s := &snapshot{
+		accountTrieRoot:    sf.accountTrie.RootHash(),
+		currentChainHeight: sf.currentChainHeight,
+		run:                sf.run,
+		rootHash:           sf.rootHash,
+		savedAccount:       make(map[string]*State, len(sf.savedAccount)),
+		cachedAccount:      make(map[hash.PKHash]*State, len(sf.cachedAccount)),
+		cachedCandidates:   make(map[hash.PKHash]*Candidate, len(sf.cachedCandidates)),
+		pendingReward:      new(big.Int).Set(sf.pendingReward),
+	}
+	if height, err := sf.dao.Get(trie.AccountKVNameSpace, []byte(CurrentHeightKey)); err == nil {
+		s.height = height
+	}
+	for addr, state := range sf.savedAccount {
+		s.savedAccount[addr] = state.clone()
+	}
+	for addr, state := range sf.cachedAccount {
+		s.cachedAccount[addr] = state.clone()
+	}
+	for addr, candidate := range sf.cachedCandidates {
+		s.cachedCandidates[addr] = candidate.clone()
+	}
+	sf.snapshots = append(sf.snapshots, s)
+	return len(sf.snapshots) - 1
+}
+
+// RevertToSnapshot rolls the factory back to the snapshot of the given id, and discards the snapshot together with the
+// ones taken after it, so that reverting doesn't keep the snapshot alive until the next commit.
+// The account trie is reset to its previous root, which stays intact in DB since the trie is copy-on-write. Pending
+// contract storage changes not yet written by RunActions() are not recorded by the snapshot and get dropped
+func (sf *factory) RevertToSnapshot(id int) error {
+	if id < 0 || id >= len(sf.snapshots) {
+		return errors.Wrapf(ErrSnapshotNotExist, "snapshot id = %d", id)
+	}
+	s := sf.snapshots[id]
+	if err := sf.accountTrie.SetRoot(s.accountTrieRoot); err != nil {
+		return errors.Wrapf(err, "failed to reset accountTrie to root %x", s.accountTrieRoot)
+	}
+	if err := sf.dao.Put(trie.AccountKVNameSpace, []byte(AccountTrieRootKey), s.accountTrieRoot[:]); err != nil {
+		return errors.Wrap(err, "failed to store accountTrie's root hash")
+	}
+	if s.height != nil {
+		if err := sf.dao.Put(trie.AccountKVNameSpace, []byte(CurrentHeightKey), s.height); err != nil {
+			return errors.Wrap(err, "failed to store accountTrie's current height")
+		}
+	}
+	sf.currentChainHeight = s.currentChainHeight
+	sf.run = s.run
+	sf.rootHash = s.rootHash
+	sf.savedAccount = make(map[string]*State, len(s.savedAccount))
+	for addr, state := range s.savedAccount {
+		sf.savedAccount[addr] = state.clone()
+	}
+	sf.cachedAccount = make(map[hash.PKHash]*State, len(s.cachedAccount))
+	for addr, state := range s.cachedAccount {
+		sf.cachedAccount[addr] = state.clone()
+	}
+	sf.cachedCandidates = make(map[hash.PKHash]*Candidate, len(s.cachedCandidates))
+	for addr, candidate := range s.cachedCandidates {
+		sf.cachedCandidates[addr] = candidate.clone()
+	}
+	sf.cachedContract = make(map[hash.PKHash]Contract)
+	sf.pendingReward = new(big.Int).Set(s.pendingReward)
+	// release the discarded snapshots for GC
+	for i := id; i < len(sf.snapshots); i++ {
+		sf.snapshots[i] = nil
+	}
+	sf.snapshots = sf.snapshots[:id]
 	return nil
 }
 
@@ -479,15 +803,18 @@ func (sf *factory) SetContractState(addr hash.PKHash, key, value hash.Hash32B) e
 // Candidates returns array of candidates in candidate pool
 func (sf *factory) Candidates() (uint64, []*Candidate) {
 	candidates, _ := MapToCandidates(sf.cachedCandidates)
+	sort.Sort(candidates)
 	if len(candidates) <= int(sf.numCandidates) {
 		return sf.currentChainHeight, candidates
 	}
-	sort.Sort(candidates)
 	return sf.currentChainHeight, candidates[:sf.numCandidates]
 }
 
 // CandidatesByHeight returns array of candidates in candidate pool of a given height
 func (sf *factory) CandidatesByHeight(height uint64) ([]*Candidate, error) {
+	if err := sf.checkPruned(height); err != nil {
+		return []*Candidate{}, err
+	}
 	// Load candidates on the given height from underlying db
 	candidates, err := sf.getCandidates(height)
 	if err != nil {
@@ -506,7 +833,7 @@ func (sf *factory) CandidatesByHeight(height uint64) ([]*Candidate, error) {
 func (sf *factory) getState(hash hash.PKHash) (*State, error) {
 	mstate, err := sf.accountTrie.Get(hash[:])
 	if errors.Cause(err) == trie.ErrNotExist {
-		return nil, errors.Wrapf(ErrAccountNotExist, "addrHash = %x", hash[:])
+		return nil, errors.Wrapf(ErrStateNotExist, "addrHash = %x", hash[:])
 	}
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get state of %x", hash)
@@ -580,6 +907,36 @@ func (sf *factory) updateCandidate(pkHash hash.PKHash, totalWeight *big.Int, blo
 	candidate.LastUpdateHeight = blockHeight
 }
 
+// prunedHeight returns the height below which the states have been pruned, which is 0 if nothing has been pruned
+func (sf *factory) prunedHeight() (uint64, error) {
+	value, err := sf.dao.Get(trie.AccountKVNameSpace, []byte(PrunedHeightKey))
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64(value), nil
+	case db.ErrNotExist, bolt.ErrBucketNotFound:
+		return 0, nil
+	default:
+		return 0, errors.Wrap(err, "failed to get factory's pruned height from underlying DB")
+	}
+}
+
+// checkPruned returns ErrHistoricalStateUnavailable if the states on the height have been pruned
+func (sf *factory) checkPruned(height uint64) error {
+	prunedHeight, err := sf.prunedHeight()
+	if err != nil {
+		return err
+	}
+	if height > 0 && height < prunedHeight {
+		return errors.Wrapf(
+			ErrHistoricalStateUnavailable,
+			"states on height %d have been pruned below %d",
+			height,
+			prunedHeight,
+		)
+	}
+	return nil
+}
+
 func (sf *factory) getCandidates(height uint64) (CandidateList, error) {
 	candidatesBytes, err := sf.dao.Get(trie.CandidateKVNameSpace, byteutil.Uint64ToBytes(height))
 	if err != nil {
@@ -591,12 +948,14 @@ func (sf *factory) getCandidates(height uint64) (CandidateList, error) {
 //======================================
 // private transfer/vote functions
 //======================================
-func (sf *factory) handleTsf(tsf []*action.Transfer) error {
+func (sf *factory) handleTsf(blockHeight uint64, tsf []*action.Transfer) error {
 	for _, tx := range tsf {
 		if tx.IsContract() {
 			continue
 		}
-		if !tx.IsCoinbase() {
+		if tx.IsCoinbase() {
+			sf.pendingReward.Add(sf.pendingReward, tx.Amount())
+		} else {
 			// check sender
 			sender, err := sf.LoadOrCreateState(tx.Sender(), 0)
 			if err != nil {
@@ -624,7 +983,9 @@ func (sf *factory) handleTsf(tsf []*action.Transfer) error {
 				}
 				// save state before modifying
 				sf.saveState(sender.Votee, voteeOfSender)
-				voteeOfSender.VotingWeight.Sub(voteeOfSender.VotingWeight, tx.Amount())
+				if err := sf.addVote(blockHeight, voteeOfSender, tx.Sender(), new(big.Int).Neg(tx.Amount())); err != nil {
+					return errors.Wrapf(err, "failed to update the votes of sender's votee %s", sender.Votee)
+				}
 			}
 		}
 		// check recipient
@@ -647,7 +1008,35 @@ func (sf *factory) handleTsf(tsf []*action.Transfer) error {
 			}
 			// save state before modifying
 			sf.saveState(recipient.Votee, voteeOfRecipient)
-			voteeOfRecipient.VotingWeight.Add(voteeOfRecipient.VotingWeight, tx.Amount())
+			if err := sf.addVote(blockHeight, voteeOfRecipient, tx.Recipient(), tx.Amount()); err != nil {
+				return errors.Wrapf(err, "failed to update the votes of recipient's votee %s", recipient.Votee)
+			}
+		}
+	}
+	return nil
+}
+
+// creditReward adds the reward to the producer's balance, as well as to the voting weight of its votee
+func (sf *factory) creditReward(producer string, amount *big.Int) error {
+	state, err := sf.LoadOrCreateState(producer, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load or create the state of producer %s", producer)
+	}
+	// save state before modifying
+	sf.saveState(producer, state)
+	if err := state.AddBalance(amount); err != nil {
+		return errors.Wrapf(err, "failed to update the balance of producer %s", producer)
+	}
+	if len(state.Votee) > 0 && state.Votee != producer {
+		votee, err := sf.LoadOrCreateState(state.Votee, 0)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load or create the state of producer's votee %s", state.Votee)
+		}
+		// save state before modifying
+		sf.saveState(state.Votee, votee)
+		// the reward is credited before running the actions of the next block
+		if err := sf.addVote(sf.currentChainHeight+1, votee, producer, amount); err != nil {
+			return errors.Wrapf(err, "failed to update the votes of producer's votee %s", state.Votee)
 		}
 	}
 	return nil
@@ -665,6 +1054,9 @@ func (sf *factory) handleVote(blockHeight uint64, vote []*action.Vote) error {
 		if v.Nonce() > voteFrom.Nonce {
 			voteFrom.Nonce = v.Nonce()
 		}
+		if sf.votingDecay != nil {
+			voteFrom.LastVoteHeight = blockHeight
+		}
 		// Update old votee's weight
 		if len(voteFrom.Votee) > 0 && voteFrom.Votee != v.Voter() {
 			// voter already voted
@@ -674,7 +1066,9 @@ func (sf *factory) handleVote(blockHeight uint64, vote []*action.Vote) error {
 			}
 			// save state before modifying
 			sf.saveState(voteFrom.Votee, oldVotee)
-			oldVotee.VotingWeight.Sub(oldVotee.VotingWeight, voteFrom.Balance)
+			if err := sf.removeVote(blockHeight, oldVotee, v.Voter(), voteFrom.Balance); err != nil {
+				return errors.Wrapf(err, "failed to remove the votes on voter's old votee %s", voteFrom.Votee)
+			}
 			voteFrom.Votee = ""
 		}
 
@@ -692,7 +1086,15 @@ func (sf *factory) handleVote(blockHeight uint64, vote []*action.Vote) error {
 		sf.saveState(v.Votee(), voteTo)
 		if v.Voter() != v.Votee() {
 			// Voter votes to a different person
-			voteTo.VotingWeight.Add(voteTo.VotingWeight, voteFrom.Balance)
+			if sf.votingDecay != nil {
+				if voteTo.VoterHeights == nil {
+					voteTo.VoterHeights = make(map[string]uint64)
+				}
+				voteTo.VoterHeights[v.Voter()] = blockHeight
+			}
+			if err := sf.addVote(blockHeight, voteTo, v.Voter(), voteFrom.Balance); err != nil {
+				return errors.Wrapf(err, "failed to update the votes of votee %s", v.Votee())
+			}
 			voteFrom.Votee = v.Votee()
 		} else {
 			// Vote to self: self-nomination or cancel the previous vote case
@@ -716,6 +1118,211 @@ func (sf *factory) handleVote(blockHeight uint64, vote []*action.Vote) error {
 	return nil
 }
 
+// applyVotingDecay recomputes the voting weight of the candidates if the block is the first one of an epoch. Candidates
+// are visited in the order of their addresses, so that all nodes agree on the result
+func (sf *factory) applyVotingDecay(blockHeight uint64) error {
+	if sf.votingDecay == nil || blockHeight == 0 || blockHeight%sf.epochLength != 0 {
+		return nil
+	}
+	addrs := make([]string, 0, len(sf.cachedCandidates))
+	for _, candidate := range sf.cachedCandidates {
+		addrs = append(addrs, candidate.Address)
+	}
+	sort.Strings(addrs)
+	for _, addr := range addrs {
+		pkHash, err := iotxaddress.GetPubkeyHash(addr)
+		if err != nil {
+			return errors.Wrap(err, "cannot get the hash of the address")
+		}
+		state, err := sf.cachedState(byteutil.BytesTo20B(pkHash))
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the state of candidate %s", addr)
+		}
+		// save state before modifying
+		sf.saveState(addr, state)
+		sf.decayVotingWeight(blockHeight, state)
+	}
+	return nil
+}
+
+// addVote adds the amount, which is negative if it's withdrawn, to the votes of the voter on the votee. Without voting
+// decay, it's added to the votee's voting weight directly
+func (sf *factory) addVote(blockHeight uint64, votee *State, voter string, amount *big.Int) error {
+	if sf.votingDecay == nil {
+		votee.VotingWeight.Add(votee.VotingWeight, amount)
+		return nil
+	}
+	vote := new(big.Int).Set(amount)
+	if prev := votee.Voters[voter]; prev != nil {
+		vote.Add(vote, prev)
+	}
+	if vote.Sign() > 0 {
+		if votee.Voters == nil {
+			votee.Voters = make(map[string]*big.Int)
+		}
+		votee.Voters[voter] = vote
+	} else {
+		delete(votee.Voters, voter)
+		delete(votee.VoterHeights, voter)
+	}
+	sf.decayVotingWeight(blockHeight, votee)
+	return nil
+}
+
+// removeVote withdraws all the votes of the voter, whose balance is given, from the votee
+func (sf *factory) removeVote(blockHeight uint64, votee *State, voter string, balance *big.Int) error {
+	if sf.votingDecay == nil {
+		votee.VotingWeight.Sub(votee.VotingWeight, balance)
+		return nil
+	}
+	delete(votee.Voters, voter)
+	delete(votee.VoterHeights, voter)
+	sf.decayVotingWeight(blockHeight, votee)
+	return nil
+}
+
+// decayVotingWeight recomputes the voting weight of the votee from the undecayed votes of its voters, each of which is
+// decayed according to the number of blocks between the voter's last vote and the beginning of the current epoch
+func (sf *factory) decayVotingWeight(blockHeight uint64, votee *State) {
+	votee.ApplyVotingDecay(blockHeight-blockHeight%sf.epochLength, sf.votingDecay)
+}
+
+//======================================
+// private trie node collection functions
+//======================================
+// trieMarker marks the nodes of accountTrie and the contract storage tries reachable from the committed root hashes
+type trieMarker struct {
+	kv           db.KVStore
+	marked       map[string]map[hash.Hash32B]bool
+	markedHeight uint64
+}
+
+func newTrieMarker(kv db.KVStore) *trieMarker {
+	return &trieMarker{
+		kv: kv,
+		marked: map[string]map[hash.Hash32B]bool{
+			trie.AccountKVNameSpace:  make(map[hash.Hash32B]bool),
+			trie.ContractKVNameSpace: make(map[hash.Hash32B]bool),
+		},
+	}
+}
+
+// height returns the committed height stored under the key, which is 0 if not stored yet
+func (m *trieMarker) height(key string) (uint64, error) {
+	value, err := m.kv.Get(trie.AccountKVNameSpace, []byte(key))
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64(value), nil
+	case db.ErrNotExist, bolt.ErrBucketNotFound:
+		return 0, nil
+	default:
+		return 0, errors.Wrapf(err, "failed to get %s from underlying DB", key)
+	}
+}
+
+// markHeights marks the nodes reachable from the roots on the heights from the given one to the committed height,
+// skipping the heights marked already
+func (m *trieMarker) markHeights(ctx context.Context, from uint64) error {
+	currentHeight, err := m.height(CurrentHeightKey)
+	if err != nil {
+		return err
+	}
+	if from <= m.markedHeight {
+		from = m.markedHeight + 1
+	}
+	for h := from; h <= currentHeight; h++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := m.markHeight(h); err != nil {
+			return err
+		}
+	}
+	if currentHeight > m.markedHeight {
+		m.markedHeight = currentHeight
+	}
+	return nil
+}
+
+// markHeight marks the nodes reachable from the root on the height, unless the height has been pruned
+func (m *trieMarker) markHeight(height uint64) error {
+	root, err := m.kv.Get(trie.AccountRootKVNameSpace, byteutil.Uint64ToBytes(height))
+	switch errors.Cause(err) {
+	case nil:
+		return m.markAccountTrie(byteutil.BytesTo32B(root))
+	case db.ErrNotExist, bolt.ErrBucketNotFound:
+		return nil
+	default:
+		return errors.Wrapf(err, "failed to get accountTrie's root hash on height %d", height)
+	}
+}
+
+// markAccountTrie marks the nodes of accountTrie with the root, and the storage tries of the contracts in it
+func (m *trieMarker) markAccountTrie(root hash.Hash32B) error {
+	return m.mark(trie.AccountKVNameSpace, root, func(value []byte) error {
+		state, err := bytesToState(value)
+		if err != nil {
+			return errors.Wrap(err, "failed to decode the state in accountTrie")
+		}
+		if state.Root == hash.ZeroHash32B {
+			return nil
+		}
+		return m.mark(trie.ContractKVNameSpace, state.Root, nil)
+	})
+}
+
+// mark marks the nodes of the trie with the root in the bucket, and calls the function with the value of every newly
+// marked leaf. The nodes under a marked node have been marked already, hence they are skipped
+func (m *trieMarker) mark(bucket string, root hash.Hash32B, leafFn func([]byte) error) error {
+	marked := m.marked[bucket]
+	return trie.Walk(m.kv, bucket, root, func(node hash.Hash32B, value []byte) (bool, error) {
+		if marked[node] {
+			return false, nil
+		}
+		marked[node] = true
+		if value == nil || leafFn == nil {
+			return true, nil
+		}
+		return true, leafFn(value)
+	})
+}
+
+// sweep deletes the nodes in the bucket which are not marked, after marking the roots committed since the last time
+func (m *trieMarker) sweep(ctx context.Context, bucket string, nodes []hash.Hash32B) (int, error) {
+	if err := m.markHeights(ctx, m.markedHeight+1); err != nil {
+		return 0, err
+	}
+	root, err := m.kv.Get(trie.AccountKVNameSpace, []byte(AccountTrieRootKey))
+	switch errors.Cause(err) {
+	case nil:
+		if err := m.markAccountTrie(byteutil.BytesTo32B(root)); err != nil {
+			return 0, err
+		}
+	case db.ErrNotExist, bolt.ErrBucketNotFound:
+	default:
+		return 0, errors.Wrap(err, "failed to get accountTrie's root hash from underlying DB")
+	}
+	batch := m.kv.Batch()
+	var deleted int
+	for _, node := range nodes {
+		if m.marked[bucket][node] {
+			continue
+		}
+		key := node
+		if err := batch.Delete(bucket, key[:], "failed to delete trie node %x", key[:8]); err != nil {
+			return 0, err
+		}
+		deleted++
+	}
+	if deleted == 0 {
+		return 0, nil
+	}
+	if err := batch.Commit(); err != nil {
+		return 0, errors.Wrapf(err, "failed to delete the trie nodes in %s", bucket)
+	}
+	return deleted, nil
+}
+
 //======================================
 // private trie constructor functions
 //======================================
@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -54,6 +55,81 @@ func TestEncodeDecode(t *testing.T) {
 	require.Equal(big.NewInt(1000000000), state.VotingWeight)
 }
 
+func TestStateVersion(t *testing.T) {
+	require := require.New(t)
+	// states without LastVoteHeight are stored untagged in the legacy version, which doesn't have the field
+	ss, err := stateToBytes(&State{Nonce: 0x10, Balance: big.NewInt(20)})
+	require.Nil(err)
+	require.True(ss[0] > maxStateVersionTag)
+	require.NotContains(string(ss), "LastVoteHeight")
+	state, err := bytesToState(ss)
+	require.Nil(err)
+	require.Equal(uint64(0x10), state.Nonce)
+	require.Equal(big.NewInt(20), state.Balance)
+
+	// the others are tagged with the current version
+	ss, err = stateToBytes(&State{Nonce: 0x10, Balance: big.NewInt(20), LastVoteHeight: 3})
+	require.Nil(err)
+	require.Equal(CurrentStateVersion, ss[0])
+	state, err = bytesToState(ss)
+	require.Nil(err)
+	require.Equal(uint64(0x10), state.Nonce)
+	require.Equal(uint64(3), state.LastVoteHeight)
+	payload := ss[1:]
+	ss, err = stateToBytes(&State{Nonce: 0x10, Balance: big.NewInt(20), VoterHeights: map[string]uint64{"io1a": 3}})
+	require.Nil(err)
+	require.Equal(CurrentStateVersion, ss[0])
+	state, err = bytesToState(ss)
+	require.Nil(err)
+	require.Equal(map[string]uint64{"io1a": 3}, state.VoterHeights)
+
+	// the states serialized before the versions were introduced are decoded as legacy ones
+	baseline, _ := hex.DecodeString("79ff8103010105537461746501ff8200010801054e6f6e6365010600010742616c616e636501ff84000104526f6f7401ff86000108436f646548617368010a00010b497343616e646964617465010200010c566f74696e6757656967687401ff84000105566f746565010c000106566f7465727301ff880000000aff83050102ff8a00000017ff85010101074861736833324201ff860001060140000024ff87040101136d61705b737472696e675d2a6269672e496e7401ff8800010c01ff8400002cff820202022d0120000000000000000000000000000000000000000000000000000000000000000003010200")
+	state, err = bytesToState(baseline)
+	require.Nil(err)
+	require.Equal(uint64(0), state.Nonce)
+	require.Equal(big.NewInt(45), state.Balance)
+
+	// unknown versions
+	_, err = bytesToState(append([]byte{CurrentStateVersion + 1}, payload...))
+	require.Equal(ErrUnsupportedStateVersion, errors.Cause(err))
+	_, err = bytesToState(append([]byte{maxStateVersionTag}, payload...))
+	require.Equal(ErrUnsupportedStateVersion, errors.Cause(err))
+}
+
+func TestJSONEncodeDecode(t *testing.T) {
+	require := require.New(t)
+	balance, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	s := &State{
+		Nonce:        0x10,
+		Balance:      balance,
+		Root:         hash.Hash32B{1, 2, 3},
+		CodeHash:     []byte{4, 5, 6},
+		IsCandidate:  true,
+		VotingWeight: big.NewInt(1000000000),
+		Votee:        "io1votee",
+		Voters:       map[string]*big.Int{"io1voter": big.NewInt(100)},
+		VoterHeights: map[string]uint64{"io1voter": 40},
+
+		LastVoteHeight: 42,
+	}
+	ss, err := StateToJSON(s)
+	require.Nil(err)
+	require.Contains(string(ss), `"lastVoteHeight":42`)
+	require.Contains(string(ss), `"voterHeights":{"io1voter":40}`)
+	require.Contains(string(ss), `"balance":"123456789012345678901234567890"`)
+	require.Contains(string(ss), `"voters":{"io1voter":"100"}`)
+
+	state, err := JSONToState(ss)
+	require.Nil(err)
+	require.Equal(s, state)
+
+	_, err = JSONToState([]byte(`{"balance":"abc"}`))
+	require.Error(err)
+	_, err = JSONToState([]byte(`{"root":"0102"}`))
+	require.Error(err)
+}
+
 func TestGob(t *testing.T) {
 	require := require.New(t)
 	ss, _ := hex.DecodeString("79ff8103010105537461746501ff8200010801054e6f6e6365010600010742616c616e636501ff84000104526f6f7401ff86000108436f646548617368010a00010b497343616e646964617465010200010c566f74696e6757656967687401ff84000105566f746565010c000106566f7465727301ff880000000aff83050102ff8a00000017ff85010101074861736833324201ff860001060140000024ff87040101136d61705b737472696e675d2a6269672e496e7401ff8800010c01ff8400002cff820202022d0120000000000000000000000000000000000000000000000000000000000000000003010200")
@@ -170,6 +246,87 @@ func TestClone(t *testing.T) {
 	require.Equal(big.NewInt(1000), ss.VotingWeight)
 	require.Equal(big.NewInt(200+100), st.Balance)
 	require.Equal(big.NewInt(1000-300), st.VotingWeight)
+
+	ss.Voters = map[string]*big.Int{"io1voter": big.NewInt(500)}
+	st = ss.clone()
+	require.Equal(ss.Voters, st.Voters)
+	st.Voters["io1voter"].Add(st.Voters["io1voter"], big.NewInt(50))
+	st.Voters["io1another"] = big.NewInt(10)
+	require.Equal(big.NewInt(500), ss.Voters["io1voter"])
+	require.Equal(1, len(ss.Voters))
+	require.Equal(big.NewInt(550), st.Voters["io1voter"])
+
+	ss.VoterHeights = map[string]uint64{"io1voter": 5}
+	st = ss.clone()
+	require.Equal(ss.VoterHeights, st.VoterHeights)
+	st.VoterHeights["io1voter"] = 6
+	require.Equal(uint64(5), ss.VoterHeights["io1voter"])
+}
+
+func TestIsContractAndIsZero(t *testing.T) {
+	require := require.New(t)
+	st := &State{Balance: big.NewInt(0)}
+	require.False(st.IsContract())
+	require.True(st.IsZero())
+
+	st.Balance = nil
+	require.True(st.IsZero())
+
+	st.Balance = big.NewInt(1)
+	require.False(st.IsZero())
+
+	st = &State{Nonce: 1, Balance: big.NewInt(0)}
+	require.False(st.IsZero())
+
+	st = &State{Balance: big.NewInt(0), CodeHash: []byte{1, 2, 3}}
+	require.True(st.IsContract())
+	require.False(st.IsZero())
+}
+
+func TestEqualAndDiff(t *testing.T) {
+	require := require.New(t)
+	s1 := &State{
+		Nonce:        1,
+		Balance:      big.NewInt(100),
+		VotingWeight: big.NewInt(10),
+		Voters:       map[string]*big.Int{"io1a": big.NewInt(10)},
+	}
+	s2 := &State{
+		Nonce:        1,
+		Balance:      big.NewInt(100),
+		VotingWeight: big.NewInt(10),
+		Voters:       map[string]*big.Int{"io1a": big.NewInt(10)},
+	}
+	require.True(s1.Equal(s2))
+	require.Empty(s1.Diff(s2))
+
+	// nil balance equals to zero balance
+	s1.Balance = nil
+	s2.Balance = big.NewInt(0)
+	require.True(s1.Equal(s2))
+	s2.Balance = big.NewInt(1)
+	require.False(s1.Equal(s2))
+	require.Equal([]string{"Balance"}, s1.Diff(s2))
+	s2.Balance = nil
+
+	// differing voter maps
+	s2.Voters["io1a"] = big.NewInt(20)
+	require.Equal([]string{"Voters"}, s1.Diff(s2))
+	s2.Voters = map[string]*big.Int{"io1b": big.NewInt(10)}
+	require.Equal([]string{"Voters"}, s1.Diff(s2))
+	s2.Voters = nil
+	require.Equal([]string{"Voters"}, s1.Diff(s2))
+
+	s2.Nonce = 2
+	s2.Votee = "io1votee"
+	require.Equal([]string{"Nonce", "Votee", "Voters"}, s1.Diff(s2))
+	s2.LastVoteHeight = 10
+	require.Equal([]string{"Nonce", "Votee", "Voters", "LastVoteHeight"}, s1.Diff(s2))
+	s2.VoterHeights = map[string]uint64{"io1a": 10}
+	require.Equal([]string{"Nonce", "Votee", "Voters", "VoterHeights", "LastVoteHeight"}, s1.Diff(s2))
+
+	require.False(s1.Equal(nil))
+	require.NotEmpty(s1.Diff(nil))
 }
 
 func voteForm(height uint64, cs []*Candidate) []string {
@@ -831,3 +988,623 @@ func compareStrings(actual []string, expected []string) bool {
 	}
 	return len(act) == 0
 }
+
+func TestSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	a := testaddress.Addrinfo["alfa"]
+	b := testaddress.Addrinfo["bravo"]
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	defer func() {
+		require.Nil(sf.Stop(context.Background()))
+	}()
+	_, err = sf.LoadOrCreateState(a.RawAddress, uint64(100))
+	require.Nil(err)
+	_, err = sf.LoadOrCreateState(b.RawAddress, uint64(200))
+	require.Nil(err)
+	_, err = sf.RunActions(0, nil, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	root := sf.RootHash()
+
+	// apply a block and revert it
+	id := sf.Snapshot()
+	tx, err := action.NewTransfer(uint64(1), big.NewInt(10), a.RawAddress, b.RawAddress, nil, uint64(0), big.NewInt(0))
+	require.Nil(err)
+	newRoot, err := sf.RunActions(1, []*action.Transfer{tx}, nil, nil)
+	require.Nil(err)
+	require.NotEqual(root, newRoot)
+	state, err := sf.CachedState(a.RawAddress)
+	require.Nil(err)
+	require.Equal(big.NewInt(90), state.Balance)
+	require.Equal(uint64(1), state.Nonce)
+	height, err := sf.Height()
+	require.Nil(err)
+	require.Equal(uint64(1), height)
+
+	require.Nil(sf.RevertToSnapshot(id))
+	// the reverted snapshot is discarded, and its id is reused by the next snapshot
+	require.Equal(ErrSnapshotNotExist, errors.Cause(sf.RevertToSnapshot(id)))
+	require.Equal(id, sf.Snapshot())
+	require.Nil(sf.RevertToSnapshot(id))
+	require.False(sf.HasRun())
+	require.Equal(root, sf.RootHash())
+	height, err = sf.Height()
+	require.Nil(err)
+	require.Equal(uint64(0), height)
+	for _, s := range []struct {
+		addr    string
+		balance int64
+	}{{a.RawAddress, 100}, {b.RawAddress, 200}} {
+		balance, err := sf.Balance(s.addr)
+		require.Nil(err)
+		require.Equal(big.NewInt(s.balance), balance)
+		nonce, err := sf.Nonce(s.addr)
+		require.Nil(err)
+		require.Equal(uint64(0), nonce)
+		state, err := sf.CachedState(s.addr)
+		require.Nil(err)
+		require.Equal(big.NewInt(s.balance), state.Balance)
+	}
+
+	// applying the same block again reaches the same state
+	root2, err := sf.RunActions(1, []*action.Transfer{tx}, nil, nil)
+	require.Nil(err)
+	require.Equal(newRoot, root2)
+	require.Nil(sf.Commit())
+	balance, err := sf.Balance(b.RawAddress)
+	require.Nil(err)
+	require.Equal(big.NewInt(210), balance)
+
+	// snapshots are discarded upon commit
+	require.Equal(ErrSnapshotNotExist, errors.Cause(sf.RevertToSnapshot(id)))
+	require.Equal(ErrSnapshotNotExist, errors.Cause(sf.RevertToSnapshot(-1)))
+}
+
+func TestApplyBlockReward(t *testing.T) {
+	require := require.New(t)
+
+	a := testaddress.Addrinfo["alfa"]
+	b := testaddress.Addrinfo["bravo"]
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	defer func() {
+		require.Nil(sf.Stop(context.Background()))
+	}()
+	_, err = sf.LoadOrCreateState(a.RawAddress, uint64(100))
+	require.Nil(err)
+	_, err = sf.RunActions(0, nil, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	reward, err := sf.AccumulatedBlockReward(0)
+	require.Nil(err)
+	require.Equal(big.NewInt(0), reward)
+
+	// the reward and fees are credited to the producer together with the coinbase transfer, while only the minted
+	// rewards are accumulated
+	require.Nil(sf.ApplyBlockReward(a.RawAddress, big.NewInt(10), big.NewInt(2)))
+	coinbase := action.NewCoinBaseTransfer(big.NewInt(5), b.RawAddress)
+	_, err = sf.RunActions(1, []*action.Transfer{coinbase}, nil, nil)
+	require.Nil(err)
+	require.Error(sf.ApplyBlockReward(a.RawAddress, big.NewInt(10), nil))
+	require.Nil(sf.Commit())
+	balance, err := sf.Balance(a.RawAddress)
+	require.Nil(err)
+	require.Equal(big.NewInt(112), balance)
+	reward, err = sf.AccumulatedBlockReward(1)
+	require.Nil(err)
+	require.Equal(big.NewInt(15), reward)
+
+	// reverting the block discards the reward
+	id := sf.Snapshot()
+	require.Nil(sf.ApplyBlockReward(a.RawAddress, big.NewInt(10), nil))
+	require.Nil(sf.RevertToSnapshot(id))
+	_, err = sf.RunActions(2, nil, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	balance, err = sf.Balance(a.RawAddress)
+	require.Nil(err)
+	require.Equal(big.NewInt(112), balance)
+	reward, err = sf.AccumulatedBlockReward(2)
+	require.Nil(err)
+	require.Equal(big.NewInt(15), reward)
+
+	require.Error(sf.ApplyBlockReward(a.RawAddress, big.NewInt(-10), big.NewInt(2)))
+}
+
+func TestDecayVote(t *testing.T) {
+	require := require.New(t)
+
+	linear := func(age uint64, weight *big.Int) *big.Int {
+		return weight.Sub(weight, new(big.Int).SetUint64(age))
+	}
+	halving := func(age uint64, weight *big.Int) *big.Int {
+		return weight.Rsh(weight, uint(age/10))
+	}
+	cutoff := func(age uint64, weight *big.Int) *big.Int {
+		if age > 50 {
+			return big.NewInt(0)
+		}
+		return weight
+	}
+	for _, c := range []struct {
+		decayFn VotingDecayFunc
+		age     uint64
+		weight  int64
+	}{
+		{nil, 100, 1000},
+		{linear, 0, 1000},
+		{linear, 30, 970},
+		{linear, 1900, 0},
+		{halving, 9, 1000},
+		{halving, 20, 250},
+		{cutoff, 50, 1000},
+		{cutoff, 51, 0},
+	} {
+		vote := big.NewInt(1000)
+		require.Equal(big.NewInt(c.weight), decayVote(c.age, vote, c.decayFn))
+		// the vote itself is never changed
+		require.Equal(big.NewInt(1000), vote)
+	}
+
+	// a nil decayed vote is zero
+	require.Equal(big.NewInt(0), decayVote(1, big.NewInt(1000), func(uint64, *big.Int) *big.Int { return nil }))
+	require.Equal(big.NewInt(0), decayVote(1, nil, linear))
+}
+
+func TestApplyVotingDecay(t *testing.T) {
+	require := require.New(t)
+
+	newVotee := func() *State {
+		return &State{
+			VotingWeight: big.NewInt(0),
+			Voters:       map[string]*big.Int{"io1a": big.NewInt(1000), "io1b": big.NewInt(500), "io1c": big.NewInt(100)},
+			VoterHeights: map[string]uint64{"io1a": 100, "io1b": 50},
+		}
+	}
+	cutoff := func(age uint64, weight *big.Int) *big.Int {
+		if age > 60 {
+			return big.NewInt(0)
+		}
+		return weight
+	}
+	for _, c := range []struct {
+		name    string
+		decayFn VotingDecayFunc
+		height  uint64
+		weight  int64
+	}{
+		// io1c has no recorded height, so it's considered to be cast at genesis
+		{"nil", nil, 200, 1600},
+		{"linear", LinearVotingDecay(100, 30), 100, 1000 + 500 + 70},
+		{"linear", LinearVotingDecay(100, 30), 200, 700 + 350 + 40},
+		{"linear", LinearVotingDecay(100, 30), 450, 100 + 0 + 0},
+		{"exponential", ExponentialVotingDecay(100, 50), 100, 1000 + 500 + 50},
+		{"exponential", ExponentialVotingDecay(100, 50), 350, 250 + 62 + 12},
+		{"exponential", ExponentialVotingDecay(100, 100), 150, 1000 + 0 + 0},
+		{"cutoff", cutoff, 110, 1000 + 500 + 0},
+		// a height before the votes doesn't decay them
+		{"cutoff", cutoff, 0, 1600},
+	} {
+		votee := newVotee()
+		votee.ApplyVotingDecay(c.height, c.decayFn)
+		require.Equal(big.NewInt(c.weight), votee.VotingWeight, "%s at %d", c.name, c.height)
+		// the undecayed votes are kept
+		require.Equal(newVotee().Voters, votee.Voters)
+	}
+}
+
+func TestVotingDecayConfig(t *testing.T) {
+	require := require.New(t)
+
+	a := testaddress.Addrinfo["alfa"]
+	b := testaddress.Addrinfo["bravo"]
+	cfg := config.Default
+	cfg.Chain.VotingDecay = config.VotingDecay{
+		EpochLength: 4,
+		Curve:       config.ExponentialVotingDecay,
+		Period:      4,
+		Percent:     50,
+	}
+	sf, err := NewFactory(&cfg, InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	defer func() {
+		require.Nil(sf.Stop(context.Background()))
+	}()
+	_, err = sf.LoadOrCreateState(a.RawAddress, uint64(100))
+	require.Nil(err)
+	_, err = sf.LoadOrCreateState(b.RawAddress, uint64(200))
+	require.Nil(err)
+	_, err = sf.RunActions(0, nil, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+
+	vote1, err := action.NewVote(1, a.RawAddress, a.RawAddress, uint64(100000), big.NewInt(0))
+	require.Nil(err)
+	vote1.SetVoterPublicKey(a.PublicKey)
+	vote2, err := action.NewVote(1, b.RawAddress, a.RawAddress, uint64(100000), big.NewInt(0))
+	require.Nil(err)
+	_, err = sf.RunActions(1, nil, []*action.Vote{vote1, vote2}, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	state, err := sf.State(a.RawAddress)
+	require.Nil(err)
+	require.Equal(map[string]uint64{b.RawAddress: 1}, state.VoterHeights)
+	require.Equal(big.NewInt(200), state.VotingWeight)
+
+	// the configured decay halves the votes of b at height 8, 7 blocks after the vote
+	for height := uint64(2); height <= 8; height++ {
+		_, err = sf.RunActions(height, nil, nil, nil)
+		require.Nil(err)
+		require.Nil(sf.Commit())
+	}
+	state, err = sf.State(a.RawAddress)
+	require.Nil(err)
+	require.Equal(big.NewInt(100), state.VotingWeight)
+
+	// refreshing the vote restores its weight
+	vote3, err := action.NewVote(2, b.RawAddress, a.RawAddress, uint64(100000), big.NewInt(0))
+	require.Nil(err)
+	_, err = sf.RunActions(9, nil, []*action.Vote{vote3}, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	state, err = sf.State(a.RawAddress)
+	require.Nil(err)
+	require.Equal(map[string]uint64{b.RawAddress: 9}, state.VoterHeights)
+	require.Equal(big.NewInt(200), state.VotingWeight)
+}
+
+func TestVotingDecayOption(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewFactory(cfg, InMemTrieOption(), VotingDecayOption(0, nil))
+	require.Error(err)
+
+	a := testaddress.Addrinfo["alfa"]
+	b := testaddress.Addrinfo["bravo"]
+	halving := func(age uint64, weight *big.Int) *big.Int {
+		return weight.Rsh(weight, uint(age/4))
+	}
+	sf, err := NewFactory(cfg, InMemTrieOption(), VotingDecayOption(4, halving))
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	defer func() {
+		require.Nil(sf.Stop(context.Background()))
+	}()
+	_, err = sf.LoadOrCreateState(a.RawAddress, uint64(100))
+	require.Nil(err)
+	_, err = sf.LoadOrCreateState(b.RawAddress, uint64(200))
+	require.Nil(err)
+	_, err = sf.RunActions(0, nil, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+
+	// a self-nominates and b votes for a at height 1
+	vote1, err := action.NewVote(1, a.RawAddress, a.RawAddress, uint64(100000), big.NewInt(0))
+	require.Nil(err)
+	vote1.SetVoterPublicKey(a.PublicKey)
+	vote2, err := action.NewVote(1, b.RawAddress, a.RawAddress, uint64(100000), big.NewInt(0))
+	require.Nil(err)
+	_, err = sf.RunActions(1, nil, []*action.Vote{vote1, vote2}, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	state, err := sf.State(b.RawAddress)
+	require.Nil(err)
+	require.Equal(uint64(1), state.LastVoteHeight)
+	state, err = sf.State(a.RawAddress)
+	require.Nil(err)
+	require.Equal(uint64(1), state.LastVoteHeight)
+	require.Equal(big.NewInt(200), state.VotingWeight)
+
+	// no decay within the epoch
+	for height := uint64(2); height < 4; height++ {
+		_, err = sf.RunActions(height, nil, nil, nil)
+		require.Nil(err)
+		require.Nil(sf.Commit())
+	}
+	state, err = sf.State(a.RawAddress)
+	require.Nil(err)
+	require.Equal(big.NewInt(200), state.VotingWeight)
+
+	// the voting weight is decayed at the beginning of the epoch, 7 blocks after the last vote
+	for height := uint64(4); height <= 8; height++ {
+		_, err = sf.RunActions(height, nil, nil, nil)
+		require.Nil(err)
+		require.Nil(sf.Commit())
+	}
+	state, err = sf.State(a.RawAddress)
+	require.Nil(err)
+	// decayed at height 4 (age 3, no change) and height 8 (age 7, halved)
+	require.Equal(big.NewInt(100), state.VotingWeight)
+	require.Equal(map[string]*big.Int{b.RawAddress: big.NewInt(200)}, state.Voters)
+	_, candidates := sf.Candidates()
+	require.Equal(1, len(candidates))
+	require.Equal(big.NewInt(200), candidates[0].Votes)
+
+	// b transfers more than the decayed votes away, which is withdrawn from the undecayed votes
+	tsf, err := action.NewTransfer(2, big.NewInt(150), b.RawAddress, a.RawAddress, nil, uint64(0), big.NewInt(0))
+	require.Nil(err)
+	_, err = sf.RunActions(9, []*action.Transfer{tsf}, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	state, err = sf.State(a.RawAddress)
+	require.Nil(err)
+	require.Equal(map[string]*big.Int{b.RawAddress: big.NewInt(50)}, state.Voters)
+	require.Equal(big.NewInt(25), state.VotingWeight)
+
+	// the decay doesn't compound over epochs, i.e., it's recomputed from the undecayed votes at age 11
+	for height := uint64(10); height <= 12; height++ {
+		_, err = sf.RunActions(height, nil, nil, nil)
+		require.Nil(err)
+		require.Nil(sf.Commit())
+	}
+	state, err = sf.State(a.RawAddress)
+	require.Nil(err)
+	require.Equal(big.NewInt(12), state.VotingWeight)
+
+	// unvoting after the decay withdraws all the votes of b
+	unvote, err := action.NewVote(3, b.RawAddress, "", uint64(100000), big.NewInt(0))
+	require.Nil(err)
+	_, err = sf.RunActions(13, nil, []*action.Vote{unvote}, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	state, err = sf.State(a.RawAddress)
+	require.Nil(err)
+	require.Empty(state.Voters)
+	require.Equal(big.NewInt(0), state.VotingWeight)
+	state, err = sf.State(b.RawAddress)
+	require.Nil(err)
+	require.Equal(uint64(13), state.LastVoteHeight)
+}
+
+func TestVotesWithoutDecay(t *testing.T) {
+	require := require.New(t)
+
+	a := testaddress.Addrinfo["alfa"]
+	b := testaddress.Addrinfo["bravo"]
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	defer func() {
+		require.Nil(sf.Stop(context.Background()))
+	}()
+	_, err = sf.LoadOrCreateState(a.RawAddress, uint64(100))
+	require.Nil(err)
+	_, err = sf.LoadOrCreateState(b.RawAddress, uint64(200))
+	require.Nil(err)
+	_, err = sf.RunActions(0, nil, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+
+	// without voting decay, neither the voters nor the vote heights are recorded, so the states are stored in the
+	// legacy version
+	vote, err := action.NewVote(1, b.RawAddress, a.RawAddress, uint64(100000), big.NewInt(0))
+	require.Nil(err)
+	_, err = sf.RunActions(1, nil, []*action.Vote{vote}, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	for _, addr := range []string{a.RawAddress, b.RawAddress} {
+		state, err := sf.State(addr)
+		require.Nil(err)
+		require.Empty(state.Voters)
+		require.Equal(uint64(0), state.LastVoteHeight)
+		ss, err := stateToBytes(state)
+		require.Nil(err)
+		require.NotContains(string(ss), "LastVoteHeight")
+	}
+	state, err := sf.State(a.RawAddress)
+	require.Nil(err)
+	require.Equal(big.NewInt(200), state.VotingWeight)
+}
+
+func TestStateAtHeight(t *testing.T) {
+	require := require.New(t)
+
+	a := testaddress.Addrinfo["alfa"]
+	b := testaddress.Addrinfo["bravo"]
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	defer func() {
+		require.Nil(sf.Stop(context.Background()))
+	}()
+	_, err = sf.LoadOrCreateState(a.RawAddress, uint64(100))
+	require.Nil(err)
+	_, err = sf.RunActions(0, nil, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	for height := uint64(1); height <= 2; height++ {
+		coinbase := action.NewCoinBaseTransfer(big.NewInt(5), a.RawAddress)
+		_, err = sf.RunActions(height, []*action.Transfer{coinbase}, nil, nil)
+		require.Nil(err)
+		require.Nil(sf.Commit())
+	}
+
+	for height, balance := range []int64{100, 105, 110} {
+		state, err := sf.StateAtHeight(a.RawAddress, uint64(height))
+		require.Nil(err)
+		require.Equal(big.NewInt(balance), state.Balance)
+	}
+	_, err = sf.StateAtHeight(b.RawAddress, 1)
+	require.Equal(ErrStateNotExist, errors.Cause(err))
+	_, err = sf.StateAtHeight(a.RawAddress, 3)
+	require.Equal(ErrHistoricalStateUnavailable, errors.Cause(err))
+}
+
+func TestPruneBelow(t *testing.T) {
+	require := require.New(t)
+
+	a := testaddress.Addrinfo["alfa"]
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	defer func() {
+		require.Nil(sf.Stop(context.Background()))
+	}()
+	_, err = sf.LoadOrCreateState(a.RawAddress, uint64(100))
+	require.Nil(err)
+	_, err = sf.RunActions(0, nil, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	for height := uint64(1); height <= 3; height++ {
+		coinbase := action.NewCoinBaseTransfer(big.NewInt(5), a.RawAddress)
+		_, err = sf.RunActions(height, []*action.Transfer{coinbase}, nil, nil)
+		require.Nil(err)
+		require.Nil(sf.Commit())
+	}
+
+	require.Nil(sf.PruneBelow(3))
+	// The genesis height is kept
+	state, err := sf.StateAtHeight(a.RawAddress, 0)
+	require.Nil(err)
+	require.Equal(big.NewInt(100), state.Balance)
+	for height := uint64(1); height < 3; height++ {
+		_, err = sf.StateAtHeight(a.RawAddress, height)
+		require.Equal(ErrHistoricalStateUnavailable, errors.Cause(err))
+		_, err = sf.CandidatesByHeight(height)
+		require.Equal(ErrHistoricalStateUnavailable, errors.Cause(err))
+		_, err = sf.AccumulatedBlockReward(height)
+		require.Equal(ErrHistoricalStateUnavailable, errors.Cause(err))
+	}
+	state, err = sf.StateAtHeight(a.RawAddress, 3)
+	require.Nil(err)
+	require.Equal(big.NewInt(115), state.Balance)
+	_, err = sf.CandidatesByHeight(3)
+	require.Nil(err)
+
+	// Pruning again below a lower height is a no-op, and the current height could not be exceeded
+	require.Nil(sf.PruneBelow(2))
+	require.Error(sf.PruneBelow(4))
+}
+
+func TestCollectTrieNodes(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	a := testaddress.Addrinfo["alfa"]
+	b := testaddress.Addrinfo["bravo"]
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(ctx))
+	defer func() {
+		require.Nil(sf.Stop(ctx))
+	}()
+	_, err = sf.LoadOrCreateState(a.RawAddress, uint64(100))
+	require.Nil(err)
+	_, err = sf.LoadOrCreateState(b.RawAddress, uint64(0))
+	require.Nil(err)
+	_, err = sf.RunActions(0, nil, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	pkHash, err := iotxaddress.GetPubkeyHash(b.RawAddress)
+	require.Nil(err)
+	contract := byteutil.BytesTo20B(pkHash)
+	key := byteutil.BytesTo32B(hash.Hash160b([]byte("cat")))
+	value := func(height uint64) hash.Hash32B {
+		return byteutil.BytesTo32B(hash.Hash256b([]byte(strconv.FormatUint(height, 10))))
+	}
+	// the contract storage is overwritten on every height
+	for height := uint64(1); height <= 5; height++ {
+		require.Nil(sf.SetContractState(contract, key, value(height)))
+		coinbase := action.NewCoinBaseTransfer(big.NewInt(5), a.RawAddress)
+		_, err = sf.RunActions(height, []*action.Transfer{coinbase}, nil, nil)
+		require.Nil(err)
+		require.Nil(sf.Commit())
+	}
+
+	kv := sf.(*factory).dao.KVStore()
+	numNodes := func() int {
+		var n int
+		for _, ns := range []string{trie.AccountKVNameSpace, trie.ContractKVNameSpace} {
+			require.Nil(kv.ForEach(ns, func(k, _ []byte) error {
+				if len(k) == hash.HashSize {
+					n++
+				}
+				return nil
+			}))
+		}
+		return n
+	}
+	storage := func(height uint64) [][]byte {
+		state, err := sf.StateAtHeight(b.RawAddress, height)
+		require.Nil(err)
+		var values [][]byte
+		require.Nil(trie.Walk(kv, trie.ContractKVNameSpace, state.Root, func(_ hash.Hash32B, v []byte) (bool, error) {
+			if v != nil {
+				values = append(values, v)
+			}
+			return true, nil
+		}))
+		return values
+	}
+
+	require.Nil(sf.PruneBelow(4))
+	before := numNodes()
+	lock := &sync.Mutex{}
+	deleted, err := sf.CollectTrieNodes(ctx, lock, 2)
+	require.Nil(err)
+	require.True(deleted > 0)
+	require.Equal(before-deleted, numNodes())
+
+	// The states on the genesis height and the heights not pruned are intact
+	state, err := sf.StateAtHeight(a.RawAddress, 0)
+	require.Nil(err)
+	require.Equal(big.NewInt(100), state.Balance)
+	require.Empty(storage(0))
+	for height := uint64(4); height <= 5; height++ {
+		state, err = sf.StateAtHeight(a.RawAddress, height)
+		require.Nil(err)
+		require.Equal(big.NewInt(100+5*int64(height)), state.Balance)
+		v := value(height)
+		require.Equal([][]byte{v[:]}, storage(height))
+	}
+	v, err := sf.GetContractState(contract, key)
+	require.Nil(err)
+	require.Equal(value(5), v)
+
+	// The factory keeps working, and nothing is left to collect
+	coinbase := action.NewCoinBaseTransfer(big.NewInt(5), a.RawAddress)
+	_, err = sf.RunActions(6, []*action.Transfer{coinbase}, nil, nil)
+	require.Nil(err)
+	require.Nil(sf.Commit())
+	state, err = sf.StateAtHeight(a.RawAddress, 6)
+	require.Nil(err)
+	require.Equal(big.NewInt(130), state.Balance)
+	deleted, err = sf.CollectTrieNodes(ctx, lock, 2)
+	require.Nil(err)
+	require.Equal(0, deleted)
+
+	_, err = sf.CollectTrieNodes(ctx, lock, 0)
+	require.Error(err)
+}
+
+func TestStateErrors(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	defer func() {
+		require.Nil(sf.Stop(context.Background()))
+	}()
+
+	a := testaddress.Addrinfo["alfa"]
+	_, err = sf.State(a.RawAddress)
+	require.Equal(ErrStateNotExist, errors.Cause(err))
+	require.Equal(ErrAccountNotExist, errors.Cause(err))
+	_, err = sf.CachedState(a.RawAddress)
+	require.Equal(ErrStateNotExist, errors.Cause(err))
+
+	state := &State{Balance: big.NewInt(10)}
+	require.Equal(ErrNotEnoughBalance, errors.Cause(state.SubBalance(big.NewInt(11))))
+	require.Nil(state.SubBalance(big.NewInt(10)))
+
+	_, err = GobCodec{}.Decode([]byte{1, 2, 3})
+	require.Equal(ErrFailedToUnmarshalState, errors.Cause(err))
+	_, err = bytesToState(nil)
+	require.Equal(ErrFailedToUnmarshalState, errors.Cause(err))
+}
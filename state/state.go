@@ -9,11 +9,47 @@ package state
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
 	"math/big"
 
+	"github.com/pkg/errors"
+
 	"github.com/iotexproject/iotex-core/pkg/hash"
 )
 
+// StateVersionLegacy is the version of states serialized by gob without a version tag, which is how the states in the
+// existing DBs are stored. It has no LastVoteHeight
+const StateVersionLegacy byte = 0
+
+// StateVersion1 is the version of states serialized by gob with LastVoteHeight and VoterHeights
+const StateVersion1 byte = 1
+
+// CurrentStateVersion is the version of the serialized states stored in the trie. The states without LastVoteHeight
+// and VoterHeights are still stored untagged in the legacy version, so that their bytes, and thus the trie root, stay
+// the same as on the other nodes
+const CurrentStateVersion = StateVersion1
+
+// maxStateVersionTag is the largest version tag. A gob stream never starts with a byte in [1, maxStateVersionTag],
+// because its first message is the type definition of State, which is longer than that, so the bytes starting with
+// such a byte are tagged, and the others are untagged legacy states
+const maxStateVersionTag byte = 0x0f
+
+// StateMigration upgrades the payload of a serialized state from one version to the next version
+type StateMigration func(payload []byte) ([]byte, error)
+
+// stateMigrations maps a version to the migration upgrading it to the next version
+var stateMigrations = map[byte]StateMigration{
+	// gob matches the fields by name, so the legacy payload decodes as is, with a zero LastVoteHeight
+	StateVersionLegacy: func(payload []byte) ([]byte, error) { return payload, nil },
+}
+
+// RegisterStateMigration registers the migration which upgrades the payload of a serialized state from version from to
+// version from+1. States serialized in old versions are upgraded on read. It should be called at initialization.
+func RegisterStateMigration(from byte, migration StateMigration) {
+	stateMigrations[from] = migration
+}
+
 // State is the canonical representation of an account.
 type State struct {
 	// 0 is reserved from actions in genesis block and coinbase transfers nonces
@@ -25,27 +61,246 @@ type State struct {
 	IsCandidate  bool
 	VotingWeight *big.Int
 	Votee        string
-	Voters       map[string]*big.Int
+	// Voters are the undecayed votes of each voter, which are only tracked with voting decay
+	Voters map[string]*big.Int
+	// VoterHeights are the heights of the blocks in which the voters cast their last votes, which are only tracked
+	// with voting decay
+	VoterHeights map[string]uint64
+	// LastVoteHeight is the height of the block in which the account cast its last vote, which is only recorded with
+	// voting decay
+	LastVoteHeight uint64
 }
 
-func stateToBytes(s *State) ([]byte, error) {
+// VotingDecayFunc returns the decayed vote given the number of blocks since the voter's last vote and a copy of the
+// vote
+type VotingDecayFunc func(age uint64, weight *big.Int) *big.Int
+
+// LinearVotingDecay returns the voting decay with which a vote loses percent percent of its original weight every
+// period blocks, until nothing is left
+func LinearVotingDecay(period uint64, percent uint64) VotingDecayFunc {
+	return func(age uint64, weight *big.Int) *big.Int {
+		if period == 0 || percent == 0 {
+			return weight
+		}
+		periods := age / period
+		if periods >= (100+percent-1)/percent {
+			return big.NewInt(0)
+		}
+		weight.Mul(weight, new(big.Int).SetUint64(100-periods*percent))
+		return weight.Div(weight, big.NewInt(100))
+	}
+}
+
+// ExponentialVotingDecay returns the voting decay with which a vote loses percent percent of its remaining weight every
+// period blocks
+func ExponentialVotingDecay(period uint64, percent uint64) VotingDecayFunc {
+	return func(age uint64, weight *big.Int) *big.Int {
+		if period == 0 || percent == 0 {
+			return weight
+		}
+		if percent >= 100 {
+			if age >= period {
+				return big.NewInt(0)
+			}
+			return weight
+		}
+		remaining := new(big.Int).SetUint64(100 - percent)
+		hundred := big.NewInt(100)
+		for periods := age / period; periods > 0 && weight.Sign() > 0; periods-- {
+			weight.Mul(weight, remaining)
+			weight.Div(weight, hundred)
+		}
+		return weight
+	}
+}
+
+// Codec serializes and deserializes the state of an account
+type Codec interface {
+	Encode(s *State) ([]byte, error)
+	Decode(ss []byte) (*State, error)
+}
+
+// GobCodec is the codec using encoding/gob, which is the default one to store states in the trie
+type GobCodec struct{}
+
+// Encode serializes the state into gob bytes
+func (GobCodec) Encode(s *State) ([]byte, error) {
 	var ss bytes.Buffer
 	e := gob.NewEncoder(&ss)
 	if err := e.Encode(s); err != nil {
-		return nil, ErrFailedToMarshalState
+		return nil, errors.Wrap(ErrFailedToMarshalState, err.Error())
 	}
 	return ss.Bytes(), nil
 }
 
-func bytesToState(ss []byte) (*State, error) {
+// Decode deserializes the state from gob bytes
+func (GobCodec) Decode(ss []byte) (*State, error) {
 	var state State
 	e := gob.NewDecoder(bytes.NewBuffer(ss))
 	if err := e.Decode(&state); err != nil {
-		return nil, ErrFailedToUnmarshalState
+		return nil, errors.Wrap(ErrFailedToUnmarshalState, err.Error())
+	}
+	return &state, nil
+}
+
+// JSONCodec is the codec serializing the state into human-readable JSON. Big integers are represented as decimal
+// strings, and the root and code hash are represented as hex strings.
+type JSONCodec struct{}
+
+type jsonState struct {
+	Nonce          uint64            `json:"nonce"`
+	Balance        string            `json:"balance"`
+	Root           string            `json:"root"`
+	CodeHash       string            `json:"codeHash"`
+	IsCandidate    bool              `json:"isCandidate"`
+	VotingWeight   string            `json:"votingWeight"`
+	Votee          string            `json:"votee"`
+	Voters         map[string]string `json:"voters"`
+	VoterHeights   map[string]uint64 `json:"voterHeights"`
+	LastVoteHeight uint64            `json:"lastVoteHeight"`
+}
+
+// Encode serializes the state into JSON bytes
+func (JSONCodec) Encode(s *State) ([]byte, error) {
+	js := jsonState{
+		Nonce:          s.Nonce,
+		Balance:        bigIntToString(s.Balance),
+		Root:           hex.EncodeToString(s.Root[:]),
+		CodeHash:       hex.EncodeToString(s.CodeHash),
+		IsCandidate:    s.IsCandidate,
+		VotingWeight:   bigIntToString(s.VotingWeight),
+		Votee:          s.Votee,
+		VoterHeights:   s.VoterHeights,
+		LastVoteHeight: s.LastVoteHeight,
+	}
+	if s.Voters != nil {
+		js.Voters = make(map[string]string, len(s.Voters))
+		for voter, weight := range s.Voters {
+			js.Voters[voter] = bigIntToString(weight)
+		}
+	}
+	ss, err := json.Marshal(&js)
+	if err != nil {
+		return nil, errors.Wrap(ErrFailedToMarshalState, err.Error())
+	}
+	return ss, nil
+}
+
+// Decode deserializes the state from JSON bytes
+func (JSONCodec) Decode(ss []byte) (*State, error) {
+	var js jsonState
+	if err := json.Unmarshal(ss, &js); err != nil {
+		return nil, errors.Wrap(ErrFailedToUnmarshalState, err.Error())
+	}
+	state := State{
+		Nonce:          js.Nonce,
+		IsCandidate:    js.IsCandidate,
+		Votee:          js.Votee,
+		VoterHeights:   js.VoterHeights,
+		LastVoteHeight: js.LastVoteHeight,
+	}
+	var err error
+	if state.Balance, err = stringToBigInt(js.Balance); err != nil {
+		return nil, err
+	}
+	if state.VotingWeight, err = stringToBigInt(js.VotingWeight); err != nil {
+		return nil, err
+	}
+	root, err := hex.DecodeString(js.Root)
+	if err != nil || (len(root) != 0 && len(root) != len(state.Root)) {
+		return nil, errors.Wrapf(ErrFailedToUnmarshalState, "invalid root %s", js.Root)
+	}
+	copy(state.Root[:], root)
+	if js.CodeHash != "" {
+		if state.CodeHash, err = hex.DecodeString(js.CodeHash); err != nil {
+			return nil, errors.Wrapf(ErrFailedToUnmarshalState, "invalid code hash %s", js.CodeHash)
+		}
+	}
+	if js.Voters != nil {
+		state.Voters = make(map[string]*big.Int, len(js.Voters))
+		for voter, weight := range js.Voters {
+			if state.Voters[voter], err = stringToBigInt(weight); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return &state, nil
 }
 
+// StateToJSON serializes the state into human-readable JSON
+func StateToJSON(s *State) ([]byte, error) { return JSONCodec{}.Encode(s) }
+
+// JSONToState deserializes the state from JSON produced by StateToJSON
+func JSONToState(ss []byte) (*State, error) { return JSONCodec{}.Decode(ss) }
+
+// stateToBytes serializes the state in the current version, which is tagged as the first byte. The states without
+// LastVoteHeight and VoterHeights are serialized untagged in the legacy version
+func stateToBytes(s *State) ([]byte, error) {
+	if s.LastVoteHeight == 0 && len(s.VoterHeights) == 0 {
+		return legacyStateToBytes(s)
+	}
+	payload, err := GobCodec{}.Encode(s)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{CurrentStateVersion}, payload...), nil
+}
+
+// legacyStateToBytes serializes the fields of the state in the legacy version by gob
+func legacyStateToBytes(s *State) ([]byte, error) {
+	// the type is named State too, as gob puts the name of the type and its fields into the bytes
+	type State struct {
+		Nonce        uint64
+		Balance      *big.Int
+		Root         hash.Hash32B
+		CodeHash     []byte
+		IsCandidate  bool
+		VotingWeight *big.Int
+		Votee        string
+		Voters       map[string]*big.Int
+	}
+	var ss bytes.Buffer
+	if err := gob.NewEncoder(&ss).Encode(&State{
+		Nonce:        s.Nonce,
+		Balance:      s.Balance,
+		Root:         s.Root,
+		CodeHash:     s.CodeHash,
+		IsCandidate:  s.IsCandidate,
+		VotingWeight: s.VotingWeight,
+		Votee:        s.Votee,
+		Voters:       s.Voters,
+	}); err != nil {
+		return nil, errors.Wrap(ErrFailedToMarshalState, err.Error())
+	}
+	return ss.Bytes(), nil
+}
+
+// bytesToState validates the version tag and deserializes the state, upgrading it first if it's in an old version.
+// Untagged bytes are in the legacy version
+func bytesToState(ss []byte) (*State, error) {
+	if len(ss) == 0 {
+		return nil, errors.Wrap(ErrFailedToUnmarshalState, "empty state")
+	}
+	version, payload := StateVersionLegacy, ss
+	if ss[0] != StateVersionLegacy && ss[0] <= maxStateVersionTag {
+		version, payload = ss[0], ss[1:]
+	}
+	if version > CurrentStateVersion {
+		return nil, errors.Wrapf(ErrUnsupportedStateVersion, "version %d", version)
+	}
+	for ; version < CurrentStateVersion; version++ {
+		migration, ok := stateMigrations[version]
+		if !ok {
+			return nil, errors.Wrapf(ErrUnsupportedStateVersion, "no migration from version %d", version)
+		}
+		var err error
+		if payload, err = migration(payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to migrate state from version %d", version)
+		}
+	}
+	return GobCodec{}.Decode(payload)
+}
+
 // AddBalance adds balance for state
 func (st *State) AddBalance(amount *big.Int) error {
 	st.Balance.Add(st.Balance, amount)
@@ -56,15 +311,165 @@ func (st *State) AddBalance(amount *big.Int) error {
 func (st *State) SubBalance(amount *big.Int) error {
 	// make sure there's enough fund to spend
 	if amount.Cmp(st.Balance) == 1 {
-		return ErrNotEnoughBalance
+		return errors.Wrapf(ErrNotEnoughBalance, "balance %s is less than %s", st.Balance, amount)
 	}
 	st.Balance.Sub(st.Balance, amount)
 	return nil
 }
 
+// decayVote returns the vote decayed according to the number of blocks since it was cast. A negative or nil decayed
+// vote is considered to be zero
+func decayVote(age uint64, vote *big.Int, decayFn VotingDecayFunc) *big.Int {
+	if vote == nil {
+		return big.NewInt(0)
+	}
+	if decayFn == nil {
+		return new(big.Int).Set(vote)
+	}
+	decayed := decayFn(age, new(big.Int).Set(vote))
+	if decayed == nil || decayed.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return decayed
+}
+
+// ApplyVotingDecay recomputes the voting weight from the undecayed votes of the voters, each of which is decayed by
+// decayFn according to the number of blocks from the voter's last vote to currentHeight. The votes of the voters
+// without a recorded height are considered to be cast at genesis
+func (st *State) ApplyVotingDecay(currentHeight uint64, decayFn VotingDecayFunc) {
+	weight := big.NewInt(0)
+	for voter, vote := range st.Voters {
+		var age uint64
+		if height := st.VoterHeights[voter]; currentHeight > height {
+			age = currentHeight - height
+		}
+		weight.Add(weight, decayVote(age, vote, decayFn))
+	}
+	st.VotingWeight = weight
+}
+
+// IsContract returns true if the state belongs to a smart contract account, i.e., it has code
+func (st *State) IsContract() bool {
+	return len(st.CodeHash) > 0
+}
+
+// IsZero returns true if the state is of a freshly initialized account, which has zero nonce, zero balance and no code
+func (st *State) IsZero() bool {
+	return st.Nonce == 0 && (st.Balance == nil || st.Balance.Sign() == 0) && !st.IsContract()
+}
+
+// Equal returns true if the two states are semantically equal. A nil big integer is considered to be equal to zero,
+// and a nil code hash or voter map is considered to be equal to an empty one.
+func (st *State) Equal(other *State) bool {
+	if st == nil || other == nil {
+		return st == other
+	}
+	return len(st.Diff(other)) == 0
+}
+
+// Diff returns the names of the fields which differ between the two states
+func (st *State) Diff(other *State) []string {
+	if st == nil || other == nil {
+		if st == other {
+			return nil
+		}
+		return []string{"Nonce", "Balance", "Root", "CodeHash", "IsCandidate", "VotingWeight", "Votee", "Voters",
+			"VoterHeights", "LastVoteHeight"}
+	}
+	var diff []string
+	if st.Nonce != other.Nonce {
+		diff = append(diff, "Nonce")
+	}
+	if !bigIntEqual(st.Balance, other.Balance) {
+		diff = append(diff, "Balance")
+	}
+	if st.Root != other.Root {
+		diff = append(diff, "Root")
+	}
+	if !bytes.Equal(st.CodeHash, other.CodeHash) {
+		diff = append(diff, "CodeHash")
+	}
+	if st.IsCandidate != other.IsCandidate {
+		diff = append(diff, "IsCandidate")
+	}
+	if !bigIntEqual(st.VotingWeight, other.VotingWeight) {
+		diff = append(diff, "VotingWeight")
+	}
+	if st.Votee != other.Votee {
+		diff = append(diff, "Votee")
+	}
+	if !votersEqual(st.Voters, other.Voters) {
+		diff = append(diff, "Voters")
+	}
+	if !voterHeightsEqual(st.VoterHeights, other.VoterHeights) {
+		diff = append(diff, "VoterHeights")
+	}
+	if st.LastVoteHeight != other.LastVoteHeight {
+		diff = append(diff, "LastVoteHeight")
+	}
+	return diff
+}
+
 //======================================
 // private functions
 //======================================
+func bigIntEqual(a *big.Int, b *big.Int) bool {
+	switch {
+	case a == nil && b == nil:
+		return true
+	case a == nil:
+		return b.Sign() == 0
+	case b == nil:
+		return a.Sign() == 0
+	default:
+		return a.Cmp(b) == 0
+	}
+}
+
+func votersEqual(a map[string]*big.Int, b map[string]*big.Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for voter, weight := range a {
+		otherWeight, ok := b[voter]
+		if !ok || !bigIntEqual(weight, otherWeight) {
+			return false
+		}
+	}
+	return true
+}
+
+func voterHeightsEqual(a map[string]uint64, b map[string]uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for voter, height := range a {
+		otherHeight, ok := b[voter]
+		if !ok || height != otherHeight {
+			return false
+		}
+	}
+	return true
+}
+
+func bigIntToString(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+	return n.String()
+}
+
+func stringToBigInt(str string) (*big.Int, error) {
+	if str == "" {
+		return nil, nil
+	}
+	n, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return nil, errors.Wrapf(ErrFailedToUnmarshalState, "invalid decimal integer %s", str)
+	}
+	return n, nil
+}
+
 func (st *State) clone() *State {
 	s := *st
 	s.Balance = nil
@@ -76,7 +481,21 @@ func (st *State) clone() *State {
 		s.CodeHash = make([]byte, len(st.CodeHash))
 		copy(s.CodeHash, st.CodeHash)
 	}
-	// Voters won't be used, set to nil for simplicity
-	s.Voters = nil
+	if st.Voters != nil {
+		s.Voters = make(map[string]*big.Int, len(st.Voters))
+		for voter, weight := range st.Voters {
+			if weight == nil {
+				s.Voters[voter] = nil
+				continue
+			}
+			s.Voters[voter] = new(big.Int).Set(weight)
+		}
+	}
+	if st.VoterHeights != nil {
+		s.VoterHeights = make(map[string]uint64, len(st.VoterHeights))
+		for voter, height := range st.VoterHeights {
+			s.VoterHeights[voter] = height
+		}
+	}
 	return &s
 }
@@ -9,6 +9,7 @@ import (
 	action "github.com/iotexproject/iotex-core/blockchain/action"
 	hash "github.com/iotexproject/iotex-core/pkg/hash"
 	proto "github.com/iotexproject/iotex-core/proto"
+	big "math/big"
 	reflect "reflect"
 )
 
@@ -59,6 +60,20 @@ func (mr *MockActPoolMockRecorder) PickActs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PickActs", reflect.TypeOf((*MockActPool)(nil).PickActs))
 }
 
+// PickActions mocks base method
+func (m *MockActPool) PickActions(arg0 uint64) ([]*action.Transfer, []*action.Vote, []*action.Execution) {
+	ret := m.ctrl.Call(m, "PickActions", arg0)
+	ret0, _ := ret[0].([]*action.Transfer)
+	ret1, _ := ret[1].([]*action.Vote)
+	ret2, _ := ret[2].([]*action.Execution)
+	return ret0, ret1, ret2
+}
+
+// PickActions indicates an expected call of PickActions
+func (mr *MockActPoolMockRecorder) PickActions(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PickActions", reflect.TypeOf((*MockActPool)(nil).PickActions), arg0)
+}
+
 // AddTsf mocks base method
 func (m *MockActPool) AddTsf(tsf *action.Transfer) error {
 	ret := m.ctrl.Call(m, "AddTsf", tsf)
@@ -95,6 +110,55 @@ func (mr *MockActPoolMockRecorder) AddExecution(execution interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddExecution", reflect.TypeOf((*MockActPool)(nil).AddExecution), execution)
 }
 
+// AddTsfs mocks base method
+func (m *MockActPool) AddTsfs(tsfs []*action.Transfer) []error {
+	ret := m.ctrl.Call(m, "AddTsfs", tsfs)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// AddTsfs indicates an expected call of AddTsfs
+func (mr *MockActPoolMockRecorder) AddTsfs(tsfs interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTsfs", reflect.TypeOf((*MockActPool)(nil).AddTsfs), tsfs)
+}
+
+// AddVotes mocks base method
+func (m *MockActPool) AddVotes(votes []*action.Vote) []error {
+	ret := m.ctrl.Call(m, "AddVotes", votes)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// AddVotes indicates an expected call of AddVotes
+func (mr *MockActPoolMockRecorder) AddVotes(votes interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVotes", reflect.TypeOf((*MockActPool)(nil).AddVotes), votes)
+}
+
+// AddExecutions mocks base method
+func (m *MockActPool) AddExecutions(executions []*action.Execution) []error {
+	ret := m.ctrl.Call(m, "AddExecutions", executions)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// AddExecutions indicates an expected call of AddExecutions
+func (mr *MockActPoolMockRecorder) AddExecutions(executions interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddExecutions", reflect.TypeOf((*MockActPool)(nil).AddExecutions), executions)
+}
+
+// AddAction mocks base method
+func (m *MockActPool) AddAction(act *proto.ActionPb) (bool, error) {
+	ret := m.ctrl.Call(m, "AddAction", act)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAction indicates an expected call of AddAction
+func (mr *MockActPoolMockRecorder) AddAction(act interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAction", reflect.TypeOf((*MockActPool)(nil).AddAction), act)
+}
+
 // GetPendingNonce mocks base method
 func (m *MockActPool) GetPendingNonce(addr string) (uint64, error) {
 	ret := m.ctrl.Call(m, "GetPendingNonce", addr)
@@ -133,6 +197,30 @@ func (mr *MockActPoolMockRecorder) GetActionByHash(hash interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionByHash", reflect.TypeOf((*MockActPool)(nil).GetActionByHash), hash)
 }
 
+// PendingActionMap mocks base method
+func (m *MockActPool) PendingActionMap() map[string][]action.Action {
+	ret := m.ctrl.Call(m, "PendingActionMap")
+	ret0, _ := ret[0].(map[string][]action.Action)
+	return ret0
+}
+
+// PendingActionMap indicates an expected call of PendingActionMap
+func (mr *MockActPoolMockRecorder) PendingActionMap() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingActionMap", reflect.TypeOf((*MockActPool)(nil).PendingActionMap))
+}
+
+// AllActions mocks base method
+func (m *MockActPool) AllActions() []action.Action {
+	ret := m.ctrl.Call(m, "AllActions")
+	ret0, _ := ret[0].([]action.Action)
+	return ret0
+}
+
+// AllActions indicates an expected call of AllActions
+func (mr *MockActPoolMockRecorder) AllActions() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllActions", reflect.TypeOf((*MockActPool)(nil).AllActions))
+}
+
 // GetSize mocks base method
 func (m *MockActPool) GetSize() uint64 {
 	ret := m.ctrl.Call(m, "GetSize")
@@ -156,3 +244,37 @@ func (m *MockActPool) GetCapacity() uint64 {
 func (mr *MockActPoolMockRecorder) GetCapacity() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapacity", reflect.TypeOf((*MockActPool)(nil).GetCapacity))
 }
+
+// GetEvictionCount mocks base method
+func (m *MockActPool) GetEvictionCount() uint64 {
+	ret := m.ctrl.Call(m, "GetEvictionCount")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetEvictionCount indicates an expected call of GetEvictionCount
+func (mr *MockActPoolMockRecorder) GetEvictionCount() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvictionCount", reflect.TypeOf((*MockActPool)(nil).GetEvictionCount))
+}
+
+// Recover mocks base method
+func (m *MockActPool) Recover() error {
+	ret := m.ctrl.Call(m, "Recover")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Recover indicates an expected call of Recover
+func (mr *MockActPoolMockRecorder) Recover() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recover", reflect.TypeOf((*MockActPool)(nil).Recover))
+}
+
+// SetMinGasPrice mocks base method
+func (m *MockActPool) SetMinGasPrice(arg0 *big.Int) {
+	m.ctrl.Call(m, "SetMinGasPrice", arg0)
+}
+
+// SetMinGasPrice indicates an expected call of SetMinGasPrice
+func (mr *MockActPoolMockRecorder) SetMinGasPrice(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinGasPrice", reflect.TypeOf((*MockActPool)(nil).SetMinGasPrice), arg0)
+}
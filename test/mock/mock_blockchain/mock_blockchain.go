@@ -180,6 +180,19 @@ func (mr *MockBlockchainMockRecorder) GetBlockByHash(h interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHash", reflect.TypeOf((*MockBlockchain)(nil).GetBlockByHash), h)
 }
 
+// GetBlockHeaderByHeight mocks base method
+func (m *MockBlockchain) GetBlockHeaderByHeight(height uint64) (*blockchain.BlockHeader, error) {
+	ret := m.ctrl.Call(m, "GetBlockHeaderByHeight", height)
+	ret0, _ := ret[0].(*blockchain.BlockHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHeaderByHeight indicates an expected call of GetBlockHeaderByHeight
+func (mr *MockBlockchainMockRecorder) GetBlockHeaderByHeight(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeaderByHeight", reflect.TypeOf((*MockBlockchain)(nil).GetBlockHeaderByHeight), height)
+}
+
 // GetTotalTransfers mocks base method
 func (m *MockBlockchain) GetTotalTransfers() (uint64, error) {
 	ret := m.ctrl.Call(m, "GetTotalTransfers")
@@ -219,6 +232,19 @@ func (mr *MockBlockchainMockRecorder) GetTotalExecutions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalExecutions", reflect.TypeOf((*MockBlockchain)(nil).GetTotalExecutions))
 }
 
+// GetActionCounts mocks base method
+func (m *MockBlockchain) GetActionCounts(height uint64) (*blockchain.ActionCounts, error) {
+	ret := m.ctrl.Call(m, "GetActionCounts", height)
+	ret0, _ := ret[0].(*blockchain.ActionCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActionCounts indicates an expected call of GetActionCounts
+func (mr *MockBlockchainMockRecorder) GetActionCounts(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionCounts", reflect.TypeOf((*MockBlockchain)(nil).GetActionCounts), height)
+}
+
 // GetTransfersFromAddress mocks base method
 func (m *MockBlockchain) GetTransfersFromAddress(address string) ([]hash.Hash32B, error) {
 	ret := m.ctrl.Call(m, "GetTransfersFromAddress", address)
@@ -512,6 +538,31 @@ func (mr *MockBlockchainMockRecorder) CommitBlock(blk interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBlock", reflect.TypeOf((*MockBlockchain)(nil).CommitBlock), blk)
 }
 
+// PruneBelow mocks base method
+func (m *MockBlockchain) PruneBelow(height uint64) error {
+	ret := m.ctrl.Call(m, "PruneBelow", height)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PruneBelow indicates an expected call of PruneBelow
+func (mr *MockBlockchainMockRecorder) PruneBelow(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneBelow", reflect.TypeOf((*MockBlockchain)(nil).PruneBelow), height)
+}
+
+// Reprocess mocks base method
+func (m *MockBlockchain) Reprocess(fromHeight, toHeight uint64) (hash.Hash32B, error) {
+	ret := m.ctrl.Call(m, "Reprocess", fromHeight, toHeight)
+	ret0, _ := ret[0].(hash.Hash32B)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reprocess indicates an expected call of Reprocess
+func (mr *MockBlockchainMockRecorder) Reprocess(fromHeight, toHeight interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reprocess", reflect.TypeOf((*MockBlockchain)(nil).Reprocess), fromHeight, toHeight)
+}
+
 // ValidateBlock mocks base method
 func (m *MockBlockchain) ValidateBlock(blk *blockchain.Block, containCoinbase bool) error {
 	ret := m.ctrl.Call(m, "ValidateBlock", blk, containCoinbase)
@@ -546,6 +597,30 @@ func (mr *MockBlockchainMockRecorder) SetValidator(val interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetValidator", reflect.TypeOf((*MockBlockchain)(nil).SetValidator), val)
 }
 
+// VerifyActionSignature mocks base method
+func (m *MockBlockchain) VerifyActionSignature(act action.Action) error {
+	ret := m.ctrl.Call(m, "VerifyActionSignature", act)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyActionSignature indicates an expected call of VerifyActionSignature
+func (mr *MockBlockchainMockRecorder) VerifyActionSignature(act interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyActionSignature", reflect.TypeOf((*MockBlockchain)(nil).VerifyActionSignature), act)
+}
+
+// VerifyActionSignatureAtHeight mocks base method
+func (m *MockBlockchain) VerifyActionSignatureAtHeight(act action.Action, height uint64) error {
+	ret := m.ctrl.Call(m, "VerifyActionSignatureAtHeight", act, height)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyActionSignatureAtHeight indicates an expected call of VerifyActionSignatureAtHeight
+func (mr *MockBlockchainMockRecorder) VerifyActionSignatureAtHeight(act, height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyActionSignatureAtHeight", reflect.TypeOf((*MockBlockchain)(nil).VerifyActionSignatureAtHeight), act, height)
+}
+
 // ExecuteContractRead mocks base method
 func (m *MockBlockchain) ExecuteContractRead(arg0 *action.Execution) ([]byte, error) {
 	ret := m.ctrl.Call(m, "ExecuteContractRead", arg0)
@@ -558,3 +633,66 @@ func (m *MockBlockchain) ExecuteContractRead(arg0 *action.Execution) ([]byte, er
 func (mr *MockBlockchainMockRecorder) ExecuteContractRead(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteContractRead", reflect.TypeOf((*MockBlockchain)(nil).ExecuteContractRead), arg0)
 }
+
+// SimulateExecution mocks base method
+func (m *MockBlockchain) SimulateExecution(arg0 *action.Execution) (*blockchain.Receipt, error) {
+	ret := m.ctrl.Call(m, "SimulateExecution", arg0)
+	ret0, _ := ret[0].(*blockchain.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateExecution indicates an expected call of SimulateExecution
+func (mr *MockBlockchainMockRecorder) SimulateExecution(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateExecution", reflect.TypeOf((*MockBlockchain)(nil).SimulateExecution), arg0)
+}
+
+// SimulateExecutionOnPending mocks base method
+func (m *MockBlockchain) SimulateExecutionOnPending(arg0 *action.Execution, arg1 []action.Action) (*blockchain.Receipt, error) {
+	ret := m.ctrl.Call(m, "SimulateExecutionOnPending", arg0, arg1)
+	ret0, _ := ret[0].(*blockchain.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateExecutionOnPending indicates an expected call of SimulateExecutionOnPending
+func (mr *MockBlockchainMockRecorder) SimulateExecutionOnPending(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateExecutionOnPending", reflect.TypeOf((*MockBlockchain)(nil).SimulateExecutionOnPending), arg0, arg1)
+}
+
+// AddSubscriber mocks base method
+func (m *MockBlockchain) AddSubscriber(arg0 blockchain.BlockCreationSubscriber) error {
+	ret := m.ctrl.Call(m, "AddSubscriber", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSubscriber indicates an expected call of AddSubscriber
+func (mr *MockBlockchainMockRecorder) AddSubscriber(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriber", reflect.TypeOf((*MockBlockchain)(nil).AddSubscriber), arg0)
+}
+
+// RemoveSubscriber mocks base method
+func (m *MockBlockchain) RemoveSubscriber(arg0 blockchain.BlockCreationSubscriber) error {
+	ret := m.ctrl.Call(m, "RemoveSubscriber", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSubscriber indicates an expected call of RemoveSubscriber
+func (mr *MockBlockchainMockRecorder) RemoveSubscriber(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscriber", reflect.TypeOf((*MockBlockchain)(nil).RemoveSubscriber), arg0)
+}
+
+// SubscribeBlockCommit mocks base method
+func (m *MockBlockchain) SubscribeBlockCommit() (<-chan *blockchain.Block, func()) {
+	ret := m.ctrl.Call(m, "SubscribeBlockCommit")
+	ret0, _ := ret[0].(<-chan *blockchain.Block)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// SubscribeBlockCommit indicates an expected call of SubscribeBlockCommit
+func (mr *MockBlockchainMockRecorder) SubscribeBlockCommit() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeBlockCommit", reflect.TypeOf((*MockBlockchain)(nil).SubscribeBlockCommit))
+}
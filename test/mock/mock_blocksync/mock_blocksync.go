@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	blockchain "github.com/iotexproject/iotex-core/blockchain"
+	blocksync "github.com/iotexproject/iotex-core/blocksync"
 	network "github.com/iotexproject/iotex-core/network"
 	proto "github.com/iotexproject/iotex-core/proto"
 	reflect "reflect"
@@ -107,3 +108,25 @@ func (m *MockBlockSync) ProcessBlockSync(blk *blockchain.Block) error {
 func (mr *MockBlockSyncMockRecorder) ProcessBlockSync(blk interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockSync", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockSync), blk)
 }
+
+// SyncStatus mocks base method
+func (m *MockBlockSync) SyncStatus() blocksync.SyncStatus {
+	ret := m.ctrl.Call(m, "SyncStatus")
+	ret0, _ := ret[0].(blocksync.SyncStatus)
+	return ret0
+}
+
+// SyncStatus indicates an expected call of SyncStatus
+func (mr *MockBlockSyncMockRecorder) SyncStatus() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockBlockSync)(nil).SyncStatus))
+}
+
+// SetCheckpoints mocks base method
+func (m *MockBlockSync) SetCheckpoints(checkpoints []blocksync.Checkpoint) {
+	m.ctrl.Call(m, "SetCheckpoints", checkpoints)
+}
+
+// SetCheckpoints indicates an expected call of SetCheckpoints
+func (mr *MockBlockSyncMockRecorder) SetCheckpoints(checkpoints interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCheckpoints", reflect.TypeOf((*MockBlockSync)(nil).SetCheckpoints), checkpoints)
+}
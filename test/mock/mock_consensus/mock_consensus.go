@@ -10,6 +10,7 @@ import (
 	scheme "github.com/iotexproject/iotex-core/consensus/scheme"
 	proto "github.com/iotexproject/iotex-core/proto"
 	reflect "reflect"
+	time "time"
 )
 
 // MockConsensus is a mock of Consensus interface
@@ -95,3 +96,70 @@ func (m *MockConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 func (mr *MockConsensusMockRecorder) Metrics() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metrics", reflect.TypeOf((*MockConsensus)(nil).Metrics))
 }
+
+// EpochMeta mocks base method
+func (m *MockConsensus) EpochMeta(epochNum uint64) (scheme.EpochMeta, error) {
+	ret := m.ctrl.Call(m, "EpochMeta", epochNum)
+	ret0, _ := ret[0].(scheme.EpochMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EpochMeta indicates an expected call of EpochMeta
+func (mr *MockConsensusMockRecorder) EpochMeta(epochNum interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EpochMeta", reflect.TypeOf((*MockConsensus)(nil).EpochMeta), epochNum)
+}
+
+// Pause mocks base method
+func (m *MockConsensus) Pause() {
+	m.ctrl.Call(m, "Pause")
+}
+
+// Pause indicates an expected call of Pause
+func (mr *MockConsensusMockRecorder) Pause() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockConsensus)(nil).Pause))
+}
+
+// Resume mocks base method
+func (m *MockConsensus) Resume() {
+	m.ctrl.Call(m, "Resume")
+}
+
+// Resume indicates an expected call of Resume
+func (mr *MockConsensusMockRecorder) Resume() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockConsensus)(nil).Resume))
+}
+
+// SetBlockInterval mocks base method
+func (m *MockConsensus) SetBlockInterval(arg0 time.Duration) error {
+	ret := m.ctrl.Call(m, "SetBlockInterval", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBlockInterval indicates an expected call of SetBlockInterval
+func (mr *MockConsensusMockRecorder) SetBlockInterval(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBlockInterval", reflect.TypeOf((*MockConsensus)(nil).SetBlockInterval), arg0)
+}
+
+// GetBlockInterval mocks base method
+func (m *MockConsensus) GetBlockInterval() time.Duration {
+	ret := m.ctrl.Call(m, "GetBlockInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetBlockInterval indicates an expected call of GetBlockInterval
+func (mr *MockConsensusMockRecorder) GetBlockInterval() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockInterval", reflect.TypeOf((*MockConsensus)(nil).GetBlockInterval))
+}
+
+// SetProposerHook mocks base method
+func (m *MockConsensus) SetProposerHook(arg0 scheme.ProposerHook) {
+	m.ctrl.Call(m, "SetProposerHook", arg0)
+}
+
+// SetProposerHook indicates an expected call of SetProposerHook
+func (mr *MockConsensusMockRecorder) SetProposerHook(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProposerHook", reflect.TypeOf((*MockConsensus)(nil).SetProposerHook), arg0)
+}
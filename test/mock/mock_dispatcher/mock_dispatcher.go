@@ -166,9 +166,21 @@ func (mr *MockDispatcherMockRecorder) AddSubscriber(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriber", reflect.TypeOf((*MockDispatcher)(nil).AddSubscriber), arg0, arg1)
 }
 
+// RemoveSubscriber mocks base method
+func (m *MockDispatcher) RemoveSubscriber(arg0 uint32) {
+	m.ctrl.Call(m, "RemoveSubscriber", arg0)
+}
+
+// RemoveSubscriber indicates an expected call of RemoveSubscriber
+func (mr *MockDispatcherMockRecorder) RemoveSubscriber(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscriber", reflect.TypeOf((*MockDispatcher)(nil).RemoveSubscriber), arg0)
+}
+
 // HandleBroadcast mocks base method
-func (m *MockDispatcher) HandleBroadcast(arg0 uint32, arg1 proto.Message, arg2 chan bool) {
-	m.ctrl.Call(m, "HandleBroadcast", arg0, arg1, arg2)
+func (m *MockDispatcher) HandleBroadcast(arg0 uint32, arg1 proto.Message, arg2 chan bool) error {
+	ret := m.ctrl.Call(m, "HandleBroadcast", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // HandleBroadcast indicates an expected call of HandleBroadcast
@@ -177,8 +189,10 @@ func (mr *MockDispatcherMockRecorder) HandleBroadcast(arg0, arg1, arg2 interface
 }
 
 // HandleTell mocks base method
-func (m *MockDispatcher) HandleTell(arg0 uint32, arg1 net.Addr, arg2 proto.Message, arg3 chan bool) {
-	m.ctrl.Call(m, "HandleTell", arg0, arg1, arg2, arg3)
+func (m *MockDispatcher) HandleTell(arg0 uint32, arg1 net.Addr, arg2 proto.Message, arg3 chan bool) error {
+	ret := m.ctrl.Call(m, "HandleTell", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // HandleTell indicates an expected call of HandleTell
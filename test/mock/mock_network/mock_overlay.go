@@ -8,6 +8,8 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	proto "github.com/golang/protobuf/proto"
+	network "github.com/iotexproject/iotex-core/network"
+	hash "github.com/iotexproject/iotex-core/pkg/hash"
 	net "net"
 	reflect "reflect"
 )
@@ -106,3 +108,57 @@ func (m *MockOverlay) GetPeers() []net.Addr {
 func (mr *MockOverlayMockRecorder) GetPeers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeers", reflect.TypeOf((*MockOverlay)(nil).GetPeers))
 }
+
+// Peers mocks base method
+func (m *MockOverlay) Peers() []network.PeerInfo {
+	ret := m.ctrl.Call(m, "Peers")
+	ret0, _ := ret[0].([]network.PeerInfo)
+	return ret0
+}
+
+// Peers indicates an expected call of Peers
+func (mr *MockOverlayMockRecorder) Peers() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peers", reflect.TypeOf((*MockOverlay)(nil).Peers))
+}
+
+// Ban mocks base method
+func (m *MockOverlay) Ban(arg0 string) {
+	m.ctrl.Call(m, "Ban", arg0)
+}
+
+// Ban indicates an expected call of Ban
+func (mr *MockOverlayMockRecorder) Ban(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ban", reflect.TypeOf((*MockOverlay)(nil).Ban), arg0)
+}
+
+// Unban mocks base method
+func (m *MockOverlay) Unban(arg0 string) {
+	m.ctrl.Call(m, "Unban", arg0)
+}
+
+// Unban indicates an expected call of Unban
+func (mr *MockOverlayMockRecorder) Unban(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unban", reflect.TypeOf((*MockOverlay)(nil).Unban), arg0)
+}
+
+// SetGenesisHash mocks base method
+func (m *MockOverlay) SetGenesisHash(arg0 hash.Hash32B) {
+	m.ctrl.Call(m, "SetGenesisHash", arg0)
+}
+
+// SetGenesisHash indicates an expected call of SetGenesisHash
+func (mr *MockOverlayMockRecorder) SetGenesisHash(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGenesisHash", reflect.TypeOf((*MockOverlay)(nil).SetGenesisHash), arg0)
+}
+
+// GenesisHash mocks base method
+func (m *MockOverlay) GenesisHash() hash.Hash32B {
+	ret := m.ctrl.Call(m, "GenesisHash")
+	ret0, _ := ret[0].(hash.Hash32B)
+	return ret0
+}
+
+// GenesisHash indicates an expected call of GenesisHash
+func (mr *MockOverlayMockRecorder) GenesisHash() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenesisHash", reflect.TypeOf((*MockOverlay)(nil).GenesisHash))
+}
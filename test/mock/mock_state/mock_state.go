@@ -12,8 +12,110 @@ import (
 	state "github.com/iotexproject/iotex-core/state"
 	big "math/big"
 	reflect "reflect"
+	sync "sync"
 )
 
+// MockStateReader is a mock of StateReader interface
+type MockStateReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockStateReaderMockRecorder
+}
+
+// MockStateReaderMockRecorder is the mock recorder for MockStateReader
+type MockStateReaderMockRecorder struct {
+	mock *MockStateReader
+}
+
+// NewMockStateReader creates a new mock instance
+func NewMockStateReader(ctrl *gomock.Controller) *MockStateReader {
+	mock := &MockStateReader{ctrl: ctrl}
+	mock.recorder = &MockStateReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStateReader) EXPECT() *MockStateReaderMockRecorder {
+	return m.recorder
+}
+
+// Balance mocks base method
+func (m *MockStateReader) Balance(arg0 string) (*big.Int, error) {
+	ret := m.ctrl.Call(m, "Balance", arg0)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance
+func (mr *MockStateReaderMockRecorder) Balance(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockStateReader)(nil).Balance), arg0)
+}
+
+// Nonce mocks base method
+func (m *MockStateReader) Nonce(arg0 string) (uint64, error) {
+	ret := m.ctrl.Call(m, "Nonce", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Nonce indicates an expected call of Nonce
+func (mr *MockStateReaderMockRecorder) Nonce(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nonce", reflect.TypeOf((*MockStateReader)(nil).Nonce), arg0)
+}
+
+// State mocks base method
+func (m *MockStateReader) State(arg0 string) (*state.State, error) {
+	ret := m.ctrl.Call(m, "State", arg0)
+	ret0, _ := ret[0].(*state.State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// State indicates an expected call of State
+func (mr *MockStateReaderMockRecorder) State(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockStateReader)(nil).State), arg0)
+}
+
+// CandidatesByHeight mocks base method
+func (m *MockStateReader) CandidatesByHeight(arg0 uint64) ([]*state.Candidate, error) {
+	ret := m.ctrl.Call(m, "CandidatesByHeight", arg0)
+	ret0, _ := ret[0].([]*state.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CandidatesByHeight indicates an expected call of CandidatesByHeight
+func (mr *MockStateReaderMockRecorder) CandidatesByHeight(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CandidatesByHeight", reflect.TypeOf((*MockStateReader)(nil).CandidatesByHeight), arg0)
+}
+
+// StateAtHeight mocks base method
+func (m *MockStateReader) StateAtHeight(arg0 string, arg1 uint64) (*state.State, error) {
+	ret := m.ctrl.Call(m, "StateAtHeight", arg0, arg1)
+	ret0, _ := ret[0].(*state.State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateAtHeight indicates an expected call of StateAtHeight
+func (mr *MockStateReaderMockRecorder) StateAtHeight(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateAtHeight", reflect.TypeOf((*MockStateReader)(nil).StateAtHeight), arg0, arg1)
+}
+
+// AccumulatedBlockReward mocks base method
+func (m *MockStateReader) AccumulatedBlockReward(arg0 uint64) (*big.Int, error) {
+	ret := m.ctrl.Call(m, "AccumulatedBlockReward", arg0)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccumulatedBlockReward indicates an expected call of AccumulatedBlockReward
+func (mr *MockStateReaderMockRecorder) AccumulatedBlockReward(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccumulatedBlockReward", reflect.TypeOf((*MockStateReader)(nil).AccumulatedBlockReward), arg0)
+}
+
 // MockFactory is a mock of Factory interface
 type MockFactory struct {
 	ctrl     *gomock.Controller
@@ -164,6 +266,69 @@ func (mr *MockFactoryMockRecorder) RunActions(arg0, arg1, arg2, arg3 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunActions", reflect.TypeOf((*MockFactory)(nil).RunActions), arg0, arg1, arg2, arg3)
 }
 
+// ApplyBlockReward mocks base method
+func (m *MockFactory) ApplyBlockReward(arg0 string, arg1, arg2 *big.Int) error {
+	ret := m.ctrl.Call(m, "ApplyBlockReward", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyBlockReward indicates an expected call of ApplyBlockReward
+func (mr *MockFactoryMockRecorder) ApplyBlockReward(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBlockReward", reflect.TypeOf((*MockFactory)(nil).ApplyBlockReward), arg0, arg1, arg2)
+}
+
+// PruneBelow mocks base method
+func (m *MockFactory) PruneBelow(arg0 uint64) error {
+	ret := m.ctrl.Call(m, "PruneBelow", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PruneBelow indicates an expected call of PruneBelow
+func (mr *MockFactoryMockRecorder) PruneBelow(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneBelow", reflect.TypeOf((*MockFactory)(nil).PruneBelow), arg0)
+}
+
+// CollectTrieNodes mocks base method
+func (m *MockFactory) CollectTrieNodes(arg0 context.Context, arg1 sync.Locker, arg2 int) (int, error) {
+	ret := m.ctrl.Call(m, "CollectTrieNodes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectTrieNodes indicates an expected call of CollectTrieNodes
+func (mr *MockFactoryMockRecorder) CollectTrieNodes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectTrieNodes", reflect.TypeOf((*MockFactory)(nil).CollectTrieNodes), arg0, arg1, arg2)
+}
+
+// StateAtHeight mocks base method
+func (m *MockFactory) StateAtHeight(arg0 string, arg1 uint64) (*state.State, error) {
+	ret := m.ctrl.Call(m, "StateAtHeight", arg0, arg1)
+	ret0, _ := ret[0].(*state.State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateAtHeight indicates an expected call of StateAtHeight
+func (mr *MockFactoryMockRecorder) StateAtHeight(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateAtHeight", reflect.TypeOf((*MockFactory)(nil).StateAtHeight), arg0, arg1)
+}
+
+// AccumulatedBlockReward mocks base method
+func (m *MockFactory) AccumulatedBlockReward(arg0 uint64) (*big.Int, error) {
+	ret := m.ctrl.Call(m, "AccumulatedBlockReward", arg0)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccumulatedBlockReward indicates an expected call of AccumulatedBlockReward
+func (mr *MockFactoryMockRecorder) AccumulatedBlockReward(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccumulatedBlockReward", reflect.TypeOf((*MockFactory)(nil).AccumulatedBlockReward), arg0)
+}
+
 // HasRun mocks base method
 func (m *MockFactory) HasRun() bool {
 	ret := m.ctrl.Call(m, "HasRun")
@@ -188,6 +353,30 @@ func (mr *MockFactoryMockRecorder) Commit() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockFactory)(nil).Commit))
 }
 
+// Snapshot mocks base method
+func (m *MockFactory) Snapshot() int {
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot
+func (mr *MockFactoryMockRecorder) Snapshot() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockFactory)(nil).Snapshot))
+}
+
+// RevertToSnapshot mocks base method
+func (m *MockFactory) RevertToSnapshot(arg0 int) error {
+	ret := m.ctrl.Call(m, "RevertToSnapshot", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevertToSnapshot indicates an expected call of RevertToSnapshot
+func (mr *MockFactoryMockRecorder) RevertToSnapshot(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertToSnapshot", reflect.TypeOf((*MockFactory)(nil).RevertToSnapshot), arg0)
+}
+
 // GetCodeHash mocks base method
 func (m *MockFactory) GetCodeHash(arg0 hash.PKHash) (hash.Hash32B, error) {
 	ret := m.ctrl.Call(m, "GetCodeHash", arg0)
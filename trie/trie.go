@@ -17,6 +17,7 @@ import (
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
 var (
@@ -32,6 +33,12 @@ var (
 	// CandidateKVNameSpace is the bucket name for candidate data storage
 	CandidateKVNameSpace = "Candidate"
 
+	// RewardKVNameSpace is the bucket name for the accumulated block rewards at each height
+	RewardKVNameSpace = "Reward"
+
+	// AccountRootKVNameSpace is the bucket name for the account trie root hashes at each height
+	AccountRootKVNameSpace = "AccountRoot"
+
 	// ErrInvalidTrie indicates something wrong causing invalid operation
 	ErrInvalidTrie = errors.New("invalid trie operation")
 
@@ -53,6 +60,7 @@ type (
 		Delete([]byte) error         // delete an entry
 		Commit() error               // commit the state changes in a batch
 		RootHash() hash.Hash32B      // returns trie's root hash
+		SetRoot(hash.Hash32B) error  // reset the trie to a previous root hash
 	}
 
 	// trie implements the Trie interface
@@ -189,6 +197,79 @@ func (t *trie) RootHash() hash.Hash32B {
 	return t.rootHash
 }
 
+// SetRoot resets the trie to a previous root hash. Since nodes are never overwritten when upserting, the trie of a
+// previous root hash remains intact in DB
+func (t *trie) SetRoot(rootHash hash.Hash32B) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if rootHash == EmptyRoot {
+		t.root = &branch{}
+		t.rootHash = rootHash
+		return t.putPatricia(t.root)
+	}
+	root, err := t.getPatricia(rootHash[:])
+	if err != nil {
+		return errors.Wrapf(err, "failed to load root = %x", rootHash)
+	}
+	t.root = root
+	t.rootHash = rootHash
+	return nil
+}
+
+// Walk visits the nodes of the trie with the root hash stored in the bucket, depth first. The visit function gets the
+// hash of each node, and the value if it is a leaf or nil otherwise, and the children of a node are skipped if it
+// returns false. The empty root is never visited since it is shared by all the empty tries, and missing nodes are
+// skipped
+func Walk(kvStore db.KVStore, bucket string, root hash.Hash32B, visit func(hash.Hash32B, []byte) (bool, error)) error {
+	stack := []hash.Hash32B{root}
+	for len(stack) > 0 {
+		key := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if key == EmptyRoot {
+			continue
+		}
+		node, err := kvStore.Get(bucket, key[:])
+		if errors.Cause(err) == db.ErrNotExist {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get key %x", key[:8])
+		}
+		ptr, err := decodePatricia(node)
+		if err != nil {
+			return err
+		}
+		var value []byte
+		var children []ptrcKey
+		switch ptr := ptr.(type) {
+		case *branch:
+			for _, child := range ptr.Path {
+				if len(child) > 0 {
+					children = append(children, child)
+				}
+			}
+		case *leaf:
+			if ptr.Ext == 1 {
+				children = append(children, ptr.Value)
+			} else {
+				value = ptr.Value
+			}
+		}
+		descend, err := visit(key, value)
+		if err != nil {
+			return err
+		}
+		if !descend {
+			continue
+		}
+		for _, child := range children {
+			stack = append(stack, byteutil.BytesTo32B(child))
+		}
+	}
+	return nil
+}
+
 //======================================
 // private functions
 //======================================
@@ -461,6 +542,11 @@ func (t *trie) getPatricia(key []byte) (patricia, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get key %x", key[:8])
 	}
+	return decodePatricia(node)
+}
+
+// decodePatricia deserializes the patricia node of the type denoted by the first byte
+func decodePatricia(node []byte) (patricia, error) {
 	var ptr patricia
 	// first byte of serialized data is type
 	switch node[0] {
@@ -18,6 +18,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/testutil"
 )
 
@@ -531,3 +532,89 @@ func TestQuery(t *testing.T) {
 	require.Nil(err)
 	require.Nil(tr.Stop(context.Background()))
 }
+
+func TestSetRoot(t *testing.T) {
+	require := require.New(t)
+
+	tr := newTrie(db.NewMemKVStore(), "", EmptyRoot)
+	require.NotNil(tr)
+	require.Nil(tr.Start(context.Background()))
+
+	require.Nil(tr.Upsert(cat, testV[2]))
+	catRoot := tr.RootHash()
+	require.Nil(tr.Upsert(rat, testV[1]))
+	require.Nil(tr.Upsert(cat, testV[3]))
+	require.NotEqual(catRoot, tr.RootHash())
+
+	// roll back to the trie with cat only
+	require.Nil(tr.SetRoot(catRoot))
+	require.Equal(catRoot, tr.RootHash())
+	b, err := tr.Get(cat)
+	require.Nil(err)
+	require.Equal(testV[2], b)
+	_, err = tr.Get(rat)
+	require.Equal(ErrNotExist, errors.Cause(err))
+
+	// the trie keeps working after rolling back
+	require.Nil(tr.Upsert(rat, testV[1]))
+	b, err = tr.Get(rat)
+	require.Nil(err)
+	require.Equal(testV[1], b)
+
+	require.Nil(tr.SetRoot(EmptyRoot))
+	_, err = tr.Get(cat)
+	require.Equal(ErrNotExist, errors.Cause(err))
+
+	require.Error(tr.SetRoot(hash.Hash32B{1, 2, 3}))
+}
+
+func TestWalk(t *testing.T) {
+	require := require.New(t)
+
+	tr, err := NewTrie(db.NewMemKVStore(), "test", EmptyRoot)
+	require.Nil(err)
+	require.Nil(tr.Start(context.Background()))
+	defer func() {
+		require.Nil(tr.Stop(context.Background()))
+	}()
+
+	require.Nil(tr.Upsert(cat, testV[2]))
+	require.Nil(tr.Upsert(rat, testV[1]))
+	require.Nil(tr.Commit())
+	root := tr.RootHash()
+	require.Nil(tr.Upsert(egg, testV[4]))
+	require.Nil(tr.Commit())
+
+	walk := func(root hash.Hash32B, descend bool) (int, [][]byte) {
+		var numNodes int
+		var values [][]byte
+		require.Nil(Walk(tr.TrieDB(), "test", root, func(_ hash.Hash32B, value []byte) (bool, error) {
+			numNodes++
+			if value != nil {
+				values = append(values, value)
+			}
+			return descend, nil
+		}))
+		return numNodes, values
+	}
+	numNodes, values := walk(root, true)
+	require.True(numNodes > 2)
+	require.ElementsMatch([][]byte{testV[2], testV[1]}, values)
+	_, values = walk(tr.RootHash(), true)
+	require.ElementsMatch([][]byte{testV[2], testV[1], testV[4]}, values)
+
+	// the children are skipped
+	numNodes, _ = walk(root, false)
+	require.Equal(1, numNodes)
+	// the empty root and the missing nodes are not visited
+	numNodes, _ = walk(EmptyRoot, true)
+	require.Equal(0, numNodes)
+	numNodes, _ = walk(hash.Hash32B{1, 2, 3}, true)
+	require.Equal(0, numNodes)
+
+	errVisit := errors.New("visit error")
+	err = Walk(tr.TrieDB(), "test", root, func(hash.Hash32B, []byte) (bool, error) {
+		return false, errVisit
+	})
+	require.Equal(errVisit, errors.Cause(err))
+}